@@ -0,0 +1,404 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/generator"
+)
+
+// Options of the commands
+type Options struct {
+	// Out is the output directory, empty for next to the melt files
+	Out string
+	// Path is the melt package search path, separated like $PATH
+	Path string
+	// Module is the go module path of the generated packages
+	Module string
+	// Root is the project directory, packages in it are generated
+	// under their path relative to it
+	Root string
+	// Deps are the directories of the Meltfile dependencies
+	Deps map[string]string
+	// SafeName numbers generic instances instead of naming them after their types
+	SafeName bool
+	// MaxErrors is the number of reported errors, 0 reports all
+	MaxErrors int
+}
+
+func newOptions() Options {
+	return Options{Deps: make(map[string]string), SafeName: true}
+}
+
+func newFlags(name string, options *Options) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(&options.Path, "path", os.Getenv("MELTPATH"), "melt package search path")
+	flags.IntVar(&options.MaxErrors, "max-errors", 10, "maximum number of reported errors, 0 for all")
+	flags.Var(&reportFormat, "format", "format of the reported errors: text or json")
+	flags.Func("trace", "trace channels: parse,typecheck,instantiate,generate or all", compiler.Trace.Enable)
+	flags.Func("trace-out", "file for the trace, default stderr", func(path string) error {
+		out, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		compiler.Trace.Out = out
+		return nil
+	})
+	return flags
+}
+
+// Check parses and type checks a package
+func Check(args []string) error {
+	options := newOptions()
+	flags := newFlags("check", &options)
+	err := flags.Parse(args)
+	if err != nil {
+		return fail(ExitUsage, err)
+	}
+	if flags.NArg() > 0 {
+		_, _, err = checkPackage(flags.Args(), options)
+		return err
+	}
+
+	dirs, err := loadProject("check", &options)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		_, _, err = checkPackage([]string{dir}, options)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Build compiles a package to a go file for each melt file in the output dir
+// Without -o the go files are written next to the melt files
+// Imported melt packages are written to their import path in the output dir
+// Without files every source dir of the project is built
+func Build(args []string) error {
+	options := newOptions()
+	flags := newFlags("build", &options)
+	flags.StringVar(&options.Out, "o", "", "output directory")
+	flags.StringVar(&options.Module, "module", "", "go module path of the output")
+	err := flags.Parse(args)
+	if err != nil {
+		return fail(ExitUsage, err)
+	}
+	if flags.NArg() > 0 {
+		_, err = buildPackage(flags.Args(), options)
+		return err
+	}
+
+	dirs, err := loadProject("build", &options)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		_, err = buildPackage([]string{dir}, options)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run builds the files in a temporary go module and executes the result
+// Everything after -- is passed to the program
+func Run(args []string) error {
+	options := newOptions()
+	options.Module = "melt.run"
+	flags := newFlags("run", &options)
+	err := flags.Parse(args)
+	if err != nil {
+		return fail(ExitUsage, err)
+	}
+
+	files, programArgs := flags.Args(), []string{}
+	for i, arg := range files {
+		if arg == "--" {
+			files, programArgs = files[:i], files[i+1:]
+			break
+		}
+	}
+	if len(files) == 0 {
+		return fail(ExitUsage, errors.New("run: no files"))
+	}
+
+	dir, err := ioutil.TempDir("", "melt")
+	if err != nil {
+		return fail(ExitIO, err)
+	}
+	defer os.RemoveAll(dir)
+
+	options.Out = dir
+	_, err = buildPackage(files, options)
+	if err != nil {
+		return err
+	}
+
+	err = goModule(dir, options.Module)
+	if err != nil {
+		return fail(ExitIO, err)
+	}
+
+	binary := filepath.Join(dir, "program")
+	goBuild := exec.Command("go", "build", "-o", binary, ".")
+	goBuild.Dir = dir
+	goBuild.Stdout = os.Stderr
+	goBuild.Stderr = os.Stderr
+	err = goBuild.Run()
+	if err != nil {
+		return fail(ExitGo, fmt.Errorf("go build: %s", err))
+	}
+
+	program := exec.Command(binary, programArgs...)
+	program.Stdin = os.Stdin
+	program.Stdout = os.Stdout
+	program.Stderr = os.Stderr
+	err = program.Run()
+	if exit, ok := err.(*exec.ExitError); ok {
+		return fail(ExitProgram, fmt.Errorf("run: the program failed: %s", exit))
+	} else if err != nil {
+		return fail(ExitGo, err)
+	}
+	return nil
+}
+
+// goModule writes the go.mod of a generated module
+// Since go 1.22 each iteration of a loop has its own variables,
+// so the closures the generator creates in loops don't share them
+func goModule(dir string, module string) error {
+	return ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte(fmt.Sprintf("module %s\n\ngo 1.22\n", module)), 0644)
+}
+
+// Ast prints the checked tree of a package, indented or with -format=json
+// as a json object for each file
+func Ast(args []string) error {
+	options := newOptions()
+	flags := newFlags("ast", &options)
+	err := flags.Parse(args)
+	if err != nil {
+		return fail(ExitUsage, err)
+	}
+	if flags.NArg() == 0 {
+		return fail(ExitUsage, errors.New("ast: no files"))
+	}
+
+	p, _, err := checkPackage(flags.Args(), options)
+	if err != nil {
+		return err
+	}
+	for _, m := range p.Modules {
+		dump := compiler.Dump(m)
+		if reportFormat == FormatJSON {
+			source, err := compiler.MarshalJSON(dump, "  ")
+			if err != nil {
+				return fail(ExitIO, err)
+			}
+			fmt.Println(string(source))
+		} else {
+			fmt.Print(dump.Text())
+		}
+	}
+	return nil
+}
+
+// loadProject reads the nearest Meltfile into the options
+// and returns its source dirs. Flags win over the Meltfile
+func loadProject(command string, options *Options) ([]string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fail(ExitIO, err)
+	}
+	path, err := compiler.FindMeltfile(wd)
+	if err != nil {
+		return nil, fail(ExitUsage, fmt.Errorf("%s: no files and no %s found", command, compiler.MeltfileName))
+	}
+
+	meltfile, err := compiler.LoadMeltfile(path)
+	var manifestError *compiler.ManifestError
+	if errors.As(err, &manifestError) {
+		return nil, fail(ExitParse, err)
+	} else if err != nil {
+		return nil, fail(ExitIO, fmt.Errorf("File: %s", err))
+	}
+
+	options.Deps, err = meltfile.ResolveDeps()
+	if err != nil {
+		return nil, fail(ExitIO, err)
+	}
+	options.Root = meltfile.Root
+	options.SafeName = meltfile.SafeName
+	if options.Out == "" {
+		options.Out = filepath.Join(meltfile.Root, meltfile.Output)
+	}
+	if options.Module == "" {
+		options.Module = meltfile.Module
+	}
+
+	dirs := []string{}
+	for _, dir := range meltfile.Source {
+		dirs = append(dirs, filepath.Join(meltfile.Root, filepath.FromSlash(dir)))
+	}
+	return dirs, nil
+}
+
+// loadPackage parses a package from a directory or from a list of files
+func loadPackage(args []string, options Options) (*compiler.Package, error) {
+	var p *compiler.Package
+	var err error
+	if info, statErr := os.Stat(args[0]); len(args) == 1 && statErr == nil && info.IsDir() {
+		p, err = compiler.LoadPackage(args[0])
+	} else {
+		p, err = compiler.ParsePackage(args)
+	}
+
+	var pathError *os.PathError
+	var list compiler.DiagnosticList
+	var diagnostic *compiler.Diagnostic
+	if errors.As(err, &pathError) {
+		return nil, fail(ExitIO, fmt.Errorf("File: %s", err))
+	} else if errors.As(err, &list) || errors.As(err, &diagnostic) {
+		return nil, fail(ExitParse, limitErrors(err, options.MaxErrors))
+	} else if err != nil {
+		return nil, fail(ExitParse, fmt.Errorf("Parser: %s", err))
+	}
+	return p, nil
+}
+
+// checkPackage checks the package and the melt packages it imports
+// The project root or else the package directory is searched last for imports
+func checkPackage(args []string, options Options) (*compiler.Package, *compiler.Loader, error) {
+	p, err := loadPackage(args, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := filepath.SplitList(options.Path)
+	if options.Root != "" {
+		path = append(path, options.Root)
+		err = projectPath(p, options)
+		if err != nil {
+			return nil, nil, fail(ExitIO, err)
+		}
+	} else {
+		path = append(path, p.Dir)
+	}
+	loader := compiler.NewLoader(path, options.Module)
+	loader.Deps = options.Deps
+	loader.SafeName = options.SafeName
+
+	_, err = loader.Check(p)
+	var pathError *os.PathError
+	if errors.As(err, &pathError) {
+		return nil, nil, fail(ExitIO, fmt.Errorf("File: %s", err))
+	} else if err != nil {
+		return nil, nil, fail(ExitType, limitErrors(err, options.MaxErrors))
+	}
+	warn(p.Warnings...)
+	return p, loader, nil
+}
+
+// limitErrors keeps the first max diagnostics of a list, like go it ends
+// with "too many errors" then
+func limitErrors(err error, max int) error {
+	var list compiler.DiagnosticList
+	if !errors.As(err, &list) || max <= 0 || len(list) <= max {
+		return err
+	}
+	limited := append(compiler.DiagnosticList{}, list[:max]...)
+	return append(limited, &compiler.Diagnostic{Code: compiler.CodeTooMany, Severity: compiler.SeverityError, Message: "too many errors"})
+}
+
+// projectPath sets the import path of a package in the project root
+func projectPath(p *compiler.Package, options Options) error {
+	dir, err := filepath.Abs(p.Dir)
+	if err != nil {
+		return err
+	}
+	relative, err := filepath.Rel(options.Root, dir)
+	if err != nil {
+		return err
+	}
+	p.GoPath = options.Module
+	if relative != "." {
+		p.Path = filepath.ToSlash(relative)
+		p.GoPath = fmt.Sprintf("%s/%s", options.Module, p.Path)
+	}
+	return nil
+}
+
+// buildPackage compiles the package with its melt imports
+// and returns the paths of the go files
+func buildPackage(args []string, options Options) ([]string, error) {
+	p, loader, err := checkPackage(args, options)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := append([]*compiler.Package{p}, loader.Packages...)
+	for _, q := range packages {
+		err = q.Instantiate(q.Context)
+		if err != nil {
+			return nil, fail(ExitType, err)
+		}
+	}
+
+	written := []string{}
+	for _, q := range packages {
+		out := options.Out
+		if out != "" {
+			out = filepath.Join(out, filepath.FromSlash(q.Path))
+		}
+		files, err := writePackage(q, out)
+		if err != nil {
+			return nil, err
+		}
+		written = append(written, files...)
+	}
+	return written, nil
+}
+
+func writePackage(p *compiler.Package, out string) ([]string, error) {
+	fileSet, files, err := generator.GeneratePackage(p, p.Context)
+	if err != nil {
+		return nil, fail(ExitGenerate, err)
+	}
+
+	if out != "" {
+		err = os.MkdirAll(out, 0755)
+		if err != nil {
+			return nil, fail(ExitIO, err)
+		}
+	}
+
+	written := []string{}
+	for i, file := range files {
+		var source bytes.Buffer
+		err = format.Node(&source, fileSet, file)
+		if err != nil {
+			return nil, fail(ExitGenerate, err)
+		}
+
+		target := fmt.Sprintf("%s.go", p.Modules[i].File)
+		if out != "" {
+			target = filepath.Join(out, filepath.Base(target))
+		}
+		err = ioutil.WriteFile(target, source.Bytes(), 0644)
+		if err != nil {
+			return nil, fail(ExitIO, err)
+		}
+		written = append(written, target)
+	}
+	return written, nil
+}
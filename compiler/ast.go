@@ -1,6 +1,5 @@
 package compiler
 
-import "fmt"
 import (
 	// "errors"
 	// "reflect"
@@ -9,8 +8,12 @@ import (
 )
 
 type LocationInfo struct {
+	File   string
 	Line   int
 	Column int
+	// EndLine and EndColumn are just after the node, 0 if unknown
+	EndLine   int
+	EndColumn int
 }
 
 type MType struct {
@@ -19,6 +22,7 @@ type MType struct {
 
 type Ast interface {
 	Location() LocationInfo
+	SetLocation(LocationInfo)
 	ToString(int) string
 	MeltType() types.Type
 	ChangeMeltType(types.Type)
@@ -35,7 +39,6 @@ func (self Info) MeltType() types.Type {
 }
 
 func (self *Info) ChangeMeltType(t types.Type) {
-	fmt.Printf("%T %s \n", self, t.ToString())
 	self.ZType = t
 }
 
@@ -46,3 +49,7 @@ func (self Info) ToString(depth int) string {
 func (self Info) Location() LocationInfo {
 	return self.LocationInfo
 }
+
+func (self *Info) SetLocation(location LocationInfo) {
+	self.LocationInfo = location
+}
@@ -58,7 +58,7 @@ func (self *Float) TypeCheck(ctx *Context) error {
 }
 
 func (self *Bool) ToString(depth int) string {
-	return fmt.Sprintf("%sBool:%s", Indent(depth), self.Value)
+	return fmt.Sprintf("%sBool:%t", Indent(depth), self.Value)
 }
 
 func (self *Integer) ToString(depth int) string {
@@ -1,21 +1,48 @@
 package compiler
 
 import (
-	"errors"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
-//Operator int
+//Operator comparison
 type Operator int
 
 const (
-	//EqualOp =
+	//EqualOp ==
 	EqualOp Operator = 1
 	// NotEqualOp !=
 	NotEqualOp Operator = 2
+	// LessOp <
+	LessOp Operator = 3
+	// LessEqualOp <=
+	LessEqualOp Operator = 4
+	// GreaterOp >
+	GreaterOp Operator = 5
+	// GreaterEqualOp >=
+	GreaterEqualOp Operator = 6
 )
 
+var operators = map[Operator]string{
+	EqualOp: "==", NotEqualOp: "!=",
+	LessOp: "<", LessEqualOp: "<=", GreaterOp: ">", GreaterEqualOp: ">="}
+
+func (op Operator) String() string {
+	if s, ok := operators[op]; ok {
+		return s
+	}
+	return "?"
+}
+
+// ToOperator is the comparison of a token
+func ToOperator(token string) (Operator, bool) {
+	for op, s := range operators {
+		if s == token {
+			return op, true
+		}
+	}
+	return 0, false
+}
+
 //BinaryOperator binary
 type BinaryOperator int
 
@@ -26,52 +53,193 @@ const (
 	SubOp BinaryOperator = 2
 	//MultOp *
 	MultOp BinaryOperator = 3
-	//DivideOp *
+	//DivideOp /
 	DivideOp BinaryOperator = 4
+	//ModOp %
+	ModOp BinaryOperator = 5
+	//BitAndOp &
+	BitAndOp BinaryOperator = 6
+	//BitOrOp |
+	BitOrOp BinaryOperator = 7
+	//XorOp ^
+	XorOp BinaryOperator = 8
+	//AndNotOp &^
+	AndNotOp BinaryOperator = 9
+	//ShiftLeftOp <<
+	ShiftLeftOp BinaryOperator = 10
+	//ShiftRightOp >>
+	ShiftRightOp BinaryOperator = 11
+	//AndOp &&
+	AndOp BinaryOperator = 12
+	//OrOp ||
+	OrOp BinaryOperator = 13
+)
+
+var binaryOperators = map[BinaryOperator]string{
+	AddOp: "+", SubOp: "-", MultOp: "*", DivideOp: "/", ModOp: "%",
+	BitAndOp: "&", BitOrOp: "|", XorOp: "^", AndNotOp: "&^",
+	ShiftLeftOp: "<<", ShiftRightOp: ">>", AndOp: "&&", OrOp: "||"}
+
+func (op BinaryOperator) String() string {
+	if s, ok := binaryOperators[op]; ok {
+		return s
+	}
+	return "?"
+}
+
+// ToBinaryOperator is the operator of a token
+func ToBinaryOperator(token string) (BinaryOperator, bool) {
+	for op, s := range binaryOperators {
+		if s == token {
+			return op, true
+		}
+	}
+	return 0, false
+}
+
+// the classes of basic types the operators accept
+var (
+	integerTypes = map[string]bool{
+		"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+		"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+		"uintptr": true, "byte": true, "rune": true}
+	floatTypes = map[string]bool{"float": true, "real": true, "float32": true, "float64": true}
 )
 
+func basicLabel(t types.Type) string {
+	if b, ok := t.(types.Basic); ok {
+		return b.Label
+	}
+	return ""
+}
+
+// IsInteger is true for the int types
+func IsInteger(t types.Type) bool {
+	return integerTypes[basicLabel(t)]
+}
+
+// IsNumeric is true for the int and float types
+func IsNumeric(t types.Type) bool {
+	return IsInteger(t) || floatTypes[basicLabel(t)]
+}
+
+// IsOrdered is true for the types accepted by < <= > >=
+func IsOrdered(t types.Type) bool {
+	return IsNumeric(t) || basicLabel(t) == "string"
+}
+
+// IsBool is true for bool
+func IsBool(t types.Type) bool {
+	return basicLabel(t) == "bool"
+}
+
+// IsString is true for string
+func IsString(t types.Type) bool {
+	return basicLabel(t) == "string"
+}
+
+// arithmetic is the result of + - * / on numbers
+// An int with a float is a float, other numbers have to be the same type
+func arithmetic(left types.Type, right types.Type) (types.Type, bool) {
+	if !IsNumeric(left) || !IsNumeric(right) {
+		return nil, false
+	} else if basicLabel(left) == basicLabel(right) {
+		return left, true
+	} else if basicLabel(left) == "int" && floatTypes[basicLabel(right)] {
+		return right, true
+	} else if basicLabel(right) == "int" && floatTypes[basicLabel(left)] {
+		return left, true
+	}
+	return nil, false
+}
+
+// untyped gives a number literal the number type it's used as,
+// like a go constant: an int64 accepts 1 and a float32 accepts 1.5
+func untyped(value Ast, expected types.Type) bool {
+	switch value.(type) {
+	case *Integer:
+		if !IsNumeric(expected) {
+			return false
+		}
+	case *Float:
+		if !floatTypes[basicLabel(expected)] {
+			return false
+		}
+	default:
+		return false
+	}
+	value.ChangeMeltType(expected)
+	return true
+}
+
+// OperationType is the type of a binary operation on the operand types
+// It's false if the operator doesn't accept them
+func OperationType(op BinaryOperator, left types.Type, right types.Type) (types.Type, bool) {
+	switch op {
+	case AddOp:
+		if basicLabel(left) == "string" && basicLabel(right) == "string" {
+			return left, true
+		}
+		return arithmetic(left, right)
+	case SubOp, MultOp, DivideOp:
+		return arithmetic(left, right)
+	case ModOp, BitAndOp, BitOrOp, XorOp, AndNotOp:
+		if IsInteger(left) && basicLabel(left) == basicLabel(right) {
+			return left, true
+		}
+	case ShiftLeftOp, ShiftRightOp:
+		if IsInteger(left) && IsInteger(right) {
+			return left, true
+		}
+	case AndOp, OrOp:
+		if IsBool(left) && IsBool(right) {
+			return left, true
+		}
+	}
+	return nil, false
+}
+
 // BinaryOperation node
 type BinaryOperation struct {
 	Op    BinaryOperator
 	Left  *Ast
 	Right *Ast
+	// Guarded is the first failing call in the right operand of && or ||,
+	// its calls run only if the left operand doesn't decide the result
+	Guarded *Failure
 
 	Info
 }
 
-// all are defined for int and float
-// + is defined also for strings
-// for int/float, return float if there is a float, otherwise int
+// + - * / are defined for numbers, + also for strings
+// an int with a float is a float
+// % & | ^ &^ << >> are defined for ints, && || for bools
 func (self *BinaryOperation) TypeCheck(ctx *Context) error {
+	before := 0
+	if ctx.Failures != nil {
+		before = len(*ctx.Failures)
+	}
 	err := (*self.Right).TypeCheck(ctx)
 	if err != nil {
 		return err
 	}
+	if (self.Op == AndOp || self.Op == OrOp) && ctx.Failures != nil && len(*ctx.Failures) > before {
+		self.Guarded = (*ctx.Failures)[before]
+	}
 
 	err = (*self.Left).TypeCheck(ctx)
 	if err != nil {
 		return err
 	}
 
-	right, ok := (*self.Right).MeltType().(types.Basic)
-	left, ok2 := (*self.Left).MeltType().(types.Basic)
-	if !ok || !ok2 {
-		return errors.New("Expected ints, floats or strings")
-	} else if right.Label == "string" {
-		if left.Label != "string" || self.Op != AddOp {
-			return errors.New("Only string + supported")
-		} else {
-			self.ZType = right
-		}
-	} else if right.Label == "int" && left.Label == "int" {
-		self.ZType = right
-	} else if right.Label == "float" && left.Label == "int" ||
-		right.Label == "float" && left.Label == "float" {
-		self.ZType = right
-	} else if right.Label == "int" && left.Label == "float" {
-		self.ZType = left
-	} else {
-		return errors.New("Types not supported binary")
+	if !untyped(*self.Right, (*self.Left).MeltType()) {
+		untyped(*self.Left, (*self.Right).MeltType())
+	}
+	left, right := (*self.Left).MeltType(), (*self.Right).MeltType()
+	t, ok := OperationType(self.Op, left, right)
+	if !ok {
+		return Errorf(self, CodeOperand, "%s and %s are not supported by %s", left.ToString(), right.ToString(), self.Op)
 	}
+	self.ZType = t
 	return nil
 }
@@ -1,9 +1,6 @@
 package compiler
 
 import (
-	"errors"
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
@@ -11,6 +8,10 @@ type MethodCall struct {
 	Receiver *Ast
 	Method   *Label
 	Args     []Ast
+	// Instance of a generic function called from an imported package
+	Instance *GenericMap
+	// Failure keeps the error of a failing call
+	Failure *Failure
 
 	Info
 }
@@ -18,30 +19,38 @@ type MethodCall struct {
 func (m *MethodCall) TypeCheck(ctx *Context) error {
 	err := (*m.Receiver).TypeCheck(ctx)
 	if err != nil {
-		return err
+		return Locate(err, *m.Receiver)
 	}
 
-	for _, arg := range m.Args {
-		err = arg.TypeCheck(ctx)
-		if err != nil {
-			return err
-		}
+	err = checkArgs(m.Args, ctx)
+	if err != nil {
+		return err
 	}
 
 	if objectType, ok := (*m.Receiver).MeltType().(types.Duck); ok {
-		kind, ok := types.Accepts(objectType, m.Method.Label)
+		kind, ok := types.Accepts(objectType, BareLabel(m.Method.Label))
 		if !ok {
-			return errors.New("method doesn't respond")
+			return Errorf(m.Method, CodeMethod, "%s doesn't have a method %s", objectType.ToString(), BareLabel(m.Method.Label))
 		}
 
-		actual, _, err := CallCheck(m.Method.Label, kind.Function, m.Args, &objectType, ctx)
+		actual, genericMap, err := CallCheck(m.Method.Label, kind.Function, m.Args, &objectType, ctx)
 		if err != nil {
-			return err
+			return Locate(err, m.Method)
 		}
 
 		m.ZType = actual
+		if label := BareLabel(m.Method.Label); label != m.Method.Label {
+			m.Failure = ctx.Fail(m, label)
+		}
+
+		if _, ok := objectType.(types.Package); ok && len(kind.Function.InstanceVars) > 0 && !ctx.IsGeneric {
+			imported := ctx.Imports[(*m.Receiver).(*Label).Label]
+			instantiations := imported.Context.Instantiations
+			instantiations.Functions[kind.Label] = append(instantiations.Functions[kind.Label], genericMap)
+			m.Instance = &genericMap
+		}
 	} else {
-		return errors.New("doesn't have method")
+		return Errorf(m.Method, CodeMethod, "%s doesn't have methods", (*m.Receiver).MeltType().ToString())
 	}
 	return nil
 }
@@ -50,6 +59,10 @@ func (m *MethodCall) TypeCheck(ctx *Context) error {
 type Call struct {
 	Function *Label
 	Args     []Ast
+	// Instance of a generic function, used to find its generated name
+	Instance *GenericMap
+	// Failure keeps the error of a failing call
+	Failure *Failure
 
 	Info
 }
@@ -57,36 +70,40 @@ type Call struct {
 func (c *Call) TypeCheck(ctx *Context) error {
 	err := c.Function.TypeCheck(ctx)
 	if err != nil {
-		return err
+		return Locate(err, c.Function)
 	}
 
-	for _, arg := range c.Args {
-		err = arg.TypeCheck(ctx)
-		if err != nil {
-			return err
-		}
+	err = checkArgs(c.Args, ctx)
+	if err != nil {
+		return err
 	}
 
-	if function, ok := c.Function.MeltType().(types.Function); ok {
+	if IsNumeric(c.Function.MeltType()) {
+		return c.convert(ctx)
+	} else if function, ok := c.Function.MeltType().(types.Function); ok {
 		actual, genericMap, err := CallCheck(c.Function.Label, function, c.Args, nil, ctx)
 		if err != nil {
-			return err
+			return Locate(err, c.Function)
+		}
+		label := BareLabel(c.Function.Label)
+		if label != c.Function.Label && instanceFails(function, genericMap, ctx) {
+			c.Failure = ctx.Fail(c, label)
 		}
-		(*ctx.Unhandled)[c.Function.Label] = true
 		c.ZType = actual
 
 		if len(function.InstanceVars) > 0 {
 			if !ctx.IsGeneric {
-				fmt.Printf("J %s %d\n", c.Function.Label, len(function.InstanceVars))
+				Tracef(TraceTypeCheck, "instance of %s in %s", c.Function.Label, ctx.Label)
 
-				functions, ok := ctx.Root.Instantiations.Functions[c.Function.Label]
+				functions, ok := ctx.Root.Instantiations.Functions[label]
 				if !ok {
 					functions = []GenericMap{}
 				}
-				ctx.Root.Instantiations.Functions[c.Function.Label] = append(functions,
+				ctx.Root.Instantiations.Functions[label] = append(functions,
 					genericMap)
+				c.Instance = &genericMap
 			} else {
-				fmt.Printf("K %s %d\n", c.Function.Label, len(function.InstanceVars))
+				Tracef(TraceTypeCheck, "generic %s depends on %s", ctx.Label, c.Function.Label)
 
 				d, ok := ctx.Root.Dependencies[ctx.Label][c.Function.Label]
 				if !ok {
@@ -97,14 +114,65 @@ func (c *Call) TypeCheck(ctx *Context) error {
 		}
 
 	} else {
-		return errors.New("not a function type")
+		return Errorf(c.Function, CodeNotFunction, "%s is not a function", c.Function.Label)
+	}
+
+	return nil
+}
+
+// instanceFails is false for a call of a generic f? with handlers which can't fail,
+// its instance returns only its value
+func instanceFails(function types.Function, genericMap GenericMap, ctx *Context) bool {
+	if function.Error != types.Maybe || len(function.InstanceVars) == 0 || ctx.IsGeneric {
+		return true
+	}
+	for _, e := range genericMap.Errors {
+		if e != types.Correct {
+			return true
+		}
+	}
+	return false
+}
+
+// convert checks a conversion between number types: int64(0) or float(n)
+func (c *Call) convert(ctx *Context) error {
+	if len(c.Args) != 1 {
+		return Errorf(c.Function, CodeArgs, "%s takes one arg, received %d", c.Function.Label, len(c.Args))
+	} else if !IsNumeric(c.Args[0].MeltType()) {
+		return Errorf(c.Args[0], CodeArgs, "Can't convert %s to %s", c.Args[0].MeltType().ToString(), c.Function.Label)
 	}
+	c.ZType = c.Function.MeltType()
+	return nil
+}
 
+// checkArgs checks the args of a call,
+// except the lambdas which are inferred by CallCheck
+func checkArgs(args []Ast, ctx *Context) error {
+	for _, arg := range args {
+		if lambda, ok := arg.(*Lambda); ok && lambda.Inferred() {
+			continue
+		}
+		err := arg.TypeCheck(ctx)
+		if err != nil {
+			return Locate(err, arg)
+		}
+	}
 	return nil
 }
 
 func CallCheck(label string, function types.Function, args []Ast, receiver *types.Duck, ctx *Context) (types.Type, GenericMap, error) {
 
+	if (label == "len" || label == "print") && receiver == nil {
+		for _, arg := range args {
+			if lambda, ok := arg.(*Lambda); ok && lambda.Inferred() {
+				err := lambda.TypeCheck(ctx)
+				if err != nil {
+					return types.Empty{}, GenericMap{}, err
+				}
+			}
+		}
+	}
+
 	if label == "len" && receiver == nil {
 		return LenCheck(function, args, ctx)
 	} else if label == "print" && receiver == nil {
@@ -112,10 +180,14 @@ func CallCheck(label string, function types.Function, args []Ast, receiver *type
 		return p, GenericMap{}, nil
 	}
 
-	if len(function.Args) != len(args) {
+	if function.Variadic && len(args) < len(function.Args)-1 {
 		return types.Empty{},
 			GenericMap{},
-			fmt.Errorf("Expected different args %s:\n    received %d\n    wanted %d", label, len(args), len(function.Args))
+			Failf(CodeArgs, "Expected more args %s: received %d, wanted at least %d", label, len(args), len(function.Args)-1)
+	} else if !function.Variadic && len(function.Args) != len(args) {
+		return types.Empty{},
+			GenericMap{},
+			Failf(CodeArgs, "Expected different args %s: received %d, wanted %d", label, len(args), len(function.Args))
 	}
 
 	error := types.Correct
@@ -126,8 +198,8 @@ func CallCheck(label string, function types.Function, args []Ast, receiver *type
 	}
 
 	if function.Error == types.Correct && error != types.Correct ||
-		function.Error == types.Fail && error == types.Correct {
-		return types.Empty{}, GenericMap{}, fmt.Errorf("Error %s: received %s, wanted %s", label, types.Alexander(error), types.Alexander(function.Error))
+		function.Error == types.Fail && error == types.Correct && !function.ErrorValue {
+		return types.Empty{}, GenericMap{}, Failf(CodeErrorKind, "Error %s: received %s, wanted %s", label, types.Alexander(error), types.Alexander(function.Error))
 	}
 
 	if len(function.GenericVars) > 0 {
@@ -135,18 +207,42 @@ func CallCheck(label string, function types.Function, args []Ast, receiver *type
 		for _, r := range function.GenericVars {
 			genericMap.Types[r.Label] = types.Empty{}
 		}
+		// lambdas are matched last, the other args give the types of their args
+		for i, arg := range args {
+			if _, ok := arg.(*Lambda); ok {
+				continue
+			}
+			fArg := function.Param(i)
+			err := Match(&genericMap, arg.MeltType(), fArg, ctx)
+			if err != nil {
+				return types.Empty{}, GenericMap{}, Locate(err, arg)
+			}
+		}
 		for i, arg := range args {
-			fArg := function.Args[i]
+			lambda, ok := arg.(*Lambda)
+			if !ok {
+				continue
+			}
+			fArg := function.Param(i)
+			if lambda.Inferred() {
+				err := inferLambda(lambda, fArg, genericMap, ctx)
+				if err != nil {
+					return types.Empty{}, GenericMap{}, err
+				}
+			}
 			err := Match(&genericMap, arg.MeltType(), fArg, ctx)
 			if err != nil {
-				return types.Empty{}, GenericMap{}, err
+				return types.Empty{}, GenericMap{}, Locate(err, arg)
 			}
 		}
 
 		for id := range genericMap.Types {
 			_, ok := genericMap.Types[id].(types.Empty)
-			if ok {
-				return types.Empty{}, GenericMap{}, fmt.Errorf("Error %s: %s not actualized", label, id)
+			if ok && IsConstructor(label, function) {
+				// the type of None() comes from where it goes
+				genericMap.Types[id] = types.GenericVar{Label: id}
+			} else if ok {
+				return types.Empty{}, GenericMap{}, Failf(CodeTypeArgs, "Error %s: %s not actualized", label, id)
 			}
 		}
 
@@ -155,10 +251,22 @@ func CallCheck(label string, function types.Function, args []Ast, receiver *type
 		return returnType, genericMap, nil
 	} else {
 		for i, arg := range args {
-			fArg := function.Args[i]
-			if !fArg.Accepts(arg.MeltType()) {
-				return types.Empty{}, GenericMap{}, fmt.Errorf("Bad call:\n    received %s\n    wanted %s", arg.MeltType().ToString(), fArg.ToString())
+			fArg := function.Param(i)
+			if lambda, ok := arg.(*Lambda); ok && lambda.Inferred() {
+				err := lambda.Infer(fArg, ctx)
+				if err != nil {
+					return types.Empty{}, GenericMap{}, err
+				}
 			}
+			if reason, ok := implements(fArg, arg.MeltType()); ok {
+				return types.Empty{}, GenericMap{}, Errorf(arg, CodeMethod, "Bad call %s: %s", label, reason)
+			} else if !fArg.Accepts(arg.MeltType()) && !untyped(arg, fArg) {
+				return types.Empty{}, GenericMap{}, Errorf(arg, CodeArgs, "Bad call %s: received %s, wanted %s", label, arg.MeltType().ToString(), fArg.ToString())
+			}
+			settle(arg, fArg)
+		}
+		if function.ErrorValue && error == types.Correct {
+			return types.Error{Label: "err"}, GenericMap{}, nil
 		}
 		return function.Return, GenericMap{}, nil
 	}
@@ -166,16 +274,14 @@ func CallCheck(label string, function types.Function, args []Ast, receiver *type
 
 func LenCheck(function types.Function, args []Ast, ctx *Context) (types.Type, GenericMap, error) {
 	if len(args) != 1 {
-		return types.Empty{}, GenericMap{}, errors.New("Len takes one arg")
+		return types.Empty{}, GenericMap{}, Failf(CodeArgs, "len takes one arg, received %d", len(args))
 	} else {
 		switch a := args[0].MeltType().(type) {
-		case types.SliceBuiltin:
+		case types.SliceBuiltin, types.Array:
 			i := function.Return
 			return i, GenericMap{}, nil
 		case types.Duck:
 			length, ok := types.Accepts(a, "Length")
-			j, k := a.(types.Interface)
-			fmt.Printf("  %s %s\n", j.Methods(), k)
 			if ok {
 				if len(length.Function.Args) == 0 && length.Function.Error == types.Correct {
 					m, ok := length.Function.Return.(types.Basic)
@@ -184,9 +290,9 @@ func LenCheck(function types.Function, args []Ast, ctx *Context) (types.Type, Ge
 					}
 				}
 			}
-			return types.Empty{}, GenericMap{}, errors.New("Length() int")
+			return types.Empty{}, GenericMap{}, Errorf(args[0], CodeArgs, "len expects a Length() int method on %s", a.ToString())
 		default:
-			return types.Empty{}, GenericMap{}, errors.New("Slice or Length")
+			return types.Empty{}, GenericMap{}, Errorf(args[0], CodeArgs, "len expects a slice, an array or a Length() int method, got %s", a.ToString())
 		}
 	}
 }
@@ -201,8 +307,6 @@ func Match(genericMap *GenericMap, callArg types.Type, arg types.Type, ctx *Cont
 		m := NewGenericMap()
 		m.Types["T"] = c.Element
 		callArg = ReplaceGenericVars(c, m)
-		t, _ := callArg.(types.SliceBuiltin)
-		fmt.Printf("  %s\n", t.Methods()[0].Function.ToString())
 	}
 
 	switch other := arg.(type) {
@@ -215,12 +319,12 @@ func Match(genericMap *GenericMap, callArg types.Type, arg types.Type, ctx *Cont
 				return nil
 			} else {
 				if !o.Accepts(callArg) {
-					return fmt.Errorf("received %s, wanted %s", callArg.ToString(), o.ToString())
+					return Failf(CodeMismatch, "received %s, wanted %s", callArg.ToString(), o.ToString())
 				}
 			}
 		} else {
 			if !arg.Accepts(callArg) {
-				return fmt.Errorf("received %s, wanted %s", callArg.ToString(), arg.ToString())
+				return Failf(CodeMismatch, "received %s, wanted %s", callArg.ToString(), arg.ToString())
 			} else {
 				return nil
 			}
@@ -235,22 +339,22 @@ func Match(genericMap *GenericMap, callArg types.Type, arg types.Type, ctx *Cont
 				return nil
 			} else {
 				if !o.Accepts(callArg) {
-					return fmt.Errorf("%s is %s, can't be %s", other.Label, o.ToString(), callArg.ToString())
+					return Failf(CodeMismatch, "%s is %s, can't be %s", other.Label, o.ToString(), callArg.ToString())
 				} else {
 					return nil
 				}
 			}
 		} else {
-			return fmt.Errorf("unknown %s", other.Label)
+			return Failf(CodeUndefined, "unknown %s", other.Label)
 		}
 
 	case types.Record:
 		o, ok := callArg.(types.Record)
 		if !ok {
-			return fmt.Errorf("%s is not a record", callArg.ToString())
+			return Failf(CodeMismatch, "%s is not a record", callArg.ToString())
 		}
 		if other.Label != o.Label && len(other.InstanceVars) != len(o.InstanceVars) {
-			return fmt.Errorf("%s is not %s", callArg.ToString(), arg.ToString())
+			return Failf(CodeMismatch, "%s is not %s", callArg.ToString(), arg.ToString())
 		}
 		for i, arg := range o.InstanceVars {
 			err := Match(genericMap, arg, other.InstanceVars[i], ctx)
@@ -259,25 +363,41 @@ func Match(genericMap *GenericMap, callArg types.Type, arg types.Type, ctx *Cont
 			}
 		}
 
+	case types.Sum:
+		o, ok := callArg.(types.Sum)
+		if !ok && other.Accepts(callArg) {
+			return nil
+		} else if !ok || other.Label != o.Label || len(other.InstanceVars) != len(o.InstanceVars) {
+			return Failf(CodeMismatch, "received %s, wanted %s", callArg.ToString(), arg.ToString())
+		}
+		for i, v := range o.InstanceVars {
+			if o.Open(i) || other.InstanceVars[i] == nil {
+				continue
+			}
+			err := Match(genericMap, v, other.InstanceVars[i], ctx)
+			if err != nil {
+				return err
+			}
+		}
+
 	case types.Function:
 		o, ok := callArg.(types.Function)
 		if !ok {
-			return fmt.Errorf("%s is not a function", callArg.ToString())
+			return Failf(CodeNotFunction, "%s is not a function", callArg.ToString())
 		}
 		if other.Error == types.Correct && o.Error != types.Correct ||
 			other.Error == types.Fail && o.Error != types.Fail {
-			return fmt.Errorf("%s fix fail", callArg.ToString())
+			return Failf(CodeMismatch, "%s can't be %s", callArg.ToString(), other.ToString())
 		}
 		if other.Error == types.Maybe {
 			if o.Error != types.Maybe {
 				genericMap.Errors = append(genericMap.Errors, o.Error)
 			} else {
-				fmt.Printf("ERRORS %s", callArg.ToString())
 				genericMap.Errors = append(genericMap.Errors, types.Maybe)
 			}
 		}
 		if len(other.Args) != len(o.Args) {
-			return fmt.Errorf("%s fix arity", callArg.ToString())
+			return Failf(CodeArgs, "%s takes %d args, wanted %d", callArg.ToString(), len(o.Args), len(other.Args))
 		}
 		for i, arg := range o.Args {
 			err := Match(genericMap, arg, other.Args[i], ctx)
@@ -294,22 +414,20 @@ func Match(genericMap *GenericMap, callArg types.Type, arg types.Type, ctx *Cont
 	case types.Interface:
 		duck, ok := callArg.(types.Duck)
 		if !ok {
-			return fmt.Errorf("%s not a duck", callArg.ToString())
+			return Failf(CodeMethod, "%s doesn't have methods", callArg.ToString())
 		}
 
-		fmt.Printf("%T\n", other.Methods())
 		for _, m := range other.Methods() {
-			fmt.Printf("  #%s\n", m.Label)
-
+			Tracef(TraceTypeCheck, "match method %s of %s", m.Label, callArg.ToString())
 			value, ok := types.Accepts(duck, m.Label)
 			if !ok {
-				return errors.New("not valid")
+				return Failf(CodeMethod, "%s doesn't have a method %s", callArg.ToString(), m.Label)
 			}
 
 			function := value.Function
 			if function.Error != m.Function.Error ||
 				len(function.Args) != len(m.Function.Args) {
-				return fmt.Errorf("%s is different", callArg.ToString())
+				return Failf(CodeMethod, "%s.%s is %s, wanted %s", callArg.ToString(), m.Label, function.ToString(), m.Function.ToString())
 			}
 
 			for i, arg := range m.Function.Args {
@@ -328,12 +446,24 @@ func Match(genericMap *GenericMap, callArg types.Type, arg types.Type, ctx *Cont
 	case types.Pointer:
 		t, ok := callArg.(types.Pointer)
 		if !ok {
-			return fmt.Errorf("%s is not a pointer", callArg.ToString())
+			return Failf(CodeMismatch, "%s is not a pointer", callArg.ToString())
 		}
 		return Match(genericMap, t.Object, other.Object, ctx)
+	case types.SliceBuiltin:
+		t, ok := callArg.(types.SliceBuiltin)
+		if !ok {
+			return Failf(CodeMismatch, "%s is not a slice", callArg.ToString())
+		}
+		return Match(genericMap, t.Element, other.Element, ctx)
+	case types.Channel:
+		t, ok := callArg.(types.Channel)
+		if !ok || other.Dir != t.Dir && t.Dir != types.Both {
+			return Failf(CodeMismatch, "received %s, wanted %s", callArg.ToString(), other.ToString())
+		}
+		return Match(genericMap, t.Element, other.Element, ctx)
 	default:
 		if !arg.Accepts(callArg) {
-			return fmt.Errorf("received %s, wanted %s", callArg.ToString(), ReplaceGenericVars(arg, *genericMap).ToString())
+			return Failf(CodeMismatch, "received %s, wanted %s", callArg.ToString(), ReplaceGenericVars(arg, *genericMap).ToString())
 		} else {
 			return nil
 		}
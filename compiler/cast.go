@@ -0,0 +1,36 @@
+package compiler
+
+import (
+	"fmt"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// Cast node: 0 as int64 converts a number to another number type
+// or gives a value a type which accepts it
+type Cast struct {
+	Value Ast
+	Type  types.Type
+
+	Info
+}
+
+func (self *Cast) ToString(depth int) string {
+	return fmt.Sprintf("%sCast %s:\n%s", Indent(depth), self.Type.ToString(), self.Value.ToString(depth+1))
+}
+
+func (self *Cast) TypeCheck(ctx *Context) error {
+	err := self.Value.TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+	target := ResolveType(self.Type, ctx)
+	value := self.Value.MeltType()
+	if !(IsNumeric(value) && IsNumeric(target)) && !target.Accepts(value) && !untyped(self.Value, target) {
+		return Errorf(self, CodeMismatch, "Can't convert %s to %s", value.ToString(), target.ToString())
+	}
+	settle(self.Value, target)
+	self.Type = target
+	self.ZType = target
+	return nil
+}
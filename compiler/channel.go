@@ -0,0 +1,329 @@
+package compiler
+
+import (
+	"fmt"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// Send node: channel <- value
+type Send struct {
+	Channel Ast
+	Value   Ast
+
+	Info
+}
+
+func (self *Send) ToString(depth int) string {
+	return fmt.Sprintf("%sSend:\n%s\n%s",
+		Indent(depth),
+		self.Channel.ToString(depth+1),
+		self.Value.ToString(depth+1))
+}
+
+func (self *Send) TypeCheck(ctx *Context) error {
+	channel, err := channelOf(self.Channel, types.Receive, "send to", ctx)
+	if err != nil {
+		return err
+	}
+	err = self.Value.TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+	if !channel.Element.Accepts(self.Value.MeltType()) {
+		return Errorf(self.Value, CodeMismatch, "Can't send %s to %s", self.Value.MeltType().ToString(), channel.ToString())
+	}
+	self.ZType = types.Empty{}
+	return nil
+}
+
+// Receive node: <-channel
+type Receive struct {
+	Channel Ast
+
+	Info
+}
+
+func (self *Receive) ToString(depth int) string {
+	return fmt.Sprintf("%sReceive:\n%s", Indent(depth), self.Channel.ToString(depth+1))
+}
+
+func (self *Receive) TypeCheck(ctx *Context) error {
+	channel, err := channelOf(self.Channel, types.Send, "receive from", ctx)
+	if err != nil {
+		return err
+	}
+	self.ZType = channel.Element
+	return nil
+}
+
+// channelOf checks a channel which isn't directed only to forbidden
+func channelOf(node Ast, forbidden types.ChannelDir, action string, ctx *Context) (types.Channel, error) {
+	err := node.TypeCheck(ctx)
+	if err != nil {
+		return types.Channel{}, err
+	}
+	channel, ok := node.MeltType().(types.Channel)
+	if !ok {
+		return types.Channel{}, Errorf(node, CodeChannel, "Can't %s %s, it's not a channel", action, node.MeltType().ToString())
+	} else if channel.Dir == forbidden {
+		return types.Channel{}, Errorf(node, CodeChannel, "Can't %s %s", action, channel.ToString())
+	}
+	return channel, nil
+}
+
+// Go node: go call(..) or a go func(): block
+// The block is a closure, it sees the labels of the function
+// Group is the label of the spawn: block waiting for the goroutine,
+// only there it can fail
+type Go struct {
+	Call  Ast
+	Code  *Code
+	Fail  bool
+	Group string
+	// Errors are the variables of the errors taken by handlers in Code
+	Errors []string
+	// Returned is true if Code can't reach its end: it ends with a
+	// return, a !! or a loop: without a break
+	Returned bool
+
+	Info
+}
+
+func (self *Go) ToString(depth int) string {
+	if self.Call != nil {
+		return fmt.Sprintf("%sGo:\n%s", Indent(depth), self.Call.ToString(depth+1))
+	}
+	return fmt.Sprintf("%sGo:\n%s", Indent(depth), self.Code.ToString(depth+1))
+}
+
+// TypeCheck warns about failing goroutines outside of a spawn: block,
+// nobody waits for them there, so their error panics
+func (self *Go) TypeCheck(ctx *Context) error {
+	self.ZType = types.Empty{}
+	if ctx.Spawn != nil {
+		self.Group = ctx.Spawn.Label.Label
+	}
+	if self.Call != nil {
+		err := self.Call.TypeCheck(ctx)
+		if err != nil {
+			return err
+		}
+		label := CallLabel(self.Call)
+		if label != BareLabel(label) {
+			// the error goes to the spawn, not to the function
+			ctx.Drop(self.Call)
+			self.Fail = true
+			if ctx.Spawn == nil {
+				ctx.Report(Warnf(self.Call, CodeLostError, "nobody waits for %s outside of a spawn: block, its error panics", label))
+			} else {
+				ctx.Spawn.Fails = true
+			}
+		}
+		return nil
+	}
+
+	if self.Fail {
+		if ctx.Spawn == nil {
+			ctx.Report(Warnf(self, CodeLostError, "nobody waits for go func()! outside of a spawn: block, its error panics"))
+		} else {
+			ctx.Spawn.Fails = true
+		}
+	}
+	c := NewContextIn(ctx)
+	unhandled := make(map[string][]*Failure)
+	c.Unhandled = &unhandled
+	handled := make(map[string]Ast)
+	c.Handled = &handled
+	c.Returned = new(bool)
+	failures := Failures{}
+	c.Failures = &failures
+	c.Loops = 0
+	c.Exit = nil
+	c.ReturnType = types.Empty{}
+	c.Z = types.Correct
+	c.ErrorTypes = nil
+	if self.Fail {
+		c.Z = types.Fail
+	}
+	// a goroutine started by this one isn't waited for by the spawn
+	c.Spawn = nil
+	err := self.Code.TypeCheck(c)
+	if err != nil {
+		return err
+	}
+	c.ReportUnhandled()
+	self.Errors = c.Failures.Errors()
+	self.Returned = *c.Returned
+	return nil
+}
+
+// CallLabel is the label of the function called by a call,
+// it's empty for other nodes
+func CallLabel(call Ast) string {
+	switch kind := call.(type) {
+	case *Call:
+		return kind.Function.Label
+	case *MethodCall:
+		return kind.Method.Label
+	}
+	return ""
+}
+
+// CallFailure is the failure of a failing call, nil for other nodes
+func CallFailure(call Ast) *Failure {
+	switch kind := call.(type) {
+	case *Call:
+		return kind.Failure
+	case *MethodCall:
+		return kind.Failure
+	}
+	return nil
+}
+
+// Loop node: loop: runs its code until a break or a return
+type Loop struct {
+	Code *Code
+	Exit Exit
+
+	Info
+}
+
+// Exit is the loop a break leaves
+type Exit struct {
+	// Label is the go label of the loop, used by the breaks in a select
+	// or a match: a go break there leaves only the select or the switch
+	Label string
+	// Broken is true if a break leaves the loop
+	Broken bool
+}
+
+// Break node: break leaves the innermost loop
+type Break struct {
+	// Label is the go label of the loop for a break in a select or a match
+	Label string
+
+	Info
+}
+
+// TypeCheck labels the loop of a break in a select or a match
+func (self *Break) TypeCheck(ctx *Context) error {
+	self.ZType = types.Empty{}
+	if ctx.Exit == nil {
+		return Errorf(self, CodeBreak, "break outside of a loop")
+	}
+	ctx.Exit.Broken = true
+	if ctx.Nested {
+		if ctx.Exit.Label == "" {
+			ctx.Exit.Label = fmt.Sprintf("loop%d", self.Location().Line)
+		}
+		self.Label = ctx.Exit.Label
+	}
+	return nil
+}
+
+// enterLoop makes a break in the code of the context leave the loop of exit
+func (self *Context) enterLoop(exit *Exit) {
+	self.Loops++
+	self.Exit = exit
+	self.Nested = false
+}
+
+func (self *Loop) ToString(depth int) string {
+	return fmt.Sprintf("%sLoop:\n%s", Indent(depth), self.Code.ToString(depth+1))
+}
+
+func (self *Loop) TypeCheck(ctx *Context) error {
+	self.ZType = types.Empty{}
+	c := ctx.Branch()
+	c.enterLoop(&self.Exit)
+	err := self.Code.TypeCheck(c)
+	// its code runs at least once
+	ctx.Join([]*Context{c}, true)
+	if !self.Exit.Broken {
+		// only a return leaves it
+		*ctx.Returned = true
+	}
+	return err
+}
+
+// Select node
+type Select struct {
+	Cases []*SelectCase
+
+	Info
+}
+
+// SelectCase node: ? value = <-channel:, ? channel <- value: or ? default:
+// Label is the optional label of the received value
+type SelectCase struct {
+	Receive *Receive
+	Label   *Label
+	Send    *Send
+	Code    *Code
+
+	Info
+}
+
+func (self *Select) ToString(depth int) string {
+	return fmt.Sprintf("%sSelect", Indent(depth))
+}
+
+func (self *SelectCase) ToString(depth int) string {
+	return fmt.Sprintf("%sSelectCase", Indent(depth))
+}
+
+func (self *SelectCase) IsDefault() bool {
+	return self.Receive == nil && self.Send == nil
+}
+
+// TypeCheck checks each case in its own scope
+func (self *Select) TypeCheck(ctx *Context) error {
+	var defaultCase *SelectCase
+	branches := []*Context{}
+	for _, c := range self.Cases {
+		if c.IsDefault() {
+			if defaultCase != nil {
+				err := Errorf(c, CodeRedefined, "select has more than one default")
+				ctx.Report(Relate(err, defaultCase, "the first default is here"))
+				continue
+			}
+			defaultCase = c
+		}
+		branch := ctx.Branch()
+		branch.Nested = true
+		branches = append(branches, branch)
+		err := c.TypeCheck(branch)
+		if err != nil {
+			ctx.Report(Locate(err, c))
+		}
+	}
+	// select waits until one of the cases runs
+	ctx.Join(branches, true)
+	self.ZType = types.Empty{}
+	return nil
+}
+
+// TypeCheck checks the code even with a broken channel, the label is poisoned then
+func (self *SelectCase) TypeCheck(ctx *Context) error {
+	caseCtx := NewContextIn(ctx)
+	var err error
+	if self.Send != nil {
+		err = self.Send.TypeCheck(ctx)
+	} else if self.Receive != nil {
+		err = self.Receive.TypeCheck(ctx)
+		if self.Label != nil {
+			if err != nil {
+				caseCtx.Poison(self.Label.Label)
+			} else {
+				self.Label.ZType = self.Receive.MeltType()
+				caseCtx.Set(self.Label.Label, self.Label.ZType)
+			}
+		}
+	}
+	if err != nil {
+		ctx.Report(err)
+	}
+	self.ZType = types.Empty{}
+	return self.Code.TypeCheck(caseCtx)
+}
@@ -1,8 +1,12 @@
 package compiler
 
-import "errors"
+import (
+	"gitlab.com/alehander42/melt/types"
+)
 
 // Cmp node
+// == and != compare values of the same type, < <= > >= numbers and strings
+// Slices, maps and functions compare only with nil like in go
 type Cmp struct {
 	Op    Operator
 	Left  Ast
@@ -21,11 +25,36 @@ func (self *Cmp) TypeCheck(ctx *Context) error {
 		return err
 	}
 
-	if self.Left.MeltType().Accepts(self.Right.MeltType()) {
-		m, _ := ctx.Get("bool")
-		self.ZType = m
+	untyped(self.Left, self.Right.MeltType())
+	untyped(self.Right, self.Left.MeltType())
+	left, right := self.Left.MeltType(), self.Right.MeltType()
+	comparable := left.Accepts(right) || right.Accepts(left)
+	if _, ok := arithmetic(left, right); ok {
+		comparable = true
+	}
+	_, leftNil := left.(types.Nil)
+	_, rightNil := right.(types.Nil)
+	if leftNil && rightNil || onlyNil(left, ctx) && !rightNil || onlyNil(right, ctx) && !leftNil {
+		comparable = false
+	}
+	if comparable && (self.Op == EqualOp || self.Op == NotEqualOp || IsOrdered(left) && IsOrdered(right)) {
+		self.ZType = types.Basic{Label: "bool"}
 		return nil
 	} else {
-		return errors.New("Left doesn't match right")
+		return Errorf(self, CodeOperand, "Can't compare %s %s %s", left.ToString(), self.Op, right.ToString())
+	}
+}
+
+// onlyNil is true for the types which can be compared only with nil
+func onlyNil(t types.Type, ctx *Context) bool {
+	t = ResolveType(t, ctx)
+	if named, ok := t.(types.Named); ok {
+		t = named.Underlying
+	}
+	switch t.(type) {
+	case types.SliceBuiltin, types.MapBuiltin, types.Function:
+		return true
+	default:
+		return false
 	}
 }
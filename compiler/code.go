@@ -1,6 +1,10 @@
 package compiler
 
-import "fmt"
+import (
+	"fmt"
+
+	"gitlab.com/alehander42/melt/types"
+)
 
 // Code node
 // Has only one member with a list of the nodes
@@ -10,11 +14,13 @@ type Code struct {
 	Info
 }
 
+// TypeCheck reports the errors of a statement and goes on with the next one
 func (self *Code) TypeCheck(ctx *Context) error {
 	for _, expression := range self.E {
 		err := expression.TypeCheck(ctx)
 		if err != nil {
-			return err
+			ctx.Report(Locate(err, expression))
+			expression.ChangeMeltType(types.Empty{})
 		}
 	}
 	return nil
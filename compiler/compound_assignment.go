@@ -0,0 +1,62 @@
+package compiler
+
+import (
+	"fmt"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// CompoundAssignment node: x += value
+// The target is a label, a field or an index: s.length += 1, xs[i] *= 2
+type CompoundAssignment struct {
+	Target Ast
+	Op     BinaryOperator
+	Value  *Ast
+
+	Info
+}
+
+func (self *CompoundAssignment) ToString(depth int) string {
+	return fmt.Sprintf("%sCompoundAssignment %s=:\n%s\n%s",
+		Indent(depth),
+		self.Op,
+		self.Target.ToString(depth+1),
+		(*self.Value).ToString(depth+1))
+}
+
+// TypeCheck checks target op value, the result has to be assignable to the target
+func (self *CompoundAssignment) TypeCheck(ctx *Context) error {
+	err := self.Target.TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+	err = (*self.Value).TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+
+	untyped(*self.Value, self.Target.MeltType())
+	target, value := self.Target.MeltType(), (*self.Value).MeltType()
+	t, ok := OperationType(self.Op, target, value)
+	if !ok {
+		return Errorf(self, CodeOperand, "%s and %s are not supported by %s=", target.ToString(), value.ToString(), self.Op)
+	} else if !target.Accepts(t) {
+		return Errorf(*self.Value, CodeMismatch, "%s is %s, can't assign %s", assignee(self.Target), target.ToString(), t.ToString())
+	}
+	self.ZType = types.Empty{}
+	return nil
+}
+
+// assignee names the target of an assignment in errors
+func assignee(target Ast) string {
+	switch node := target.(type) {
+	case *Label:
+		return node.Label
+	case *Selector:
+		return fmt.Sprintf("%s.%s", assignee(node.Object), node.Field.Label)
+	case *Index:
+		return fmt.Sprintf("%s[..]", assignee(node.Collection))
+	default:
+		return "the target"
+	}
+}
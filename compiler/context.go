@@ -1,9 +1,6 @@
 package compiler
 
 import (
-	"errors"
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
@@ -24,6 +21,10 @@ type Instantiation struct {
 	Functions  map[string][]GenericMap
 	Interfaces map[string][]GenericMap
 	Records    map[string][]GenericMap
+	// Sums are the instances of generic sums used by the generated code, by go name
+	Sums map[string]types.Sum
+	// Names of the generated instances: label -> FunctionName key -> name
+	Names map[string]map[string]string
 }
 
 type Context struct {
@@ -34,22 +35,58 @@ type Context struct {
 	IsGeneric      bool
 	Dependencies   map[string]map[string][]GenericMap
 	Label          string
-	Unhandled      *map[string]bool
-	ReturnType     types.Type
-	Z              types.ErrorFunction
+	// Unhandled has the failures of each label which aren't handled on this path
+	Unhandled *map[string][]*Failure
+	// Handled has the handler of each handled error since the last call
+	Handled *map[string]Ast
+	// Returned is true once this path returned, the code after it doesn't run
+	Returned *bool
+	// Failures are the failing calls of the function, their errors go to the handlers
+	Failures *Failures
+	// Loops is the number of loops around the code in its function
+	Loops int
+	// Exit is the loop a break leaves, nil outside of loops
+	Exit *Exit
+	// Nested is true in a select or a match in the loop of Exit,
+	// they're a go select or switch which a bare break would leave
+	Nested     bool
+	ReturnType types.Type
+	Z          types.ErrorFunction
+	// ErrorTypes are the errors the function declares it fails with,
+	// the records or the pointers to them it returns
+	ErrorTypes []types.Type
+	Loader     *Loader
+	Imports    map[string]*Package
+	// Path is the melt import path of the checked package, empty for the main package
+	Path string
+	// SafeName numbers the generated instances instead of naming them after their types
+	SafeName bool
+	// Diagnostics are the errors found so far, shared by all contexts of a package
+	Diagnostics *DiagnosticList
+	// Spawn is the spawn: block waiting for the goroutines started here
+	Spawn *Spawn
 }
 
 func NewContext() Context {
-	unhandled := make(map[string]bool)
+	unhandled := make(map[string][]*Failure)
+	handled := make(map[string]Ast)
+	failures := Failures{}
+	diagnostics := DiagnosticList{}
 	return Context{
 		Values:         make(TypeMap),
 		Parent:         nil,
 		Root:           nil,
 		Label:          "",
-		Instantiations: &Instantiation{Functions: make(map[string][]GenericMap), Records: make(map[string][]GenericMap), Interfaces: make(map[string][]GenericMap)},
+		Instantiations: &Instantiation{Functions: make(map[string][]GenericMap), Records: make(map[string][]GenericMap), Interfaces: make(map[string][]GenericMap), Sums: make(map[string]types.Sum), Names: make(map[string]map[string]string)},
 		Dependencies:   make(map[string]map[string][]GenericMap),
 		Z:              types.Correct,
 		Unhandled:      &unhandled,
+		Handled:        &handled,
+		Returned:       new(bool),
+		Failures:       &failures,
+		Imports:        make(map[string]*Package),
+		SafeName:       true,
+		Diagnostics:    &diagnostics,
 		IsGeneric:      false}
 }
 
@@ -62,12 +99,25 @@ func NewContextIn(parent *Context) *Context {
 	}
 
 	return &Context{
-		Values:    make(TypeMap),
-		Parent:    parent,
-		Root:      root,
-		Label:     parent.Label,
-		Unhandled: parent.Unhandled,
-		IsGeneric: parent.IsGeneric}
+		Values:      make(TypeMap),
+		Parent:      parent,
+		Root:        root,
+		Label:       parent.Label,
+		Unhandled:   parent.Unhandled,
+		Handled:     parent.Handled,
+		Returned:    parent.Returned,
+		Failures:    parent.Failures,
+		Loops:       parent.Loops,
+		Exit:        parent.Exit,
+		Nested:      parent.Nested,
+		Loader:      parent.Loader,
+		Imports:     root.Imports,
+		Diagnostics: parent.Diagnostics,
+		ReturnType:  parent.ReturnType,
+		Z:           parent.Z,
+		ErrorTypes:  parent.ErrorTypes,
+		Spawn:       parent.Spawn,
+		IsGeneric:   parent.IsGeneric}
 }
 
 func (t *Context) Set(label string, value types.Type) {
@@ -86,10 +136,24 @@ func (t *Context) Get(label string) (types.Type, error) {
 		}
 		current = current.Parent
 	}
-	return types.Empty{}, errors.New(fmt.Sprintf("Undefined %s", label))
+	return types.Empty{}, Failf(CodeUndefined, "Undefined %s", label)
 }
 
 func (t *Context) Contains(label string) bool {
 	_, ok := t.Values[label]
 	return ok
 }
+
+// InstanceName returns the generated name of an instance of a generic function
+func (t *Context) InstanceName(label string, genericMap GenericMap) (string, bool) {
+	root := t
+	if t.Root != nil {
+		root = t.Root
+	}
+	names, ok := root.Instantiations.Names[label]
+	if !ok {
+		return "", false
+	}
+	name, ok := names[FunctionName(Function{}, genericMap)]
+	return name, ok
+}
@@ -0,0 +1,307 @@
+package compiler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// Poisoned is returned for expressions using the result of a broken one
+// It's not reported: the broken expression already was
+var Poisoned = errors.New("poisoned")
+
+// Severity of a diagnostic
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a compiler error at a position in a melt file
+type Diagnostic struct {
+	LocationInfo
+	Code     ErrorCode
+	Severity Severity
+	Message  string
+	// Source is the line of the position, used to show a caret under it
+	Source string
+	// Related are other positions explaining the diagnostic
+	Related []Related
+}
+
+// Related is a position mentioned by a diagnostic, e.g. an earlier definition
+type Related struct {
+	LocationInfo
+	Message string
+}
+
+func (d *Diagnostic) Error() string {
+	message := d.Message
+	if d.Severity == SeverityWarning {
+		message = "warning: " + message
+	}
+	if d.Line == 0 {
+		if d.File == "" {
+			return message
+		}
+		return fmt.Sprintf("%s: %s", d.File, message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, message)
+}
+
+// Show adds the source line, a caret under the column and the related positions
+func (d *Diagnostic) Show() string {
+	related := ""
+	for _, r := range d.Related {
+		related += fmt.Sprintf("\n%s:%d:%d: %s", r.File, r.Line, r.Column, r.Message)
+	}
+	if d.Line == 0 || d.Source == "" {
+		return d.Error() + related
+	}
+
+	// tabs are kept, so the caret is aligned however they're shown
+	caret := []rune{}
+	for i, c := range []rune(d.Source) {
+		if i >= d.Column-1 {
+			break
+		}
+		if c == '\t' {
+			caret = append(caret, '\t')
+		} else {
+			caret = append(caret, ' ')
+		}
+	}
+	return fmt.Sprintf("%s\n%s\n%s^%s", d.Error(), d.Source, string(caret), related)
+}
+
+// Errorf returns a diagnostic at the position of the node
+func Errorf(node Ast, code ErrorCode, format string, args ...interface{}) error {
+	return &Diagnostic{LocationInfo: node.Location(), Code: code, Severity: SeverityError, Message: fmt.Sprintf(format, args...)}
+}
+
+// Warnf returns a warning at the position of the node, it doesn't fail the check
+func Warnf(node Ast, code ErrorCode, format string, args ...interface{}) error {
+	return &Diagnostic{LocationInfo: node.Location(), Code: code, Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)}
+}
+
+// Failf returns a diagnostic without a position, Locate gives it one
+func Failf(code ErrorCode, format string, args ...interface{}) error {
+	return &Diagnostic{Code: code, Severity: SeverityError, Message: fmt.Sprintf(format, args...)}
+}
+
+// Relate adds a related position to a diagnostic
+func Relate(err error, node Ast, format string, args ...interface{}) error {
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) {
+		diagnostic.Related = append(diagnostic.Related, Related{LocationInfo: node.Location(), Message: fmt.Sprintf(format, args...)})
+	}
+	return err
+}
+
+// Locate gives an error without a position the position of the node
+func Locate(err error, node Ast) error {
+	if err == nil || err == Poisoned {
+		return err
+	}
+	var list DiagnosticList
+	if errors.As(err, &list) {
+		return list
+	}
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) {
+		if diagnostic.Line == 0 && node.Location().Line != 0 {
+			diagnostic.LocationInfo = node.Location()
+		}
+		return diagnostic
+	}
+	return &Diagnostic{LocationInfo: node.Location(), Code: CodeInternal, Severity: SeverityError, Message: err.Error()}
+}
+
+// DiagnosticList is the error of a check with several diagnostics
+type DiagnosticList []*Diagnostic
+
+func (l DiagnosticList) Error() string {
+	lines := []string{}
+	for _, diagnostic := range l {
+		lines = append(lines, diagnostic.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Sort orders the diagnostics by position
+func (l DiagnosticList) Sort() {
+	sort.SliceStable(l, func(i, j int) bool {
+		a, b := l[i].LocationInfo, l[j].LocationInfo
+		if a.File != b.File {
+			return a.File < b.File
+		} else if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// Err is nil without diagnostics or with warnings only
+func (l DiagnosticList) Err() error {
+	for _, diagnostic := range l {
+		if diagnostic.Severity != SeverityWarning {
+			return l
+		}
+	}
+	return nil
+}
+
+// Warnings are the warnings of the list
+func (l DiagnosticList) Warnings() DiagnosticList {
+	warnings := DiagnosticList{}
+	for _, diagnostic := range l {
+		if diagnostic.Severity == SeverityWarning {
+			warnings = append(warnings, diagnostic)
+		}
+	}
+	return warnings
+}
+
+// Report collects an error, so checking can go on after it
+func (self *Context) Report(err error) {
+	if err == nil || err == Poisoned {
+		return
+	}
+	var list DiagnosticList
+	var diagnostic *Diagnostic
+	if errors.As(err, &list) {
+		*self.Diagnostics = append(*self.Diagnostics, list...)
+	} else if errors.As(err, &diagnostic) {
+		*self.Diagnostics = append(*self.Diagnostics, diagnostic)
+	} else {
+		*self.Diagnostics = append(*self.Diagnostics, &Diagnostic{Code: CodeInternal, Severity: SeverityError, Message: err.Error()})
+	}
+}
+
+// Poison defines a label whose definition is broken
+func (self *Context) Poison(label string) {
+	self.Set(label, types.Empty{})
+}
+
+// PoisonedName is true for a name of a broken import: util.Pair when util isn't found
+func (self *Context) PoisonedName(label string) bool {
+	dot := strings.Index(label, ".")
+	if dot == -1 {
+		return false
+	}
+	t, err := self.Get(label[:dot])
+	return err == nil && IsPoisoned(t)
+}
+
+// IsPoisoned is true for the type of a broken definition
+func IsPoisoned(t types.Type) bool {
+	_, ok := t.(types.Empty)
+	return ok
+}
+
+// Explain fills in the file and the source line of a diagnostic in the module
+func (self *Module) Explain(err error) error {
+	if err == nil {
+		return nil
+	}
+	var list DiagnosticList
+	if errors.As(err, &list) {
+		for _, diagnostic := range list {
+			if diagnostic.File == self.File {
+				self.Explain(diagnostic)
+			}
+		}
+		return list
+	}
+	var diagnostic *Diagnostic
+	if !errors.As(err, &diagnostic) {
+		return &Diagnostic{LocationInfo: LocationInfo{File: self.File}, Code: CodeInternal, Severity: SeverityError, Message: err.Error()}
+	}
+	if diagnostic.File == "" {
+		diagnostic.File = self.File
+	}
+	for i, related := range diagnostic.Related {
+		if related.File == "" {
+			diagnostic.Related[i].File = diagnostic.File
+		}
+	}
+	if diagnostic.File == self.File && diagnostic.Source == "" && diagnostic.Line > 0 && diagnostic.Line <= len(self.Source) {
+		diagnostic.Source = strings.TrimRight(self.Source[diagnostic.Line-1], "\r")
+	}
+	return diagnostic
+}
+
+type jsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type jsonRange struct {
+	Start jsonPosition `json:"start"`
+	End   jsonPosition `json:"end"`
+}
+
+type jsonRelated struct {
+	File    string    `json:"file"`
+	Range   jsonRange `json:"range"`
+	Message string    `json:"message"`
+}
+
+type jsonDiagnostic struct {
+	File     string        `json:"file"`
+	Range    jsonRange     `json:"range"`
+	Severity Severity      `json:"severity"`
+	Code     ErrorCode     `json:"code"`
+	Message  string        `json:"message"`
+	Related  []jsonRelated `json:"related"`
+}
+
+// rangeOf is the range of a position, a position without an end is empty
+func rangeOf(location LocationInfo) jsonRange {
+	start := jsonPosition{Line: location.Line, Column: location.Column}
+	end := start
+	if location.EndLine != 0 {
+		end = jsonPosition{Line: location.EndLine, Column: location.EndColumn}
+	}
+	return jsonRange{Start: start, End: end}
+}
+
+// MarshalJSON writes a diagnostic as
+// {"file", "range": {"start", "end"}, "severity", "code", "message", "related"}
+// Lines and columns start from 1, 0 if unknown
+func (d *Diagnostic) MarshalJSON() ([]byte, error) {
+	severity, code := d.Severity, d.Code
+	if severity == "" {
+		severity = SeverityError
+	}
+	if code == "" {
+		code = CodeInternal
+	}
+	related := []jsonRelated{}
+	for _, r := range d.Related {
+		related = append(related, jsonRelated{File: r.File, Range: rangeOf(r.LocationInfo), Message: r.Message})
+	}
+	return MarshalJSON(jsonDiagnostic{
+		File:     d.File,
+		Range:    rangeOf(d.LocationInfo),
+		Severity: severity,
+		Code:     code,
+		Message:  d.Message,
+		Related:  related}, "")
+}
+
+// MarshalJSON is json.Marshal without escaping the < > and & of types
+func MarshalJSON(v interface{}, indent string) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	err := encoder.Encode(v)
+	return bytes.TrimRight(buffer.Bytes(), "\n"), err
+}
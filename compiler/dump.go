@@ -0,0 +1,143 @@
+package compiler
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// AstDump is a node of the tree printed by melt ast
+// Children are the nodes in the fields of the node, in field order
+type AstDump struct {
+	Kind string `json:"kind"`
+	// Field is the field of the parent holding the node
+	Field string            `json:"field,omitempty"`
+	Attrs map[string]string `json:"attrs,omitempty"`
+	// Type is the resolved melt type, empty before type checking
+	Type     string     `json:"type,omitempty"`
+	Range    jsonRange  `json:"range"`
+	Children []*AstDump `json:"children"`
+}
+
+// dumpSkipped are fields which aren't part of the tree
+var dumpSkipped = map[string]bool{"Info": true, "Source": true, "Instance": true, "Signature": true, "Failure": true}
+
+var (
+	astType      = reflect.TypeOf((*Ast)(nil)).Elem()
+	meltTypeType = reflect.TypeOf((*types.Type)(nil)).Elem()
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// Dump walks a node and its children
+func Dump(node Ast) *AstDump {
+	return dumpValue(reflect.ValueOf(node), "")
+}
+
+func dumpValue(v reflect.Value, field string) *AstDump {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	d := &AstDump{Kind: v.Type().Name(), Field: field, Attrs: map[string]string{}, Children: []*AstDump{}}
+	var node Ast
+	if v.CanAddr() && v.Addr().Type().Implements(astType) {
+		node = v.Addr().Interface().(Ast)
+	} else if v.Type().Implements(astType) {
+		node = v.Interface().(Ast)
+	}
+	if node != nil {
+		d.Range = rangeOf(node.Location())
+		if t := node.MeltType(); t != nil {
+			d.Type = t.ToString()
+		}
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		if dumpSkipped[name] || v.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		f := v.Field(i)
+		if attr, ok := dumpAttr(f); ok {
+			d.Attrs[name] = attr
+		} else if f.Kind() == reflect.Slice {
+			for j := 0; j < f.Len(); j++ {
+				if child := dumpValue(f.Index(j), name); child != nil {
+					d.Children = append(d.Children, child)
+				}
+			}
+		} else if f.Kind() != reflect.Map {
+			if child := dumpValue(f, name); child != nil {
+				d.Children = append(d.Children, child)
+			}
+		}
+	}
+	return d
+}
+
+// dumpAttr shows the values of a node which aren't nodes: labels, literals, types and operators
+func dumpAttr(f reflect.Value) (string, bool) {
+	if f.Type().Implements(meltTypeType) && f.Kind() != reflect.Ptr {
+		if f.Kind() == reflect.Interface && f.IsNil() {
+			return "", false
+		}
+		return f.Interface().(types.Type).ToString(), true
+	}
+	if f.Type().Implements(stringerType) && f.Kind() != reflect.Ptr && f.Kind() != reflect.Interface {
+		return f.Interface().(fmt.Stringer).String(), true
+	}
+	switch f.Kind() {
+	case reflect.String:
+		return f.String(), f.String() != ""
+	case reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+		return fmt.Sprintf("%v", f.Interface()), true
+	case reflect.Slice:
+		if f.Type().Elem().Kind() == reflect.String {
+			return fmt.Sprintf("%q", f.Interface()), true
+		}
+	}
+	return "", false
+}
+
+// Text is the dump as an indented tree, a node on each line:
+// Field: Kind Attr=value : type @line:column
+func (d *AstDump) Text() string {
+	lines := []string{}
+	d.text(0, &lines)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (d *AstDump) text(depth int, lines *[]string) {
+	line := Indent(depth)
+	if d.Field != "" {
+		line += d.Field + ": "
+	}
+	line += d.Kind
+	keys := []string{}
+	for key := range d.Attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		line += fmt.Sprintf(" %s=%s", key, d.Attrs[key])
+	}
+	if d.Type != "" {
+		line += " : " + d.Type
+	}
+	if d.Range.Start.Line != 0 {
+		line += fmt.Sprintf(" @%d:%d", d.Range.Start.Line, d.Range.Start.Column)
+	}
+	*lines = append(*lines, line)
+	for _, child := range d.Children {
+		child.text(depth+1, lines)
+	}
+}
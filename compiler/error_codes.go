@@ -0,0 +1,54 @@
+package compiler
+
+// ErrorCode identifies the kind of a diagnostic
+// Codes are stable: a code is never reused for another kind of error,
+// so tools can suppress or count specific ones
+type ErrorCode string
+
+const (
+	CodeInternal ErrorCode = "E0000"
+	CodeTooMany  ErrorCode = "E0001"
+
+	// Parsing
+	CodeSyntax      ErrorCode = "E0101"
+	CodeIndentation ErrorCode = "E0102"
+	CodeUnsupported ErrorCode = "E0103"
+	CodePackageName ErrorCode = "E0104"
+
+	// Names
+	CodeUndefined ErrorCode = "E0201"
+	CodeRedefined ErrorCode = "E0202"
+	CodeImport    ErrorCode = "E0203"
+
+	// Types
+	CodeMismatch    ErrorCode = "E0301"
+	CodeNotFunction ErrorCode = "E0302"
+	CodeArgs        ErrorCode = "E0303"
+	CodeMethod      ErrorCode = "E0304"
+	CodeOperand     ErrorCode = "E0305"
+	CodeCondition   ErrorCode = "E0306"
+	CodeIteration   ErrorCode = "E0307"
+	CodeIndex       ErrorCode = "E0308"
+	CodeTypeArgs    ErrorCode = "E0309"
+	CodeNoValue     ErrorCode = "E0310"
+	CodeChannel     ErrorCode = "E0311"
+	CodeInfer       ErrorCode = "E0312"
+	CodeExhaustive  ErrorCode = "E0313"
+	CodeUnreachable ErrorCode = "E0314"
+	CodeField       ErrorCode = "E0315"
+	CodeBreak       ErrorCode = "E0316"
+	CodeNoReturn    ErrorCode = "E0317"
+
+	// Errors
+	CodeErrorKind ErrorCode = "E0401"
+	CodeCantFail  ErrorCode = "E0402"
+	CodeHandled   ErrorCode = "E0403"
+	CodeErrValue  ErrorCode = "E0404"
+	CodeLostError ErrorCode = "E0405"
+	CodeUnhandled ErrorCode = "E0406"
+	// a warning
+	CodeNeverHandled ErrorCode = "E0407"
+
+	// Projects
+	CodeManifest ErrorCode = "E0501"
+)
@@ -0,0 +1,159 @@
+package compiler
+
+import (
+	"strings"
+	"unicode"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// errorType is the type of $err in an on f as NotFound: arm and of the values
+// a function returns as a NotFound error: the record or, if its Error method
+// has a pointer receiver, a pointer to it
+func errorType(label *Label, ctx *Context) (types.Type, error) {
+	t, err := ctx.Get(label.Label)
+	record, ok := t.(types.Record)
+	if err != nil {
+		return nil, Errorf(label, CodeUndefined, "Undefined error type %s", label.Label)
+	} else if !ok {
+		return nil, Errorf(label, CodeErrorKind, "%s is not a record, errors are records with an Error() string method", label.Label)
+	}
+	if IsError(record) {
+		return record, nil
+	} else if pointer := (types.Pointer{Object: record}); IsError(pointer) {
+		return pointer, nil
+	}
+	return nil, Errorf(label, CodeErrorKind, "%s doesn't have an Error() string method", label.Label)
+}
+
+// errorLabel is the record of an error type: NotFound for NotFound and *NotFound
+func errorLabel(t types.Type) string {
+	switch other := t.(type) {
+	case types.Basic:
+		return other.Label
+	case types.Record:
+		return other.Label
+	case types.Pointer:
+		return errorLabel(other.Object)
+	}
+	return ""
+}
+
+// declares is true if the label is one of the error types
+func declares(errorTypes []types.Type, label string) bool {
+	for _, t := range errorTypes {
+		if errorLabel(t) == label {
+			return true
+		}
+	}
+	return false
+}
+
+// declaredError is true for a value of one of the declared error types
+func declaredError(errorTypes []types.Type, t types.Type) bool {
+	if _, ok := t.(types.Basic); ok {
+		return false
+	}
+	for _, declared := range errorTypes {
+		if declared.Accepts(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorTypeList is NotFound | Denied
+func errorTypeList(errorTypes []types.Type) string {
+	labels := []string{}
+	for _, t := range errorTypes {
+		labels = append(labels, errorLabel(t))
+	}
+	return strings.Join(labels, " | ")
+}
+
+// ErrorVariable is the go variable of $err: err in on f:
+// and notFoundErr in on f as NotFound:
+func ErrorVariable(t types.Type) string {
+	label := errorLabel(t)
+	if label == "" {
+		return "err"
+	}
+	runes := []rune(label)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes) + "Err"
+}
+
+// checkEscalated warns about the errors escalated with a declared type
+// which no function up the escalate chain handles with an on f as T: arm
+// The callers outside of the package aren't known, they aren't counted
+func (p *Package) checkEscalated(ctx *Context) {
+	// escalated has the functions escalating the errors of each label,
+	// arms the error types handled for each label
+	escalated := map[string]map[string]bool{}
+	arms := map[string]map[string]bool{}
+	for _, m := range p.Modules {
+		for _, function := range m.Functions {
+			inspectOwn(function, func(node Ast) {
+				if e, ok := node.(*Escalate); ok {
+					for _, arg := range e.Args {
+						if escalated[BareLabel(arg.Label)] == nil {
+							escalated[BareLabel(arg.Label)] = map[string]bool{}
+						}
+						escalated[BareLabel(arg.Label)][function.Label.Label] = true
+					}
+				}
+			})
+			Inspect(function, func(node Ast) {
+				if o, ok := node.(*On); ok && o.Type != nil {
+					label := BareLabel(o.Label.Label)
+					if arms[label] == nil {
+						arms[label] = map[string]bool{}
+					}
+					arms[label][o.Type.Label] = true
+				}
+			})
+		}
+	}
+
+	// handled is true if a caller of label handles errorType or escalates it
+	// to a caller handling it
+	var handled func(label string, errorType string, visited map[string]bool) bool
+	handled = func(label string, errorType string, visited map[string]bool) bool {
+		if arms[label][errorType] {
+			return true
+		} else if visited[label] {
+			return false
+		}
+		visited[label] = true
+		for caller := range escalated[label] {
+			if handled(caller, errorType, visited) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, m := range p.Modules {
+		for _, function := range m.Functions {
+			inspectOwn(function, func(node Ast) {
+				e, ok := node.(*Escalate)
+				if !ok {
+					return
+				}
+				for _, arg := range e.Args {
+					label := BareLabel(arg.Label)
+					t, err := ctx.Get(label)
+					f, ok := t.(types.Function)
+					if err != nil || !ok {
+						continue
+					}
+					for _, errorType := range f.ErrorTypes {
+						if !handled(function.Label.Label, errorLabel(errorType), map[string]bool{}) {
+							ctx.Report(Warnf(arg, CodeNeverHandled, "%s errors of %s are escalated, but never handled by an on .. as %s: in the package", errorLabel(errorType), label, errorLabel(errorType)))
+						}
+					}
+				}
+			})
+		}
+	}
+}
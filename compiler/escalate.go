@@ -7,9 +7,18 @@ import (
 	"gitlab.com/alehander42/melt/types"
 )
 
-// Escalate node
+// Escalate node: escalate f returns the first error of the calls of f before it,
+// escalate f with "message" wraps it with the message
 type Escalate struct {
-	Args []*Label
+	Args    []*Label
+	Message Ast
+	// Errors are the variables of the errors it returns, in order
+	Errors []string
+	// Return is the return type of the function, it returns its zero value
+	// with the error
+	Return types.Type
+	// Fails is false in a generic instance which can't fail
+	Fails bool
 
 	Info
 }
@@ -30,6 +39,17 @@ func (self *Escalate) TypeCheck(ctx *Context) error {
 		}
 	}
 
+	if self.Message != nil {
+		err := self.Message.TypeCheck(ctx)
+		if err != nil {
+			return err
+		}
+		if !IsString(self.Message.MeltType()) {
+			return Errorf(self.Message, CodeErrValue, "escalate .. with expects a string message, got %s", self.Message.MeltType().ToString())
+		}
+	}
+	self.Return = ctx.ReturnType
+	self.Fails = ctx.Z != types.Correct
 	self.ZType = types.Nil{}
 	return nil
 }
@@ -45,12 +65,16 @@ func (self *Escalate) typeCheckSingle(arg *Label, ctx *Context) error {
 	}
 
 	m, err := ctx.Get(label)
+	if err != nil && ctx.Failures.Pending(label) {
+		// a failing method isn't a label: escalate Atoi for strconv.Atoi!(..)
+		m, err = types.Function{Error: types.Fail}, nil
+	}
 	if err != nil {
-		return fmt.Errorf("escalate %s is not defined", label)
+		return Errorf(arg, CodeUndefined, "escalate %s is not defined", label)
 	} else {
 		if f, ok := m.(types.Function); ok {
 			if f.Error == types.Correct {
-				return fmt.Errorf("%s can't return an error", label)
+				return Errorf(arg, CodeCantFail, "%s can't return an error", label)
 			} else if f.Error == types.Maybe {
 				if ctx.Z == types.Correct {
 					ctx.Z = types.Maybe
@@ -58,10 +82,36 @@ func (self *Escalate) typeCheckSingle(arg *Label, ctx *Context) error {
 			} else {
 				ctx.Z = types.Fail
 			}
+			if len(ctx.ErrorTypes) > 0 {
+				// reported after the errors are taken, they aren't unhandled too
+				err = escalatesDeclared(arg, f, ctx)
+			}
 		} else {
-			return fmt.Errorf("%s is not a function", label)
+			return Errorf(arg, CodeNotFunction, "%s is not a function", label)
+		}
+	}
+	for _, failure := range ctx.Take(label, nil) {
+		if failure.Loops > ctx.Loops {
+			// the error of a later iteration would overwrite it
+			failure.Escalate = self
+		} else {
+			self.Errors = append(self.Errors, failure.Variable())
+		}
+	}
+	return err
+}
+
+// escalatesDeclared checks that a function declaring its errors
+// escalates only the errors it declares
+func escalatesDeclared(arg *Label, f types.Function, ctx *Context) error {
+	label := BareLabel(arg.Label)
+	if len(f.ErrorTypes) == 0 {
+		return Errorf(arg, CodeErrValue, "%s fails only with %s, %s doesn't declare its errors", ctx.Label, errorTypeList(ctx.ErrorTypes), label)
+	}
+	for _, t := range f.ErrorTypes {
+		if !declares(ctx.ErrorTypes, errorLabel(t)) {
+			return Errorf(arg, CodeErrValue, "%s fails only with %s, %s can fail with %s", ctx.Label, errorTypeList(ctx.ErrorTypes), label, errorLabel(t))
 		}
 	}
-	delete(*ctx.Unhandled, label)
 	return nil
 }
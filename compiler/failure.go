@@ -0,0 +1,269 @@
+package compiler
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Failure is a failing call or a spawn: block, its error is kept in a go
+// variable until an on handler takes it
+type Failure struct {
+	// Location is the position of the call or the spawn: block
+	Location LocationInfo
+	Label    string
+	// Index numbers the failures of Label in the function: readErr0, readErr1..
+	Index int
+	// Taken is true if a handler uses the error
+	Taken bool
+	// Handler is the last on taking the error
+	Handler *On
+	// Spawn is true for a spawn: block, it declares its own error
+	Spawn bool
+	// Loops is the number of loops around the call in its function
+	Loops int
+	// Escalate is the escalate out of the call's loop taking the error,
+	// the call returns it right away
+	Escalate *Escalate
+	// Lost is the first return or !! the failure reaches unhandled,
+	// LostAt names it
+	Lost   Ast
+	LostAt string
+	// First is true if its handler is after the call's loop,
+	// the error of a later iteration doesn't overwrite the first one
+	First bool
+}
+
+// Variable keeps the error of the failure
+func (self *Failure) Variable() string {
+	if self.Spawn {
+		return self.Label + "Err"
+	}
+	return fmt.Sprintf("%sErr%d", self.Label, self.Index)
+}
+
+// Temp keeps the value of a failing call until the expression using it
+func (self *Failure) Temp() string {
+	return fmt.Sprintf("%sValue%d", self.Label, self.Index)
+}
+
+// Iteration keeps the error of one iteration of a call with First
+func (self *Failure) Iteration() string {
+	return fmt.Sprintf("%sLoopErr%d", self.Label, self.Index)
+}
+
+// Cond keeps the result of a && or || with the call in its right operand
+func (self *Failure) Cond() string {
+	return fmt.Sprintf("%sCond%d", self.Label, self.Index)
+}
+
+// Failures are the failures of a function, a lambda or a goroutine in order
+type Failures []*Failure
+
+// Add is a new failure of label in loops loops
+func (self *Failures) Add(label string, loops int) *Failure {
+	index := 0
+	for _, failure := range *self {
+		if failure.Label == label && !failure.Spawn && failure.Index >= index {
+			index = failure.Index + 1
+		}
+	}
+	failure := &Failure{Label: label, Index: index, Loops: loops}
+	*self = append(*self, failure)
+	return failure
+}
+
+// Pending is true if a failure of label isn't handled yet
+func (self Failures) Pending(label string) bool {
+	for _, failure := range self {
+		if failure.Label == label && !failure.Taken {
+			return true
+		}
+	}
+	return false
+}
+
+// Drop forgets the failure of a call, its error goes somewhere else:
+// to the spawn: block of a go call or out of a short lambda
+func (self *Failures) Drop(call Ast) {
+	failure := CallFailure(call)
+	switch kind := call.(type) {
+	case *Call:
+		kind.Failure = nil
+	case *MethodCall:
+		kind.Failure = nil
+	}
+	for i, other := range *self {
+		if other == failure {
+			*self = append((*self)[:i], (*self)[i+1:]...)
+			return
+		}
+	}
+}
+
+// Errors are the variables of the taken errors, declared with the function
+// so a handler in any of its blocks sees them
+func (self Failures) Errors() []string {
+	errors := []string{}
+	for _, failure := range self {
+		if failure.Taken && !failure.Spawn {
+			errors = append(errors, failure.Variable())
+		}
+	}
+	return errors
+}
+
+// Fail records a failing call or spawn: block of label, an on or an escalate
+// has to handle its error on each path to the end of the function
+func (self *Context) Fail(node Ast, label string) *Failure {
+	failure := self.Failures.Add(label, self.Loops)
+	failure.Location = node.Location()
+	(*self.Unhandled)[label] = append((*self.Unhandled)[label], failure)
+	delete(*self.Handled, label)
+	return failure
+}
+
+// Take handles the failures of label which aren't handled on this path
+// by handler, it returns them in order. A failure handled on another path
+// before is cleared by that handler, so it isn't handled twice
+func (self *Context) Take(label string, handler *On) []*Failure {
+	taken := (*self.Unhandled)[label]
+	for _, failure := range taken {
+		failure.Taken = true
+		if failure.Handler != nil {
+			failure.Handler.Clear = true
+		}
+		failure.Handler = handler
+	}
+	delete(*self.Unhandled, label)
+	return taken
+}
+
+// Drop forgets the failure of a call, its error goes out of the function
+func (self *Context) Drop(call Ast) {
+	failure := CallFailure(call)
+	if failure != nil {
+		unhandled := []*Failure{}
+		for _, other := range (*self.Unhandled)[failure.Label] {
+			if other != failure {
+				unhandled = append(unhandled, other)
+			}
+		}
+		(*self.Unhandled)[failure.Label] = unhandled
+		if len(unhandled) == 0 {
+			delete(*self.Unhandled, failure.Label)
+		}
+	}
+	self.Failures.Drop(call)
+}
+
+// Branch is the context of an if or match arm, a select case or a loop body:
+// the errors handled in it are handled only on its path
+func (self *Context) Branch() *Context {
+	c := NewContextIn(self)
+	unhandled := make(map[string][]*Failure)
+	for label, failures := range *self.Unhandled {
+		unhandled[label] = append([]*Failure{}, failures...)
+	}
+	handled := make(map[string]Ast)
+	for label, handler := range *self.Handled {
+		handled[label] = handler
+	}
+	c.Unhandled = &unhandled
+	c.Handled = &handled
+	c.Returned = new(bool)
+	return c
+}
+
+// Join goes on after the branches: a failure unhandled on one of their paths
+// is still unhandled, an error is handled only if it is on all of them.
+// If the branches aren't exhaustive, the code can skip them all.
+// A branch which returned doesn't go on, if all of them return the path returns
+func (self *Context) Join(branches []*Context, exhaustive bool) {
+	paths := []*Context{}
+	for _, branch := range branches {
+		if !*branch.Returned {
+			paths = append(paths, branch)
+		}
+	}
+	if exhaustive && len(branches) > 0 && len(paths) == 0 {
+		*self.Returned = true
+		return
+	}
+	if !exhaustive || len(branches) == 0 {
+		paths = append([]*Context{self}, paths...)
+	}
+	unhandled := make(map[string][]*Failure)
+	for _, path := range paths {
+		for label, failures := range *path.Unhandled {
+			for _, failure := range failures {
+				if !containsFailure(unhandled[label], failure) {
+					unhandled[label] = append(unhandled[label], failure)
+				}
+			}
+		}
+	}
+	for _, failures := range unhandled {
+		sort.SliceStable(failures, func(i, j int) bool { return failures[i].Index < failures[j].Index })
+	}
+	handled := make(map[string]Ast)
+	for label, handler := range *paths[0].Handled {
+		all := true
+		for _, path := range paths[1:] {
+			if _, ok := (*path.Handled)[label]; !ok {
+				all = false
+				break
+			}
+		}
+		if all {
+			handled[label] = handler
+		}
+	}
+	*self.Unhandled = unhandled
+	*self.Handled = handled
+}
+
+func containsFailure(failures []*Failure, failure *Failure) bool {
+	for _, other := range failures {
+		if other == failure {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportUnhandled reports the failures which reach a return, a !! or
+// the end of the function without an on or an escalate, in the order of
+// their calls. It runs at the end, as an escalate after a loop handles the
+// failures of the loop at the call, also before a return in the loop
+func (self *Context) ReportUnhandled() {
+	for _, failure := range *self.Failures {
+		if failure.Escalate != nil || failure.Lost == nil && !containsFailure((*self.Unhandled)[failure.Label], failure) {
+			continue
+		}
+		name := failure.Label
+		if failure.Spawn {
+			name = "spawn " + name
+		}
+		diagnostic := &Diagnostic{
+			LocationInfo: failure.Location,
+			Code:         CodeUnhandled,
+			Severity:     SeverityError,
+			Message:      fmt.Sprintf("The error of %s isn't handled, it needs an on %s: or an escalate %s", name, failure.Label, failure.Label)}
+		if failure.Lost != nil {
+			diagnostic.Message = fmt.Sprintf("The error of %s isn't handled before the %s, it needs an on %s: or an escalate %s before it", name, failure.LostAt, failure.Label, failure.Label)
+			diagnostic.Related = append(diagnostic.Related, Related{LocationInfo: failure.Lost.Location(), Message: fmt.Sprintf("the %s is here", failure.LostAt)})
+		}
+		self.Report(diagnostic)
+	}
+}
+
+// Leave ends the path at a return or a !!, the failures which aren't
+// handled before it are reported with the function
+func (self *Context) Leave(exit Ast, name string) {
+	for _, failure := range *self.Failures {
+		if failure.Lost == nil && containsFailure((*self.Unhandled)[failure.Label], failure) {
+			failure.Lost, failure.LostAt = exit, name
+		}
+	}
+	*self.Returned = true
+}
@@ -1,9 +1,6 @@
 package compiler
 
 import (
-	"errors"
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
@@ -11,11 +8,31 @@ type ForIn struct {
 	Index    []Label
 	Sequence *Ast
 	Code     *Code
+	Exit     Exit
 
 	Info
 }
 
+// TypeCheck checks the body even with a broken sequence,
+// its indices are poisoned then
 func (f *ForIn) TypeCheck(ctx *Context) error {
+	codeCtx := ctx.Branch()
+	codeCtx.enterLoop(&f.Exit)
+	err := f.defineIndex(ctx, codeCtx)
+	if err != nil {
+		ctx.Report(err)
+		for _, index := range f.Index {
+			codeCtx.Poison(index.Label)
+		}
+	}
+
+	err = f.Code.TypeCheck(codeCtx)
+	// the body can run no times
+	ctx.Join([]*Context{codeCtx}, false)
+	return err
+}
+
+func (f *ForIn) defineIndex(ctx *Context, codeCtx *Context) error {
 	err := (*f.Sequence).TypeCheck(ctx)
 	if err != nil {
 		return err
@@ -23,7 +40,7 @@ func (f *ForIn) TypeCheck(ctx *Context) error {
 
 	duck, ok := (*f.Sequence).MeltType().(types.Duck)
 	if !ok {
-		return errors.New("Doesn't support for")
+		return Errorf(*f.Sequence, CodeIteration, "Can't iterate over %s", (*f.Sequence).MeltType().ToString())
 	}
 
 	var index Label
@@ -39,15 +56,14 @@ func (f *ForIn) TypeCheck(ctx *Context) error {
 	if len(f.Index) == 2 {
 		_, err = ctx.Get(index.Label)
 		if err == nil {
-			return fmt.Errorf("Can't redefine index %s", index.Label)
+			return Errorf(&index, CodeRedefined, "Can't redefine index %s", index.Label)
 		}
 	}
 	_, err = ctx.Get(value.Label)
 	if err == nil {
-		return fmt.Errorf("Can't redefine index %s", value.Label)
+		return Errorf(&value, CodeRedefined, "Can't redefine index %s", value.Label)
 	}
 
-	codeCtx := NewContextIn(ctx)
 	if s, ok := duck.(types.MapBuiltin); ok {
 		codeCtx.Set(index.Label, s.Key)
 		codeCtx.Set(value.Label, s.Value)
@@ -58,28 +74,26 @@ func (f *ForIn) TypeCheck(ctx *Context) error {
 		}
 		if t, ok := duck.(types.SliceBuiltin); ok {
 			codeCtx.Set(value.Label, t.Element)
+		} else if t, ok := duck.(types.Array); ok {
+			codeCtx.Set(value.Label, t.Element)
 		} else {
 			u, ok := types.Accepts(duck, "Begin")
 			v, ok2 := types.Accepts(duck, "Next")
 			if !ok || !ok2 {
-				return errors.New("Needs to define Begin() *T and Next() *T")
+				return Errorf(*f.Sequence, CodeIteration, "%s needs to define Begin() *T and Next() *T", duck.ToString())
 			}
 
 			if u2, ok := IterableMethod(u.Function); !ok {
-				return errors.New("Invalid Begin()")
+				return Errorf(*f.Sequence, CodeIteration, "Invalid Begin() %s", u.Function.ToString())
 			} else if v2, ok := IterableMethod(v.Function); !ok {
-				return errors.New("Invalid Next()")
+				return Errorf(*f.Sequence, CodeIteration, "Invalid Next() %s", v.Function.ToString())
 			} else if !u2.Object.Accepts(v2.Object) {
-				return errors.New("Invalid Next() type")
+				return Errorf(*f.Sequence, CodeIteration, "Next() returns %s, Begin() %s", v2.ToString(), u2.ToString())
 			} else {
 				codeCtx.Set(value.Label, v2.Object)
 			}
 		}
 	}
-	err = f.Code.TypeCheck(codeCtx)
-	if err != nil {
-		return err
-	}
 	return nil
 }
 
@@ -93,11 +107,15 @@ func IterableMethod(u types.Function) (types.Pointer, bool) {
 	}
 }
 
+// ForLoop node: for i in begin...end
+// .. includes end, ... stops before it
 type ForLoop struct {
-	Index *Label
-	Begin *Ast
-	End   *Ast
-	Code  *Code
+	Index     *Label
+	Begin     *Ast
+	End       *Ast
+	Inclusive bool
+	Code      *Code
+	Exit      Exit
 
 	Info
 }
@@ -114,32 +132,34 @@ func (self *ForLoop) TypeCheck(ctx *Context) error {
 	}
 
 	_, err = ctx.Get(self.Index.Label)
-	if err != nil {
-		return errors.New("For index already defined")
+	if err == nil {
+		return Errorf(self.Index, CodeRedefined, "Can't redefine index %s", self.Index.Label)
 	}
 
 	if begin, ok := (*self.Begin).MeltType().(types.Basic); ok {
 		if begin.Label != "int" {
-			return errors.New("For begin should be an int")
+			return Errorf(*self.Begin, CodeIteration, "For begin should be an int")
 		}
 
 		if end, ok := (*self.End).MeltType().(types.Basic); ok {
 			if end.Label != "int" {
-				return errors.New("For end should be an int")
+				return Errorf(*self.End, CodeIteration, "For end should be an int")
 			}
 		} else {
-			return errors.New("For end should be an int")
+			return Errorf(*self.End, CodeIteration, "For end should be an int")
 		}
 
-		forCtx := NewContextIn(ctx)
+		forCtx := ctx.Branch()
+		forCtx.enterLoop(&self.Exit)
 		forCtx.Set(self.Index.Label, begin)
 		self.Index.ZType = begin
 		err = self.Code.TypeCheck(forCtx)
+		ctx.Join([]*Context{forCtx}, false)
 		if err != nil {
 			return err
 		}
 	} else {
-		return errors.New("For begin should be an int")
+		return Errorf(*self.Begin, CodeIteration, "For begin should be an int")
 	}
 	return nil
 }
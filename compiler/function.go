@@ -15,11 +15,15 @@ type Signature struct {
 }
 
 // Function node
+// A method has a Receiver, its type doesn't include it
 type Function struct {
 	Label     *Label
+	Receiver  *Arg
 	Signature *Signature
 	Code      *Code
 	Args      []Arg
+	// Errors are the variables of the errors taken by handlers
+	Errors []string
 
 	Info
 }
@@ -42,47 +46,52 @@ func (*Signature) TypeCheck(*Context) error {
 
 func (f *Function) TypeCheck(ctx *Context) error {
 	c := NewContextIn(ctx)
-	unhandled := make(map[string]bool)
+	unhandled := make(map[string][]*Failure)
 	c.Unhandled = &unhandled
+	handled := make(map[string]Ast)
+	c.Handled = &handled
+	c.Returned = new(bool)
+	failures := Failures{}
+	c.Failures = &failures
+	c.Loops = 0
+	c.Exit = nil
 	ftype, _ := f.ZType.(types.Function)
 	c.ReturnType = ftype.Return
 	c.Z = ftype.Error
-	c.Root.Dependencies[f.Label.Label] = make(map[string][]GenericMap)
-	c.Label = f.Label.Label
+	c.Root.Dependencies[f.Name()] = make(map[string][]GenericMap)
+	c.Label = f.Name()
+
+	for _, t := range ftype.ErrorTypes {
+		label := ToLabel(errorLabel(t))
+		label.SetLocation(f.Label.Location())
+		declared, err := errorType(label, ctx)
+		if err != nil {
+			return err
+		}
+		c.ErrorTypes = append(c.ErrorTypes, declared)
+	}
+
+	if f.Receiver != nil {
+		receiver, generic, err := f.receiverType(ctx)
+		if err != nil {
+			return err
+		}
+		c.IsGeneric = generic
+		c.Set(f.Receiver.ID.Label, receiver)
+	}
 
 	baba := []Arg{}
 	for _, arg := range f.Args {
 		if placeholder, ok := arg.Type.(types.Interface); ok {
-			kind, err := ctx.Get(placeholder.Label)
+			next, err := refineArg(&arg, placeholder, ctx)
 			if err != nil {
-				return err
-			}
-
-			var next types.Type
-
-			switch actual := kind.(type) {
-			case types.Interface:
-				if len(actual.GenericVars) != len(placeholder.GenericVars) {
-					return fmt.Errorf("Refine %s", placeholder.Label)
-				} else {
-					(&placeholder).Extend(actual.Methods())
-					next = placeholder
-				}
-			case types.Record:
-				if len(actual.GenericVars) != len(placeholder.GenericVars) {
-					return fmt.Errorf("Wrong number of %s args", placeholder.Label)
-				} else {
-					next2 := types.Record{Label: actual.Label, Fields: actual.Fields, GenericVars: actual.GenericVars, InstanceVars: actual.InstanceVars}
-					(&next2).ReplaceMethods(actual.Methods())
-					for i, let := range placeholder.GenericVars {
-						next2.InstanceVars[i] = let
-					}
-					next = next2
-				}
-			default:
-				return fmt.Errorf("Signature")
+				// the body is still checked, without the broken arg
+				ctx.Report(err)
+				baba = append(baba, arg)
+				c.Poison(arg.ID.Label)
+				continue
 			}
-			baba = append(baba, Arg{ID: arg.ID, Type: next})
+			baba = append(baba, Arg{ID: arg.ID, Type: next, Info: arg.Info})
 		} else {
 			baba = append(baba, arg)
 		}
@@ -103,13 +112,86 @@ func (f *Function) TypeCheck(ctx *Context) error {
 	if err != nil {
 		return err
 	}
+	if _, ok := c.ReturnType.(types.Empty); !ok && !*c.Returned {
+		ctx.Report(Errorf(f.Label, CodeNoReturn, "%s returns %s, it needs a return at its end", f.Label.Label, c.ReturnType.ToString()))
+	}
+	c.ReportUnhandled()
+	f.Errors = c.Failures.Errors()
 
 	ftype.Args = fArgs
 	f.ZType = ftype
-	ctx.Set(f.Label.Label, f.ZType)
+	if f.Receiver == nil {
+		ctx.Set(f.Label.Label, f.ZType)
+	}
 	return nil
 }
 
+// Name is the label of a function or Stack.Push for a method,
+// methods of different records can have the same label
+func (f *Function) Name() string {
+	if f.Receiver == nil {
+		return f.Label.Label
+	}
+	return fmt.Sprintf("%s.%s", receiverLabel(f.Receiver.Type), f.Label.Label)
+}
+
+// receiverType is the record of a method or a pointer to it,
+// with its generic vars as they're labeled in the receiver
+func (f *Function) receiverType(ctx *Context) (types.Type, bool, error) {
+	label := receiverLabel(f.Receiver.Type)
+	t, err := ctx.Get(label)
+	record, ok := t.(types.Record)
+	if err != nil || !ok {
+		// CollectMethods reported it
+		return nil, false, Poisoned
+	}
+	if placeholder, ok := receiverObject(f.Receiver.Type).(types.Interface); ok && len(placeholder.GenericVars) == len(record.GenericVars) {
+		genericMap := NewGenericMap()
+		for i, v := range record.GenericVars {
+			genericMap.Types[v.Label] = types.Basic{Label: placeholder.GenericVars[i].Label}
+		}
+		record = ReplaceGenericVars(record, genericMap).(types.Record)
+	}
+
+	var receiver types.Type = record
+	if _, ok := f.Receiver.Type.(types.Pointer); ok {
+		receiver = types.Pointer{Object: record}
+	}
+	return receiver, record.IsGeneric(), nil
+}
+
+// refineArg replaces an interface or record placeholder
+// with the actual type of the arg
+func refineArg(arg *Arg, placeholder types.Interface, ctx *Context) (types.Type, error) {
+	kind, err := ctx.Get(placeholder.Label)
+	if err != nil && ctx.PoisonedName(placeholder.Label) {
+		return nil, Poisoned
+	} else if err != nil {
+		return nil, Locate(err, arg)
+	}
+
+	switch actual := kind.(type) {
+	case types.Interface:
+		if len(actual.GenericVars) != len(placeholder.GenericVars) {
+			return nil, Errorf(arg, CodeTypeArgs, "%s expects %d type args", placeholder.Label, len(actual.GenericVars))
+		}
+		(&placeholder).Extend(actual.Methods())
+		return placeholder, nil
+	case types.Record:
+		if len(actual.GenericVars) != len(placeholder.GenericVars) {
+			return nil, Errorf(arg, CodeTypeArgs, "%s expects %d type args", placeholder.Label, len(actual.GenericVars))
+		}
+		next := types.Record{Label: actual.Label, Fields: actual.Fields, Order: actual.Order, GenericVars: actual.GenericVars, InstanceVars: actual.InstanceVars, Package: actual.Package}
+		(&next).ReplaceMethods(actual.Methods())
+		for i, let := range placeholder.GenericVars {
+			next.InstanceVars[i] = let
+		}
+		return next, nil
+	default:
+		return nil, Errorf(arg, CodeTypeArgs, "%s is not an interface or a record", placeholder.Label)
+	}
+}
+
 func (*Arg) TypeCheck(*Context) error {
 	return nil
 }
@@ -3,16 +3,317 @@ package compiler
 import (
 	"errors"
 	"fmt"
+	"go/importer"
+	"go/token"
 	go_types "go/types"
 
 	"gitlab.com/alehander42/melt/types"
 )
 
+// NewGoImporter returns an importer which type checks go packages from source
+func NewGoImporter() go_types.Importer {
+	return importer.ForCompiler(token.NewFileSet(), "source", nil)
+}
+
+// unchecked are the go functions whose results are left out like in go,
+// printing to the standard output isn't a failing call
+var unchecked = map[string]bool{"fmt.Print": true, "fmt.Printf": true, "fmt.Println": true}
+
+// ImportGo loads a go package and translates its exported members
+// Members which can't be expressed in melt are left out
+func ImportGo(goImporter go_types.Importer, path string) (types.Package, error) {
+	goPackage, err := goImporter.Import(path)
+	if err != nil {
+		return types.Package{}, Failf(CodeImport, "Can't import %s: %s", path, err)
+	}
+
+	p := types.NewPackage(goPackage.Name(), path)
+	translator := NewTranslator()
+	scope := goPackage.Scope()
+	for _, name := range scope.Names() {
+		object := scope.Lookup(name)
+		if !object.Exported() {
+			continue
+		}
+		t, err := translator.Translate(object.Type())
+		if err != nil {
+			continue
+		}
+		if function, ok := t.(types.Function); ok && unchecked[path+"."+name] {
+			function.Error = types.Correct
+			function.Return = types.Empty{}
+			t = function
+		}
+		p.Members[name] = t
+	}
+	return p, nil
+}
+
+// Translator converts go types to melt types
+type Translator struct {
+	named map[*go_types.Named]types.Type
+}
+
+func NewTranslator() *Translator {
+	return &Translator{named: make(map[*go_types.Named]types.Type)}
+}
+
+// TranslateType converts a single go type
 func TranslateType(goType go_types.Type) (types.Type, error) {
+	return NewTranslator().Translate(goType)
+}
+
+func (self *Translator) Translate(goType go_types.Type) (types.Type, error) {
 	switch g := goType.(type) {
 	case *go_types.Basic:
-		return types.Basic{Label: g.Name()}, nil
+		return translateBasic(g), nil
+
+	case *go_types.Named:
+		return self.translateNamed(g)
+
+	case *go_types.Alias:
+		return self.Translate(go_types.Unalias(g))
+
+	case *go_types.Pointer:
+		object, err := self.Translate(g.Elem())
+		if err != nil {
+			return types.Pointer{}, err
+		}
+		return types.Pointer{Object: object}, nil
+
+	case *go_types.Slice:
+		element, err := self.Translate(g.Elem())
+		if err != nil {
+			return types.SliceBuiltin{}, err
+		}
+		return types.SliceBuiltin{Element: element}, nil
+
+	case *go_types.Array:
+		element, err := self.Translate(g.Elem())
+		if err != nil {
+			return types.Array{}, err
+		}
+		return types.Array{Element: element, Length: g.Len()}, nil
+
+	case *go_types.Map:
+		key, err := self.Translate(g.Key())
+		if err != nil {
+			return types.MapBuiltin{}, err
+		}
+		value, err := self.Translate(g.Elem())
+		if err != nil {
+			return types.MapBuiltin{}, err
+		}
+		return types.MapBuiltin{Key: key, Value: value}, nil
+
+	case *go_types.Chan:
+		element, err := self.Translate(g.Elem())
+		if err != nil {
+			return types.Channel{}, err
+		}
+		dir := types.Both
+		if g.Dir() == go_types.SendOnly {
+			dir = types.Send
+		} else if g.Dir() == go_types.RecvOnly {
+			dir = types.Receive
+		}
+		return types.Channel{Element: element, Dir: dir}, nil
+
+	case *go_types.Signature:
+		return self.translateSignature(g)
+
+	case *go_types.Struct:
+		fields, err := self.translateFields(g)
+		if err != nil {
+			return types.Record{}, err
+		}
+		return types.NewRecord(g.String(), fields, []types.Method{}, []types.GenericVar{}), nil
+
+	case *go_types.Interface:
+		methods, err := self.translateMethods(g)
+		if err != nil {
+			return types.Interface{}, err
+		}
+		return types.NewInterface("interface{}", methods, []types.GenericVar{}), nil
+
+	default:
+		return types.Basic{}, errors.New(fmt.Sprintf("No melt type for %s", goType.String()))
+	}
+}
+
+func translateBasic(g *go_types.Basic) types.Type {
+	switch g.Kind() {
+	case go_types.UntypedBool:
+		return types.Basic{Label: "bool"}
+	case go_types.UntypedInt:
+		return types.Basic{Label: "int"}
+	case go_types.UntypedFloat:
+		return types.Basic{Label: "float"}
+	case go_types.UntypedString:
+		return types.Basic{Label: "string"}
+	case go_types.UntypedRune:
+		return types.Basic{Label: "rune"}
+	case go_types.UntypedNil:
+		return types.Nil{}
+	default:
+		return types.Basic{Label: g.Name()}
+	}
+}
+
+// translateNamed keeps the go name: structs become records,
+// interfaces melt interfaces and everything else a named type
+// with the methods of the go type
+func (self *Translator) translateNamed(g *go_types.Named) (types.Type, error) {
+	if t, ok := self.named[g]; ok {
+		return t, nil
+	}
+
+	object := g.Obj()
+	if object.Pkg() == nil {
+		if object.Name() == "error" {
+			return types.Error{Label: "err"}, nil
+		}
+		return types.Basic{Label: object.Name()}, nil
+	}
+	if g.TypeArgs().Len() > 0 || g.TypeParams().Len() > 0 {
+		return types.Basic{}, fmt.Errorf("No melt type for generic %s", g.String())
+	}
+
+	label := fmt.Sprintf("%s.%s", object.Pkg().Name(), object.Name())
+	// recursive types see themselves by name
+	self.named[g] = types.Basic{Label: label}
+
+	var t types.Type
+	switch underlying := g.Underlying().(type) {
+	case *go_types.Struct:
+		fields, err := self.translateFields(underlying)
+		if err != nil {
+			delete(self.named, g)
+			return types.Record{}, err
+		}
+		t = types.NewRecord(label, fields, self.namedMethods(g), []types.GenericVar{})
+
+	case *go_types.Interface:
+		methods, err := self.translateMethods(underlying)
+		if err != nil {
+			delete(self.named, g)
+			return types.Interface{}, err
+		}
+		t = types.NewInterface(label, methods, []types.GenericVar{})
+
+	default:
+		translated, err := self.Translate(underlying)
+		if err != nil {
+			delete(self.named, g)
+			return types.Named{}, err
+		}
+		t = types.NewNamed(label, translated, self.namedMethods(g))
+	}
+
+	self.named[g] = t
+	return t, nil
+}
+
+// namedMethods leaves out the methods which can't be expressed in melt
+func (self *Translator) namedMethods(g *go_types.Named) []types.Method {
+	methods := []types.Method{}
+	methodSet := go_types.NewMethodSet(go_types.NewPointer(g))
+	for i := 0; i < methodSet.Len(); i++ {
+		function, ok := methodSet.At(i).Obj().(*go_types.Func)
+		if !ok || !function.Exported() {
+			continue
+		}
+		f, err := self.translateSignature(function.Type().(*go_types.Signature))
+		if err != nil {
+			continue
+		}
+		methods = append(methods, types.Method{Label: function.Name(), Function: f})
+	}
+	return methods
+}
+
+func (self *Translator) translateFields(g *go_types.Struct) (map[string]types.Type, error) {
+	fields := make(map[string]types.Type)
+	for i := 0; i < g.NumFields(); i++ {
+		field := g.Field(i)
+		if !field.Exported() {
+			continue
+		}
+		t, err := self.Translate(field.Type())
+		if err != nil {
+			return fields, err
+		}
+		fields[field.Name()] = t
+	}
+	return fields, nil
+}
+
+// translateMethods leaves out the methods which can't be expressed in melt,
+// like the method sets of structs
+func (self *Translator) translateMethods(g *go_types.Interface) ([]types.Method, error) {
+	methods := []types.Method{}
+	for i := 0; i < g.NumMethods(); i++ {
+		method := g.Method(i)
+		f, err := self.translateSignature(method.Type().(*go_types.Signature))
+		if err != nil {
+			continue
+		}
+		methods = append(methods, types.Method{Label: method.Name(), Function: f})
+	}
+	return methods, nil
+}
+
+// translateSignature converts a go function
+// A last error result makes it a failing melt function: f!()
+// If it's the only result, f() without ! returns the error: errors.New("..")
+func (self *Translator) translateSignature(g *go_types.Signature) (types.Function, error) {
+	if g.TypeParams().Len() > 0 {
+		return types.Function{}, errors.New("No melt type for generic functions")
+	}
+
+	args := []types.Type{}
+	for i := 0; i < g.Params().Len(); i++ {
+		arg, err := self.Translate(g.Params().At(i).Type())
+		if err != nil {
+			return types.Function{}, err
+		}
+		args = append(args, arg)
+	}
+
+	results := []types.Type{}
+	for i := 0; i < g.Results().Len(); i++ {
+		result, err := self.Translate(g.Results().At(i).Type())
+		if err != nil {
+			return types.Function{}, err
+		}
+		results = append(results, result)
+	}
+
+	e := types.Correct
+	errorValue := len(results) == 1
+	if len(results) > 0 {
+		if _, ok := results[len(results)-1].(types.Error); ok {
+			e = types.Fail
+			results = results[:len(results)-1]
+		}
+	}
+
+	var result types.Type
+	switch len(results) {
+	case 0:
+		result = types.Empty{}
+	case 1:
+		result = results[0]
 	default:
-		return types.Basic{}, errors.New(fmt.Sprintf("No %s", "type"))
+		return types.Function{}, errors.New("No melt type for multiple results")
 	}
+
+	return types.Function{
+		Args:         args,
+		Return:       result,
+		Error:        e,
+		GenericVars:  []types.GenericVar{},
+		InstanceVars: []types.Type{},
+		Variadic:     g.Variadic(),
+		ErrorValue:   errorValue && e == types.Fail}, nil
 }
@@ -7,3 +7,11 @@ import (
 func Indent(depth int) string {
 	return strings.Repeat("  ", depth)
 }
+
+// BareLabel strips the ! or ? of a function label
+func BareLabel(label string) string {
+	if len(label) > 0 && (label[len(label)-1] == '!' || label[len(label)-1] == '?') {
+		return label[:len(label)-1]
+	}
+	return label
+}
@@ -1,5 +1,11 @@
 package compiler
 
+import (
+	"fmt"
+	go_types "go/types"
+	"path"
+)
+
 // Import node
 type Import struct {
 	Package string
@@ -16,10 +22,86 @@ type MeltImport struct {
 	Info
 }
 
+// TypeCheck loads the go and melt imports and defines their exported
+// members as qualified names: pkg.Map
+// A broken import is reported and its name poisoned, with the names in it
 func (m *MeltImport) TypeCheck(ctx *Context) error {
+	for i := range m.Go {
+		err := m.Go[i].TypeCheckGo(ctx)
+		if err != nil {
+			ctx.Report(err)
+			ctx.Poison(m.Go[i].Name())
+		}
+	}
+
+	for i := range m.Melt {
+		err := m.Melt[i].TypeCheck(ctx)
+		if err != nil {
+			ctx.Report(err)
+			ctx.Poison(m.Melt[i].Name())
+		}
+	}
+	return nil
+}
+
+// Name is the alias or the last element of the import path
+func (i *Import) Name() string {
+	if i.Alias != "" {
+		return i.Alias
+	}
+	return path.Base(i.Package)
+}
+
+// TypeCheckGo loads a go package with go/types
+func (i *Import) TypeCheckGo(ctx *Context) error {
+	var goImporter go_types.Importer
+	if ctx.Loader != nil {
+		goImporter = ctx.Loader.GoImporter()
+	} else {
+		goImporter = NewGoImporter()
+	}
+
+	t, err := ImportGo(goImporter, i.Package)
+	if err != nil {
+		return Locate(err, i)
+	}
+
+	alias := i.Alias
+	if alias == "" {
+		alias = t.Label
+	}
+	ctx.Set(alias, t)
+	for label, member := range t.Members {
+		ctx.Set(fmt.Sprintf("%s.%s", alias, label), member)
+	}
+	i.ZType = t
 	return nil
 }
 
 func (i *Import) TypeCheck(ctx *Context) error {
+	if ctx.Loader == nil {
+		return Errorf(i, CodeImport, "Can't import %s without a melt path", i.Package)
+	}
+
+	p, err := ctx.Loader.Import(i.Package)
+	if err != nil {
+		return Locate(err, i)
+	}
+
+	alias := i.Alias
+	if alias == "" {
+		alias = p.Name
+	}
+	if other, ok := ctx.Imports[alias]; ok && other != p {
+		return Errorf(i, CodeRedefined, "%s is imported twice", alias)
+	}
+
+	t := p.Type()
+	ctx.Imports[alias] = p
+	ctx.Set(alias, t)
+	for label, member := range t.Members {
+		ctx.Set(fmt.Sprintf("%s.%s", alias, label), member)
+	}
+	i.ZType = t
 	return nil
 }
@@ -0,0 +1,53 @@
+package compiler
+
+import (
+	"fmt"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// Index node: xs[i] reads an element of a slice or an array,
+// m[key] the value of a key, the zero value for a missing key like in go
+type Index struct {
+	Collection Ast
+	Index      Ast
+
+	Info
+}
+
+func (self *Index) ToString(depth int) string {
+	return fmt.Sprintf("%sIndex:\n%s\n%s", Indent(depth), self.Collection.ToString(depth+1), self.Index.ToString(depth+1))
+}
+
+func (self *Index) TypeCheck(ctx *Context) error {
+	err := self.Collection.TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+	err = self.Index.TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+
+	index := self.Index.MeltType()
+	switch collection := ResolveType(self.Collection.MeltType(), ctx).(type) {
+	case types.SliceBuiltin:
+		if !IsInteger(index) {
+			return Errorf(self.Index, CodeIndex, "Slice index should be an int, got %s", index.ToString())
+		}
+		self.ZType = collection.Element
+	case types.Array:
+		if !IsInteger(index) {
+			return Errorf(self.Index, CodeIndex, "Array index should be an int, got %s", index.ToString())
+		}
+		self.ZType = collection.Element
+	case types.MapBuiltin:
+		if !collection.Key.Accepts(index) {
+			return Errorf(self.Index, CodeIndex, "%s has %s keys, got %s", collection.ToString(), collection.Key.ToString(), index.ToString())
+		}
+		self.ZType = collection.Value
+	default:
+		return Errorf(self.Collection, CodeIndex, "Index only supported for slices, arrays and maps, got %s", collection.ToString())
+	}
+	return nil
+}
@@ -1,7 +1,6 @@
 package compiler
 
 import (
-	"errors"
 	"fmt"
 
 	"gitlab.com/alehander42/melt/types"
@@ -46,23 +45,24 @@ func (self *IndexAssignment) TypeCheck(ctx *Context) error {
 				if object.Element.Accepts((*self.Value).MeltType()) {
 					return nil
 				} else {
-					return fmt.Errorf("%s doesn't accept %s",
+					return Errorf(*self.Value, CodeIndex, "%s doesn't accept %s",
 						object.ToString(),
 						(*self.Value).MeltType().ToString())
 				}
 			} else {
-				return errors.New("Slice expect int")
+				return Errorf(*self.Index, CodeIndex, "Slice index should be an int, got %s", j.ToString())
 			}
 		} else {
-			return errors.New("Slice expect basic")
+			return Errorf(*self.Index, CodeIndex, "Slice index should be an int, got %s", (*self.Index).MeltType().ToString())
 		}
 	case types.MapBuiltin:
 		if object.Key.Accepts((*self.Index).MeltType()) && object.Value.Accepts((*self.Value).MeltType()) {
 			return nil
 		} else {
-			return errors.New("Map confused")
+			return Errorf(self, CodeIndex, "%s doesn't accept [%s] = %s", object.ToString(),
+				(*self.Index).MeltType().ToString(), (*self.Value).MeltType().ToString())
 		}
 	default:
-		return errors.New("Index only supported for slices and maps")
+		return Errorf(*self.Collection, CodeIndex, "Index only supported for slices and maps, got %s", object.ToString())
 	}
 }
@@ -2,130 +2,201 @@ package compiler
 
 import (
 	"fmt"
-	// "reflect"
+	"reflect"
+	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/alehander42/deepcopy"
 	"gitlab.com/alehander42/melt/types"
 )
 
 func Instantiate(m *Module, ctx *Context) error {
-	fmt.Printf("DEPENDENCIES\n%s\n", ctx.Dependencies)
-	fmt.Printf("INSTANTIATIONS\n%s\n", ctx.Instantiations.Functions["Map"][0].Errors)
-	x := deepcopy.Copy(m)
-	fmt.Printf("MODULE %s\n", x)
+	return instantiateModules([]*Module{m}, ctx)
+}
+
+// instantiateModules expands the instantiations of a package:
+// every instance is added to the module of its generic function
+func instantiateModules(modules []*Module, ctx *Context) error {
+	Tracef(TraceInstantiate, "dependencies %v", ctx.Dependencies)
+	Tracef(TraceInstantiate, "instantiations %v", ctx.Instantiations.Functions)
 	expanded := make(map[string]map[string]Function)
 	functions := make(map[string]Function)
 
-	for _, f := range m.Functions {
-		g, ok := ctx.Dependencies[f.Label.Label]
-		if ok {
-			err := ExpandDependencies(&g, f, functions, ctx)
-			if err != nil {
-				return err
+	// the methods aren't instantiated, their labels can be the labels of functions
+	for _, m := range modules {
+		for _, f := range m.Functions {
+			if f.Receiver != nil {
+				continue
+			}
+			g, ok := ctx.Dependencies[f.Label.Label]
+			if ok {
+				err := ExpandDependencies(&g, f, functions, ctx)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
 
-	for _, f := range m.Functions {
-		functions[f.Label.Label] = *f
-		expanded[f.Label.Label] = make(map[string]Function)
+	for _, m := range modules {
+		for _, f := range m.Functions {
+			if f.Receiver != nil {
+				continue
+			}
+			functions[f.Label.Label] = *f
+			expanded[f.Label.Label] = make(map[string]Function)
+		}
 	}
 
-	for _, f := range m.Functions {
-		i, ok := ctx.Instantiations.Functions[f.Label.Label]
-		g, ok2 := ctx.Dependencies[f.Label.Label]
-		if ok2 {
-		}
-		if ok {
+	for _, m := range modules {
+		for _, f := range m.Functions {
+			if f.Receiver != nil {
+				continue
+			}
+			i, ok := ctx.Instantiations.Functions[f.Label.Label]
+			g := ctx.Dependencies[f.Label.Label]
+			if !ok {
+				continue
+			}
 			for _, in := range i {
 				label := FunctionName(*f, in)
-				sex, ok := expanded[f.Label.Label]
-
-				if !ok {
-					expanded[f.Label.Label] = make(map[string]Function)
-					sex, _ = expanded[f.Label.Label]
-				}
+				sex := expanded[f.Label.Label]
 				_, ok = sex[label]
 				if ok {
 					continue
 				}
 
-				exp, err := ExpandInstance(*f, len(sex), in)
+				exp, err := ExpandInstance(*f, ctx.InstanceLabel(f.Label.Label, len(sex), in), in)
 				if err != nil {
 					return err
 				}
 
 				expanded[f.Label.Label][label] = exp
+				ctx.nameInstance(f.Label.Label, label, exp.Label.Label)
 				for l, dep := range g {
 					functionDep, ok := functions[l]
 					if !ok {
-						return fmt.Errorf("%s is missing", l)
+						return Failf(CodeTypeArgs, "%s is missing", l)
 					}
 					for _, d := range dep {
 						label := FunctionName(functionDep, d)
-						sex, ok := expanded[functionDep.Label.Label]
-						if !ok {
-							sex = make(map[string]Function)
-							expanded[functionDep.Label.Label] = sex
-						}
+						sex := expanded[functionDep.Label.Label]
 						_, ok = sex[label]
 						if ok {
 							continue
 						}
-						exp, err := ExpandInstance(functionDep, len(sex), d)
+						exp, err := ExpandInstance(functionDep, ctx.InstanceLabel(functionDep.Label.Label, len(sex), d), d)
 						if err != nil {
 							return err
 						}
 						expanded[functionDep.Label.Label][label] = exp
+						ctx.nameInstance(functionDep.Label.Label, label, exp.Label.Label)
 					}
 				}
 			}
 		}
 	}
-	funs := []*Function{}
-	for _, v := range expanded {
-		for _, x := range v {
-			funs = append(funs, &x)
-		}
-	}
-	normal := []*Function{}
-	for _, f := range m.Functions {
-		  if f2, ok := (f.MeltType()).(types.Function); ok {
+
+	for _, m := range modules {
+		funs := []*Function{}
+		normal := []*Function{}
+		for _, f := range m.Functions {
+			instances := expanded[f.Label.Label]
+			if f.Receiver != nil {
+				instances = nil
+			}
+			labels := []string{}
+			for label := range instances {
+				labels = append(labels, label)
+			}
+			sort.Strings(labels)
+			for _, label := range labels {
+				instance := instances[label]
+				funs = append(funs, &instance)
+			}
+
+			// the methods of generic records are generated with their instances
+			if f.IsGenericMethod() {
+				continue
+			}
+			if f2, ok := (f.MeltType()).(types.Function); ok {
 				if len(f2.InstanceVars) == 0 {
 					normal = append(normal, f)
-			  }
+				}
 			}
+		}
+		m.Functions = append(funs, normal...)
 	}
-	fmt.Printf("SEXY BED%s %s\n", normal[1].MeltType().ToString(), normal[1].Label.Label)
-	funs = append(funs, normal...)
-	m.Functions = funs
 
 	return nil
 }
 
+func (self *Context) nameInstance(function string, key string, name string) {
+	names, ok := self.Instantiations.Names[function]
+	if !ok {
+		names = make(map[string]string)
+		self.Instantiations.Names[function] = names
+	}
+	names[key] = name
+}
+
 func ExpandDependencies(dependencies *map[string][]GenericMap, function *Function, functions map[string]Function, ctx *Context) error {
 	f, ok := function.MeltType().(types.Function)
 	if !ok {
 		return fmt.Errorf("err")
 	}
-	fmt.Printf("  %s %s\n", f, dependencies)
+	Tracef(TraceInstantiate, "dependencies of %s %s: %v", function.Label.Label, f.ToString(), *dependencies)
 	return nil
 }
 
-func ExpandInstance(function Function, index int, genericMap GenericMap) (Function, error) {
+func ExpandInstance(function Function, label string, genericMap GenericMap) (Function, error) {
 	// fmt.Printf("%s @\n", genericMap)
+	// a handler? arg takes the error of its instance, not of the function
+	handlers := map[string]types.Type{}
+	if instance, ok := ReplaceGenericVars(function.MeltType(), genericMap).(types.Function); ok {
+		for i, arg := range function.Args {
+			if i < len(instance.Args) {
+				handlers[BareLabel(arg.ID.Label)] = instance.Args[i]
+			}
+		}
+	}
 	fun := Walk(function, true, func(node Ast) {
-		t := ReplaceGenericVars(node.MeltType(), genericMap)
+		before := node.MeltType()
+		if before == nil {
+			return
+		}
+		t := ReplaceGenericVars(before, genericMap)
+		if handler, ok := handlerType(node, before, handlers); ok {
+			t = handler
+		}
 		node.ChangeMeltType(t)
-		fmt.Printf("before:%s after:%s %s\n", node.MeltType().ToString(), t.ToString(), genericMap)
+		switch kind := node.(type) {
+		case *Arg:
+			kind.Type = t
+		case *Lambda:
+			kind.Return = ReplaceGenericVars(kind.Return, genericMap)
+		case *Make:
+			kind.Type = ReplaceGenericVars(kind.Type, genericMap)
+		case *RecordLiteral:
+			kind.Type = ReplaceGenericVars(kind.Type, genericMap)
+		case *Cast:
+			kind.Type = t
+		case *Set:
+			if kind.Type != nil {
+				kind.Type = ReplaceGenericVars(kind.Type, genericMap)
+			}
+		}
+		if Trace.On(TraceInstantiate) {
+			Tracef(TraceInstantiate, "%T: %s -> %s", node, before.ToString(), t.ToString())
+		}
 	})
-	fun.Label.Label = fmt.Sprintf("%s%d", function.Label.Label, index)
+	fun.Label.Label = label
 	f, ok := fun.MeltType().(types.Function)
 	if !ok {
 		return Function{}, fmt.Errorf("Sick function")
 	}
-	fmt.Printf("Expand %s\n", f.ToString())
+	Tracef(TraceInstantiate, "expand %s: %s", label, f.ToString())
 
 	if f.Error == types.Maybe {
 		e := types.Correct
@@ -139,18 +210,190 @@ func ExpandInstance(function Function, index int, genericMap GenericMap) (Functi
 		}
 		f.Error = e
 	}
+	if generic, ok := function.MeltType().(types.Function); ok && generic.Error == types.Maybe && f.Error == types.Correct {
+		settleCorrect(&fun)
+	}
 	fun.ZType = f
 	// fmt.Printf("type %s\n", fun.MeltType().ToString())
 	return fun, nil
 }
 
+// handlerType is the instance type of a handler? arg and its labels
+func handlerType(node Ast, before types.Type, handlers map[string]types.Type) (types.Type, bool) {
+	if function, ok := before.(types.Function); !ok || function.Error != types.Maybe {
+		return nil, false
+	}
+	var t types.Type
+	var ok bool
+	switch kind := node.(type) {
+	case *Arg:
+		t, ok = handlers[BareLabel(kind.ID.Label)]
+	case *Label:
+		t, ok = handlers[BareLabel(kind.Label)]
+	}
+	return t, ok
+}
+
+// settleCorrect makes an instance which can't fail return only its value:
+// its handler? calls don't fail, so they have no errors to handle or escalate
+func settleCorrect(function *Function) {
+	correct := map[string]bool{}
+	inspectOwn(function, func(node Ast) {
+		if call, ok := node.(*Call); ok && call.Failure != nil {
+			if t, ok := call.Function.MeltType().(types.Function); ok && t.Error == types.Correct {
+				correct[call.Failure.Variable()] = true
+			}
+		}
+	})
+	failing := func(variables []string) []string {
+		result := []string{}
+		for _, variable := range variables {
+			if !correct[variable] {
+				result = append(result, variable)
+			}
+		}
+		return result
+	}
+	function.Errors = failing(function.Errors)
+	inspectOwn(function, func(node Ast) {
+		switch kind := node.(type) {
+		case *Return:
+			kind.Fails = false
+		case *Escalate:
+			kind.Fails = false
+		case *On:
+			kind.Errors = failing(kind.Errors)
+		}
+	})
+}
+
+// inspectOwn calls handler with the nodes of a function outside of its lambdas
+// and go blocks, they fail on their own
+func inspectOwn(function *Function, handler func(Ast)) {
+	visited := make(map[uintptr]bool)
+	Inspect(function, func(node Ast) {
+		switch kind := node.(type) {
+		case *Lambda:
+			walkNodes(reflect.ValueOf(kind.Code), func(Ast) {}, visited)
+			walkNodes(reflect.ValueOf(kind.Body), func(Ast) {}, visited)
+		case *Go:
+			walkNodes(reflect.ValueOf(kind.Code), func(Ast) {}, visited)
+		}
+	})
+	walkNodes(reflect.ValueOf(function), handler, visited)
+}
+
+// RecordInstance returns the go name of an instance of a generic record
+// and records it, the instances are recorded when the generated code
+// names them: in a signature, a field, a make or a literal
+func (self *Context) RecordInstance(record types.Record) string {
+	root := self
+	if self.Root != nil {
+		root = self.Root
+	}
+	genericMap := NewGenericMap()
+	for i, v := range record.GenericVars {
+		genericMap.Types[v.Label] = record.InstanceVars[i]
+	}
+	if name, ok := root.InstanceName(record.Label, genericMap); ok {
+		return name
+	}
+	instances := root.Instantiations.Records[record.Label]
+	name := root.InstanceLabel(record.Label, len(instances), genericMap)
+	root.Instantiations.Records[record.Label] = append(instances, genericMap)
+	root.nameInstance(record.Label, FunctionName(Function{}, genericMap), name)
+	return name
+}
+
+// SumInstance is the go name of an instance of a generic sum,
+// it's named like a record: Option0 or OptionOfInt
+func (self *Context) SumInstance(sum types.Sum) string {
+	root := self
+	if self.Root != nil {
+		root = self.Root
+	}
+	genericMap := NewGenericMap()
+	for i, v := range sum.GenericVars {
+		genericMap.Types[v.Label] = sum.InstanceVars[i]
+	}
+	if name, ok := root.InstanceName(sum.Label, genericMap); ok {
+		return name
+	}
+	name := root.InstanceLabel(sum.Label, len(root.Instantiations.Names[sum.Label]), genericMap)
+	root.nameInstance(sum.Label, FunctionName(Function{}, genericMap), name)
+	return name
+}
+
+// InstanceLabel is the go name of an instance: Map0 with safe names
+// or MapOfIntAndString, which can clash with other names
+func (self *Context) InstanceLabel(label string, index int, genericMap GenericMap) string {
+	if self.SafeName {
+		return fmt.Sprintf("%s%d", label, index)
+	}
+
+	vars := []string{}
+	for arg := range genericMap.Types {
+		vars = append(vars, arg)
+	}
+	sort.Strings(vars)
+
+	names := []string{}
+	for _, arg := range vars {
+		names = append(names, TypeName(genericMap.Types[arg]))
+	}
+	for _, e := range genericMap.Errors {
+		if e == types.Fail {
+			names = append(names, "Fail")
+		} else if e == types.Maybe {
+			names = append(names, "Maybe")
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("%s%d", label, index)
+	}
+	return fmt.Sprintf("%sOf%s", label, strings.Join(names, "And"))
+}
+
+// TypeName spells a type as a part of a go name: Vector<string> is VectorOfString
+func TypeName(t types.Type) string {
+	switch kind := t.(type) {
+	case types.SliceBuiltin:
+		return fmt.Sprintf("SliceOf%s", TypeName(kind.Element))
+	case types.MapBuiltin:
+		return fmt.Sprintf("MapOf%sTo%s", TypeName(kind.Key), TypeName(kind.Value))
+	case types.Pointer:
+		return fmt.Sprintf("PointerTo%s", TypeName(kind.Object))
+	case types.Channel:
+		return fmt.Sprintf("ChannelOf%s", TypeName(kind.Element))
+	}
+
+	name := []rune{}
+	upper := true
+	for _, c := range t.ToString() {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			if upper {
+				c = unicode.ToUpper(c)
+			}
+			name = append(name, c)
+			upper = false
+		} else if c == '<' {
+			name = append(name, []rune("Of")...)
+			upper = true
+		} else {
+			upper = true
+		}
+	}
+	return string(name)
+}
+
 func FunctionName(function Function, genericMap GenericMap) string {
 	args := []string{}
 	for arg, kind := range genericMap.Types {
 		args = append(args, fmt.Sprintf("[%s %s]", arg, kind.ToString()))
 	}
+	sort.Strings(args)
 	for _, e := range genericMap.Errors {
-		args = append(args, fmt.Sprintf("{%s}", e))
+		args = append(args, fmt.Sprintf("{%d}", e))
 	}
 	return strings.Join(args, "")
 }
@@ -165,19 +408,61 @@ func Walk(function Function, clone bool, handler func(Ast)) Function {
 			return Function{}
 		}
 	}
-	handler(f)
+	walkNodes(reflect.ValueOf(f), handler, make(map[uintptr]bool))
 	return *f
 }
 
+// Inspect calls handler with node and the nodes in it
+func Inspect(node Ast, handler func(Ast)) {
+	walkNodes(reflect.ValueOf(node), handler, make(map[uintptr]bool))
+}
+
+// walkNodes calls handler with a node and the nodes in its fields
+func walkNodes(v reflect.Value, handler func(Ast), visited map[uintptr]bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Ptr {
+			if visited[v.Pointer()] {
+				return
+			}
+			visited[v.Pointer()] = true
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(astType) {
+		handler(v.Addr().Interface().(Ast))
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if dumpSkipped[v.Type().Field(i).Name] || v.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		f := v.Field(i)
+		if f.Type().Implements(meltTypeType) {
+			continue
+		} else if f.Kind() == reflect.Slice {
+			for j := 0; j < f.Len(); j++ {
+				walkNodes(f.Index(j), handler, visited)
+			}
+		} else if f.Kind() == reflect.Ptr || f.Kind() == reflect.Interface || f.Kind() == reflect.Struct {
+			walkNodes(f, handler, visited)
+		}
+	}
+}
+
 func Pre(a Ast) Ast {
 	b := deepcopy.Copy(a)
 	c, ok := b.(Ast)
 	if !ok {
-		fmt.Printf("Ops\n")
+		Tracef(TraceInstantiate, "can't copy %T", a)
 		return &Module{}
 	}
 	c.ChangeMeltType(a.MeltType())
-	fmt.Printf("BEFORE  %s\n  %s\n", a.MeltType().ToString(), c.MeltType().ToString())
 
 	return c
 }
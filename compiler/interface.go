@@ -1,6 +1,10 @@
 package compiler
 
-import "gitlab.com/alehander42/melt/types"
+import (
+	"fmt"
+
+	"gitlab.com/alehander42/melt/types"
+)
 
 // Interface node
 type Interface struct {
@@ -25,3 +29,30 @@ func (i *Interface) TypeCheck(ctx *Context) error {
 func (i *InterfaceMethod) TypeCheck(ctx *Context) error {
 	return nil
 }
+
+// implements explains why a value of t can't be used as the interface expected:
+// the method it doesn't have or has with another signature
+// It returns false if expected isn't an interface or it accepts t
+func implements(expected types.Type, t types.Type) (string, bool) {
+	i, ok := expected.(types.Interface)
+	if !ok || i.Accepts(t) {
+		return "", false
+	}
+	wanted, lacks := i.Lacks(t)
+	if !lacks {
+		return "", false
+	}
+
+	duck, ok := t.(types.Duck)
+	if !ok {
+		return fmt.Sprintf("%s doesn't have a method %s of %s", t.ToString(), wanted.Label, i.ToString()), true
+	}
+	method, ok := types.Accepts(duck, wanted.Label)
+	if !ok {
+		return fmt.Sprintf("%s doesn't have a method %s of %s", t.ToString(), wanted.Label, i.ToString()), true
+	} else if wanted.Function.Accepts(method.Function) {
+		// only a pointer has the methods with a pointer receiver
+		return fmt.Sprintf("%s.%s has a pointer receiver, only *%s is %s", t.ToString(), wanted.Label, t.ToString(), i.ToString()), true
+	}
+	return fmt.Sprintf("%s.%s is %s, %s wants %s", t.ToString(), wanted.Label, method.Function.ToString(), i.ToString(), wanted.Function.ToString()), true
+}
@@ -28,23 +28,26 @@ func (self *Label) TypeCheck(ctx *Context) error {
 
 	m, err := ctx.Get(label)
 	if err != nil {
-		return fmt.Errorf("%s is not defined on %d", label, self.Location().Line)
+		return Errorf(self, CodeUndefined, "%s is not defined", label)
+	} else if IsPoisoned(m) {
+		self.ZType = m
+		return Poisoned
 	}
 
 	n, ok := m.(types.Function)
 	if (fail == '!' || fail == '?') && !ok {
-		return fmt.Errorf("%s is not a function", label)
+		return Errorf(self, CodeNotFunction, "%s is not a function", label)
 	}
 	if ok && fail != '!' && fail != '?' && (n.Error == types.Fail) {
-		return fmt.Errorf("%s needs %s", label, types.Alexander(n.Error))
+		return Errorf(self, CodeErrorKind, "%s needs %s", label, types.Alexander(n.Error))
 	}
 
 	if ok && fail == '!' && n.Error != types.Fail {
-		return fmt.Errorf("%s shouldn't be !, but %s", label, types.Alexander(n.Error))
+		return Errorf(self, CodeErrorKind, "%s shouldn't be !, but %s", label, types.Alexander(n.Error))
 	}
 
 	if ok && fail == '?' && n.Error != types.Maybe {
-		return fmt.Errorf("%s shouldn't be ?, but %s", label, types.Alexander(n.Error))
+		return Errorf(self, CodeErrorKind, "%s shouldn't be ?, but %s", label, types.Alexander(n.Error))
 	}
 
 	self.ZType = m
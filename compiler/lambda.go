@@ -0,0 +1,192 @@
+package compiler
+
+import (
+	"fmt"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// Lambda node: func(x int) int: with a Code block or x -> x * 2 with a Body
+// It's a closure, it sees the labels of the code around it.
+// Args without a type are inferred from the function the lambda is passed to,
+// the return type of the short form is the type of its body
+type Lambda struct {
+	Args   []Arg
+	Return types.Type
+	Error  types.ErrorFunction
+	Code   *Code
+	Body   Ast
+	// Errors are the variables of the errors taken by handlers
+	Errors []string
+
+	Info
+}
+
+func (self *Lambda) ToString(depth int) string {
+	return fmt.Sprintf("%sLambda", Indent(depth))
+}
+
+// Inferred is true if the type of some arg has to be inferred
+func (self *Lambda) Inferred() bool {
+	for _, arg := range self.Args {
+		if arg.Type == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *Lambda) TypeCheck(ctx *Context) error {
+	for _, arg := range self.Args {
+		if arg.Type == nil {
+			return Errorf(arg.ID, CodeInfer, "Can't infer the type of %s, it needs a type here", arg.ID.Label)
+		}
+	}
+	return self.check(nil, ctx)
+}
+
+// Infer gives the args without a type the types of the args of expected,
+// the type of the arg of a call
+func (self *Lambda) Infer(expected types.Type, ctx *Context) error {
+	function, ok := expected.(types.Function)
+	if !ok {
+		return Errorf(self, CodeNotFunction, "a function is passed, %s is expected", expected.ToString())
+	} else if len(function.Args) != len(self.Args) {
+		return Errorf(self, CodeArgs, "the function takes %d args, %s is expected", len(self.Args), function.ToString())
+	}
+
+	for i := range self.Args {
+		arg := &self.Args[i]
+		if arg.Type != nil {
+			continue
+		}
+		t := function.Args[i]
+		if !inferable(t) {
+			return Errorf(arg.ID, CodeInfer, "Can't infer the type of %s from %s", arg.ID.Label, function.ToString())
+		}
+		arg.Type = t
+		arg.ID.ZType = t
+	}
+	return self.check(&function, ctx)
+}
+
+// check checks the body of the lambda in its own context,
+// which has its own errors like a function
+func (self *Lambda) check(expected *types.Function, ctx *Context) error {
+	c := NewContextIn(ctx)
+	unhandled := make(map[string][]*Failure)
+	c.Unhandled = &unhandled
+	handled := make(map[string]Ast)
+	c.Handled = &handled
+	c.Returned = new(bool)
+	failures := Failures{}
+	c.Failures = &failures
+	c.Loops = 0
+	c.Exit = nil
+	c.Spawn = nil
+	c.Z = self.Error
+	c.ErrorTypes = nil
+	args := []types.Type{}
+	for i := range self.Args {
+		arg := &self.Args[i]
+		arg.Type = ResolveType(arg.Type, ctx)
+		c.Set(arg.ID.Label, arg.Type)
+		args = append(args, arg.Type)
+	}
+
+	if self.Code != nil {
+		if self.Return == nil {
+			self.Return = types.Empty{}
+		}
+		self.Return = ResolveType(self.Return, ctx)
+		c.ReturnType = self.Return
+		err := self.Code.TypeCheck(c)
+		if err != nil {
+			return err
+		}
+		if _, ok := self.Return.(types.Empty); !ok && !*c.Returned {
+			return Errorf(self, CodeNoReturn, "The lambda returns %s, it needs a return at its end", self.Return.ToString())
+		}
+	} else {
+		err := self.Body.TypeCheck(c)
+		if err != nil {
+			return err
+		}
+		self.Return = self.Body.MeltType()
+		// the error of a failing body is the error of the lambda
+		label := CallLabel(self.Body)
+		if label != BareLabel(label) {
+			c.Drop(self.Body)
+			self.Error = types.Fail
+		} else if expected != nil && expected.Error == types.Fail {
+			self.Error = types.Fail
+		}
+		// the other failing calls in it return their error right away
+		escalate := &Escalate{Return: self.Return, Fails: true}
+		for label := range *c.Unhandled {
+			for _, failure := range c.Take(label, nil) {
+				failure.Escalate = escalate
+				self.Error = types.Fail
+			}
+		}
+	}
+
+	c.ReportUnhandled()
+	self.Errors = c.Failures.Errors()
+	self.ZType = types.Function{Args: args, Return: self.Return, Error: self.Error}
+	return nil
+}
+
+// inferable types don't have generic vars which aren't known yet
+func inferable(t types.Type) bool {
+	switch other := t.(type) {
+	case types.Empty, nil:
+		return false
+	case types.SliceBuiltin:
+		return inferable(other.Element)
+	case types.Pointer:
+		return inferable(other.Object)
+	case types.Channel:
+		return inferable(other.Element)
+	case types.MapBuiltin:
+		return inferable(other.Key) && inferable(other.Value)
+	}
+	return true
+}
+
+// inferArg replaces the known generic vars of the arg of a lambda
+func inferArg(t types.Type, genericMap GenericMap) types.Type {
+	switch other := t.(type) {
+	case types.Basic:
+		if s, ok := genericMap.Types[other.Label]; ok {
+			return s
+		}
+	case types.GenericVar:
+		if s, ok := genericMap.Types[other.Label]; ok {
+			return s
+		}
+	case types.SliceBuiltin:
+		return types.SliceBuiltin{Element: inferArg(other.Element, genericMap)}
+	case types.Pointer:
+		return types.Pointer{Object: inferArg(other.Object, genericMap)}
+	case types.Channel:
+		return types.Channel{Element: inferArg(other.Element, genericMap), Dir: other.Dir}
+	case types.MapBuiltin:
+		return types.MapBuiltin{Key: inferArg(other.Key, genericMap), Value: inferArg(other.Value, genericMap)}
+	}
+	return t
+}
+
+// inferLambda infers a lambda passed as param of a generic function
+// with the generic vars matched by the other args
+func inferLambda(lambda *Lambda, param types.Type, genericMap GenericMap, ctx *Context) error {
+	function, ok := param.(types.Function)
+	if !ok {
+		return lambda.Infer(param, ctx)
+	}
+	args := []types.Type{}
+	for _, arg := range function.Args {
+		args = append(args, inferArg(arg, genericMap))
+	}
+	return lambda.Infer(types.Function{Args: args, Return: function.Return, Error: function.Error}, ctx)
+}
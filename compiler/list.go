@@ -1,9 +1,6 @@
 package compiler
 
 import (
-	"errors"
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
@@ -16,7 +13,7 @@ type List struct {
 func (l *List) TypeCheck(ctx *Context) error {
 	var item types.Type
 	if len(l.Elements) == 0 {
-		return errors.New("[] needs as")
+		return Errorf(l, CodeNoValue, "[] needs as")
 	}
 	for i, element := range l.Elements {
 		err := element.TypeCheck(ctx)
@@ -26,7 +23,7 @@ func (l *List) TypeCheck(ctx *Context) error {
 		if i == 0 {
 			item = element.MeltType()
 		} else if !item.Accepts(element.MeltType()) {
-			return fmt.Errorf("List expects %s", item.ToString())
+			return Errorf(element, CodeMismatch, "List expects %s, got %s", item.ToString(), element.MeltType().ToString())
 		}
 	}
 	l.ZType = types.SliceBuiltin{Element: item}
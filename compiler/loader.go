@@ -0,0 +1,120 @@
+package compiler
+
+import (
+	go_types "go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Loader finds and checks the melt packages imported with import: melt:
+// Every package is checked once, in its own context
+type Loader struct {
+	// Path is searched in order for the directory of an import path
+	Path []string
+	// Module is the go module path of the generated packages
+	Module string
+	// Packages are the imported packages, in the order they were checked
+	Packages []*Package
+	// Deps map the first element of an import path to a directory
+	Deps map[string]string
+	// SafeName numbers generic instances instead of naming them after their types
+	SafeName bool
+
+	loaded     map[string]*Package
+	loading    map[string]bool
+	goImporter go_types.Importer
+}
+
+func NewLoader(path []string, module string) *Loader {
+	return &Loader{
+		Path:     path,
+		Module:   module,
+		Packages: []*Package{},
+		Deps:     make(map[string]string),
+		SafeName: true,
+		loaded:   make(map[string]*Package),
+		loading:  make(map[string]bool)}
+}
+
+// Check type checks a package in a new context
+func (l *Loader) Check(p *Package) (*Context, error) {
+	ctx := NewContext()
+	ctx.LoadBuiltinTypes()
+	ctx.Loader = l
+	ctx.SafeName = l.SafeName
+	ctx.Path = p.Path
+	p.Context = &ctx
+	err := p.TypeCheck(&ctx)
+	return &ctx, err
+}
+
+// Import returns the checked package for an import path
+func (l *Loader) Import(path string) (*Package, error) {
+	if p, ok := l.loaded[path]; ok {
+		return p, nil
+	}
+	if l.loading[path] {
+		return nil, Failf(CodeImport, "import cycle through %s", path)
+	}
+
+	dir, err := l.Find(path)
+	if err != nil {
+		return nil, err
+	}
+
+	l.loading[path] = true
+	defer delete(l.loading, path)
+
+	p, err := LoadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	p.Path = path
+	p.GoPath = path
+	if l.Module != "" {
+		p.GoPath = l.Module + "/" + path
+	}
+
+	_, err = l.Check(p)
+	if err != nil {
+		return nil, err
+	}
+
+	l.loaded[path] = p
+	l.Packages = append(l.Packages, p)
+	return p, nil
+}
+
+// GoImporter is shared by all packages, so each go package is checked once
+func (l *Loader) GoImporter() go_types.Importer {
+	if l.goImporter == nil {
+		l.goImporter = NewGoImporter()
+	}
+	return l.goImporter
+}
+
+// Find returns the directory of a dependency
+// or the first directory for the import path in the search path
+func (l *Loader) Find(path string) (string, error) {
+	parts := strings.SplitN(path, "/", 2)
+	if dep, ok := l.Deps[parts[0]]; ok {
+		dir := dep
+		if len(parts) > 1 {
+			dir = filepath.Join(dep, filepath.FromSlash(parts[1]))
+		}
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			return "", Failf(CodeImport, "melt package %s not found in dependency %s", path, parts[0])
+		}
+		return dir, nil
+	}
+	for _, root := range l.Path {
+		dir := filepath.Join(root, filepath.FromSlash(path))
+		info, err := os.Stat(dir)
+		if err == nil && info.IsDir() {
+			return dir, nil
+		}
+	}
+	return "", Failf(CodeImport, "melt package %s not found in %s", path, strings.Join(l.Path, string(filepath.ListSeparator)))
+}
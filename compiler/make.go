@@ -1,9 +1,6 @@
 package compiler
 
 import (
-	"errors"
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
@@ -19,7 +16,7 @@ func (m *Make) TypeCheck(ctx *Context) error {
 	slice, ok := m.Type.(types.SliceBuiltin)
 	if ok {
 		if len(m.Args) < 1 {
-			return errors.New("make expect 1 arg")
+			return Errorf(m, CodeArgs, "make expects a length for %s", slice.ToString())
 		} else {
 			arg := m.Args[0]
 			err := arg.TypeCheck(ctx)
@@ -31,16 +28,26 @@ func (m *Make) TypeCheck(ctx *Context) error {
 			if ok && argType.Label == "int" {
 				m.ZType = slice
 			} else {
-				return errors.New("please pass an int")
+				return Errorf(arg, CodeArgs, "make expects an int length, got %s", arg.MeltType().ToString())
 			}
 		}
-	} else {
-		n, ok := m.Type.(types.MapBuiltin)
-		if ok {
-			m.ZType = n
-		} else {
-			return fmt.Errorf("make slice map %s", m.Type.ToString())
+	} else if n, ok := m.Type.(types.MapBuiltin); ok {
+		m.ZType = n
+	} else if channel, ok := m.Type.(types.Channel); ok {
+		// the optional arg is the buffer size
+		if len(m.Args) > 0 {
+			arg := m.Args[0]
+			err := arg.TypeCheck(ctx)
+			if err != nil {
+				return err
+			}
+			if !IsInteger(arg.MeltType()) {
+				return Errorf(arg, CodeArgs, "make expects an int buffer size, got %s", arg.MeltType().ToString())
+			}
 		}
+		m.ZType = channel
+	} else {
+		return Errorf(m, CodeArgs, "make expects a slice, a map or a channel, got %s", m.Type.ToString())
 	}
 	return nil
 }
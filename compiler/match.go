@@ -0,0 +1,331 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// MatchBlock node: match value: with a ? pattern: arm for each case
+// The first arm matching the value runs
+type MatchBlock struct {
+	Value Ast
+	Arms  []*MatchArm
+
+	Info
+}
+
+// MatchArm node: ? pattern if guard:
+type MatchArm struct {
+	Pattern *Pattern
+	Guard   Ast
+	Code    *Code
+
+	Info
+}
+
+// Pattern node: Circle(r), Point{x, y: 0}, a literal, _ or a label
+// Constructor is the variant or the record taken apart, Fields are
+// the patterns of its fields and Labels their labels.
+// A pattern without a constructor binds the value to Binding,
+// compares it to Literal or it's a _
+type Pattern struct {
+	Constructor *Label
+	Record      bool
+	Fields      []*Pattern
+	Labels      []string
+	Binding     *Label
+	Literal     Ast
+	// Text is the pattern as written, for the errors
+	Text string
+
+	Info
+}
+
+func (self *MatchBlock) ToString(depth int) string {
+	return fmt.Sprintf("%sMatch:\n%s", Indent(depth), self.Value.ToString(depth+1))
+}
+
+func (self *MatchArm) ToString(depth int) string {
+	return fmt.Sprintf("%sMatchArm %s", Indent(depth), self.Pattern.Text)
+}
+
+func (self *Pattern) ToString(depth int) string {
+	return fmt.Sprintf("%sPattern %s", Indent(depth), self.Text)
+}
+
+// Irrefutable is true if the pattern matches every value it gets:
+// a label, a _ or a constructor with such fields
+func (self *Pattern) Irrefutable() bool {
+	if self.Literal != nil {
+		return false
+	}
+	for _, field := range self.Fields {
+		if !field.Irrefutable() {
+			return false
+		}
+	}
+	return true
+}
+
+// TypeCheck checks each arm in its own scope and reports
+// the values it doesn't match and the arms which can't run
+func (self *MatchBlock) TypeCheck(ctx *Context) error {
+	self.ZType = types.Empty{}
+	err := self.Value.TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+	value := ResolveType(self.Value.MeltType(), ctx)
+
+	coverage := newCoverage(value)
+	branches := []*Context{}
+	for _, arm := range self.Arms {
+		if reason, earlier, matched := coverage.unreachable(arm); earlier != nil {
+			err := Errorf(arm.Pattern, CodeUnreachable, "The arm ? %s can't run, %s", arm.Pattern.Text, reason)
+			ctx.Report(Relate(err, earlier.Pattern, "? %s matches %s before", earlier.Pattern.Text, matched))
+		}
+		branch := ctx.Branch()
+		branch.Nested = true
+		branches = append(branches, branch)
+		err := arm.check(value, branch)
+		if err != nil {
+			ctx.Report(Locate(err, arm))
+			continue
+		}
+		coverage.add(arm)
+	}
+
+	missing := coverage.missing()
+	ctx.Join(branches, missing == "")
+	if missing != "" {
+		return Errorf(self, CodeExhaustive, "match on %s doesn't cover %s", value.ToString(), missing)
+	}
+	return nil
+}
+
+func (self *MatchArm) check(value types.Type, ctx *Context) error {
+	self.ZType = types.Empty{}
+	c := NewContextIn(ctx)
+	err := self.Pattern.check(value, c, make(map[string]*Label))
+	if err != nil {
+		return err
+	}
+	if self.Guard != nil {
+		before := len(*c.Failures)
+		err = self.Guard.TypeCheck(c)
+		if err != nil {
+			return err
+		}
+		if len(*c.Failures) > before {
+			// its calls would be lowered before the match, where the bindings don't exist
+			return Errorf(self.Guard, CodeCondition, "The guard of %s can't call the failing %s, call it before the match", self.Pattern.Text, (*c.Failures)[before].Label)
+		}
+		if !(types.Basic{Label: "bool"}).Accepts(self.Guard.MeltType()) {
+			return Errorf(self.Guard, CodeCondition, "The guard of %s is %s, it should be bool", self.Pattern.Text, self.Guard.MeltType().ToString())
+		}
+	}
+	return self.Code.TypeCheck(c)
+}
+
+// TypeCheck of an arm is done by its match, with the type of the value
+func (self *MatchArm) TypeCheck(ctx *Context) error {
+	return nil
+}
+
+func (self *Pattern) TypeCheck(ctx *Context) error {
+	return nil
+}
+
+// check binds the labels of the pattern in ctx, bound has the labels
+// bound by the pattern so far
+func (self *Pattern) check(value types.Type, ctx *Context, bound map[string]*Label) error {
+	self.ZType = value
+	if self.Binding != nil {
+		label := self.Binding.Label
+		if first, ok := bound[label]; ok {
+			err := Errorf(self.Binding, CodeRedefined, "%s is bound more than once in a pattern", label)
+			return Relate(err, first, "the first %s is here", label)
+		}
+		bound[label] = self.Binding
+		self.Binding.ZType = value
+		ctx.Set(label, value)
+	} else if self.Literal != nil {
+		err := self.Literal.TypeCheck(ctx)
+		if err != nil {
+			return err
+		}
+		if !value.Accepts(self.Literal.MeltType()) {
+			return Errorf(self, CodeMismatch, "%s is %s, it can't match %s", self.Text, self.Literal.MeltType().ToString(), value.ToString())
+		}
+	} else if self.Constructor != nil && self.Record {
+		return self.checkRecord(value, ctx, bound)
+	} else if self.Constructor != nil {
+		return self.checkVariant(value, ctx, bound)
+	}
+	return nil
+}
+
+// checkVariant checks Circle(r), a variant without the parens matches
+// with any fields
+func (self *Pattern) checkVariant(value types.Type, ctx *Context, bound map[string]*Label) error {
+	label := self.Constructor.Label
+	sum, ok := value.(types.Sum)
+	if !ok {
+		return Errorf(self.Constructor, CodeMismatch, "%s is not a variant of %s", label, value.ToString())
+	}
+	variant, ok := sum.Variant(label)
+	if !ok {
+		return Errorf(self.Constructor, CodeUndefined, "%s is not a variant of %s", label, sum.ToString())
+	}
+	if self.Fields == nil {
+		return nil
+	} else if len(self.Fields) != len(variant.Fields) {
+		return Errorf(self, CodeArgs, "%s has %d fields, the pattern has %d", label, len(variant.Fields), len(self.Fields))
+	}
+
+	self.Labels = []string{}
+	for i, field := range self.Fields {
+		self.Labels = append(self.Labels, variant.Fields[i].Label)
+		err := field.check(variant.Fields[i].Type, ctx, bound)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRecord checks Point{x, y: 0}, the fields which aren't in it can be anything
+func (self *Pattern) checkRecord(value types.Type, ctx *Context, bound map[string]*Label) error {
+	label := self.Constructor.Label
+	t, err := ctx.Get(label)
+	record, ok := t.(types.Record)
+	if err != nil || !ok {
+		return Errorf(self.Constructor, CodeUndefined, "%s is not a record", label)
+	}
+	switch other := value.(type) {
+	case types.Record:
+		ok = other.Label == label
+	case types.Basic:
+		ok = other.Label == label
+	default:
+		ok = false
+	}
+	if !ok {
+		return Errorf(self.Constructor, CodeMismatch, "%s is %s, it can't match a %s", self.Text, value.ToString(), label)
+	}
+
+	for i, field := range self.Fields {
+		t, ok := record.Fields[self.Labels[i]]
+		if !ok {
+			return Errorf(field, CodeUndefined, "%s doesn't have a field %s", label, self.Labels[i])
+		}
+		err := field.check(t, ctx, bound)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// coverage are the values matched by the arms so far
+type coverage struct {
+	value types.Type
+	// covered are the matched variants, bools and literals with their arm
+	covered map[string]*MatchArm
+	// all is the arm after which every value is matched
+	all *MatchArm
+	// partial is true if the arms before all match some of the values
+	partial bool
+}
+
+func newCoverage(value types.Type) *coverage {
+	return &coverage{value: value, covered: make(map[string]*MatchArm)}
+}
+
+// unreachable returns why an arm can't run, the arm before it which matches
+// its values and what that arm matches
+func (self *coverage) unreachable(arm *MatchArm) (string, *MatchArm, string) {
+	p := arm.Pattern
+	if p.Constructor != nil && !p.Record && self.covered[p.Constructor.Label] != nil {
+		return fmt.Sprintf("%s is already matched", p.Constructor.Label), self.covered[p.Constructor.Label], "it"
+	} else if p.Literal != nil && self.covered[p.Text] != nil {
+		return fmt.Sprintf("%s is already matched", p.Text), self.covered[p.Text], "it"
+	} else if self.all != nil && self.partial {
+		return "every value is already matched", self.all, "the last values"
+	} else if self.all != nil {
+		return "every value is already matched", self.all, "every value"
+	}
+	return "", nil, ""
+}
+
+// add the values matched by an arm, an arm with a guard doesn't match for sure
+func (self *coverage) add(arm *MatchArm) {
+	p := arm.Pattern
+	if arm.Guard != nil || self.all != nil {
+		return
+	}
+	switch {
+	case p.Constructor == nil && p.Literal == nil:
+		self.all = arm
+		return
+	case p.Literal != nil:
+		self.cover(p.Text, arm)
+	case !p.Irrefutable():
+		return
+	case p.Record:
+		self.all = arm
+		return
+	default:
+		self.cover(p.Constructor.Label, arm)
+	}
+
+	if sum, ok := self.value.(types.Sum); ok && len(self.left(sum)) == 0 {
+		self.all, self.partial = arm, true
+	} else if isBool(self.value) && self.covered["true"] != nil && self.covered["false"] != nil {
+		self.all, self.partial = arm, true
+	}
+}
+
+// cover keeps the first arm matching a value
+func (self *coverage) cover(value string, arm *MatchArm) {
+	if self.covered[value] == nil {
+		self.covered[value] = arm
+	}
+}
+
+// missing are the values which aren't matched, empty if all are
+func (self *coverage) missing() string {
+	if self.all != nil {
+		return ""
+	}
+	if sum, ok := self.value.(types.Sum); ok {
+		return strings.Join(self.left(sum), ", ")
+	} else if isBool(self.value) {
+		left := []string{}
+		for _, value := range []string{"false", "true"} {
+			if self.covered[value] == nil {
+				left = append(left, value)
+			}
+		}
+		return strings.Join(left, ", ")
+	}
+	return "every value, it needs a _ arm"
+}
+
+// left are the variants which aren't matched
+func (self *coverage) left(sum types.Sum) []string {
+	left := []string{}
+	for _, variant := range sum.Variants {
+		if self.covered[variant.Label] == nil {
+			left = append(left, variant.Label)
+		}
+	}
+	return left
+}
+
+func isBool(t types.Type) bool {
+	basic, ok := t.(types.Basic)
+	return ok && basic.Label == "bool"
+}
@@ -1,10 +1,14 @@
 package compiler
 
+// Code generated by peg melt.peg DO NOT EDIT.
+
 import (
 	"fmt"
-	"math"
+	"io"
+	"os"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 const endSymbol rune = 1114112
@@ -20,7 +24,12 @@ const (
 	ruleImport
 	ruleGoImport
 	ruleMeltImport
+	ruleImportLine
+	ruleImportPath
 	ruleFunction
+	ruleFailingLabel
+	ruleErrorTypes
+	ruleReceiver
 	ruleInterface
 	ruleArray
 	ruleDeclaration
@@ -28,6 +37,9 @@ const (
 	ruleRecord
 	ruleRecordContents
 	ruleSex
+	ruleUnion
+	ruleVariant
+	ruleVariantField
 	ruleFunArgs
 	ruleGenericArgs
 	ruleFunArg
@@ -43,38 +55,90 @@ const (
 	ruleBuiltinSlice
 	ruleBuiltinArray
 	ruleBuiltinMap
+	ruleChannelType
+	ruleChannelDir
 	ruleTypeExceptFun
+	ruleTypeLabel
 	ruleCode
 	ruleIndent
 	ruleDedent
 	ruleLine
 	ruleIndexAssignment
+	ruleFieldAssignment
 	ruleAssignment
+	ruleCompoundAssignment
+	ruleAssignOperator
 	ruleExpression
+	ruleDisjunction
+	ruleConjunction
+	ruleComparison
+	ruleSum
+	ruleProduct
+	ruleCast
+	ruleUnary
+	rulePrimary
+	ruleIndex
+	ruleIndexObject
+	ruleIndexKey
+	ruleSelector
+	ruleSelectorObject
+	ruleFieldLabel
+	ruleRecordLiteral
+	ruleLiteralField
+	ruleParens
+	ruleLambda
+	ruleShortLambda
+	ruleLambdaArgs
+	ruleLambdaArg
+	ruleLambdaError
+	ruleOrOperator
+	ruleAndOperator
+	ruleCmpOperator
+	ruleSumOperator
+	ruleProductOperator
+	ruleUnaryOperator
 	ruleSimple
 	ruleList
-	ruleBinaryOperation
-	ruleExpressionExceptBinaryOperation
-	ruleBinaryOperator
-	ruleUnaryOperation
-	ruleUnaryOperator
-	ruleExpressionExceptOperation
 	ruleMethodCall
 	ruleCall
 	ruleBuiltinCall
 	ruleBuiltinFun
 	ruleBuiltinArg
 	ruleFunCall
+	ruleIf
+	ruleElif
+	ruleElse
 	ruleFor
 	ruleForIn
 	ruleForLoop
 	ruleRange
 	ruleRangeOperator
+	ruleLoop
+	ruleBreak
+	ruleSend
+	ruleReceive
+	ruleGo
+	ruleGoFunc
+	ruleSpawn
+	ruleSelect
+	ruleSelectCase
+	ruleSelectReceive
+	ruleSelectDefault
+	ruleMatch
+	ruleMatchArm
+	rulePattern
+	ruleVariantPattern
+	ruleRecordPattern
+	ruleFieldPattern
+	ruleSubPattern
+	ruleWildcard
+	rulePatternLiteral
 	ruleOn
 	ruleReturn
 	ruleReturnValue
 	ruleReturnError
 	ruleEscalator
+	ruleWrapping
 	ruleLowerLabel
 	ruleCapitalLabel
 	ruleFunLowerLabel
@@ -91,6 +155,7 @@ const (
 	ruleError
 	ruleSlot
 	ruleWhitespace
+	ruleGap
 	ruleNewline
 	ruleEOT
 )
@@ -103,7 +168,12 @@ var rul3s = [...]string{
 	"Import",
 	"GoImport",
 	"MeltImport",
+	"ImportLine",
+	"ImportPath",
 	"Function",
+	"FailingLabel",
+	"ErrorTypes",
+	"Receiver",
 	"Interface",
 	"Array",
 	"Declaration",
@@ -111,6 +181,9 @@ var rul3s = [...]string{
 	"Record",
 	"RecordContents",
 	"Sex",
+	"Union",
+	"Variant",
+	"VariantField",
 	"FunArgs",
 	"GenericArgs",
 	"FunArg",
@@ -126,38 +199,90 @@ var rul3s = [...]string{
 	"BuiltinSlice",
 	"BuiltinArray",
 	"BuiltinMap",
+	"ChannelType",
+	"ChannelDir",
 	"TypeExceptFun",
+	"TypeLabel",
 	"Code",
 	"Indent",
 	"Dedent",
 	"Line",
 	"IndexAssignment",
+	"FieldAssignment",
 	"Assignment",
+	"CompoundAssignment",
+	"AssignOperator",
 	"Expression",
+	"Disjunction",
+	"Conjunction",
+	"Comparison",
+	"Sum",
+	"Product",
+	"Cast",
+	"Unary",
+	"Primary",
+	"Index",
+	"IndexObject",
+	"IndexKey",
+	"Selector",
+	"SelectorObject",
+	"FieldLabel",
+	"RecordLiteral",
+	"LiteralField",
+	"Parens",
+	"Lambda",
+	"ShortLambda",
+	"LambdaArgs",
+	"LambdaArg",
+	"LambdaError",
+	"OrOperator",
+	"AndOperator",
+	"CmpOperator",
+	"SumOperator",
+	"ProductOperator",
+	"UnaryOperator",
 	"Simple",
 	"List",
-	"BinaryOperation",
-	"ExpressionExceptBinaryOperation",
-	"BinaryOperator",
-	"UnaryOperation",
-	"UnaryOperator",
-	"ExpressionExceptOperation",
 	"MethodCall",
 	"Call",
 	"BuiltinCall",
 	"BuiltinFun",
 	"BuiltinArg",
 	"FunCall",
+	"If",
+	"Elif",
+	"Else",
 	"For",
 	"ForIn",
 	"ForLoop",
 	"Range",
 	"RangeOperator",
+	"Loop",
+	"Break",
+	"Send",
+	"Receive",
+	"Go",
+	"GoFunc",
+	"Spawn",
+	"Select",
+	"SelectCase",
+	"SelectReceive",
+	"SelectDefault",
+	"Match",
+	"MatchArm",
+	"Pattern",
+	"VariantPattern",
+	"RecordPattern",
+	"FieldPattern",
+	"SubPattern",
+	"Wildcard",
+	"PatternLiteral",
 	"On",
 	"Return",
 	"ReturnValue",
 	"ReturnError",
 	"Escalator",
+	"Wrapping",
 	"LowerLabel",
 	"CapitalLabel",
 	"FunLowerLabel",
@@ -174,6 +299,7 @@ var rul3s = [...]string{
 	"Error",
 	"Slot",
 	"Whitespace",
+	"Gap",
 	"Newline",
 	"EOT",
 }
@@ -192,14 +318,20 @@ type node32 struct {
 	up, next *node32
 }
 
-func (node *node32) Print(buffer string) {
+func (node *node32) print(w io.Writer, pretty bool, buffer string) {
 	var print func(node *node32, depth int)
 	print = func(node *node32, depth int) {
 		for node != nil {
 			for c := 0; c < depth; c++ {
-				fmt.Printf(" ")
+				fmt.Fprintf(w, " ")
+			}
+			rule := rul3s[node.pegRule]
+			quote := strconv.Quote(string(([]rune(buffer)[node.begin:node.end])))
+			if !pretty {
+				fmt.Fprintf(w, "%v %v\n", rule, quote)
+			} else {
+				fmt.Fprintf(w, "\x1B[36m%v\x1B[m %v\n", rule, quote)
 			}
-			fmt.Printf("\x1B[34m%v\x1B[m %v\n", rul3s[node.pegRule], strconv.Quote(string(([]rune(buffer)[node.begin:node.end]))))
 			if node.up != nil {
 				print(node.up, depth+1)
 			}
@@ -209,6 +341,14 @@ func (node *node32) Print(buffer string) {
 	print(node, 0)
 }
 
+func (node *node32) Print(w io.Writer, buffer string) {
+	node.print(w, false, buffer)
+}
+
+func (node *node32) PrettyPrint(w io.Writer, buffer string) {
+	node.print(w, true, buffer)
+}
+
 type tokens32 struct {
 	tree []token32
 }
@@ -249,20 +389,24 @@ func (t *tokens32) AST() *node32 {
 }
 
 func (t *tokens32) PrintSyntaxTree(buffer string) {
-	t.AST().Print(buffer)
+	t.AST().Print(os.Stdout, buffer)
+}
+
+func (t *tokens32) WriteSyntaxTree(w io.Writer, buffer string) {
+	t.AST().Print(w, buffer)
+}
+
+func (t *tokens32) PrettyPrintSyntaxTree(buffer string) {
+	t.AST().PrettyPrint(os.Stdout, buffer)
 }
 
 func (t *tokens32) Add(rule pegRule, begin, end, index uint32) {
-	if tree := t.tree; int(index) >= len(tree) {
-		expanded := make([]token32, 2*len(tree))
-		copy(expanded, tree)
-		t.tree = expanded
-	}
-	t.tree[index] = token32{
-		pegRule: rule,
-		begin:   begin,
-		end:     end,
+	tree, i := t.tree, int(index)
+	if i >= len(tree) {
+		t.tree = append(tree, token32{pegRule: rule, begin: begin, end: end})
+		return
 	}
+	tree[i] = token32{pegRule: rule, begin: begin, end: end}
 }
 
 func (t *tokens32) Tokens() []token32 {
@@ -270,9 +414,11 @@ func (t *tokens32) Tokens() []token32 {
 }
 
 type MeltParser struct {
+	Positions *SourceMap
+
 	Buffer string
 	buffer []rune
-	rules  [80]func() bool
+	rules  [141]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -324,7 +470,7 @@ type parseError struct {
 }
 
 func (e *parseError) Error() string {
-	tokens, error := []token32{e.max}, "\n"
+	tokens, err := []token32{e.max}, "\n"
 	positions, p := make([]int, 2*len(tokens)), 0
 	for _, token := range tokens {
 		positions[p], p = int(token.begin), p+1
@@ -337,26 +483,59 @@ func (e *parseError) Error() string {
 	}
 	for _, token := range tokens {
 		begin, end := int(token.begin), int(token.end)
-		error += fmt.Sprintf(format,
+		err += fmt.Sprintf(format,
 			rul3s[token.pegRule],
 			translations[begin].line, translations[begin].symbol,
 			translations[end].line, translations[end].symbol,
 			strconv.Quote(string(e.p.buffer[begin:end])))
 	}
 
-	return error
+	return err
 }
 
 func (p *MeltParser) PrintSyntaxTree() {
-	p.tokens32.PrintSyntaxTree(p.Buffer)
+	if p.Pretty {
+		p.tokens32.PrettyPrintSyntaxTree(p.Buffer)
+	} else {
+		p.tokens32.PrintSyntaxTree(p.Buffer)
+	}
+}
+
+func (p *MeltParser) WriteSyntaxTree(w io.Writer) {
+	p.tokens32.WriteSyntaxTree(w, p.Buffer)
+}
+
+func (p *MeltParser) SprintSyntaxTree() string {
+	var bldr strings.Builder
+	p.WriteSyntaxTree(&bldr)
+	return bldr.String()
+}
+
+func Pretty(pretty bool) func(*MeltParser) error {
+	return func(p *MeltParser) error {
+		p.Pretty = pretty
+		return nil
+	}
 }
 
-func (p *MeltParser) Init() {
+func Size(size int) func(*MeltParser) error {
+	return func(p *MeltParser) error {
+		p.tokens32 = tokens32{tree: make([]token32, 0, size)}
+		return nil
+	}
+}
+func (p *MeltParser) Init(options ...func(*MeltParser) error) error {
 	var (
 		max                  token32
 		position, tokenIndex uint32
 		buffer               []rune
 	)
+	for _, option := range options {
+		err := option(p)
+		if err != nil {
+			return err
+		}
+	}
 	p.reset = func() {
 		max = token32{}
 		position, tokenIndex = 0, 0
@@ -369,7 +548,8 @@ func (p *MeltParser) Init() {
 	}
 	p.reset()
 
-	_rules, tree := p.rules, tokens32{tree: make([]token32, math.MaxInt16)}
+	_rules := p.rules
+	tree := p.tokens32
 	p.parse = func(rule ...int) error {
 		r := 1
 		if len(rule) > 0 {
@@ -423,1145 +603,47 @@ func (p *MeltParser) Init() {
 			position0, tokenIndex0 := position, tokenIndex
 			{
 				position1 := position
-				{
-					position2 := position
-					{
-						position3, tokenIndex3 := position, tokenIndex
-						if buffer[position] != rune('p') {
-							goto l4
-						}
-						position++
-						goto l3
-					l4:
-						position, tokenIndex = position3, tokenIndex3
-						if buffer[position] != rune('P') {
-							goto l0
-						}
-						position++
-					}
-				l3:
-					{
-						position5, tokenIndex5 := position, tokenIndex
-						if buffer[position] != rune('a') {
-							goto l6
-						}
-						position++
-						goto l5
-					l6:
-						position, tokenIndex = position5, tokenIndex5
-						if buffer[position] != rune('A') {
-							goto l0
-						}
-						position++
-					}
-				l5:
-					{
-						position7, tokenIndex7 := position, tokenIndex
-						if buffer[position] != rune('c') {
-							goto l8
-						}
-						position++
-						goto l7
-					l8:
-						position, tokenIndex = position7, tokenIndex7
-						if buffer[position] != rune('C') {
-							goto l0
-						}
-						position++
-					}
-				l7:
-					{
-						position9, tokenIndex9 := position, tokenIndex
-						if buffer[position] != rune('k') {
-							goto l10
-						}
-						position++
-						goto l9
-					l10:
-						position, tokenIndex = position9, tokenIndex9
-						if buffer[position] != rune('K') {
-							goto l0
-						}
-						position++
-					}
-				l9:
-					{
-						position11, tokenIndex11 := position, tokenIndex
-						if buffer[position] != rune('a') {
-							goto l12
-						}
-						position++
-						goto l11
-					l12:
-						position, tokenIndex = position11, tokenIndex11
-						if buffer[position] != rune('A') {
-							goto l0
-						}
-						position++
-					}
-				l11:
-					{
-						position13, tokenIndex13 := position, tokenIndex
-						if buffer[position] != rune('g') {
-							goto l14
-						}
-						position++
-						goto l13
-					l14:
-						position, tokenIndex = position13, tokenIndex13
-						if buffer[position] != rune('G') {
-							goto l0
-						}
-						position++
-					}
-				l13:
-					{
-						position15, tokenIndex15 := position, tokenIndex
-						if buffer[position] != rune('e') {
-							goto l16
-						}
-						position++
-						goto l15
-					l16:
-						position, tokenIndex = position15, tokenIndex15
-						if buffer[position] != rune('E') {
-							goto l0
-						}
-						position++
-					}
-				l15:
-					if !_rules[ruleWhitespace]() {
-						goto l0
-					}
-					if !_rules[ruleLowerLabel]() {
-						goto l0
-					}
-					add(rulePackage, position2)
+				if !_rules[rulePackage]() {
+					goto l0
 				}
 				if !_rules[ruleNewline]() {
 					goto l0
 				}
 				{
-					position17, tokenIndex17 := position, tokenIndex
-					{
-						position19 := position
-						{
-							position20, tokenIndex20 := position, tokenIndex
-							if buffer[position] != rune('i') {
-								goto l21
-							}
-							position++
-							goto l20
-						l21:
-							position, tokenIndex = position20, tokenIndex20
-							if buffer[position] != rune('I') {
-								goto l17
-							}
-							position++
-						}
-					l20:
-						{
-							position22, tokenIndex22 := position, tokenIndex
-							if buffer[position] != rune('m') {
-								goto l23
-							}
-							position++
-							goto l22
-						l23:
-							position, tokenIndex = position22, tokenIndex22
-							if buffer[position] != rune('M') {
-								goto l17
-							}
-							position++
-						}
-					l22:
-						{
-							position24, tokenIndex24 := position, tokenIndex
-							if buffer[position] != rune('p') {
-								goto l25
-							}
-							position++
-							goto l24
-						l25:
-							position, tokenIndex = position24, tokenIndex24
-							if buffer[position] != rune('P') {
-								goto l17
-							}
-							position++
-						}
-					l24:
-						{
-							position26, tokenIndex26 := position, tokenIndex
-							if buffer[position] != rune('o') {
-								goto l27
-							}
-							position++
-							goto l26
-						l27:
-							position, tokenIndex = position26, tokenIndex26
-							if buffer[position] != rune('O') {
-								goto l17
-							}
-							position++
-						}
-					l26:
-						{
-							position28, tokenIndex28 := position, tokenIndex
-							if buffer[position] != rune('r') {
-								goto l29
-							}
-							position++
-							goto l28
-						l29:
-							position, tokenIndex = position28, tokenIndex28
-							if buffer[position] != rune('R') {
-								goto l17
-							}
-							position++
-						}
-					l28:
-						{
-							position30, tokenIndex30 := position, tokenIndex
-							if buffer[position] != rune('t') {
-								goto l31
-							}
-							position++
-							goto l30
-						l31:
-							position, tokenIndex = position30, tokenIndex30
-							if buffer[position] != rune('T') {
-								goto l17
-							}
-							position++
-						}
-					l30:
-						if buffer[position] != rune(':') {
-							goto l17
-						}
-						position++
-						if !_rules[ruleNewline]() {
-							goto l17
-						}
-						if !_rules[ruleIndent]() {
-							goto l17
-						}
-						{
-							position32, tokenIndex32 := position, tokenIndex
-							{
-								position34 := position
-								{
-									position35, tokenIndex35 := position, tokenIndex
-									if buffer[position] != rune('g') {
-										goto l36
-									}
-									position++
-									goto l35
-								l36:
-									position, tokenIndex = position35, tokenIndex35
-									if buffer[position] != rune('G') {
-										goto l32
-									}
-									position++
-								}
-							l35:
-								{
-									position37, tokenIndex37 := position, tokenIndex
-									if buffer[position] != rune('o') {
-										goto l38
-									}
-									position++
-									goto l37
-								l38:
-									position, tokenIndex = position37, tokenIndex37
-									if buffer[position] != rune('O') {
-										goto l32
-									}
-									position++
-								}
-							l37:
-								if buffer[position] != rune(':') {
-									goto l32
-								}
-								position++
-								if !_rules[ruleNewline]() {
-									goto l32
-								}
-								if !_rules[ruleIndent]() {
-									goto l32
-								}
-								if !_rules[ruleString]() {
-									goto l32
-								}
-								if !_rules[ruleNewline]() {
-									goto l32
-								}
-							l39:
-								{
-									position40, tokenIndex40 := position, tokenIndex
-									if !_rules[ruleString]() {
-										goto l40
-									}
-									if !_rules[ruleNewline]() {
-										goto l40
-									}
-									goto l39
-								l40:
-									position, tokenIndex = position40, tokenIndex40
-								}
-								if !_rules[ruleDedent]() {
-									goto l32
-								}
-								add(ruleGoImport, position34)
-							}
-							goto l33
-						l32:
-							position, tokenIndex = position32, tokenIndex32
-						}
-					l33:
-						{
-							position41, tokenIndex41 := position, tokenIndex
-							{
-								position43 := position
-								{
-									position44, tokenIndex44 := position, tokenIndex
-									if buffer[position] != rune('m') {
-										goto l45
-									}
-									position++
-									goto l44
-								l45:
-									position, tokenIndex = position44, tokenIndex44
-									if buffer[position] != rune('M') {
-										goto l41
-									}
-									position++
-								}
-							l44:
-								{
-									position46, tokenIndex46 := position, tokenIndex
-									if buffer[position] != rune('e') {
-										goto l47
-									}
-									position++
-									goto l46
-								l47:
-									position, tokenIndex = position46, tokenIndex46
-									if buffer[position] != rune('E') {
-										goto l41
-									}
-									position++
-								}
-							l46:
-								{
-									position48, tokenIndex48 := position, tokenIndex
-									if buffer[position] != rune('l') {
-										goto l49
-									}
-									position++
-									goto l48
-								l49:
-									position, tokenIndex = position48, tokenIndex48
-									if buffer[position] != rune('L') {
-										goto l41
-									}
-									position++
-								}
-							l48:
-								{
-									position50, tokenIndex50 := position, tokenIndex
-									if buffer[position] != rune('t') {
-										goto l51
-									}
-									position++
-									goto l50
-								l51:
-									position, tokenIndex = position50, tokenIndex50
-									if buffer[position] != rune('T') {
-										goto l41
-									}
-									position++
-								}
-							l50:
-								if buffer[position] != rune(':') {
-									goto l41
-								}
-								position++
-								if !_rules[ruleNewline]() {
-									goto l41
-								}
-								if !_rules[ruleIndent]() {
-									goto l41
-								}
-								if !_rules[ruleString]() {
-									goto l41
-								}
-								if !_rules[ruleNewline]() {
-									goto l41
-								}
-							l52:
-								{
-									position53, tokenIndex53 := position, tokenIndex
-									if !_rules[ruleString]() {
-										goto l53
-									}
-									if !_rules[ruleNewline]() {
-										goto l53
-									}
-									goto l52
-								l53:
-									position, tokenIndex = position53, tokenIndex53
-								}
-								if !_rules[ruleDedent]() {
-									goto l41
-								}
-								add(ruleMeltImport, position43)
-							}
-							goto l42
-						l41:
-							position, tokenIndex = position41, tokenIndex41
-						}
-					l42:
-						add(ruleImport, position19)
+					position2, tokenIndex2 := position, tokenIndex
+					if !_rules[ruleImport]() {
+						goto l2
 					}
-					goto l18
-				l17:
-					position, tokenIndex = position17, tokenIndex17
+					goto l3
+				l2:
+					position, tokenIndex = position2, tokenIndex2
 				}
-			l18:
+			l3:
 				{
-					position54, tokenIndex54 := position, tokenIndex
+					position4, tokenIndex4 := position, tokenIndex
 					if !_rules[ruleNewline]() {
-						goto l54
+						goto l4
 					}
-					goto l55
-				l54:
-					position, tokenIndex = position54, tokenIndex54
+					goto l5
+				l4:
+					position, tokenIndex = position4, tokenIndex4
 				}
-			l55:
-			l56:
+			l5:
+			l6:
 				{
-					position57, tokenIndex57 := position, tokenIndex
-					{
-						position58 := position
-						{
-							switch buffer[position] {
-							case 'R', 'r':
-								{
-									position60 := position
-									{
-										position61, tokenIndex61 := position, tokenIndex
-										if buffer[position] != rune('r') {
-											goto l62
-										}
-										position++
-										goto l61
-									l62:
-										position, tokenIndex = position61, tokenIndex61
-										if buffer[position] != rune('R') {
-											goto l57
-										}
-										position++
-									}
-								l61:
-									{
-										position63, tokenIndex63 := position, tokenIndex
-										if buffer[position] != rune('e') {
-											goto l64
-										}
-										position++
-										goto l63
-									l64:
-										position, tokenIndex = position63, tokenIndex63
-										if buffer[position] != rune('E') {
-											goto l57
-										}
-										position++
-									}
-								l63:
-									{
-										position65, tokenIndex65 := position, tokenIndex
-										if buffer[position] != rune('c') {
-											goto l66
-										}
-										position++
-										goto l65
-									l66:
-										position, tokenIndex = position65, tokenIndex65
-										if buffer[position] != rune('C') {
-											goto l57
-										}
-										position++
-									}
-								l65:
-									{
-										position67, tokenIndex67 := position, tokenIndex
-										if buffer[position] != rune('o') {
-											goto l68
-										}
-										position++
-										goto l67
-									l68:
-										position, tokenIndex = position67, tokenIndex67
-										if buffer[position] != rune('O') {
-											goto l57
-										}
-										position++
-									}
-								l67:
-									{
-										position69, tokenIndex69 := position, tokenIndex
-										if buffer[position] != rune('r') {
-											goto l70
-										}
-										position++
-										goto l69
-									l70:
-										position, tokenIndex = position69, tokenIndex69
-										if buffer[position] != rune('R') {
-											goto l57
-										}
-										position++
-									}
-								l69:
-									{
-										position71, tokenIndex71 := position, tokenIndex
-										if buffer[position] != rune('d') {
-											goto l72
-										}
-										position++
-										goto l71
-									l72:
-										position, tokenIndex = position71, tokenIndex71
-										if buffer[position] != rune('D') {
-											goto l57
-										}
-										position++
-									}
-								l71:
-									if !_rules[ruleWhitespace]() {
-										goto l57
-									}
-									if !_rules[ruleCapitalLabel]() {
-										goto l57
-									}
-									{
-										position73, tokenIndex73 := position, tokenIndex
-										if !_rules[ruleGenericArgs]() {
-											goto l73
-										}
-										goto l74
-									l73:
-										position, tokenIndex = position73, tokenIndex73
-									}
-								l74:
-									{
-										position75, tokenIndex75 := position, tokenIndex
-										{
-											position77 := position
-											if buffer[position] != rune(':') {
-												goto l75
-											}
-											position++
-											if !_rules[ruleNewline]() {
-												goto l75
-											}
-											if !_rules[ruleIndent]() {
-												goto l75
-											}
-											{
-												position80 := position
-												if !_rules[ruleLabel]() {
-													goto l75
-												}
-												if !_rules[ruleWhitespace]() {
-													goto l75
-												}
-												if !_rules[ruleType]() {
-													goto l75
-												}
-												add(ruleSex, position80)
-											}
-											if !_rules[ruleNewline]() {
-												goto l75
-											}
-										l78:
-											{
-												position79, tokenIndex79 := position, tokenIndex
-												{
-													position81 := position
-													if !_rules[ruleLabel]() {
-														goto l79
-													}
-													if !_rules[ruleWhitespace]() {
-														goto l79
-													}
-													if !_rules[ruleType]() {
-														goto l79
-													}
-													add(ruleSex, position81)
-												}
-												if !_rules[ruleNewline]() {
-													goto l79
-												}
-												goto l78
-											l79:
-												position, tokenIndex = position79, tokenIndex79
-											}
-											if !_rules[ruleDedent]() {
-												goto l75
-											}
-											add(ruleRecordContents, position77)
-										}
-										goto l76
-									l75:
-										position, tokenIndex = position75, tokenIndex75
-									}
-								l76:
-									add(ruleRecord, position60)
-								}
-								break
-							case 'I', 'i':
-								{
-									position82 := position
-									{
-										position83, tokenIndex83 := position, tokenIndex
-										if buffer[position] != rune('i') {
-											goto l84
-										}
-										position++
-										goto l83
-									l84:
-										position, tokenIndex = position83, tokenIndex83
-										if buffer[position] != rune('I') {
-											goto l57
-										}
-										position++
-									}
-								l83:
-									{
-										position85, tokenIndex85 := position, tokenIndex
-										if buffer[position] != rune('n') {
-											goto l86
-										}
-										position++
-										goto l85
-									l86:
-										position, tokenIndex = position85, tokenIndex85
-										if buffer[position] != rune('N') {
-											goto l57
-										}
-										position++
-									}
-								l85:
-									{
-										position87, tokenIndex87 := position, tokenIndex
-										if buffer[position] != rune('t') {
-											goto l88
-										}
-										position++
-										goto l87
-									l88:
-										position, tokenIndex = position87, tokenIndex87
-										if buffer[position] != rune('T') {
-											goto l57
-										}
-										position++
-									}
-								l87:
-									{
-										position89, tokenIndex89 := position, tokenIndex
-										if buffer[position] != rune('e') {
-											goto l90
-										}
-										position++
-										goto l89
-									l90:
-										position, tokenIndex = position89, tokenIndex89
-										if buffer[position] != rune('E') {
-											goto l57
-										}
-										position++
-									}
-								l89:
-									{
-										position91, tokenIndex91 := position, tokenIndex
-										if buffer[position] != rune('r') {
-											goto l92
-										}
-										position++
-										goto l91
-									l92:
-										position, tokenIndex = position91, tokenIndex91
-										if buffer[position] != rune('R') {
-											goto l57
-										}
-										position++
-									}
-								l91:
-									{
-										position93, tokenIndex93 := position, tokenIndex
-										if buffer[position] != rune('f') {
-											goto l94
-										}
-										position++
-										goto l93
-									l94:
-										position, tokenIndex = position93, tokenIndex93
-										if buffer[position] != rune('F') {
-											goto l57
-										}
-										position++
-									}
-								l93:
-									{
-										position95, tokenIndex95 := position, tokenIndex
-										if buffer[position] != rune('a') {
-											goto l96
-										}
-										position++
-										goto l95
-									l96:
-										position, tokenIndex = position95, tokenIndex95
-										if buffer[position] != rune('A') {
-											goto l57
-										}
-										position++
-									}
-								l95:
-									{
-										position97, tokenIndex97 := position, tokenIndex
-										if buffer[position] != rune('c') {
-											goto l98
-										}
-										position++
-										goto l97
-									l98:
-										position, tokenIndex = position97, tokenIndex97
-										if buffer[position] != rune('C') {
-											goto l57
-										}
-										position++
-									}
-								l97:
-									{
-										position99, tokenIndex99 := position, tokenIndex
-										if buffer[position] != rune('e') {
-											goto l100
-										}
-										position++
-										goto l99
-									l100:
-										position, tokenIndex = position99, tokenIndex99
-										if buffer[position] != rune('E') {
-											goto l57
-										}
-										position++
-									}
-								l99:
-									if !_rules[ruleWhitespace]() {
-										goto l57
-									}
-									if !_rules[ruleCapitalLabel]() {
-										goto l57
-									}
-									{
-										position101, tokenIndex101 := position, tokenIndex
-										if !_rules[ruleGenericArgs]() {
-											goto l101
-										}
-										goto l102
-									l101:
-										position, tokenIndex = position101, tokenIndex101
-									}
-								l102:
-									{
-										position103, tokenIndex103 := position, tokenIndex
-										{
-											position105 := position
-											if buffer[position] != rune(':') {
-												goto l103
-											}
-											position++
-											if !_rules[ruleNewline]() {
-												goto l103
-											}
-											if !_rules[ruleIndent]() {
-												goto l103
-											}
-											{
-												position108 := position
-												if !_rules[ruleFunLabel]() {
-													goto l103
-												}
-												if buffer[position] != rune('(') {
-													goto l103
-												}
-												position++
-											l109:
-												{
-													position110, tokenIndex110 := position, tokenIndex
-													if !_rules[ruleType]() {
-														goto l110
-													}
-													if buffer[position] != rune(',') {
-														goto l110
-													}
-													position++
-													{
-														position111, tokenIndex111 := position, tokenIndex
-														if !_rules[ruleWhitespace]() {
-															goto l111
-														}
-														goto l112
-													l111:
-														position, tokenIndex = position111, tokenIndex111
-													}
-												l112:
-													goto l109
-												l110:
-													position, tokenIndex = position110, tokenIndex110
-												}
-												{
-													position113, tokenIndex113 := position, tokenIndex
-													if !_rules[ruleType]() {
-														goto l113
-													}
-													goto l114
-												l113:
-													position, tokenIndex = position113, tokenIndex113
-												}
-											l114:
-												if buffer[position] != rune(')') {
-													goto l103
-												}
-												position++
-												{
-													position115, tokenIndex115 := position, tokenIndex
-													{
-														position117 := position
-														if !_rules[ruleWhitespace]() {
-															goto l115
-														}
-														if !_rules[ruleType]() {
-															goto l115
-														}
-														add(ruleZ, position117)
-													}
-													goto l116
-												l115:
-													position, tokenIndex = position115, tokenIndex115
-												}
-											l116:
-												add(ruleDeclaration, position108)
-											}
-											if !_rules[ruleNewline]() {
-												goto l103
-											}
-										l106:
-											{
-												position107, tokenIndex107 := position, tokenIndex
-												{
-													position118 := position
-													if !_rules[ruleFunLabel]() {
-														goto l107
-													}
-													if buffer[position] != rune('(') {
-														goto l107
-													}
-													position++
-												l119:
-													{
-														position120, tokenIndex120 := position, tokenIndex
-														if !_rules[ruleType]() {
-															goto l120
-														}
-														if buffer[position] != rune(',') {
-															goto l120
-														}
-														position++
-														{
-															position121, tokenIndex121 := position, tokenIndex
-															if !_rules[ruleWhitespace]() {
-																goto l121
-															}
-															goto l122
-														l121:
-															position, tokenIndex = position121, tokenIndex121
-														}
-													l122:
-														goto l119
-													l120:
-														position, tokenIndex = position120, tokenIndex120
-													}
-													{
-														position123, tokenIndex123 := position, tokenIndex
-														if !_rules[ruleType]() {
-															goto l123
-														}
-														goto l124
-													l123:
-														position, tokenIndex = position123, tokenIndex123
-													}
-												l124:
-													if buffer[position] != rune(')') {
-														goto l107
-													}
-													position++
-													{
-														position125, tokenIndex125 := position, tokenIndex
-														{
-															position127 := position
-															if !_rules[ruleWhitespace]() {
-																goto l125
-															}
-															if !_rules[ruleType]() {
-																goto l125
-															}
-															add(ruleZ, position127)
-														}
-														goto l126
-													l125:
-														position, tokenIndex = position125, tokenIndex125
-													}
-												l126:
-													add(ruleDeclaration, position118)
-												}
-												if !_rules[ruleNewline]() {
-													goto l107
-												}
-												goto l106
-											l107:
-												position, tokenIndex = position107, tokenIndex107
-											}
-											if !_rules[ruleDedent]() {
-												goto l103
-											}
-											add(ruleArray, position105)
-										}
-										goto l104
-									l103:
-										position, tokenIndex = position103, tokenIndex103
-									}
-								l104:
-									add(ruleInterface, position82)
-								}
-								break
-							default:
-								{
-									position128 := position
-									{
-										position129, tokenIndex129 := position, tokenIndex
-										if buffer[position] != rune('f') {
-											goto l130
-										}
-										position++
-										goto l129
-									l130:
-										position, tokenIndex = position129, tokenIndex129
-										if buffer[position] != rune('F') {
-											goto l57
-										}
-										position++
-									}
-								l129:
-									{
-										position131, tokenIndex131 := position, tokenIndex
-										if buffer[position] != rune('u') {
-											goto l132
-										}
-										position++
-										goto l131
-									l132:
-										position, tokenIndex = position131, tokenIndex131
-										if buffer[position] != rune('U') {
-											goto l57
-										}
-										position++
-									}
-								l131:
-									{
-										position133, tokenIndex133 := position, tokenIndex
-										if buffer[position] != rune('n') {
-											goto l134
-										}
-										position++
-										goto l133
-									l134:
-										position, tokenIndex = position133, tokenIndex133
-										if buffer[position] != rune('N') {
-											goto l57
-										}
-										position++
-									}
-								l133:
-									{
-										position135, tokenIndex135 := position, tokenIndex
-										if buffer[position] != rune('c') {
-											goto l136
-										}
-										position++
-										goto l135
-									l136:
-										position, tokenIndex = position135, tokenIndex135
-										if buffer[position] != rune('C') {
-											goto l57
-										}
-										position++
-									}
-								l135:
-									if !_rules[ruleWhitespace]() {
-										goto l57
-									}
-									if !_rules[ruleFunLabel]() {
-										goto l57
-									}
-									{
-										position137, tokenIndex137 := position, tokenIndex
-										if !_rules[ruleGenericArgs]() {
-											goto l137
-										}
-										goto l138
-									l137:
-										position, tokenIndex = position137, tokenIndex137
-									}
-								l138:
-									{
-										position139, tokenIndex139 := position, tokenIndex
-										{
-											position141 := position
-											if buffer[position] != rune('(') {
-												goto l139
-											}
-											position++
-										l142:
-											{
-												position143, tokenIndex143 := position, tokenIndex
-												{
-													position144 := position
-													{
-														position145, tokenIndex145 := position, tokenIndex
-														{
-															position147 := position
-															if !_rules[ruleFunLowerLabel]() {
-																goto l146
-															}
-															if !_rules[ruleWhitespace]() {
-																goto l146
-															}
-															if !_rules[ruleType]() {
-																goto l146
-															}
-															if buffer[position] != rune(',') {
-																goto l146
-															}
-															position++
-															{
-																position148, tokenIndex148 := position, tokenIndex
-																if !_rules[ruleWhitespace]() {
-																	goto l148
-																}
-																goto l149
-															l148:
-																position, tokenIndex = position148, tokenIndex148
-															}
-														l149:
-															add(rulePreArg, position147)
-														}
-														goto l145
-													l146:
-														position, tokenIndex = position145, tokenIndex145
-														{
-															position150 := position
-															if !_rules[ruleFunLowerLabel]() {
-																goto l143
-															}
-															if !_rules[ruleWhitespace]() {
-																goto l143
-															}
-															if !_rules[ruleType]() {
-																goto l143
-															}
-															add(ruleLastArg, position150)
-														}
-													}
-												l145:
-													add(ruleFunArg, position144)
-												}
-												goto l142
-											l143:
-												position, tokenIndex = position143, tokenIndex143
-											}
-											if buffer[position] != rune(')') {
-												goto l139
-											}
-											position++
-											add(ruleFunArgs, position141)
-										}
-										goto l140
-									l139:
-										position, tokenIndex = position139, tokenIndex139
-									}
-								l140:
-									{
-										position151, tokenIndex151 := position, tokenIndex
-										if !_rules[ruleWhitespace]() {
-											goto l151
-										}
-										goto l152
-									l151:
-										position, tokenIndex = position151, tokenIndex151
-									}
-								l152:
-									{
-										position153, tokenIndex153 := position, tokenIndex
-										if !_rules[ruleType]() {
-											goto l153
-										}
-										goto l154
-									l153:
-										position, tokenIndex = position153, tokenIndex153
-									}
-								l154:
-									if buffer[position] != rune(':') {
-										goto l57
-									}
-									position++
-									if !_rules[ruleNewline]() {
-										goto l57
-									}
-									if !_rules[ruleIndent]() {
-										goto l57
-									}
-									if !_rules[ruleCode]() {
-										goto l57
-									}
-									add(ruleFunction, position128)
-								}
-								break
-							}
-						}
-
-						add(ruleTop, position58)
+					position7, tokenIndex7 := position, tokenIndex
+					if !_rules[ruleTop]() {
+						goto l7
 					}
 					if !_rules[ruleNewline]() {
-						goto l57
+						goto l7
 					}
-					goto l56
-				l57:
-					position, tokenIndex = position57, tokenIndex57
+					goto l6
+				l7:
+					position, tokenIndex = position7, tokenIndex7
 				}
-				{
-					position155 := position
-					{
-						position156, tokenIndex156 := position, tokenIndex
-						if !matchDot() {
-							goto l156
-						}
-						goto l0
-					l156:
-						position, tokenIndex = position156, tokenIndex156
-					}
-					add(ruleEOT, position155)
+				if !_rules[ruleEOT]() {
+					goto l0
 				}
 				add(ruleModule, position1)
 			}
@@ -1570,3652 +652,9723 @@ func (p *MeltParser) Init() {
 			position, tokenIndex = position0, tokenIndex0
 			return false
 		},
-		/* 1 Top <- <((&('R' | 'r') Record) | (&('I' | 'i') Interface) | (&('F' | 'f') Function))> */
-		nil,
-		/* 2 Package <- <(('p' / 'P') ('a' / 'A') ('c' / 'C') ('k' / 'K') ('a' / 'A') ('g' / 'G') ('e' / 'E') Whitespace LowerLabel)> */
-		nil,
-		/* 3 Import <- <(('i' / 'I') ('m' / 'M') ('p' / 'P') ('o' / 'O') ('r' / 'R') ('t' / 'T') ':' Newline Indent GoImport? MeltImport?)> */
-		nil,
-		/* 4 GoImport <- <(('g' / 'G') ('o' / 'O') ':' Newline Indent (String Newline)+ Dedent)> */
-		nil,
-		/* 5 MeltImport <- <(('m' / 'M') ('e' / 'E') ('l' / 'L') ('t' / 'T') ':' Newline Indent (String Newline)+ Dedent)> */
-		nil,
-		/* 6 Function <- <(('f' / 'F') ('u' / 'U') ('n' / 'N') ('c' / 'C') Whitespace FunLabel GenericArgs? FunArgs? Whitespace? Type? ':' Newline Indent Code)> */
-		nil,
-		/* 7 Interface <- <(('i' / 'I') ('n' / 'N') ('t' / 'T') ('e' / 'E') ('r' / 'R') ('f' / 'F') ('a' / 'A') ('c' / 'C') ('e' / 'E') Whitespace CapitalLabel GenericArgs? Array?)> */
-		nil,
-		/* 8 Array <- <(':' Newline Indent (Declaration Newline)+ Dedent)> */
-		nil,
-		/* 9 Declaration <- <(FunLabel '(' (Type ',' Whitespace?)* Type? ')' Z?)> */
-		nil,
-		/* 10 Z <- <(Whitespace Type)> */
-		nil,
-		/* 11 Record <- <(('r' / 'R') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('r' / 'R') ('d' / 'D') Whitespace CapitalLabel GenericArgs? RecordContents?)> */
-		nil,
-		/* 12 RecordContents <- <(':' Newline Indent (Sex Newline)+ Dedent)> */
-		nil,
-		/* 13 Sex <- <(Label Whitespace Type)> */
-		nil,
-		/* 14 FunArgs <- <('(' FunArg* ')')> */
-		nil,
-		/* 15 GenericArgs <- <('<' (CapitalLabel ',' Whitespace?)* CapitalLabel '>')> */
+		/* 1 Top <- <(Function / Interface / Record / Union)> */
 		func() bool {
-			position171, tokenIndex171 := position, tokenIndex
+			position8, tokenIndex8 := position, tokenIndex
 			{
-				position172 := position
-				if buffer[position] != rune('<') {
-					goto l171
-				}
-				position++
-			l173:
+				position9 := position
 				{
-					position174, tokenIndex174 := position, tokenIndex
-					if !_rules[ruleCapitalLabel]() {
-						goto l174
+					position10, tokenIndex10 := position, tokenIndex
+					if !_rules[ruleFunction]() {
+						goto l11
 					}
-					if buffer[position] != rune(',') {
-						goto l174
+					goto l10
+				l11:
+					position, tokenIndex = position10, tokenIndex10
+					if !_rules[ruleInterface]() {
+						goto l12
 					}
-					position++
-					{
-						position175, tokenIndex175 := position, tokenIndex
-						if !_rules[ruleWhitespace]() {
-							goto l175
-						}
-						goto l176
-					l175:
-						position, tokenIndex = position175, tokenIndex175
+					goto l10
+				l12:
+					position, tokenIndex = position10, tokenIndex10
+					if !_rules[ruleRecord]() {
+						goto l13
+					}
+					goto l10
+				l13:
+					position, tokenIndex = position10, tokenIndex10
+					if !_rules[ruleUnion]() {
+						goto l8
 					}
-				l176:
-					goto l173
-				l174:
-					position, tokenIndex = position174, tokenIndex174
-				}
-				if !_rules[ruleCapitalLabel]() {
-					goto l171
-				}
-				if buffer[position] != rune('>') {
-					goto l171
 				}
-				position++
-				add(ruleGenericArgs, position172)
+			l10:
+				add(ruleTop, position9)
 			}
 			return true
-		l171:
-			position, tokenIndex = position171, tokenIndex171
+		l8:
+			position, tokenIndex = position8, tokenIndex8
 			return false
 		},
-		/* 16 FunArg <- <(PreArg / LastArg)> */
-		nil,
-		/* 17 PreArg <- <(FunLowerLabel Whitespace Type ',' Whitespace?)> */
-		nil,
-		/* 18 LastArg <- <(FunLowerLabel Whitespace Type)> */
-		nil,
-		/* 19 FunLabel <- <(([A-Z] / [a-z]) ((&('_') '_') | (&('`') '`') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]))* ('?' / '!')?)> */
+		/* 2 Package <- <(('p' / 'P') ('a' / 'A') ('c' / 'C') ('k' / 'K') ('a' / 'A') ('g' / 'G') ('e' / 'E') Whitespace LowerLabel)> */
 		func() bool {
-			position180, tokenIndex180 := position, tokenIndex
+			position14, tokenIndex14 := position, tokenIndex
 			{
-				position181 := position
+				position15 := position
 				{
-					position182, tokenIndex182 := position, tokenIndex
-					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l183
+					position16, tokenIndex16 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l17
 					}
 					position++
-					goto l182
-				l183:
-					position, tokenIndex = position182, tokenIndex182
-					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l180
+					goto l16
+				l17:
+					position, tokenIndex = position16, tokenIndex16
+					if buffer[position] != rune('P') {
+						goto l14
 					}
 					position++
 				}
-			l182:
-			l184:
+			l16:
 				{
-					position185, tokenIndex185 := position, tokenIndex
-					{
-						switch buffer[position] {
-						case '_':
-							if buffer[position] != rune('_') {
-								goto l185
-							}
-							position++
-							break
-						case '`':
-							if buffer[position] != rune('`') {
-								goto l185
-							}
-							position++
-							break
-						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l185
-							}
-							position++
-							break
-						case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
-							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l185
-							}
-							position++
-							break
-						default:
-							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l185
-							}
-							position++
-							break
-						}
+					position18, tokenIndex18 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l19
 					}
-
-					goto l184
-				l185:
-					position, tokenIndex = position185, tokenIndex185
+					position++
+					goto l18
+				l19:
+					position, tokenIndex = position18, tokenIndex18
+					if buffer[position] != rune('A') {
+						goto l14
+					}
+					position++
 				}
+			l18:
 				{
-					position187, tokenIndex187 := position, tokenIndex
+					position20, tokenIndex20 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l21
+					}
+					position++
+					goto l20
+				l21:
+					position, tokenIndex = position20, tokenIndex20
+					if buffer[position] != rune('C') {
+						goto l14
+					}
+					position++
+				}
+			l20:
+				{
+					position22, tokenIndex22 := position, tokenIndex
+					if buffer[position] != rune('k') {
+						goto l23
+					}
+					position++
+					goto l22
+				l23:
+					position, tokenIndex = position22, tokenIndex22
+					if buffer[position] != rune('K') {
+						goto l14
+					}
+					position++
+				}
+			l22:
+				{
+					position24, tokenIndex24 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l25
+					}
+					position++
+					goto l24
+				l25:
+					position, tokenIndex = position24, tokenIndex24
+					if buffer[position] != rune('A') {
+						goto l14
+					}
+					position++
+				}
+			l24:
+				{
+					position26, tokenIndex26 := position, tokenIndex
+					if buffer[position] != rune('g') {
+						goto l27
+					}
+					position++
+					goto l26
+				l27:
+					position, tokenIndex = position26, tokenIndex26
+					if buffer[position] != rune('G') {
+						goto l14
+					}
+					position++
+				}
+			l26:
+				{
+					position28, tokenIndex28 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l29
+					}
+					position++
+					goto l28
+				l29:
+					position, tokenIndex = position28, tokenIndex28
+					if buffer[position] != rune('E') {
+						goto l14
+					}
+					position++
+				}
+			l28:
+				if !_rules[ruleWhitespace]() {
+					goto l14
+				}
+				if !_rules[ruleLowerLabel]() {
+					goto l14
+				}
+				add(rulePackage, position15)
+			}
+			return true
+		l14:
+			position, tokenIndex = position14, tokenIndex14
+			return false
+		},
+		/* 3 Import <- <(('i' / 'I') ('m' / 'M') ('p' / 'P') ('o' / 'O') ('r' / 'R') ('t' / 'T') ':' Newline Indent (GoImport / MeltImport / ImportLine)+ Dedent)> */
+		func() bool {
+			position30, tokenIndex30 := position, tokenIndex
+			{
+				position31 := position
+				{
+					position32, tokenIndex32 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l33
+					}
+					position++
+					goto l32
+				l33:
+					position, tokenIndex = position32, tokenIndex32
+					if buffer[position] != rune('I') {
+						goto l30
+					}
+					position++
+				}
+			l32:
+				{
+					position34, tokenIndex34 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l35
+					}
+					position++
+					goto l34
+				l35:
+					position, tokenIndex = position34, tokenIndex34
+					if buffer[position] != rune('M') {
+						goto l30
+					}
+					position++
+				}
+			l34:
+				{
+					position36, tokenIndex36 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l37
+					}
+					position++
+					goto l36
+				l37:
+					position, tokenIndex = position36, tokenIndex36
+					if buffer[position] != rune('P') {
+						goto l30
+					}
+					position++
+				}
+			l36:
+				{
+					position38, tokenIndex38 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l39
+					}
+					position++
+					goto l38
+				l39:
+					position, tokenIndex = position38, tokenIndex38
+					if buffer[position] != rune('O') {
+						goto l30
+					}
+					position++
+				}
+			l38:
+				{
+					position40, tokenIndex40 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l41
+					}
+					position++
+					goto l40
+				l41:
+					position, tokenIndex = position40, tokenIndex40
+					if buffer[position] != rune('R') {
+						goto l30
+					}
+					position++
+				}
+			l40:
+				{
+					position42, tokenIndex42 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l43
+					}
+					position++
+					goto l42
+				l43:
+					position, tokenIndex = position42, tokenIndex42
+					if buffer[position] != rune('T') {
+						goto l30
+					}
+					position++
+				}
+			l42:
+				if buffer[position] != rune(':') {
+					goto l30
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l30
+				}
+				if !_rules[ruleIndent]() {
+					goto l30
+				}
+				{
+					position46, tokenIndex46 := position, tokenIndex
+					if !_rules[ruleGoImport]() {
+						goto l47
+					}
+					goto l46
+				l47:
+					position, tokenIndex = position46, tokenIndex46
+					if !_rules[ruleMeltImport]() {
+						goto l48
+					}
+					goto l46
+				l48:
+					position, tokenIndex = position46, tokenIndex46
+					if !_rules[ruleImportLine]() {
+						goto l30
+					}
+				}
+			l46:
+			l44:
+				{
+					position45, tokenIndex45 := position, tokenIndex
 					{
-						position189, tokenIndex189 := position, tokenIndex
-						if buffer[position] != rune('?') {
-							goto l190
+						position49, tokenIndex49 := position, tokenIndex
+						if !_rules[ruleGoImport]() {
+							goto l50
+						}
+						goto l49
+					l50:
+						position, tokenIndex = position49, tokenIndex49
+						if !_rules[ruleMeltImport]() {
+							goto l51
+						}
+						goto l49
+					l51:
+						position, tokenIndex = position49, tokenIndex49
+						if !_rules[ruleImportLine]() {
+							goto l45
+						}
+					}
+				l49:
+					goto l44
+				l45:
+					position, tokenIndex = position45, tokenIndex45
+				}
+				if !_rules[ruleDedent]() {
+					goto l30
+				}
+				add(ruleImport, position31)
+			}
+			return true
+		l30:
+			position, tokenIndex = position30, tokenIndex30
+			return false
+		},
+		/* 4 GoImport <- <(('g' / 'G') ('o' / 'O') ':' Newline Indent ImportLine+ Dedent Newline)> */
+		func() bool {
+			position52, tokenIndex52 := position, tokenIndex
+			{
+				position53 := position
+				{
+					position54, tokenIndex54 := position, tokenIndex
+					if buffer[position] != rune('g') {
+						goto l55
+					}
+					position++
+					goto l54
+				l55:
+					position, tokenIndex = position54, tokenIndex54
+					if buffer[position] != rune('G') {
+						goto l52
+					}
+					position++
+				}
+			l54:
+				{
+					position56, tokenIndex56 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l57
+					}
+					position++
+					goto l56
+				l57:
+					position, tokenIndex = position56, tokenIndex56
+					if buffer[position] != rune('O') {
+						goto l52
+					}
+					position++
+				}
+			l56:
+				if buffer[position] != rune(':') {
+					goto l52
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l52
+				}
+				if !_rules[ruleIndent]() {
+					goto l52
+				}
+				if !_rules[ruleImportLine]() {
+					goto l52
+				}
+			l58:
+				{
+					position59, tokenIndex59 := position, tokenIndex
+					if !_rules[ruleImportLine]() {
+						goto l59
+					}
+					goto l58
+				l59:
+					position, tokenIndex = position59, tokenIndex59
+				}
+				if !_rules[ruleDedent]() {
+					goto l52
+				}
+				if !_rules[ruleNewline]() {
+					goto l52
+				}
+				add(ruleGoImport, position53)
+			}
+			return true
+		l52:
+			position, tokenIndex = position52, tokenIndex52
+			return false
+		},
+		/* 5 MeltImport <- <(('m' / 'M') ('e' / 'E') ('l' / 'L') ('t' / 'T') ':' Newline Indent ImportLine+ Dedent Newline)> */
+		func() bool {
+			position60, tokenIndex60 := position, tokenIndex
+			{
+				position61 := position
+				{
+					position62, tokenIndex62 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l63
+					}
+					position++
+					goto l62
+				l63:
+					position, tokenIndex = position62, tokenIndex62
+					if buffer[position] != rune('M') {
+						goto l60
+					}
+					position++
+				}
+			l62:
+				{
+					position64, tokenIndex64 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l65
+					}
+					position++
+					goto l64
+				l65:
+					position, tokenIndex = position64, tokenIndex64
+					if buffer[position] != rune('E') {
+						goto l60
+					}
+					position++
+				}
+			l64:
+				{
+					position66, tokenIndex66 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l67
+					}
+					position++
+					goto l66
+				l67:
+					position, tokenIndex = position66, tokenIndex66
+					if buffer[position] != rune('L') {
+						goto l60
+					}
+					position++
+				}
+			l66:
+				{
+					position68, tokenIndex68 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l69
+					}
+					position++
+					goto l68
+				l69:
+					position, tokenIndex = position68, tokenIndex68
+					if buffer[position] != rune('T') {
+						goto l60
+					}
+					position++
+				}
+			l68:
+				if buffer[position] != rune(':') {
+					goto l60
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l60
+				}
+				if !_rules[ruleIndent]() {
+					goto l60
+				}
+				if !_rules[ruleImportLine]() {
+					goto l60
+				}
+			l70:
+				{
+					position71, tokenIndex71 := position, tokenIndex
+					if !_rules[ruleImportLine]() {
+						goto l71
+					}
+					goto l70
+				l71:
+					position, tokenIndex = position71, tokenIndex71
+				}
+				if !_rules[ruleDedent]() {
+					goto l60
+				}
+				if !_rules[ruleNewline]() {
+					goto l60
+				}
+				add(ruleMeltImport, position61)
+			}
+			return true
+		l60:
+			position, tokenIndex = position60, tokenIndex60
+			return false
+		},
+		/* 6 ImportLine <- <(ImportPath (Whitespace (('a' / 'A') ('s' / 'S')) Whitespace LowerLabel)? Newline)> */
+		func() bool {
+			position72, tokenIndex72 := position, tokenIndex
+			{
+				position73 := position
+				if !_rules[ruleImportPath]() {
+					goto l72
+				}
+				{
+					position74, tokenIndex74 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l74
+					}
+					{
+						position76, tokenIndex76 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l77
 						}
 						position++
-						goto l189
-					l190:
-						position, tokenIndex = position189, tokenIndex189
-						if buffer[position] != rune('!') {
-							goto l187
+						goto l76
+					l77:
+						position, tokenIndex = position76, tokenIndex76
+						if buffer[position] != rune('A') {
+							goto l74
+						}
+						position++
+					}
+				l76:
+					{
+						position78, tokenIndex78 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l79
+						}
+						position++
+						goto l78
+					l79:
+						position, tokenIndex = position78, tokenIndex78
+						if buffer[position] != rune('S') {
+							goto l74
 						}
 						position++
 					}
-				l189:
-					goto l188
-				l187:
-					position, tokenIndex = position187, tokenIndex187
+				l78:
+					if !_rules[ruleWhitespace]() {
+						goto l74
+					}
+					if !_rules[ruleLowerLabel]() {
+						goto l74
+					}
+					goto l75
+				l74:
+					position, tokenIndex = position74, tokenIndex74
+				}
+			l75:
+				if !_rules[ruleNewline]() {
+					goto l72
+				}
+				add(ruleImportLine, position73)
+			}
+			return true
+		l72:
+			position, tokenIndex = position72, tokenIndex72
+			return false
+		},
+		/* 7 ImportPath <- <(Text / ([a-z] ([a-z] / [0-9] / '_' / '.' / '/')*))> */
+		func() bool {
+			position80, tokenIndex80 := position, tokenIndex
+			{
+				position81 := position
+				{
+					position82, tokenIndex82 := position, tokenIndex
+					if !_rules[ruleText]() {
+						goto l83
+					}
+					goto l82
+				l83:
+					position, tokenIndex = position82, tokenIndex82
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l80
+					}
+					position++
+				l84:
+					{
+						position85, tokenIndex85 := position, tokenIndex
+						{
+							position86, tokenIndex86 := position, tokenIndex
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l87
+							}
+							position++
+							goto l86
+						l87:
+							position, tokenIndex = position86, tokenIndex86
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l88
+							}
+							position++
+							goto l86
+						l88:
+							position, tokenIndex = position86, tokenIndex86
+							if buffer[position] != rune('_') {
+								goto l89
+							}
+							position++
+							goto l86
+						l89:
+							position, tokenIndex = position86, tokenIndex86
+							if buffer[position] != rune('.') {
+								goto l90
+							}
+							position++
+							goto l86
+						l90:
+							position, tokenIndex = position86, tokenIndex86
+							if buffer[position] != rune('/') {
+								goto l85
+							}
+							position++
+						}
+					l86:
+						goto l84
+					l85:
+						position, tokenIndex = position85, tokenIndex85
+					}
+				}
+			l82:
+				add(ruleImportPath, position81)
+			}
+			return true
+		l80:
+			position, tokenIndex = position80, tokenIndex80
+			return false
+		},
+		/* 8 Function <- <(('f' / 'F') ('u' / 'U') ('n' / 'N') ('c' / 'C') Whitespace (Receiver Whitespace)? ((FailingLabel ErrorTypes) / FunLabel) GenericArgs? FunArgs? Whitespace? Type? ':' Newline Indent Code)> */
+		func() bool {
+			position91, tokenIndex91 := position, tokenIndex
+			{
+				position92 := position
+				{
+					position93, tokenIndex93 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l94
+					}
+					position++
+					goto l93
+				l94:
+					position, tokenIndex = position93, tokenIndex93
+					if buffer[position] != rune('F') {
+						goto l91
+					}
+					position++
+				}
+			l93:
+				{
+					position95, tokenIndex95 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l96
+					}
+					position++
+					goto l95
+				l96:
+					position, tokenIndex = position95, tokenIndex95
+					if buffer[position] != rune('U') {
+						goto l91
+					}
+					position++
+				}
+			l95:
+				{
+					position97, tokenIndex97 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l98
+					}
+					position++
+					goto l97
+				l98:
+					position, tokenIndex = position97, tokenIndex97
+					if buffer[position] != rune('N') {
+						goto l91
+					}
+					position++
+				}
+			l97:
+				{
+					position99, tokenIndex99 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l100
+					}
+					position++
+					goto l99
+				l100:
+					position, tokenIndex = position99, tokenIndex99
+					if buffer[position] != rune('C') {
+						goto l91
+					}
+					position++
+				}
+			l99:
+				if !_rules[ruleWhitespace]() {
+					goto l91
+				}
+				{
+					position101, tokenIndex101 := position, tokenIndex
+					if !_rules[ruleReceiver]() {
+						goto l101
+					}
+					if !_rules[ruleWhitespace]() {
+						goto l101
+					}
+					goto l102
+				l101:
+					position, tokenIndex = position101, tokenIndex101
+				}
+			l102:
+				{
+					position103, tokenIndex103 := position, tokenIndex
+					if !_rules[ruleFailingLabel]() {
+						goto l104
+					}
+					if !_rules[ruleErrorTypes]() {
+						goto l104
+					}
+					goto l103
+				l104:
+					position, tokenIndex = position103, tokenIndex103
+					if !_rules[ruleFunLabel]() {
+						goto l91
+					}
+				}
+			l103:
+				{
+					position105, tokenIndex105 := position, tokenIndex
+					if !_rules[ruleGenericArgs]() {
+						goto l105
+					}
+					goto l106
+				l105:
+					position, tokenIndex = position105, tokenIndex105
+				}
+			l106:
+				{
+					position107, tokenIndex107 := position, tokenIndex
+					if !_rules[ruleFunArgs]() {
+						goto l107
+					}
+					goto l108
+				l107:
+					position, tokenIndex = position107, tokenIndex107
+				}
+			l108:
+				{
+					position109, tokenIndex109 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l109
+					}
+					goto l110
+				l109:
+					position, tokenIndex = position109, tokenIndex109
+				}
+			l110:
+				{
+					position111, tokenIndex111 := position, tokenIndex
+					if !_rules[ruleType]() {
+						goto l111
+					}
+					goto l112
+				l111:
+					position, tokenIndex = position111, tokenIndex111
+				}
+			l112:
+				if buffer[position] != rune(':') {
+					goto l91
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l91
+				}
+				if !_rules[ruleIndent]() {
+					goto l91
+				}
+				if !_rules[ruleCode]() {
+					goto l91
+				}
+				add(ruleFunction, position92)
+			}
+			return true
+		l91:
+			position, tokenIndex = position91, tokenIndex91
+			return false
+		},
+		/* 9 FailingLabel <- <(([A-Z] / [a-z]) ([A-Z] / [a-z] / [0-9] / '`' / '_')* '!')> */
+		func() bool {
+			position113, tokenIndex113 := position, tokenIndex
+			{
+				position114 := position
+				{
+					position115, tokenIndex115 := position, tokenIndex
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l116
+					}
+					position++
+					goto l115
+				l116:
+					position, tokenIndex = position115, tokenIndex115
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l113
+					}
+					position++
+				}
+			l115:
+			l117:
+				{
+					position118, tokenIndex118 := position, tokenIndex
+					{
+						position119, tokenIndex119 := position, tokenIndex
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l120
+						}
+						position++
+						goto l119
+					l120:
+						position, tokenIndex = position119, tokenIndex119
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l121
+						}
+						position++
+						goto l119
+					l121:
+						position, tokenIndex = position119, tokenIndex119
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l122
+						}
+						position++
+						goto l119
+					l122:
+						position, tokenIndex = position119, tokenIndex119
+						if buffer[position] != rune('`') {
+							goto l123
+						}
+						position++
+						goto l119
+					l123:
+						position, tokenIndex = position119, tokenIndex119
+						if buffer[position] != rune('_') {
+							goto l118
+						}
+						position++
+					}
+				l119:
+					goto l117
+				l118:
+					position, tokenIndex = position118, tokenIndex118
+				}
+				if buffer[position] != rune('!') {
+					goto l113
+				}
+				position++
+				add(ruleFailingLabel, position114)
+			}
+			return true
+		l113:
+			position, tokenIndex = position113, tokenIndex113
+			return false
+		},
+		/* 10 ErrorTypes <- <('<' CapitalLabel (Whitespace? '|' Whitespace? CapitalLabel)* '>')> */
+		func() bool {
+			position124, tokenIndex124 := position, tokenIndex
+			{
+				position125 := position
+				if buffer[position] != rune('<') {
+					goto l124
+				}
+				position++
+				if !_rules[ruleCapitalLabel]() {
+					goto l124
+				}
+			l126:
+				{
+					position127, tokenIndex127 := position, tokenIndex
+					{
+						position128, tokenIndex128 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l128
+						}
+						goto l129
+					l128:
+						position, tokenIndex = position128, tokenIndex128
+					}
+				l129:
+					if buffer[position] != rune('|') {
+						goto l127
+					}
+					position++
+					{
+						position130, tokenIndex130 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l130
+						}
+						goto l131
+					l130:
+						position, tokenIndex = position130, tokenIndex130
+					}
+				l131:
+					if !_rules[ruleCapitalLabel]() {
+						goto l127
+					}
+					goto l126
+				l127:
+					position, tokenIndex = position127, tokenIndex127
+				}
+				if buffer[position] != rune('>') {
+					goto l124
+				}
+				position++
+				add(ruleErrorTypes, position125)
+			}
+			return true
+		l124:
+			position, tokenIndex = position124, tokenIndex124
+			return false
+		},
+		/* 11 Receiver <- <('(' LowerLabel Whitespace Type ')')> */
+		func() bool {
+			position132, tokenIndex132 := position, tokenIndex
+			{
+				position133 := position
+				if buffer[position] != rune('(') {
+					goto l132
+				}
+				position++
+				if !_rules[ruleLowerLabel]() {
+					goto l132
+				}
+				if !_rules[ruleWhitespace]() {
+					goto l132
+				}
+				if !_rules[ruleType]() {
+					goto l132
+				}
+				if buffer[position] != rune(')') {
+					goto l132
+				}
+				position++
+				add(ruleReceiver, position133)
+			}
+			return true
+		l132:
+			position, tokenIndex = position132, tokenIndex132
+			return false
+		},
+		/* 12 Interface <- <(('i' / 'I') ('n' / 'N') ('t' / 'T') ('e' / 'E') ('r' / 'R') ('f' / 'F') ('a' / 'A') ('c' / 'C') ('e' / 'E') Whitespace CapitalLabel GenericArgs? Array?)> */
+		func() bool {
+			position134, tokenIndex134 := position, tokenIndex
+			{
+				position135 := position
+				{
+					position136, tokenIndex136 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l137
+					}
+					position++
+					goto l136
+				l137:
+					position, tokenIndex = position136, tokenIndex136
+					if buffer[position] != rune('I') {
+						goto l134
+					}
+					position++
+				}
+			l136:
+				{
+					position138, tokenIndex138 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l139
+					}
+					position++
+					goto l138
+				l139:
+					position, tokenIndex = position138, tokenIndex138
+					if buffer[position] != rune('N') {
+						goto l134
+					}
+					position++
+				}
+			l138:
+				{
+					position140, tokenIndex140 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l141
+					}
+					position++
+					goto l140
+				l141:
+					position, tokenIndex = position140, tokenIndex140
+					if buffer[position] != rune('T') {
+						goto l134
+					}
+					position++
+				}
+			l140:
+				{
+					position142, tokenIndex142 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l143
+					}
+					position++
+					goto l142
+				l143:
+					position, tokenIndex = position142, tokenIndex142
+					if buffer[position] != rune('E') {
+						goto l134
+					}
+					position++
+				}
+			l142:
+				{
+					position144, tokenIndex144 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l145
+					}
+					position++
+					goto l144
+				l145:
+					position, tokenIndex = position144, tokenIndex144
+					if buffer[position] != rune('R') {
+						goto l134
+					}
+					position++
+				}
+			l144:
+				{
+					position146, tokenIndex146 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l147
+					}
+					position++
+					goto l146
+				l147:
+					position, tokenIndex = position146, tokenIndex146
+					if buffer[position] != rune('F') {
+						goto l134
+					}
+					position++
+				}
+			l146:
+				{
+					position148, tokenIndex148 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l149
+					}
+					position++
+					goto l148
+				l149:
+					position, tokenIndex = position148, tokenIndex148
+					if buffer[position] != rune('A') {
+						goto l134
+					}
+					position++
+				}
+			l148:
+				{
+					position150, tokenIndex150 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l151
+					}
+					position++
+					goto l150
+				l151:
+					position, tokenIndex = position150, tokenIndex150
+					if buffer[position] != rune('C') {
+						goto l134
+					}
+					position++
+				}
+			l150:
+				{
+					position152, tokenIndex152 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l153
+					}
+					position++
+					goto l152
+				l153:
+					position, tokenIndex = position152, tokenIndex152
+					if buffer[position] != rune('E') {
+						goto l134
+					}
+					position++
+				}
+			l152:
+				if !_rules[ruleWhitespace]() {
+					goto l134
+				}
+				if !_rules[ruleCapitalLabel]() {
+					goto l134
+				}
+				{
+					position154, tokenIndex154 := position, tokenIndex
+					if !_rules[ruleGenericArgs]() {
+						goto l154
+					}
+					goto l155
+				l154:
+					position, tokenIndex = position154, tokenIndex154
+				}
+			l155:
+				{
+					position156, tokenIndex156 := position, tokenIndex
+					if !_rules[ruleArray]() {
+						goto l156
+					}
+					goto l157
+				l156:
+					position, tokenIndex = position156, tokenIndex156
+				}
+			l157:
+				add(ruleInterface, position135)
+			}
+			return true
+		l134:
+			position, tokenIndex = position134, tokenIndex134
+			return false
+		},
+		/* 13 Array <- <(':' Newline Indent (Declaration Newline)+ Dedent)> */
+		func() bool {
+			position158, tokenIndex158 := position, tokenIndex
+			{
+				position159 := position
+				if buffer[position] != rune(':') {
+					goto l158
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l158
+				}
+				if !_rules[ruleIndent]() {
+					goto l158
+				}
+				if !_rules[ruleDeclaration]() {
+					goto l158
+				}
+				if !_rules[ruleNewline]() {
+					goto l158
+				}
+			l160:
+				{
+					position161, tokenIndex161 := position, tokenIndex
+					if !_rules[ruleDeclaration]() {
+						goto l161
+					}
+					if !_rules[ruleNewline]() {
+						goto l161
+					}
+					goto l160
+				l161:
+					position, tokenIndex = position161, tokenIndex161
+				}
+				if !_rules[ruleDedent]() {
+					goto l158
+				}
+				add(ruleArray, position159)
+			}
+			return true
+		l158:
+			position, tokenIndex = position158, tokenIndex158
+			return false
+		},
+		/* 14 Declaration <- <(FunLabel '(' (Type ',' Whitespace?)* Type? ')' Z?)> */
+		func() bool {
+			position162, tokenIndex162 := position, tokenIndex
+			{
+				position163 := position
+				if !_rules[ruleFunLabel]() {
+					goto l162
+				}
+				if buffer[position] != rune('(') {
+					goto l162
+				}
+				position++
+			l164:
+				{
+					position165, tokenIndex165 := position, tokenIndex
+					if !_rules[ruleType]() {
+						goto l165
+					}
+					if buffer[position] != rune(',') {
+						goto l165
+					}
+					position++
+					{
+						position166, tokenIndex166 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l166
+						}
+						goto l167
+					l166:
+						position, tokenIndex = position166, tokenIndex166
+					}
+				l167:
+					goto l164
+				l165:
+					position, tokenIndex = position165, tokenIndex165
+				}
+				{
+					position168, tokenIndex168 := position, tokenIndex
+					if !_rules[ruleType]() {
+						goto l168
+					}
+					goto l169
+				l168:
+					position, tokenIndex = position168, tokenIndex168
+				}
+			l169:
+				if buffer[position] != rune(')') {
+					goto l162
+				}
+				position++
+				{
+					position170, tokenIndex170 := position, tokenIndex
+					if !_rules[ruleZ]() {
+						goto l170
+					}
+					goto l171
+				l170:
+					position, tokenIndex = position170, tokenIndex170
+				}
+			l171:
+				add(ruleDeclaration, position163)
+			}
+			return true
+		l162:
+			position, tokenIndex = position162, tokenIndex162
+			return false
+		},
+		/* 15 Z <- <(Whitespace Type)> */
+		func() bool {
+			position172, tokenIndex172 := position, tokenIndex
+			{
+				position173 := position
+				if !_rules[ruleWhitespace]() {
+					goto l172
+				}
+				if !_rules[ruleType]() {
+					goto l172
+				}
+				add(ruleZ, position173)
+			}
+			return true
+		l172:
+			position, tokenIndex = position172, tokenIndex172
+			return false
+		},
+		/* 16 Record <- <(((('r' / 'R') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('r' / 'R') ('d' / 'D')) / (('s' / 'S') ('t' / 'T') ('r' / 'R') ('u' / 'U') ('c' / 'C') ('t' / 'T'))) Whitespace CapitalLabel GenericArgs? RecordContents?)> */
+		func() bool {
+			position174, tokenIndex174 := position, tokenIndex
+			{
+				position175 := position
+				{
+					position176, tokenIndex176 := position, tokenIndex
+					{
+						position178, tokenIndex178 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l179
+						}
+						position++
+						goto l178
+					l179:
+						position, tokenIndex = position178, tokenIndex178
+						if buffer[position] != rune('R') {
+							goto l177
+						}
+						position++
+					}
+				l178:
+					{
+						position180, tokenIndex180 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l181
+						}
+						position++
+						goto l180
+					l181:
+						position, tokenIndex = position180, tokenIndex180
+						if buffer[position] != rune('E') {
+							goto l177
+						}
+						position++
+					}
+				l180:
+					{
+						position182, tokenIndex182 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l183
+						}
+						position++
+						goto l182
+					l183:
+						position, tokenIndex = position182, tokenIndex182
+						if buffer[position] != rune('C') {
+							goto l177
+						}
+						position++
+					}
+				l182:
+					{
+						position184, tokenIndex184 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l185
+						}
+						position++
+						goto l184
+					l185:
+						position, tokenIndex = position184, tokenIndex184
+						if buffer[position] != rune('O') {
+							goto l177
+						}
+						position++
+					}
+				l184:
+					{
+						position186, tokenIndex186 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l187
+						}
+						position++
+						goto l186
+					l187:
+						position, tokenIndex = position186, tokenIndex186
+						if buffer[position] != rune('R') {
+							goto l177
+						}
+						position++
+					}
+				l186:
+					{
+						position188, tokenIndex188 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l189
+						}
+						position++
+						goto l188
+					l189:
+						position, tokenIndex = position188, tokenIndex188
+						if buffer[position] != rune('D') {
+							goto l177
+						}
+						position++
+					}
+				l188:
+					goto l176
+				l177:
+					position, tokenIndex = position176, tokenIndex176
+					{
+						position190, tokenIndex190 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l191
+						}
+						position++
+						goto l190
+					l191:
+						position, tokenIndex = position190, tokenIndex190
+						if buffer[position] != rune('S') {
+							goto l174
+						}
+						position++
+					}
+				l190:
+					{
+						position192, tokenIndex192 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l193
+						}
+						position++
+						goto l192
+					l193:
+						position, tokenIndex = position192, tokenIndex192
+						if buffer[position] != rune('T') {
+							goto l174
+						}
+						position++
+					}
+				l192:
+					{
+						position194, tokenIndex194 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l195
+						}
+						position++
+						goto l194
+					l195:
+						position, tokenIndex = position194, tokenIndex194
+						if buffer[position] != rune('R') {
+							goto l174
+						}
+						position++
+					}
+				l194:
+					{
+						position196, tokenIndex196 := position, tokenIndex
+						if buffer[position] != rune('u') {
+							goto l197
+						}
+						position++
+						goto l196
+					l197:
+						position, tokenIndex = position196, tokenIndex196
+						if buffer[position] != rune('U') {
+							goto l174
+						}
+						position++
+					}
+				l196:
+					{
+						position198, tokenIndex198 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l199
+						}
+						position++
+						goto l198
+					l199:
+						position, tokenIndex = position198, tokenIndex198
+						if buffer[position] != rune('C') {
+							goto l174
+						}
+						position++
+					}
+				l198:
+					{
+						position200, tokenIndex200 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l201
+						}
+						position++
+						goto l200
+					l201:
+						position, tokenIndex = position200, tokenIndex200
+						if buffer[position] != rune('T') {
+							goto l174
+						}
+						position++
+					}
+				l200:
+				}
+			l176:
+				if !_rules[ruleWhitespace]() {
+					goto l174
+				}
+				if !_rules[ruleCapitalLabel]() {
+					goto l174
+				}
+				{
+					position202, tokenIndex202 := position, tokenIndex
+					if !_rules[ruleGenericArgs]() {
+						goto l202
+					}
+					goto l203
+				l202:
+					position, tokenIndex = position202, tokenIndex202
+				}
+			l203:
+				{
+					position204, tokenIndex204 := position, tokenIndex
+					if !_rules[ruleRecordContents]() {
+						goto l204
+					}
+					goto l205
+				l204:
+					position, tokenIndex = position204, tokenIndex204
+				}
+			l205:
+				add(ruleRecord, position175)
+			}
+			return true
+		l174:
+			position, tokenIndex = position174, tokenIndex174
+			return false
+		},
+		/* 17 RecordContents <- <(':' Newline Indent (Sex Newline)+ Dedent)> */
+		func() bool {
+			position206, tokenIndex206 := position, tokenIndex
+			{
+				position207 := position
+				if buffer[position] != rune(':') {
+					goto l206
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l206
+				}
+				if !_rules[ruleIndent]() {
+					goto l206
+				}
+				if !_rules[ruleSex]() {
+					goto l206
+				}
+				if !_rules[ruleNewline]() {
+					goto l206
+				}
+			l208:
+				{
+					position209, tokenIndex209 := position, tokenIndex
+					if !_rules[ruleSex]() {
+						goto l209
+					}
+					if !_rules[ruleNewline]() {
+						goto l209
+					}
+					goto l208
+				l209:
+					position, tokenIndex = position209, tokenIndex209
+				}
+				if !_rules[ruleDedent]() {
+					goto l206
+				}
+				add(ruleRecordContents, position207)
+			}
+			return true
+		l206:
+			position, tokenIndex = position206, tokenIndex206
+			return false
+		},
+		/* 18 Sex <- <(Label Whitespace Type)> */
+		func() bool {
+			position210, tokenIndex210 := position, tokenIndex
+			{
+				position211 := position
+				if !_rules[ruleLabel]() {
+					goto l210
+				}
+				if !_rules[ruleWhitespace]() {
+					goto l210
+				}
+				if !_rules[ruleType]() {
+					goto l210
+				}
+				add(ruleSex, position211)
+			}
+			return true
+		l210:
+			position, tokenIndex = position210, tokenIndex210
+			return false
+		},
+		/* 19 Union <- <(('u' / 'U') ('n' / 'N') ('i' / 'I') ('o' / 'O') ('n' / 'N') Whitespace CapitalLabel GenericArgs? ':' ((Whitespace Variant (Whitespace? '|' Whitespace? Variant)*) / (Newline Indent (('|' Whitespace)? Variant Newline)+ Dedent)))> */
+		func() bool {
+			position212, tokenIndex212 := position, tokenIndex
+			{
+				position213 := position
+				{
+					position214, tokenIndex214 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l215
+					}
+					position++
+					goto l214
+				l215:
+					position, tokenIndex = position214, tokenIndex214
+					if buffer[position] != rune('U') {
+						goto l212
+					}
+					position++
+				}
+			l214:
+				{
+					position216, tokenIndex216 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l217
+					}
+					position++
+					goto l216
+				l217:
+					position, tokenIndex = position216, tokenIndex216
+					if buffer[position] != rune('N') {
+						goto l212
+					}
+					position++
+				}
+			l216:
+				{
+					position218, tokenIndex218 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l219
+					}
+					position++
+					goto l218
+				l219:
+					position, tokenIndex = position218, tokenIndex218
+					if buffer[position] != rune('I') {
+						goto l212
+					}
+					position++
+				}
+			l218:
+				{
+					position220, tokenIndex220 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l221
+					}
+					position++
+					goto l220
+				l221:
+					position, tokenIndex = position220, tokenIndex220
+					if buffer[position] != rune('O') {
+						goto l212
+					}
+					position++
+				}
+			l220:
+				{
+					position222, tokenIndex222 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l223
+					}
+					position++
+					goto l222
+				l223:
+					position, tokenIndex = position222, tokenIndex222
+					if buffer[position] != rune('N') {
+						goto l212
+					}
+					position++
+				}
+			l222:
+				if !_rules[ruleWhitespace]() {
+					goto l212
+				}
+				if !_rules[ruleCapitalLabel]() {
+					goto l212
+				}
+				{
+					position224, tokenIndex224 := position, tokenIndex
+					if !_rules[ruleGenericArgs]() {
+						goto l224
+					}
+					goto l225
+				l224:
+					position, tokenIndex = position224, tokenIndex224
+				}
+			l225:
+				if buffer[position] != rune(':') {
+					goto l212
+				}
+				position++
+				{
+					position226, tokenIndex226 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l227
+					}
+					if !_rules[ruleVariant]() {
+						goto l227
+					}
+				l228:
+					{
+						position229, tokenIndex229 := position, tokenIndex
+						{
+							position230, tokenIndex230 := position, tokenIndex
+							if !_rules[ruleWhitespace]() {
+								goto l230
+							}
+							goto l231
+						l230:
+							position, tokenIndex = position230, tokenIndex230
+						}
+					l231:
+						if buffer[position] != rune('|') {
+							goto l229
+						}
+						position++
+						{
+							position232, tokenIndex232 := position, tokenIndex
+							if !_rules[ruleWhitespace]() {
+								goto l232
+							}
+							goto l233
+						l232:
+							position, tokenIndex = position232, tokenIndex232
+						}
+					l233:
+						if !_rules[ruleVariant]() {
+							goto l229
+						}
+						goto l228
+					l229:
+						position, tokenIndex = position229, tokenIndex229
+					}
+					goto l226
+				l227:
+					position, tokenIndex = position226, tokenIndex226
+					if !_rules[ruleNewline]() {
+						goto l212
+					}
+					if !_rules[ruleIndent]() {
+						goto l212
+					}
+					{
+						position236, tokenIndex236 := position, tokenIndex
+						if buffer[position] != rune('|') {
+							goto l236
+						}
+						position++
+						if !_rules[ruleWhitespace]() {
+							goto l236
+						}
+						goto l237
+					l236:
+						position, tokenIndex = position236, tokenIndex236
+					}
+				l237:
+					if !_rules[ruleVariant]() {
+						goto l212
+					}
+					if !_rules[ruleNewline]() {
+						goto l212
+					}
+				l234:
+					{
+						position235, tokenIndex235 := position, tokenIndex
+						{
+							position238, tokenIndex238 := position, tokenIndex
+							if buffer[position] != rune('|') {
+								goto l238
+							}
+							position++
+							if !_rules[ruleWhitespace]() {
+								goto l238
+							}
+							goto l239
+						l238:
+							position, tokenIndex = position238, tokenIndex238
+						}
+					l239:
+						if !_rules[ruleVariant]() {
+							goto l235
+						}
+						if !_rules[ruleNewline]() {
+							goto l235
+						}
+						goto l234
+					l235:
+						position, tokenIndex = position235, tokenIndex235
+					}
+					if !_rules[ruleDedent]() {
+						goto l212
+					}
+				}
+			l226:
+				add(ruleUnion, position213)
+			}
+			return true
+		l212:
+			position, tokenIndex = position212, tokenIndex212
+			return false
+		},
+		/* 20 Variant <- <(CapitalLabel ('(' (VariantField ',' Whitespace?)* VariantField? ')')?)> */
+		func() bool {
+			position240, tokenIndex240 := position, tokenIndex
+			{
+				position241 := position
+				if !_rules[ruleCapitalLabel]() {
+					goto l240
+				}
+				{
+					position242, tokenIndex242 := position, tokenIndex
+					if buffer[position] != rune('(') {
+						goto l242
+					}
+					position++
+				l244:
+					{
+						position245, tokenIndex245 := position, tokenIndex
+						if !_rules[ruleVariantField]() {
+							goto l245
+						}
+						if buffer[position] != rune(',') {
+							goto l245
+						}
+						position++
+						{
+							position246, tokenIndex246 := position, tokenIndex
+							if !_rules[ruleWhitespace]() {
+								goto l246
+							}
+							goto l247
+						l246:
+							position, tokenIndex = position246, tokenIndex246
+						}
+					l247:
+						goto l244
+					l245:
+						position, tokenIndex = position245, tokenIndex245
+					}
+					{
+						position248, tokenIndex248 := position, tokenIndex
+						if !_rules[ruleVariantField]() {
+							goto l248
+						}
+						goto l249
+					l248:
+						position, tokenIndex = position248, tokenIndex248
+					}
+				l249:
+					if buffer[position] != rune(')') {
+						goto l242
+					}
+					position++
+					goto l243
+				l242:
+					position, tokenIndex = position242, tokenIndex242
+				}
+			l243:
+				add(ruleVariant, position241)
+			}
+			return true
+		l240:
+			position, tokenIndex = position240, tokenIndex240
+			return false
+		},
+		/* 21 VariantField <- <((LowerLabel Whitespace)? Type)> */
+		func() bool {
+			position250, tokenIndex250 := position, tokenIndex
+			{
+				position251 := position
+				{
+					position252, tokenIndex252 := position, tokenIndex
+					if !_rules[ruleLowerLabel]() {
+						goto l252
+					}
+					if !_rules[ruleWhitespace]() {
+						goto l252
+					}
+					goto l253
+				l252:
+					position, tokenIndex = position252, tokenIndex252
+				}
+			l253:
+				if !_rules[ruleType]() {
+					goto l250
+				}
+				add(ruleVariantField, position251)
+			}
+			return true
+		l250:
+			position, tokenIndex = position250, tokenIndex250
+			return false
+		},
+		/* 22 FunArgs <- <('(' FunArg* ')')> */
+		func() bool {
+			position254, tokenIndex254 := position, tokenIndex
+			{
+				position255 := position
+				if buffer[position] != rune('(') {
+					goto l254
+				}
+				position++
+			l256:
+				{
+					position257, tokenIndex257 := position, tokenIndex
+					if !_rules[ruleFunArg]() {
+						goto l257
+					}
+					goto l256
+				l257:
+					position, tokenIndex = position257, tokenIndex257
+				}
+				if buffer[position] != rune(')') {
+					goto l254
+				}
+				position++
+				add(ruleFunArgs, position255)
+			}
+			return true
+		l254:
+			position, tokenIndex = position254, tokenIndex254
+			return false
+		},
+		/* 23 GenericArgs <- <('<' (CapitalLabel ',' Whitespace?)* CapitalLabel '>')> */
+		func() bool {
+			position258, tokenIndex258 := position, tokenIndex
+			{
+				position259 := position
+				if buffer[position] != rune('<') {
+					goto l258
+				}
+				position++
+			l260:
+				{
+					position261, tokenIndex261 := position, tokenIndex
+					if !_rules[ruleCapitalLabel]() {
+						goto l261
+					}
+					if buffer[position] != rune(',') {
+						goto l261
+					}
+					position++
+					{
+						position262, tokenIndex262 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l262
+						}
+						goto l263
+					l262:
+						position, tokenIndex = position262, tokenIndex262
+					}
+				l263:
+					goto l260
+				l261:
+					position, tokenIndex = position261, tokenIndex261
+				}
+				if !_rules[ruleCapitalLabel]() {
+					goto l258
+				}
+				if buffer[position] != rune('>') {
+					goto l258
+				}
+				position++
+				add(ruleGenericArgs, position259)
+			}
+			return true
+		l258:
+			position, tokenIndex = position258, tokenIndex258
+			return false
+		},
+		/* 24 FunArg <- <(PreArg / LastArg)> */
+		func() bool {
+			position264, tokenIndex264 := position, tokenIndex
+			{
+				position265 := position
+				{
+					position266, tokenIndex266 := position, tokenIndex
+					if !_rules[rulePreArg]() {
+						goto l267
+					}
+					goto l266
+				l267:
+					position, tokenIndex = position266, tokenIndex266
+					if !_rules[ruleLastArg]() {
+						goto l264
+					}
+				}
+			l266:
+				add(ruleFunArg, position265)
+			}
+			return true
+		l264:
+			position, tokenIndex = position264, tokenIndex264
+			return false
+		},
+		/* 25 PreArg <- <(FunLowerLabel Whitespace Type ',' Whitespace?)> */
+		func() bool {
+			position268, tokenIndex268 := position, tokenIndex
+			{
+				position269 := position
+				if !_rules[ruleFunLowerLabel]() {
+					goto l268
+				}
+				if !_rules[ruleWhitespace]() {
+					goto l268
+				}
+				if !_rules[ruleType]() {
+					goto l268
+				}
+				if buffer[position] != rune(',') {
+					goto l268
+				}
+				position++
+				{
+					position270, tokenIndex270 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l270
+					}
+					goto l271
+				l270:
+					position, tokenIndex = position270, tokenIndex270
+				}
+			l271:
+				add(rulePreArg, position269)
+			}
+			return true
+		l268:
+			position, tokenIndex = position268, tokenIndex268
+			return false
+		},
+		/* 26 LastArg <- <(FunLowerLabel Whitespace Type)> */
+		func() bool {
+			position272, tokenIndex272 := position, tokenIndex
+			{
+				position273 := position
+				if !_rules[ruleFunLowerLabel]() {
+					goto l272
+				}
+				if !_rules[ruleWhitespace]() {
+					goto l272
+				}
+				if !_rules[ruleType]() {
+					goto l272
+				}
+				add(ruleLastArg, position273)
+			}
+			return true
+		l272:
+			position, tokenIndex = position272, tokenIndex272
+			return false
+		},
+		/* 27 FunLabel <- <(([A-Z] / [a-z]) ([A-Z] / [a-z] / [0-9] / '`' / '_')* ('?' / '!')?)> */
+		func() bool {
+			position274, tokenIndex274 := position, tokenIndex
+			{
+				position275 := position
+				{
+					position276, tokenIndex276 := position, tokenIndex
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l277
+					}
+					position++
+					goto l276
+				l277:
+					position, tokenIndex = position276, tokenIndex276
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l274
+					}
+					position++
+				}
+			l276:
+			l278:
+				{
+					position279, tokenIndex279 := position, tokenIndex
+					{
+						position280, tokenIndex280 := position, tokenIndex
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l281
+						}
+						position++
+						goto l280
+					l281:
+						position, tokenIndex = position280, tokenIndex280
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l282
+						}
+						position++
+						goto l280
+					l282:
+						position, tokenIndex = position280, tokenIndex280
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l283
+						}
+						position++
+						goto l280
+					l283:
+						position, tokenIndex = position280, tokenIndex280
+						if buffer[position] != rune('`') {
+							goto l284
+						}
+						position++
+						goto l280
+					l284:
+						position, tokenIndex = position280, tokenIndex280
+						if buffer[position] != rune('_') {
+							goto l279
+						}
+						position++
+					}
+				l280:
+					goto l278
+				l279:
+					position, tokenIndex = position279, tokenIndex279
+				}
+				{
+					position285, tokenIndex285 := position, tokenIndex
+					{
+						position287, tokenIndex287 := position, tokenIndex
+						if buffer[position] != rune('?') {
+							goto l288
+						}
+						position++
+						goto l287
+					l288:
+						position, tokenIndex = position287, tokenIndex287
+						if buffer[position] != rune('!') {
+							goto l285
+						}
+						position++
+					}
+				l287:
+					goto l286
+				l285:
+					position, tokenIndex = position285, tokenIndex285
+				}
+			l286:
+				add(ruleFunLabel, position275)
+			}
+			return true
+		l274:
+			position, tokenIndex = position274, tokenIndex274
+			return false
+		},
+		/* 28 Type <- <(PointerType / ChannelType / FunType / GenericType / BuiltinType / TypeLabel)> */
+		func() bool {
+			position289, tokenIndex289 := position, tokenIndex
+			{
+				position290 := position
+				{
+					position291, tokenIndex291 := position, tokenIndex
+					if !_rules[rulePointerType]() {
+						goto l292
+					}
+					goto l291
+				l292:
+					position, tokenIndex = position291, tokenIndex291
+					if !_rules[ruleChannelType]() {
+						goto l293
+					}
+					goto l291
+				l293:
+					position, tokenIndex = position291, tokenIndex291
+					if !_rules[ruleFunType]() {
+						goto l294
+					}
+					goto l291
+				l294:
+					position, tokenIndex = position291, tokenIndex291
+					if !_rules[ruleGenericType]() {
+						goto l295
+					}
+					goto l291
+				l295:
+					position, tokenIndex = position291, tokenIndex291
+					if !_rules[ruleBuiltinType]() {
+						goto l296
+					}
+					goto l291
+				l296:
+					position, tokenIndex = position291, tokenIndex291
+					if !_rules[ruleTypeLabel]() {
+						goto l289
+					}
+				}
+			l291:
+				add(ruleType, position290)
+			}
+			return true
+		l289:
+			position, tokenIndex = position289, tokenIndex289
+			return false
+		},
+		/* 29 PointerType <- <('*' Type)> */
+		func() bool {
+			position297, tokenIndex297 := position, tokenIndex
+			{
+				position298 := position
+				if buffer[position] != rune('*') {
+					goto l297
+				}
+				position++
+				if !_rules[ruleType]() {
+					goto l297
+				}
+				add(rulePointerType, position298)
+			}
+			return true
+		l297:
+			position, tokenIndex = position297, tokenIndex297
+			return false
+		},
+		/* 30 FunType <- <((TypeExceptFun ',' Whitespace?)* TypeExceptFun Whitespace ('-' '>') Whitespace TypeExceptFun)> */
+		func() bool {
+			position299, tokenIndex299 := position, tokenIndex
+			{
+				position300 := position
+			l301:
+				{
+					position302, tokenIndex302 := position, tokenIndex
+					if !_rules[ruleTypeExceptFun]() {
+						goto l302
+					}
+					if buffer[position] != rune(',') {
+						goto l302
+					}
+					position++
+					{
+						position303, tokenIndex303 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l303
+						}
+						goto l304
+					l303:
+						position, tokenIndex = position303, tokenIndex303
+					}
+				l304:
+					goto l301
+				l302:
+					position, tokenIndex = position302, tokenIndex302
+				}
+				if !_rules[ruleTypeExceptFun]() {
+					goto l299
+				}
+				if !_rules[ruleWhitespace]() {
+					goto l299
+				}
+				if buffer[position] != rune('-') {
+					goto l299
+				}
+				position++
+				if buffer[position] != rune('>') {
+					goto l299
+				}
+				position++
+				if !_rules[ruleWhitespace]() {
+					goto l299
+				}
+				if !_rules[ruleTypeExceptFun]() {
+					goto l299
+				}
+				add(ruleFunType, position300)
+			}
+			return true
+		l299:
+			position, tokenIndex = position299, tokenIndex299
+			return false
+		},
+		/* 31 GenericType <- <(TypeLabel '<' (Type ',' Whitespace?)* Type '>')> */
+		func() bool {
+			position305, tokenIndex305 := position, tokenIndex
+			{
+				position306 := position
+				if !_rules[ruleTypeLabel]() {
+					goto l305
+				}
+				if buffer[position] != rune('<') {
+					goto l305
+				}
+				position++
+			l307:
+				{
+					position308, tokenIndex308 := position, tokenIndex
+					if !_rules[ruleType]() {
+						goto l308
+					}
+					if buffer[position] != rune(',') {
+						goto l308
+					}
+					position++
+					{
+						position309, tokenIndex309 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l309
+						}
+						goto l310
+					l309:
+						position, tokenIndex = position309, tokenIndex309
+					}
+				l310:
+					goto l307
+				l308:
+					position, tokenIndex = position308, tokenIndex308
+				}
+				if !_rules[ruleType]() {
+					goto l305
+				}
+				if buffer[position] != rune('>') {
+					goto l305
+				}
+				position++
+				add(ruleGenericType, position306)
+			}
+			return true
+		l305:
+			position, tokenIndex = position305, tokenIndex305
+			return false
+		},
+		/* 32 BuiltinType <- <(BuiltinSimple / BuiltinSlice / BuiltinArray / BuiltinMap)> */
+		func() bool {
+			position311, tokenIndex311 := position, tokenIndex
+			{
+				position312 := position
+				{
+					position313, tokenIndex313 := position, tokenIndex
+					if !_rules[ruleBuiltinSimple]() {
+						goto l314
+					}
+					goto l313
+				l314:
+					position, tokenIndex = position313, tokenIndex313
+					if !_rules[ruleBuiltinSlice]() {
+						goto l315
+					}
+					goto l313
+				l315:
+					position, tokenIndex = position313, tokenIndex313
+					if !_rules[ruleBuiltinArray]() {
+						goto l316
+					}
+					goto l313
+				l316:
+					position, tokenIndex = position313, tokenIndex313
+					if !_rules[ruleBuiltinMap]() {
+						goto l311
+					}
+				}
+			l313:
+				add(ruleBuiltinType, position312)
+			}
+			return true
+		l311:
+			position, tokenIndex = position311, tokenIndex311
+			return false
+		},
+		/* 33 BuiltinSimple <- <(((('i' / 'I') ('n' / 'N') ('t' / 'T') '8') / (('i' / 'I') ('n' / 'N') ('t' / 'T') '1' '6') / (('i' / 'I') ('n' / 'N') ('t' / 'T') '3' '2') / (('i' / 'I') ('n' / 'N') ('t' / 'T') '6' '4') / (('i' / 'I') ('n' / 'N') ('t' / 'T')) / (('f' / 'F') ('l' / 'L') ('o' / 'O') ('a' / 'A') ('t' / 'T')) / (('r' / 'R') ('e' / 'E') ('a' / 'A') ('l' / 'L')) / (('s' / 'S') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('n' / 'N') ('g' / 'G')) / (('b' / 'B') ('o' / 'O') ('o' / 'O') ('l' / 'L')) / (('b' / 'B') ('y' / 'Y') ('t' / 'T') ('e' / 'E')) / (('i' / 'I') ('n' / 'N') ('t' / 'T'))) !([A-Z] / [a-z] / [0-9] / '_'))> */
+		func() bool {
+			position317, tokenIndex317 := position, tokenIndex
+			{
+				position318 := position
+				{
+					position319, tokenIndex319 := position, tokenIndex
+					{
+						position321, tokenIndex321 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l322
+						}
+						position++
+						goto l321
+					l322:
+						position, tokenIndex = position321, tokenIndex321
+						if buffer[position] != rune('I') {
+							goto l320
+						}
+						position++
+					}
+				l321:
+					{
+						position323, tokenIndex323 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l324
+						}
+						position++
+						goto l323
+					l324:
+						position, tokenIndex = position323, tokenIndex323
+						if buffer[position] != rune('N') {
+							goto l320
+						}
+						position++
+					}
+				l323:
+					{
+						position325, tokenIndex325 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l326
+						}
+						position++
+						goto l325
+					l326:
+						position, tokenIndex = position325, tokenIndex325
+						if buffer[position] != rune('T') {
+							goto l320
+						}
+						position++
+					}
+				l325:
+					if buffer[position] != rune('8') {
+						goto l320
+					}
+					position++
+					goto l319
+				l320:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position328, tokenIndex328 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l329
+						}
+						position++
+						goto l328
+					l329:
+						position, tokenIndex = position328, tokenIndex328
+						if buffer[position] != rune('I') {
+							goto l327
+						}
+						position++
+					}
+				l328:
+					{
+						position330, tokenIndex330 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l331
+						}
+						position++
+						goto l330
+					l331:
+						position, tokenIndex = position330, tokenIndex330
+						if buffer[position] != rune('N') {
+							goto l327
+						}
+						position++
+					}
+				l330:
+					{
+						position332, tokenIndex332 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l333
+						}
+						position++
+						goto l332
+					l333:
+						position, tokenIndex = position332, tokenIndex332
+						if buffer[position] != rune('T') {
+							goto l327
+						}
+						position++
+					}
+				l332:
+					if buffer[position] != rune('1') {
+						goto l327
+					}
+					position++
+					if buffer[position] != rune('6') {
+						goto l327
+					}
+					position++
+					goto l319
+				l327:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position335, tokenIndex335 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l336
+						}
+						position++
+						goto l335
+					l336:
+						position, tokenIndex = position335, tokenIndex335
+						if buffer[position] != rune('I') {
+							goto l334
+						}
+						position++
+					}
+				l335:
+					{
+						position337, tokenIndex337 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l338
+						}
+						position++
+						goto l337
+					l338:
+						position, tokenIndex = position337, tokenIndex337
+						if buffer[position] != rune('N') {
+							goto l334
+						}
+						position++
+					}
+				l337:
+					{
+						position339, tokenIndex339 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l340
+						}
+						position++
+						goto l339
+					l340:
+						position, tokenIndex = position339, tokenIndex339
+						if buffer[position] != rune('T') {
+							goto l334
+						}
+						position++
+					}
+				l339:
+					if buffer[position] != rune('3') {
+						goto l334
+					}
+					position++
+					if buffer[position] != rune('2') {
+						goto l334
+					}
+					position++
+					goto l319
+				l334:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position342, tokenIndex342 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l343
+						}
+						position++
+						goto l342
+					l343:
+						position, tokenIndex = position342, tokenIndex342
+						if buffer[position] != rune('I') {
+							goto l341
+						}
+						position++
+					}
+				l342:
+					{
+						position344, tokenIndex344 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l345
+						}
+						position++
+						goto l344
+					l345:
+						position, tokenIndex = position344, tokenIndex344
+						if buffer[position] != rune('N') {
+							goto l341
+						}
+						position++
+					}
+				l344:
+					{
+						position346, tokenIndex346 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l347
+						}
+						position++
+						goto l346
+					l347:
+						position, tokenIndex = position346, tokenIndex346
+						if buffer[position] != rune('T') {
+							goto l341
+						}
+						position++
+					}
+				l346:
+					if buffer[position] != rune('6') {
+						goto l341
+					}
+					position++
+					if buffer[position] != rune('4') {
+						goto l341
+					}
+					position++
+					goto l319
+				l341:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position349, tokenIndex349 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l350
+						}
+						position++
+						goto l349
+					l350:
+						position, tokenIndex = position349, tokenIndex349
+						if buffer[position] != rune('I') {
+							goto l348
+						}
+						position++
+					}
+				l349:
+					{
+						position351, tokenIndex351 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l352
+						}
+						position++
+						goto l351
+					l352:
+						position, tokenIndex = position351, tokenIndex351
+						if buffer[position] != rune('N') {
+							goto l348
+						}
+						position++
+					}
+				l351:
+					{
+						position353, tokenIndex353 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l354
+						}
+						position++
+						goto l353
+					l354:
+						position, tokenIndex = position353, tokenIndex353
+						if buffer[position] != rune('T') {
+							goto l348
+						}
+						position++
+					}
+				l353:
+					goto l319
+				l348:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position356, tokenIndex356 := position, tokenIndex
+						if buffer[position] != rune('f') {
+							goto l357
+						}
+						position++
+						goto l356
+					l357:
+						position, tokenIndex = position356, tokenIndex356
+						if buffer[position] != rune('F') {
+							goto l355
+						}
+						position++
+					}
+				l356:
+					{
+						position358, tokenIndex358 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l359
+						}
+						position++
+						goto l358
+					l359:
+						position, tokenIndex = position358, tokenIndex358
+						if buffer[position] != rune('L') {
+							goto l355
+						}
+						position++
+					}
+				l358:
+					{
+						position360, tokenIndex360 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l361
+						}
+						position++
+						goto l360
+					l361:
+						position, tokenIndex = position360, tokenIndex360
+						if buffer[position] != rune('O') {
+							goto l355
+						}
+						position++
+					}
+				l360:
+					{
+						position362, tokenIndex362 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l363
+						}
+						position++
+						goto l362
+					l363:
+						position, tokenIndex = position362, tokenIndex362
+						if buffer[position] != rune('A') {
+							goto l355
+						}
+						position++
+					}
+				l362:
+					{
+						position364, tokenIndex364 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l365
+						}
+						position++
+						goto l364
+					l365:
+						position, tokenIndex = position364, tokenIndex364
+						if buffer[position] != rune('T') {
+							goto l355
+						}
+						position++
+					}
+				l364:
+					goto l319
+				l355:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position367, tokenIndex367 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l368
+						}
+						position++
+						goto l367
+					l368:
+						position, tokenIndex = position367, tokenIndex367
+						if buffer[position] != rune('R') {
+							goto l366
+						}
+						position++
+					}
+				l367:
+					{
+						position369, tokenIndex369 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l370
+						}
+						position++
+						goto l369
+					l370:
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('E') {
+							goto l366
+						}
+						position++
+					}
+				l369:
+					{
+						position371, tokenIndex371 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l372
+						}
+						position++
+						goto l371
+					l372:
+						position, tokenIndex = position371, tokenIndex371
+						if buffer[position] != rune('A') {
+							goto l366
+						}
+						position++
+					}
+				l371:
+					{
+						position373, tokenIndex373 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l374
+						}
+						position++
+						goto l373
+					l374:
+						position, tokenIndex = position373, tokenIndex373
+						if buffer[position] != rune('L') {
+							goto l366
+						}
+						position++
+					}
+				l373:
+					goto l319
+				l366:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position376, tokenIndex376 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l377
+						}
+						position++
+						goto l376
+					l377:
+						position, tokenIndex = position376, tokenIndex376
+						if buffer[position] != rune('S') {
+							goto l375
+						}
+						position++
+					}
+				l376:
+					{
+						position378, tokenIndex378 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l379
+						}
+						position++
+						goto l378
+					l379:
+						position, tokenIndex = position378, tokenIndex378
+						if buffer[position] != rune('T') {
+							goto l375
+						}
+						position++
+					}
+				l378:
+					{
+						position380, tokenIndex380 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l381
+						}
+						position++
+						goto l380
+					l381:
+						position, tokenIndex = position380, tokenIndex380
+						if buffer[position] != rune('R') {
+							goto l375
+						}
+						position++
+					}
+				l380:
+					{
+						position382, tokenIndex382 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l383
+						}
+						position++
+						goto l382
+					l383:
+						position, tokenIndex = position382, tokenIndex382
+						if buffer[position] != rune('I') {
+							goto l375
+						}
+						position++
+					}
+				l382:
+					{
+						position384, tokenIndex384 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l385
+						}
+						position++
+						goto l384
+					l385:
+						position, tokenIndex = position384, tokenIndex384
+						if buffer[position] != rune('N') {
+							goto l375
+						}
+						position++
+					}
+				l384:
+					{
+						position386, tokenIndex386 := position, tokenIndex
+						if buffer[position] != rune('g') {
+							goto l387
+						}
+						position++
+						goto l386
+					l387:
+						position, tokenIndex = position386, tokenIndex386
+						if buffer[position] != rune('G') {
+							goto l375
+						}
+						position++
+					}
+				l386:
+					goto l319
+				l375:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position389, tokenIndex389 := position, tokenIndex
+						if buffer[position] != rune('b') {
+							goto l390
+						}
+						position++
+						goto l389
+					l390:
+						position, tokenIndex = position389, tokenIndex389
+						if buffer[position] != rune('B') {
+							goto l388
+						}
+						position++
+					}
+				l389:
+					{
+						position391, tokenIndex391 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l392
+						}
+						position++
+						goto l391
+					l392:
+						position, tokenIndex = position391, tokenIndex391
+						if buffer[position] != rune('O') {
+							goto l388
+						}
+						position++
+					}
+				l391:
+					{
+						position393, tokenIndex393 := position, tokenIndex
+						if buffer[position] != rune('o') {
+							goto l394
+						}
+						position++
+						goto l393
+					l394:
+						position, tokenIndex = position393, tokenIndex393
+						if buffer[position] != rune('O') {
+							goto l388
+						}
+						position++
+					}
+				l393:
+					{
+						position395, tokenIndex395 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l396
+						}
+						position++
+						goto l395
+					l396:
+						position, tokenIndex = position395, tokenIndex395
+						if buffer[position] != rune('L') {
+							goto l388
+						}
+						position++
+					}
+				l395:
+					goto l319
+				l388:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position398, tokenIndex398 := position, tokenIndex
+						if buffer[position] != rune('b') {
+							goto l399
+						}
+						position++
+						goto l398
+					l399:
+						position, tokenIndex = position398, tokenIndex398
+						if buffer[position] != rune('B') {
+							goto l397
+						}
+						position++
+					}
+				l398:
+					{
+						position400, tokenIndex400 := position, tokenIndex
+						if buffer[position] != rune('y') {
+							goto l401
+						}
+						position++
+						goto l400
+					l401:
+						position, tokenIndex = position400, tokenIndex400
+						if buffer[position] != rune('Y') {
+							goto l397
+						}
+						position++
+					}
+				l400:
+					{
+						position402, tokenIndex402 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l403
+						}
+						position++
+						goto l402
+					l403:
+						position, tokenIndex = position402, tokenIndex402
+						if buffer[position] != rune('T') {
+							goto l397
+						}
+						position++
+					}
+				l402:
+					{
+						position404, tokenIndex404 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l405
+						}
+						position++
+						goto l404
+					l405:
+						position, tokenIndex = position404, tokenIndex404
+						if buffer[position] != rune('E') {
+							goto l397
+						}
+						position++
+					}
+				l404:
+					goto l319
+				l397:
+					position, tokenIndex = position319, tokenIndex319
+					{
+						position406, tokenIndex406 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l407
+						}
+						position++
+						goto l406
+					l407:
+						position, tokenIndex = position406, tokenIndex406
+						if buffer[position] != rune('I') {
+							goto l317
+						}
+						position++
+					}
+				l406:
+					{
+						position408, tokenIndex408 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l409
+						}
+						position++
+						goto l408
+					l409:
+						position, tokenIndex = position408, tokenIndex408
+						if buffer[position] != rune('N') {
+							goto l317
+						}
+						position++
+					}
+				l408:
+					{
+						position410, tokenIndex410 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l411
+						}
+						position++
+						goto l410
+					l411:
+						position, tokenIndex = position410, tokenIndex410
+						if buffer[position] != rune('T') {
+							goto l317
+						}
+						position++
+					}
+				l410:
+				}
+			l319:
+				{
+					position412, tokenIndex412 := position, tokenIndex
+					{
+						position413, tokenIndex413 := position, tokenIndex
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l414
+						}
+						position++
+						goto l413
+					l414:
+						position, tokenIndex = position413, tokenIndex413
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l415
+						}
+						position++
+						goto l413
+					l415:
+						position, tokenIndex = position413, tokenIndex413
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l416
+						}
+						position++
+						goto l413
+					l416:
+						position, tokenIndex = position413, tokenIndex413
+						if buffer[position] != rune('_') {
+							goto l412
+						}
+						position++
+					}
+				l413:
+					goto l317
+				l412:
+					position, tokenIndex = position412, tokenIndex412
+				}
+				add(ruleBuiltinSimple, position318)
+			}
+			return true
+		l317:
+			position, tokenIndex = position317, tokenIndex317
+			return false
+		},
+		/* 34 BuiltinSlice <- <('[' ']' Type)> */
+		func() bool {
+			position417, tokenIndex417 := position, tokenIndex
+			{
+				position418 := position
+				if buffer[position] != rune('[') {
+					goto l417
+				}
+				position++
+				if buffer[position] != rune(']') {
+					goto l417
+				}
+				position++
+				if !_rules[ruleType]() {
+					goto l417
+				}
+				add(ruleBuiltinSlice, position418)
+			}
+			return true
+		l417:
+			position, tokenIndex = position417, tokenIndex417
+			return false
+		},
+		/* 35 BuiltinArray <- <('[' Integer ']' Type)> */
+		func() bool {
+			position419, tokenIndex419 := position, tokenIndex
+			{
+				position420 := position
+				if buffer[position] != rune('[') {
+					goto l419
+				}
+				position++
+				if !_rules[ruleInteger]() {
+					goto l419
+				}
+				if buffer[position] != rune(']') {
+					goto l419
+				}
+				position++
+				if !_rules[ruleType]() {
+					goto l419
+				}
+				add(ruleBuiltinArray, position420)
+			}
+			return true
+		l419:
+			position, tokenIndex = position419, tokenIndex419
+			return false
+		},
+		/* 36 BuiltinMap <- <(((('m' / 'M') ('a' / 'A') ('p' / 'P') '[') / (('m' / 'M') ('a' / 'A') ('p' / 'P') '[')) Type ']' Type)> */
+		func() bool {
+			position421, tokenIndex421 := position, tokenIndex
+			{
+				position422 := position
+				{
+					position423, tokenIndex423 := position, tokenIndex
+					{
+						position425, tokenIndex425 := position, tokenIndex
+						if buffer[position] != rune('m') {
+							goto l426
+						}
+						position++
+						goto l425
+					l426:
+						position, tokenIndex = position425, tokenIndex425
+						if buffer[position] != rune('M') {
+							goto l424
+						}
+						position++
+					}
+				l425:
+					{
+						position427, tokenIndex427 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l428
+						}
+						position++
+						goto l427
+					l428:
+						position, tokenIndex = position427, tokenIndex427
+						if buffer[position] != rune('A') {
+							goto l424
+						}
+						position++
+					}
+				l427:
+					{
+						position429, tokenIndex429 := position, tokenIndex
+						if buffer[position] != rune('p') {
+							goto l430
+						}
+						position++
+						goto l429
+					l430:
+						position, tokenIndex = position429, tokenIndex429
+						if buffer[position] != rune('P') {
+							goto l424
+						}
+						position++
+					}
+				l429:
+					if buffer[position] != rune('[') {
+						goto l424
+					}
+					position++
+					goto l423
+				l424:
+					position, tokenIndex = position423, tokenIndex423
+					{
+						position431, tokenIndex431 := position, tokenIndex
+						if buffer[position] != rune('m') {
+							goto l432
+						}
+						position++
+						goto l431
+					l432:
+						position, tokenIndex = position431, tokenIndex431
+						if buffer[position] != rune('M') {
+							goto l421
+						}
+						position++
+					}
+				l431:
+					{
+						position433, tokenIndex433 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l434
+						}
+						position++
+						goto l433
+					l434:
+						position, tokenIndex = position433, tokenIndex433
+						if buffer[position] != rune('A') {
+							goto l421
+						}
+						position++
+					}
+				l433:
+					{
+						position435, tokenIndex435 := position, tokenIndex
+						if buffer[position] != rune('p') {
+							goto l436
+						}
+						position++
+						goto l435
+					l436:
+						position, tokenIndex = position435, tokenIndex435
+						if buffer[position] != rune('P') {
+							goto l421
+						}
+						position++
+					}
+				l435:
+					if buffer[position] != rune('[') {
+						goto l421
+					}
+					position++
+				}
+			l423:
+				if !_rules[ruleType]() {
+					goto l421
+				}
+				if buffer[position] != rune(']') {
+					goto l421
+				}
+				position++
+				if !_rules[ruleType]() {
+					goto l421
+				}
+				add(ruleBuiltinMap, position422)
+			}
+			return true
+		l421:
+			position, tokenIndex = position421, tokenIndex421
+			return false
+		},
+		/* 37 ChannelType <- <(('~' ChannelDir? (('<' Type '>') / (Whitespace Type))) / (('c' / 'C') ('h' / 'H') ('a' / 'A') ('n' / 'N') Whitespace Type))> */
+		func() bool {
+			position437, tokenIndex437 := position, tokenIndex
+			{
+				position438 := position
+				{
+					position439, tokenIndex439 := position, tokenIndex
+					if buffer[position] != rune('~') {
+						goto l440
+					}
+					position++
+					{
+						position441, tokenIndex441 := position, tokenIndex
+						if !_rules[ruleChannelDir]() {
+							goto l441
+						}
+						goto l442
+					l441:
+						position, tokenIndex = position441, tokenIndex441
+					}
+				l442:
+					{
+						position443, tokenIndex443 := position, tokenIndex
+						if buffer[position] != rune('<') {
+							goto l444
+						}
+						position++
+						if !_rules[ruleType]() {
+							goto l444
+						}
+						if buffer[position] != rune('>') {
+							goto l444
+						}
+						position++
+						goto l443
+					l444:
+						position, tokenIndex = position443, tokenIndex443
+						if !_rules[ruleWhitespace]() {
+							goto l440
+						}
+						if !_rules[ruleType]() {
+							goto l440
+						}
+					}
+				l443:
+					goto l439
+				l440:
+					position, tokenIndex = position439, tokenIndex439
+					{
+						position445, tokenIndex445 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l446
+						}
+						position++
+						goto l445
+					l446:
+						position, tokenIndex = position445, tokenIndex445
+						if buffer[position] != rune('C') {
+							goto l437
+						}
+						position++
+					}
+				l445:
+					{
+						position447, tokenIndex447 := position, tokenIndex
+						if buffer[position] != rune('h') {
+							goto l448
+						}
+						position++
+						goto l447
+					l448:
+						position, tokenIndex = position447, tokenIndex447
+						if buffer[position] != rune('H') {
+							goto l437
+						}
+						position++
+					}
+				l447:
+					{
+						position449, tokenIndex449 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l450
+						}
+						position++
+						goto l449
+					l450:
+						position, tokenIndex = position449, tokenIndex449
+						if buffer[position] != rune('A') {
+							goto l437
+						}
+						position++
+					}
+				l449:
+					{
+						position451, tokenIndex451 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l452
+						}
+						position++
+						goto l451
+					l452:
+						position, tokenIndex = position451, tokenIndex451
+						if buffer[position] != rune('N') {
+							goto l437
+						}
+						position++
+					}
+				l451:
+					if !_rules[ruleWhitespace]() {
+						goto l437
+					}
+					if !_rules[ruleType]() {
+						goto l437
+					}
+				}
+			l439:
+				add(ruleChannelType, position438)
+			}
+			return true
+		l437:
+			position, tokenIndex = position437, tokenIndex437
+			return false
+		},
+		/* 38 ChannelDir <- <((('s' / 'S') ('e' / 'E') ('n' / 'N') ('d' / 'D')) / (('r' / 'R') ('e' / 'E') ('c' / 'C') ('e' / 'E') ('i' / 'I') ('v' / 'V') ('e' / 'E')))> */
+		func() bool {
+			position453, tokenIndex453 := position, tokenIndex
+			{
+				position454 := position
+				{
+					position455, tokenIndex455 := position, tokenIndex
+					{
+						position457, tokenIndex457 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l458
+						}
+						position++
+						goto l457
+					l458:
+						position, tokenIndex = position457, tokenIndex457
+						if buffer[position] != rune('S') {
+							goto l456
+						}
+						position++
+					}
+				l457:
+					{
+						position459, tokenIndex459 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l460
+						}
+						position++
+						goto l459
+					l460:
+						position, tokenIndex = position459, tokenIndex459
+						if buffer[position] != rune('E') {
+							goto l456
+						}
+						position++
+					}
+				l459:
+					{
+						position461, tokenIndex461 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l462
+						}
+						position++
+						goto l461
+					l462:
+						position, tokenIndex = position461, tokenIndex461
+						if buffer[position] != rune('N') {
+							goto l456
+						}
+						position++
+					}
+				l461:
+					{
+						position463, tokenIndex463 := position, tokenIndex
+						if buffer[position] != rune('d') {
+							goto l464
+						}
+						position++
+						goto l463
+					l464:
+						position, tokenIndex = position463, tokenIndex463
+						if buffer[position] != rune('D') {
+							goto l456
+						}
+						position++
+					}
+				l463:
+					goto l455
+				l456:
+					position, tokenIndex = position455, tokenIndex455
+					{
+						position465, tokenIndex465 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l466
+						}
+						position++
+						goto l465
+					l466:
+						position, tokenIndex = position465, tokenIndex465
+						if buffer[position] != rune('R') {
+							goto l453
+						}
+						position++
+					}
+				l465:
+					{
+						position467, tokenIndex467 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l468
+						}
+						position++
+						goto l467
+					l468:
+						position, tokenIndex = position467, tokenIndex467
+						if buffer[position] != rune('E') {
+							goto l453
+						}
+						position++
+					}
+				l467:
+					{
+						position469, tokenIndex469 := position, tokenIndex
+						if buffer[position] != rune('c') {
+							goto l470
+						}
+						position++
+						goto l469
+					l470:
+						position, tokenIndex = position469, tokenIndex469
+						if buffer[position] != rune('C') {
+							goto l453
+						}
+						position++
+					}
+				l469:
+					{
+						position471, tokenIndex471 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l472
+						}
+						position++
+						goto l471
+					l472:
+						position, tokenIndex = position471, tokenIndex471
+						if buffer[position] != rune('E') {
+							goto l453
+						}
+						position++
+					}
+				l471:
+					{
+						position473, tokenIndex473 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l474
+						}
+						position++
+						goto l473
+					l474:
+						position, tokenIndex = position473, tokenIndex473
+						if buffer[position] != rune('I') {
+							goto l453
+						}
+						position++
+					}
+				l473:
+					{
+						position475, tokenIndex475 := position, tokenIndex
+						if buffer[position] != rune('v') {
+							goto l476
+						}
+						position++
+						goto l475
+					l476:
+						position, tokenIndex = position475, tokenIndex475
+						if buffer[position] != rune('V') {
+							goto l453
+						}
+						position++
+					}
+				l475:
+					{
+						position477, tokenIndex477 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l478
+						}
+						position++
+						goto l477
+					l478:
+						position, tokenIndex = position477, tokenIndex477
+						if buffer[position] != rune('E') {
+							goto l453
+						}
+						position++
+					}
+				l477:
+				}
+			l455:
+				add(ruleChannelDir, position454)
+			}
+			return true
+		l453:
+			position, tokenIndex = position453, tokenIndex453
+			return false
+		},
+		/* 39 TypeExceptFun <- <(ChannelType / GenericType / BuiltinType / TypeLabel)> */
+		func() bool {
+			position479, tokenIndex479 := position, tokenIndex
+			{
+				position480 := position
+				{
+					position481, tokenIndex481 := position, tokenIndex
+					if !_rules[ruleChannelType]() {
+						goto l482
+					}
+					goto l481
+				l482:
+					position, tokenIndex = position481, tokenIndex481
+					if !_rules[ruleGenericType]() {
+						goto l483
+					}
+					goto l481
+				l483:
+					position, tokenIndex = position481, tokenIndex481
+					if !_rules[ruleBuiltinType]() {
+						goto l484
+					}
+					goto l481
+				l484:
+					position, tokenIndex = position481, tokenIndex481
+					if !_rules[ruleTypeLabel]() {
+						goto l479
+					}
+				}
+			l481:
+				add(ruleTypeExceptFun, position480)
+			}
+			return true
+		l479:
+			position, tokenIndex = position479, tokenIndex479
+			return false
+		},
+		/* 40 TypeLabel <- <((LowerLabel '.')? CapitalLabel)> */
+		func() bool {
+			position485, tokenIndex485 := position, tokenIndex
+			{
+				position486 := position
+				{
+					position487, tokenIndex487 := position, tokenIndex
+					if !_rules[ruleLowerLabel]() {
+						goto l487
+					}
+					if buffer[position] != rune('.') {
+						goto l487
+					}
+					position++
+					goto l488
+				l487:
+					position, tokenIndex = position487, tokenIndex487
+				}
+			l488:
+				if !_rules[ruleCapitalLabel]() {
+					goto l485
+				}
+				add(ruleTypeLabel, position486)
+			}
+			return true
+		l485:
+			position, tokenIndex = position485, tokenIndex485
+			return false
+		},
+		/* 41 Code <- <((Line Newline)+ Dedent)> */
+		func() bool {
+			position489, tokenIndex489 := position, tokenIndex
+			{
+				position490 := position
+				if !_rules[ruleLine]() {
+					goto l489
+				}
+				if !_rules[ruleNewline]() {
+					goto l489
+				}
+			l491:
+				{
+					position492, tokenIndex492 := position, tokenIndex
+					if !_rules[ruleLine]() {
+						goto l492
+					}
+					if !_rules[ruleNewline]() {
+						goto l492
+					}
+					goto l491
+				l492:
+					position, tokenIndex = position492, tokenIndex492
+				}
+				if !_rules[ruleDedent]() {
+					goto l489
+				}
+				add(ruleCode, position490)
+			}
+			return true
+		l489:
+			position, tokenIndex = position489, tokenIndex489
+			return false
+		},
+		/* 42 Indent <- <('@' '@' ('i' / 'I') ('n' / 'N') ('d' / 'D') ('e' / 'E') ('n' / 'N') ('t' / 'T') '@' '@')> */
+		func() bool {
+			position493, tokenIndex493 := position, tokenIndex
+			{
+				position494 := position
+				if buffer[position] != rune('@') {
+					goto l493
+				}
+				position++
+				if buffer[position] != rune('@') {
+					goto l493
+				}
+				position++
+				{
+					position495, tokenIndex495 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l496
+					}
+					position++
+					goto l495
+				l496:
+					position, tokenIndex = position495, tokenIndex495
+					if buffer[position] != rune('I') {
+						goto l493
+					}
+					position++
+				}
+			l495:
+				{
+					position497, tokenIndex497 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l498
+					}
+					position++
+					goto l497
+				l498:
+					position, tokenIndex = position497, tokenIndex497
+					if buffer[position] != rune('N') {
+						goto l493
+					}
+					position++
+				}
+			l497:
+				{
+					position499, tokenIndex499 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l500
+					}
+					position++
+					goto l499
+				l500:
+					position, tokenIndex = position499, tokenIndex499
+					if buffer[position] != rune('D') {
+						goto l493
+					}
+					position++
+				}
+			l499:
+				{
+					position501, tokenIndex501 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l502
+					}
+					position++
+					goto l501
+				l502:
+					position, tokenIndex = position501, tokenIndex501
+					if buffer[position] != rune('E') {
+						goto l493
+					}
+					position++
+				}
+			l501:
+				{
+					position503, tokenIndex503 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l504
+					}
+					position++
+					goto l503
+				l504:
+					position, tokenIndex = position503, tokenIndex503
+					if buffer[position] != rune('N') {
+						goto l493
+					}
+					position++
+				}
+			l503:
+				{
+					position505, tokenIndex505 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l506
+					}
+					position++
+					goto l505
+				l506:
+					position, tokenIndex = position505, tokenIndex505
+					if buffer[position] != rune('T') {
+						goto l493
+					}
+					position++
+				}
+			l505:
+				if buffer[position] != rune('@') {
+					goto l493
+				}
+				position++
+				if buffer[position] != rune('@') {
+					goto l493
+				}
+				position++
+				add(ruleIndent, position494)
+			}
+			return true
+		l493:
+			position, tokenIndex = position493, tokenIndex493
+			return false
+		},
+		/* 43 Dedent <- <('@' '@' ('d' / 'D') ('e' / 'E') ('d' / 'D') ('e' / 'E') ('n' / 'N') ('t' / 'T') '@' '@')> */
+		func() bool {
+			position507, tokenIndex507 := position, tokenIndex
+			{
+				position508 := position
+				if buffer[position] != rune('@') {
+					goto l507
+				}
+				position++
+				if buffer[position] != rune('@') {
+					goto l507
+				}
+				position++
+				{
+					position509, tokenIndex509 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l510
+					}
+					position++
+					goto l509
+				l510:
+					position, tokenIndex = position509, tokenIndex509
+					if buffer[position] != rune('D') {
+						goto l507
+					}
+					position++
+				}
+			l509:
+				{
+					position511, tokenIndex511 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l512
+					}
+					position++
+					goto l511
+				l512:
+					position, tokenIndex = position511, tokenIndex511
+					if buffer[position] != rune('E') {
+						goto l507
+					}
+					position++
+				}
+			l511:
+				{
+					position513, tokenIndex513 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l514
+					}
+					position++
+					goto l513
+				l514:
+					position, tokenIndex = position513, tokenIndex513
+					if buffer[position] != rune('D') {
+						goto l507
+					}
+					position++
+				}
+			l513:
+				{
+					position515, tokenIndex515 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l516
+					}
+					position++
+					goto l515
+				l516:
+					position, tokenIndex = position515, tokenIndex515
+					if buffer[position] != rune('E') {
+						goto l507
+					}
+					position++
+				}
+			l515:
+				{
+					position517, tokenIndex517 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l518
+					}
+					position++
+					goto l517
+				l518:
+					position, tokenIndex = position517, tokenIndex517
+					if buffer[position] != rune('N') {
+						goto l507
+					}
+					position++
+				}
+			l517:
+				{
+					position519, tokenIndex519 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l520
+					}
+					position++
+					goto l519
+				l520:
+					position, tokenIndex = position519, tokenIndex519
+					if buffer[position] != rune('T') {
+						goto l507
+					}
+					position++
+				}
+			l519:
+				if buffer[position] != rune('@') {
+					goto l507
+				}
+				position++
+				if buffer[position] != rune('@') {
+					goto l507
+				}
+				position++
+				add(ruleDedent, position508)
+			}
+			return true
+		l507:
+			position, tokenIndex = position507, tokenIndex507
+			return false
+		},
+		/* 44 Line <- <(IndexAssignment / FieldAssignment / Assignment / CompoundAssignment / Send / Call / Receive / If / For / Loop / Select / Match / Spawn / Go / On / Return / Break)> */
+		func() bool {
+			position521, tokenIndex521 := position, tokenIndex
+			{
+				position522 := position
+				{
+					position523, tokenIndex523 := position, tokenIndex
+					if !_rules[ruleIndexAssignment]() {
+						goto l524
+					}
+					goto l523
+				l524:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleFieldAssignment]() {
+						goto l525
+					}
+					goto l523
+				l525:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleAssignment]() {
+						goto l526
+					}
+					goto l523
+				l526:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleCompoundAssignment]() {
+						goto l527
+					}
+					goto l523
+				l527:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleSend]() {
+						goto l528
+					}
+					goto l523
+				l528:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleCall]() {
+						goto l529
+					}
+					goto l523
+				l529:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleReceive]() {
+						goto l530
+					}
+					goto l523
+				l530:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleIf]() {
+						goto l531
+					}
+					goto l523
+				l531:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleFor]() {
+						goto l532
+					}
+					goto l523
+				l532:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleLoop]() {
+						goto l533
+					}
+					goto l523
+				l533:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleSelect]() {
+						goto l534
+					}
+					goto l523
+				l534:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleMatch]() {
+						goto l535
+					}
+					goto l523
+				l535:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleSpawn]() {
+						goto l536
+					}
+					goto l523
+				l536:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleGo]() {
+						goto l537
+					}
+					goto l523
+				l537:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleOn]() {
+						goto l538
+					}
+					goto l523
+				l538:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleReturn]() {
+						goto l539
+					}
+					goto l523
+				l539:
+					position, tokenIndex = position523, tokenIndex523
+					if !_rules[ruleBreak]() {
+						goto l521
+					}
+				}
+			l523:
+				add(ruleLine, position522)
+			}
+			return true
+		l521:
+			position, tokenIndex = position521, tokenIndex521
+			return false
+		},
+		/* 45 IndexAssignment <- <(Index Whitespace '=' Whitespace Expression)> */
+		func() bool {
+			position540, tokenIndex540 := position, tokenIndex
+			{
+				position541 := position
+				if !_rules[ruleIndex]() {
+					goto l540
+				}
+				if !_rules[ruleWhitespace]() {
+					goto l540
+				}
+				if buffer[position] != rune('=') {
+					goto l540
+				}
+				position++
+				if !_rules[ruleWhitespace]() {
+					goto l540
+				}
+				if !_rules[ruleExpression]() {
+					goto l540
+				}
+				add(ruleIndexAssignment, position541)
+			}
+			return true
+		l540:
+			position, tokenIndex = position540, tokenIndex540
+			return false
+		},
+		/* 46 FieldAssignment <- <(Selector Whitespace '=' Whitespace Expression)> */
+		func() bool {
+			position542, tokenIndex542 := position, tokenIndex
+			{
+				position543 := position
+				if !_rules[ruleSelector]() {
+					goto l542
+				}
+				if !_rules[ruleWhitespace]() {
+					goto l542
+				}
+				if buffer[position] != rune('=') {
+					goto l542
+				}
+				position++
+				if !_rules[ruleWhitespace]() {
+					goto l542
+				}
+				if !_rules[ruleExpression]() {
+					goto l542
+				}
+				add(ruleFieldAssignment, position543)
+			}
+			return true
+		l542:
+			position, tokenIndex = position542, tokenIndex542
+			return false
+		},
+		/* 47 Assignment <- <(LowerLabel (Whitespace? ':' Whitespace Type)? Whitespace '=' Whitespace Expression)> */
+		func() bool {
+			position544, tokenIndex544 := position, tokenIndex
+			{
+				position545 := position
+				if !_rules[ruleLowerLabel]() {
+					goto l544
+				}
+				{
+					position546, tokenIndex546 := position, tokenIndex
+					{
+						position548, tokenIndex548 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l548
+						}
+						goto l549
+					l548:
+						position, tokenIndex = position548, tokenIndex548
+					}
+				l549:
+					if buffer[position] != rune(':') {
+						goto l546
+					}
+					position++
+					if !_rules[ruleWhitespace]() {
+						goto l546
+					}
+					if !_rules[ruleType]() {
+						goto l546
+					}
+					goto l547
+				l546:
+					position, tokenIndex = position546, tokenIndex546
+				}
+			l547:
+				if !_rules[ruleWhitespace]() {
+					goto l544
+				}
+				if buffer[position] != rune('=') {
+					goto l544
+				}
+				position++
+				if !_rules[ruleWhitespace]() {
+					goto l544
+				}
+				if !_rules[ruleExpression]() {
+					goto l544
+				}
+				add(ruleAssignment, position545)
+			}
+			return true
+		l544:
+			position, tokenIndex = position544, tokenIndex544
+			return false
+		},
+		/* 48 CompoundAssignment <- <((Index / Selector / LowerLabel) Whitespace? AssignOperator Whitespace? Expression)> */
+		func() bool {
+			position550, tokenIndex550 := position, tokenIndex
+			{
+				position551 := position
+				{
+					position552, tokenIndex552 := position, tokenIndex
+					if !_rules[ruleIndex]() {
+						goto l553
+					}
+					goto l552
+				l553:
+					position, tokenIndex = position552, tokenIndex552
+					if !_rules[ruleSelector]() {
+						goto l554
+					}
+					goto l552
+				l554:
+					position, tokenIndex = position552, tokenIndex552
+					if !_rules[ruleLowerLabel]() {
+						goto l550
+					}
+				}
+			l552:
+				{
+					position555, tokenIndex555 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l555
+					}
+					goto l556
+				l555:
+					position, tokenIndex = position555, tokenIndex555
+				}
+			l556:
+				if !_rules[ruleAssignOperator]() {
+					goto l550
+				}
+				{
+					position557, tokenIndex557 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l557
+					}
+					goto l558
+				l557:
+					position, tokenIndex = position557, tokenIndex557
+				}
+			l558:
+				if !_rules[ruleExpression]() {
+					goto l550
+				}
+				add(ruleCompoundAssignment, position551)
+			}
+			return true
+		l550:
+			position, tokenIndex = position550, tokenIndex550
+			return false
+		},
+		/* 49 AssignOperator <- <((('<' '<') / ('>' '>') / ('&' '^') / ('-' / '+' / '*' / '/' / '%' / '&' / '|' / '^')) '=')> */
+		func() bool {
+			position559, tokenIndex559 := position, tokenIndex
+			{
+				position560 := position
+				{
+					position561, tokenIndex561 := position, tokenIndex
+					if buffer[position] != rune('<') {
+						goto l562
+					}
+					position++
+					if buffer[position] != rune('<') {
+						goto l562
+					}
+					position++
+					goto l561
+				l562:
+					position, tokenIndex = position561, tokenIndex561
+					if buffer[position] != rune('>') {
+						goto l563
+					}
+					position++
+					if buffer[position] != rune('>') {
+						goto l563
+					}
+					position++
+					goto l561
+				l563:
+					position, tokenIndex = position561, tokenIndex561
+					if buffer[position] != rune('&') {
+						goto l564
+					}
+					position++
+					if buffer[position] != rune('^') {
+						goto l564
+					}
+					position++
+					goto l561
+				l564:
+					position, tokenIndex = position561, tokenIndex561
+					{
+						position565, tokenIndex565 := position, tokenIndex
+						if buffer[position] != rune('-') {
+							goto l566
+						}
+						position++
+						goto l565
+					l566:
+						position, tokenIndex = position565, tokenIndex565
+						if buffer[position] != rune('+') {
+							goto l567
+						}
+						position++
+						goto l565
+					l567:
+						position, tokenIndex = position565, tokenIndex565
+						if buffer[position] != rune('*') {
+							goto l568
+						}
+						position++
+						goto l565
+					l568:
+						position, tokenIndex = position565, tokenIndex565
+						if buffer[position] != rune('/') {
+							goto l569
+						}
+						position++
+						goto l565
+					l569:
+						position, tokenIndex = position565, tokenIndex565
+						if buffer[position] != rune('%') {
+							goto l570
+						}
+						position++
+						goto l565
+					l570:
+						position, tokenIndex = position565, tokenIndex565
+						if buffer[position] != rune('&') {
+							goto l571
+						}
+						position++
+						goto l565
+					l571:
+						position, tokenIndex = position565, tokenIndex565
+						if buffer[position] != rune('|') {
+							goto l572
+						}
+						position++
+						goto l565
+					l572:
+						position, tokenIndex = position565, tokenIndex565
+						if buffer[position] != rune('^') {
+							goto l559
+						}
+						position++
+					}
+				l565:
+				}
+			l561:
+				if buffer[position] != rune('=') {
+					goto l559
+				}
+				position++
+				add(ruleAssignOperator, position560)
+			}
+			return true
+		l559:
+			position, tokenIndex = position559, tokenIndex559
+			return false
+		},
+		/* 50 Expression <- <Disjunction> */
+		func() bool {
+			position573, tokenIndex573 := position, tokenIndex
+			{
+				position574 := position
+				if !_rules[ruleDisjunction]() {
+					goto l573
+				}
+				add(ruleExpression, position574)
+			}
+			return true
+		l573:
+			position, tokenIndex = position573, tokenIndex573
+			return false
+		},
+		/* 51 Disjunction <- <(Conjunction (Whitespace? OrOperator Whitespace? Conjunction)*)> */
+		func() bool {
+			position575, tokenIndex575 := position, tokenIndex
+			{
+				position576 := position
+				if !_rules[ruleConjunction]() {
+					goto l575
+				}
+			l577:
+				{
+					position578, tokenIndex578 := position, tokenIndex
+					{
+						position579, tokenIndex579 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l579
+						}
+						goto l580
+					l579:
+						position, tokenIndex = position579, tokenIndex579
+					}
+				l580:
+					if !_rules[ruleOrOperator]() {
+						goto l578
+					}
+					{
+						position581, tokenIndex581 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l581
+						}
+						goto l582
+					l581:
+						position, tokenIndex = position581, tokenIndex581
+					}
+				l582:
+					if !_rules[ruleConjunction]() {
+						goto l578
+					}
+					goto l577
+				l578:
+					position, tokenIndex = position578, tokenIndex578
+				}
+				add(ruleDisjunction, position576)
+			}
+			return true
+		l575:
+			position, tokenIndex = position575, tokenIndex575
+			return false
+		},
+		/* 52 Conjunction <- <(Comparison (Whitespace? AndOperator Whitespace? Comparison)*)> */
+		func() bool {
+			position583, tokenIndex583 := position, tokenIndex
+			{
+				position584 := position
+				if !_rules[ruleComparison]() {
+					goto l583
+				}
+			l585:
+				{
+					position586, tokenIndex586 := position, tokenIndex
+					{
+						position587, tokenIndex587 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l587
+						}
+						goto l588
+					l587:
+						position, tokenIndex = position587, tokenIndex587
+					}
+				l588:
+					if !_rules[ruleAndOperator]() {
+						goto l586
+					}
+					{
+						position589, tokenIndex589 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l589
+						}
+						goto l590
+					l589:
+						position, tokenIndex = position589, tokenIndex589
+					}
+				l590:
+					if !_rules[ruleComparison]() {
+						goto l586
+					}
+					goto l585
+				l586:
+					position, tokenIndex = position586, tokenIndex586
+				}
+				add(ruleConjunction, position584)
+			}
+			return true
+		l583:
+			position, tokenIndex = position583, tokenIndex583
+			return false
+		},
+		/* 53 Comparison <- <(Sum (Whitespace? CmpOperator Whitespace? Sum)*)> */
+		func() bool {
+			position591, tokenIndex591 := position, tokenIndex
+			{
+				position592 := position
+				if !_rules[ruleSum]() {
+					goto l591
+				}
+			l593:
+				{
+					position594, tokenIndex594 := position, tokenIndex
+					{
+						position595, tokenIndex595 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l595
+						}
+						goto l596
+					l595:
+						position, tokenIndex = position595, tokenIndex595
+					}
+				l596:
+					if !_rules[ruleCmpOperator]() {
+						goto l594
+					}
+					{
+						position597, tokenIndex597 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l597
+						}
+						goto l598
+					l597:
+						position, tokenIndex = position597, tokenIndex597
+					}
+				l598:
+					if !_rules[ruleSum]() {
+						goto l594
+					}
+					goto l593
+				l594:
+					position, tokenIndex = position594, tokenIndex594
+				}
+				add(ruleComparison, position592)
+			}
+			return true
+		l591:
+			position, tokenIndex = position591, tokenIndex591
+			return false
+		},
+		/* 54 Sum <- <(Product (Whitespace? SumOperator Whitespace? Product)*)> */
+		func() bool {
+			position599, tokenIndex599 := position, tokenIndex
+			{
+				position600 := position
+				if !_rules[ruleProduct]() {
+					goto l599
+				}
+			l601:
+				{
+					position602, tokenIndex602 := position, tokenIndex
+					{
+						position603, tokenIndex603 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l603
+						}
+						goto l604
+					l603:
+						position, tokenIndex = position603, tokenIndex603
+					}
+				l604:
+					if !_rules[ruleSumOperator]() {
+						goto l602
+					}
+					{
+						position605, tokenIndex605 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l605
+						}
+						goto l606
+					l605:
+						position, tokenIndex = position605, tokenIndex605
+					}
+				l606:
+					if !_rules[ruleProduct]() {
+						goto l602
+					}
+					goto l601
+				l602:
+					position, tokenIndex = position602, tokenIndex602
+				}
+				add(ruleSum, position600)
+			}
+			return true
+		l599:
+			position, tokenIndex = position599, tokenIndex599
+			return false
+		},
+		/* 55 Product <- <(Cast (Whitespace? ProductOperator Whitespace? Cast)*)> */
+		func() bool {
+			position607, tokenIndex607 := position, tokenIndex
+			{
+				position608 := position
+				if !_rules[ruleCast]() {
+					goto l607
+				}
+			l609:
+				{
+					position610, tokenIndex610 := position, tokenIndex
+					{
+						position611, tokenIndex611 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l611
+						}
+						goto l612
+					l611:
+						position, tokenIndex = position611, tokenIndex611
+					}
+				l612:
+					if !_rules[ruleProductOperator]() {
+						goto l610
+					}
+					{
+						position613, tokenIndex613 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l613
+						}
+						goto l614
+					l613:
+						position, tokenIndex = position613, tokenIndex613
+					}
+				l614:
+					if !_rules[ruleCast]() {
+						goto l610
+					}
+					goto l609
+				l610:
+					position, tokenIndex = position610, tokenIndex610
+				}
+				add(ruleProduct, position608)
+			}
+			return true
+		l607:
+			position, tokenIndex = position607, tokenIndex607
+			return false
+		},
+		/* 56 Cast <- <(Unary (Whitespace (('a' / 'A') ('s' / 'S')) Whitespace Type)?)> */
+		func() bool {
+			position615, tokenIndex615 := position, tokenIndex
+			{
+				position616 := position
+				if !_rules[ruleUnary]() {
+					goto l615
+				}
+				{
+					position617, tokenIndex617 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l617
+					}
+					{
+						position619, tokenIndex619 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l620
+						}
+						position++
+						goto l619
+					l620:
+						position, tokenIndex = position619, tokenIndex619
+						if buffer[position] != rune('A') {
+							goto l617
+						}
+						position++
+					}
+				l619:
+					{
+						position621, tokenIndex621 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l622
+						}
+						position++
+						goto l621
+					l622:
+						position, tokenIndex = position621, tokenIndex621
+						if buffer[position] != rune('S') {
+							goto l617
+						}
+						position++
+					}
+				l621:
+					if !_rules[ruleWhitespace]() {
+						goto l617
+					}
+					if !_rules[ruleType]() {
+						goto l617
+					}
+					goto l618
+				l617:
+					position, tokenIndex = position617, tokenIndex617
+				}
+			l618:
+				add(ruleCast, position616)
+			}
+			return true
+		l615:
+			position, tokenIndex = position615, tokenIndex615
+			return false
+		},
+		/* 57 Unary <- <(Receive / (UnaryOperator Unary) / Primary)> */
+		func() bool {
+			position623, tokenIndex623 := position, tokenIndex
+			{
+				position624 := position
+				{
+					position625, tokenIndex625 := position, tokenIndex
+					if !_rules[ruleReceive]() {
+						goto l626
+					}
+					goto l625
+				l626:
+					position, tokenIndex = position625, tokenIndex625
+					if !_rules[ruleUnaryOperator]() {
+						goto l627
+					}
+					if !_rules[ruleUnary]() {
+						goto l627
+					}
+					goto l625
+				l627:
+					position, tokenIndex = position625, tokenIndex625
+					if !_rules[rulePrimary]() {
+						goto l623
+					}
+				}
+			l625:
+				add(ruleUnary, position624)
+			}
+			return true
+		l623:
+			position, tokenIndex = position623, tokenIndex623
+			return false
+		},
+		/* 58 Primary <- <(Lambda / ShortLambda / Index / Call / RecordLiteral / Selector / Simple / Parens)> */
+		func() bool {
+			position628, tokenIndex628 := position, tokenIndex
+			{
+				position629 := position
+				{
+					position630, tokenIndex630 := position, tokenIndex
+					if !_rules[ruleLambda]() {
+						goto l631
+					}
+					goto l630
+				l631:
+					position, tokenIndex = position630, tokenIndex630
+					if !_rules[ruleShortLambda]() {
+						goto l632
+					}
+					goto l630
+				l632:
+					position, tokenIndex = position630, tokenIndex630
+					if !_rules[ruleIndex]() {
+						goto l633
+					}
+					goto l630
+				l633:
+					position, tokenIndex = position630, tokenIndex630
+					if !_rules[ruleCall]() {
+						goto l634
+					}
+					goto l630
+				l634:
+					position, tokenIndex = position630, tokenIndex630
+					if !_rules[ruleRecordLiteral]() {
+						goto l635
+					}
+					goto l630
+				l635:
+					position, tokenIndex = position630, tokenIndex630
+					if !_rules[ruleSelector]() {
+						goto l636
+					}
+					goto l630
+				l636:
+					position, tokenIndex = position630, tokenIndex630
+					if !_rules[ruleSimple]() {
+						goto l637
+					}
+					goto l630
+				l637:
+					position, tokenIndex = position630, tokenIndex630
+					if !_rules[ruleParens]() {
+						goto l628
+					}
+				}
+			l630:
+				add(rulePrimary, position629)
+			}
+			return true
+		l628:
+			position, tokenIndex = position628, tokenIndex628
+			return false
+		},
+		/* 59 Index <- <(IndexObject IndexKey+)> */
+		func() bool {
+			position638, tokenIndex638 := position, tokenIndex
+			{
+				position639 := position
+				if !_rules[ruleIndexObject]() {
+					goto l638
+				}
+				if !_rules[ruleIndexKey]() {
+					goto l638
+				}
+			l640:
+				{
+					position641, tokenIndex641 := position, tokenIndex
+					if !_rules[ruleIndexKey]() {
+						goto l641
+					}
+					goto l640
+				l641:
+					position, tokenIndex = position641, tokenIndex641
+				}
+				add(ruleIndex, position639)
+			}
+			return true
+		l638:
+			position, tokenIndex = position638, tokenIndex638
+			return false
+		},
+		/* 60 IndexObject <- <(Call / Selector / Label / Parens)> */
+		func() bool {
+			position642, tokenIndex642 := position, tokenIndex
+			{
+				position643 := position
+				{
+					position644, tokenIndex644 := position, tokenIndex
+					if !_rules[ruleCall]() {
+						goto l645
+					}
+					goto l644
+				l645:
+					position, tokenIndex = position644, tokenIndex644
+					if !_rules[ruleSelector]() {
+						goto l646
+					}
+					goto l644
+				l646:
+					position, tokenIndex = position644, tokenIndex644
+					if !_rules[ruleLabel]() {
+						goto l647
+					}
+					goto l644
+				l647:
+					position, tokenIndex = position644, tokenIndex644
+					if !_rules[ruleParens]() {
+						goto l642
+					}
+				}
+			l644:
+				add(ruleIndexObject, position643)
+			}
+			return true
+		l642:
+			position, tokenIndex = position642, tokenIndex642
+			return false
+		},
+		/* 61 IndexKey <- <('[' Whitespace? Expression Whitespace? ']')> */
+		func() bool {
+			position648, tokenIndex648 := position, tokenIndex
+			{
+				position649 := position
+				if buffer[position] != rune('[') {
+					goto l648
+				}
+				position++
+				{
+					position650, tokenIndex650 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l650
+					}
+					goto l651
+				l650:
+					position, tokenIndex = position650, tokenIndex650
+				}
+			l651:
+				if !_rules[ruleExpression]() {
+					goto l648
+				}
+				{
+					position652, tokenIndex652 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l652
+					}
+					goto l653
+				l652:
+					position, tokenIndex = position652, tokenIndex652
+				}
+			l653:
+				if buffer[position] != rune(']') {
+					goto l648
+				}
+				position++
+				add(ruleIndexKey, position649)
+			}
+			return true
+		l648:
+			position, tokenIndex = position648, tokenIndex648
+			return false
+		},
+		/* 62 Selector <- <(SelectorObject ('.' FieldLabel !('?' / '!' / '('))+)> */
+		func() bool {
+			position654, tokenIndex654 := position, tokenIndex
+			{
+				position655 := position
+				if !_rules[ruleSelectorObject]() {
+					goto l654
+				}
+				if buffer[position] != rune('.') {
+					goto l654
+				}
+				position++
+				if !_rules[ruleFieldLabel]() {
+					goto l654
+				}
+				{
+					position658, tokenIndex658 := position, tokenIndex
+					{
+						position659, tokenIndex659 := position, tokenIndex
+						if buffer[position] != rune('?') {
+							goto l660
+						}
+						position++
+						goto l659
+					l660:
+						position, tokenIndex = position659, tokenIndex659
+						if buffer[position] != rune('!') {
+							goto l661
+						}
+						position++
+						goto l659
+					l661:
+						position, tokenIndex = position659, tokenIndex659
+						if buffer[position] != rune('(') {
+							goto l658
+						}
+						position++
+					}
+				l659:
+					goto l654
+				l658:
+					position, tokenIndex = position658, tokenIndex658
+				}
+			l656:
+				{
+					position657, tokenIndex657 := position, tokenIndex
+					if buffer[position] != rune('.') {
+						goto l657
+					}
+					position++
+					if !_rules[ruleFieldLabel]() {
+						goto l657
+					}
+					{
+						position662, tokenIndex662 := position, tokenIndex
+						{
+							position663, tokenIndex663 := position, tokenIndex
+							if buffer[position] != rune('?') {
+								goto l664
+							}
+							position++
+							goto l663
+						l664:
+							position, tokenIndex = position663, tokenIndex663
+							if buffer[position] != rune('!') {
+								goto l665
+							}
+							position++
+							goto l663
+						l665:
+							position, tokenIndex = position663, tokenIndex663
+							if buffer[position] != rune('(') {
+								goto l662
+							}
+							position++
+						}
+					l663:
+						goto l657
+					l662:
+						position, tokenIndex = position662, tokenIndex662
+					}
+					goto l656
+				l657:
+					position, tokenIndex = position657, tokenIndex657
+				}
+				add(ruleSelector, position655)
+			}
+			return true
+		l654:
+			position, tokenIndex = position654, tokenIndex654
+			return false
+		},
+		/* 63 SelectorObject <- <(FunCall / Parens / Label / Error)> */
+		func() bool {
+			position666, tokenIndex666 := position, tokenIndex
+			{
+				position667 := position
+				{
+					position668, tokenIndex668 := position, tokenIndex
+					if !_rules[ruleFunCall]() {
+						goto l669
+					}
+					goto l668
+				l669:
+					position, tokenIndex = position668, tokenIndex668
+					if !_rules[ruleParens]() {
+						goto l670
+					}
+					goto l668
+				l670:
+					position, tokenIndex = position668, tokenIndex668
+					if !_rules[ruleLabel]() {
+						goto l671
+					}
+					goto l668
+				l671:
+					position, tokenIndex = position668, tokenIndex668
+					if !_rules[ruleError]() {
+						goto l666
+					}
+				}
+			l668:
+				add(ruleSelectorObject, position667)
+			}
+			return true
+		l666:
+			position, tokenIndex = position666, tokenIndex666
+			return false
+		},
+		/* 64 FieldLabel <- <(([A-Z] / [a-z]) ([A-Z] / [a-z] / [0-9] / '_')*)> */
+		func() bool {
+			position672, tokenIndex672 := position, tokenIndex
+			{
+				position673 := position
+				{
+					position674, tokenIndex674 := position, tokenIndex
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l675
+					}
+					position++
+					goto l674
+				l675:
+					position, tokenIndex = position674, tokenIndex674
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l672
+					}
+					position++
+				}
+			l674:
+			l676:
+				{
+					position677, tokenIndex677 := position, tokenIndex
+					{
+						position678, tokenIndex678 := position, tokenIndex
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l679
+						}
+						position++
+						goto l678
+					l679:
+						position, tokenIndex = position678, tokenIndex678
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l680
+						}
+						position++
+						goto l678
+					l680:
+						position, tokenIndex = position678, tokenIndex678
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l681
+						}
+						position++
+						goto l678
+					l681:
+						position, tokenIndex = position678, tokenIndex678
+						if buffer[position] != rune('_') {
+							goto l677
+						}
+						position++
+					}
+				l678:
+					goto l676
+				l677:
+					position, tokenIndex = position677, tokenIndex677
+				}
+				add(ruleFieldLabel, position673)
+			}
+			return true
+		l672:
+			position, tokenIndex = position672, tokenIndex672
+			return false
+		},
+		/* 65 RecordLiteral <- <((GenericType / TypeLabel) '{' Gap? ((LiteralField (',' Gap? LiteralField)*) / (Expression (',' Gap? Expression)*))? ','? Gap? '}')> */
+		func() bool {
+			position682, tokenIndex682 := position, tokenIndex
+			{
+				position683 := position
+				{
+					position684, tokenIndex684 := position, tokenIndex
+					if !_rules[ruleGenericType]() {
+						goto l685
+					}
+					goto l684
+				l685:
+					position, tokenIndex = position684, tokenIndex684
+					if !_rules[ruleTypeLabel]() {
+						goto l682
+					}
+				}
+			l684:
+				if buffer[position] != rune('{') {
+					goto l682
+				}
+				position++
+				{
+					position686, tokenIndex686 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l686
+					}
+					goto l687
+				l686:
+					position, tokenIndex = position686, tokenIndex686
+				}
+			l687:
+				{
+					position688, tokenIndex688 := position, tokenIndex
+					{
+						position690, tokenIndex690 := position, tokenIndex
+						if !_rules[ruleLiteralField]() {
+							goto l691
+						}
+					l692:
+						{
+							position693, tokenIndex693 := position, tokenIndex
+							if buffer[position] != rune(',') {
+								goto l693
+							}
+							position++
+							{
+								position694, tokenIndex694 := position, tokenIndex
+								if !_rules[ruleGap]() {
+									goto l694
+								}
+								goto l695
+							l694:
+								position, tokenIndex = position694, tokenIndex694
+							}
+						l695:
+							if !_rules[ruleLiteralField]() {
+								goto l693
+							}
+							goto l692
+						l693:
+							position, tokenIndex = position693, tokenIndex693
+						}
+						goto l690
+					l691:
+						position, tokenIndex = position690, tokenIndex690
+						if !_rules[ruleExpression]() {
+							goto l688
+						}
+					l696:
+						{
+							position697, tokenIndex697 := position, tokenIndex
+							if buffer[position] != rune(',') {
+								goto l697
+							}
+							position++
+							{
+								position698, tokenIndex698 := position, tokenIndex
+								if !_rules[ruleGap]() {
+									goto l698
+								}
+								goto l699
+							l698:
+								position, tokenIndex = position698, tokenIndex698
+							}
+						l699:
+							if !_rules[ruleExpression]() {
+								goto l697
+							}
+							goto l696
+						l697:
+							position, tokenIndex = position697, tokenIndex697
+						}
+					}
+				l690:
+					goto l689
+				l688:
+					position, tokenIndex = position688, tokenIndex688
+				}
+			l689:
+				{
+					position700, tokenIndex700 := position, tokenIndex
+					if buffer[position] != rune(',') {
+						goto l700
+					}
+					position++
+					goto l701
+				l700:
+					position, tokenIndex = position700, tokenIndex700
+				}
+			l701:
+				{
+					position702, tokenIndex702 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l702
+					}
+					goto l703
+				l702:
+					position, tokenIndex = position702, tokenIndex702
+				}
+			l703:
+				if buffer[position] != rune('}') {
+					goto l682
+				}
+				position++
+				add(ruleRecordLiteral, position683)
+			}
+			return true
+		l682:
+			position, tokenIndex = position682, tokenIndex682
+			return false
+		},
+		/* 66 LiteralField <- <(FieldLabel ':' Whitespace? Expression)> */
+		func() bool {
+			position704, tokenIndex704 := position, tokenIndex
+			{
+				position705 := position
+				if !_rules[ruleFieldLabel]() {
+					goto l704
+				}
+				if buffer[position] != rune(':') {
+					goto l704
+				}
+				position++
+				{
+					position706, tokenIndex706 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l706
+					}
+					goto l707
+				l706:
+					position, tokenIndex = position706, tokenIndex706
+				}
+			l707:
+				if !_rules[ruleExpression]() {
+					goto l704
+				}
+				add(ruleLiteralField, position705)
+			}
+			return true
+		l704:
+			position, tokenIndex = position704, tokenIndex704
+			return false
+		},
+		/* 67 Parens <- <('(' Whitespace? Expression Whitespace? ')')> */
+		func() bool {
+			position708, tokenIndex708 := position, tokenIndex
+			{
+				position709 := position
+				if buffer[position] != rune('(') {
+					goto l708
+				}
+				position++
+				{
+					position710, tokenIndex710 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l710
+					}
+					goto l711
+				l710:
+					position, tokenIndex = position710, tokenIndex710
+				}
+			l711:
+				if !_rules[ruleExpression]() {
+					goto l708
+				}
+				{
+					position712, tokenIndex712 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l712
+					}
+					goto l713
+				l712:
+					position, tokenIndex = position712, tokenIndex712
+				}
+			l713:
+				if buffer[position] != rune(')') {
+					goto l708
+				}
+				position++
+				add(ruleParens, position709)
+			}
+			return true
+		l708:
+			position, tokenIndex = position708, tokenIndex708
+			return false
+		},
+		/* 68 Lambda <- <(('f' / 'F') ('u' / 'U') ('n' / 'N') ('c' / 'C') '(' LambdaArgs? ')' LambdaError? (Whitespace Type)? ':' Newline Indent Code)> */
+		func() bool {
+			position714, tokenIndex714 := position, tokenIndex
+			{
+				position715 := position
+				{
+					position716, tokenIndex716 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l717
+					}
+					position++
+					goto l716
+				l717:
+					position, tokenIndex = position716, tokenIndex716
+					if buffer[position] != rune('F') {
+						goto l714
+					}
+					position++
+				}
+			l716:
+				{
+					position718, tokenIndex718 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l719
+					}
+					position++
+					goto l718
+				l719:
+					position, tokenIndex = position718, tokenIndex718
+					if buffer[position] != rune('U') {
+						goto l714
+					}
+					position++
+				}
+			l718:
+				{
+					position720, tokenIndex720 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l721
+					}
+					position++
+					goto l720
+				l721:
+					position, tokenIndex = position720, tokenIndex720
+					if buffer[position] != rune('N') {
+						goto l714
+					}
+					position++
+				}
+			l720:
+				{
+					position722, tokenIndex722 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l723
+					}
+					position++
+					goto l722
+				l723:
+					position, tokenIndex = position722, tokenIndex722
+					if buffer[position] != rune('C') {
+						goto l714
+					}
+					position++
+				}
+			l722:
+				if buffer[position] != rune('(') {
+					goto l714
+				}
+				position++
+				{
+					position724, tokenIndex724 := position, tokenIndex
+					if !_rules[ruleLambdaArgs]() {
+						goto l724
+					}
+					goto l725
+				l724:
+					position, tokenIndex = position724, tokenIndex724
+				}
+			l725:
+				if buffer[position] != rune(')') {
+					goto l714
+				}
+				position++
+				{
+					position726, tokenIndex726 := position, tokenIndex
+					if !_rules[ruleLambdaError]() {
+						goto l726
+					}
+					goto l727
+				l726:
+					position, tokenIndex = position726, tokenIndex726
+				}
+			l727:
+				{
+					position728, tokenIndex728 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l728
+					}
+					if !_rules[ruleType]() {
+						goto l728
+					}
+					goto l729
+				l728:
+					position, tokenIndex = position728, tokenIndex728
+				}
+			l729:
+				if buffer[position] != rune(':') {
+					goto l714
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l714
+				}
+				if !_rules[ruleIndent]() {
+					goto l714
+				}
+				if !_rules[ruleCode]() {
+					goto l714
+				}
+				add(ruleLambda, position715)
+			}
+			return true
+		l714:
+			position, tokenIndex = position714, tokenIndex714
+			return false
+		},
+		/* 69 ShortLambda <- <((LambdaArg / ('(' LambdaArgs? ')')) Whitespace? ('-' '>') Whitespace? Expression)> */
+		func() bool {
+			position730, tokenIndex730 := position, tokenIndex
+			{
+				position731 := position
+				{
+					position732, tokenIndex732 := position, tokenIndex
+					if !_rules[ruleLambdaArg]() {
+						goto l733
+					}
+					goto l732
+				l733:
+					position, tokenIndex = position732, tokenIndex732
+					if buffer[position] != rune('(') {
+						goto l730
+					}
+					position++
+					{
+						position734, tokenIndex734 := position, tokenIndex
+						if !_rules[ruleLambdaArgs]() {
+							goto l734
+						}
+						goto l735
+					l734:
+						position, tokenIndex = position734, tokenIndex734
+					}
+				l735:
+					if buffer[position] != rune(')') {
+						goto l730
+					}
+					position++
+				}
+			l732:
+				{
+					position736, tokenIndex736 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l736
+					}
+					goto l737
+				l736:
+					position, tokenIndex = position736, tokenIndex736
+				}
+			l737:
+				if buffer[position] != rune('-') {
+					goto l730
+				}
+				position++
+				if buffer[position] != rune('>') {
+					goto l730
+				}
+				position++
+				{
+					position738, tokenIndex738 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l738
+					}
+					goto l739
+				l738:
+					position, tokenIndex = position738, tokenIndex738
+				}
+			l739:
+				if !_rules[ruleExpression]() {
+					goto l730
+				}
+				add(ruleShortLambda, position731)
+			}
+			return true
+		l730:
+			position, tokenIndex = position730, tokenIndex730
+			return false
+		},
+		/* 70 LambdaArgs <- <(LambdaArg (',' Whitespace? LambdaArg)*)> */
+		func() bool {
+			position740, tokenIndex740 := position, tokenIndex
+			{
+				position741 := position
+				if !_rules[ruleLambdaArg]() {
+					goto l740
+				}
+			l742:
+				{
+					position743, tokenIndex743 := position, tokenIndex
+					if buffer[position] != rune(',') {
+						goto l743
+					}
+					position++
+					{
+						position744, tokenIndex744 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l744
+						}
+						goto l745
+					l744:
+						position, tokenIndex = position744, tokenIndex744
+					}
+				l745:
+					if !_rules[ruleLambdaArg]() {
+						goto l743
+					}
+					goto l742
+				l743:
+					position, tokenIndex = position743, tokenIndex743
+				}
+				add(ruleLambdaArgs, position741)
+			}
+			return true
+		l740:
+			position, tokenIndex = position740, tokenIndex740
+			return false
+		},
+		/* 71 LambdaArg <- <(LowerLabel (Whitespace Type)?)> */
+		func() bool {
+			position746, tokenIndex746 := position, tokenIndex
+			{
+				position747 := position
+				if !_rules[ruleLowerLabel]() {
+					goto l746
+				}
+				{
+					position748, tokenIndex748 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l748
+					}
+					if !_rules[ruleType]() {
+						goto l748
+					}
+					goto l749
+				l748:
+					position, tokenIndex = position748, tokenIndex748
+				}
+			l749:
+				add(ruleLambdaArg, position747)
+			}
+			return true
+		l746:
+			position, tokenIndex = position746, tokenIndex746
+			return false
+		},
+		/* 72 LambdaError <- <('!' / '?')> */
+		func() bool {
+			position750, tokenIndex750 := position, tokenIndex
+			{
+				position751 := position
+				{
+					position752, tokenIndex752 := position, tokenIndex
+					if buffer[position] != rune('!') {
+						goto l753
+					}
+					position++
+					goto l752
+				l753:
+					position, tokenIndex = position752, tokenIndex752
+					if buffer[position] != rune('?') {
+						goto l750
+					}
+					position++
+				}
+			l752:
+				add(ruleLambdaError, position751)
+			}
+			return true
+		l750:
+			position, tokenIndex = position750, tokenIndex750
+			return false
+		},
+		/* 73 OrOperator <- <('|' '|')> */
+		func() bool {
+			position754, tokenIndex754 := position, tokenIndex
+			{
+				position755 := position
+				if buffer[position] != rune('|') {
+					goto l754
+				}
+				position++
+				if buffer[position] != rune('|') {
+					goto l754
+				}
+				position++
+				add(ruleOrOperator, position755)
+			}
+			return true
+		l754:
+			position, tokenIndex = position754, tokenIndex754
+			return false
+		},
+		/* 74 AndOperator <- <('&' '&')> */
+		func() bool {
+			position756, tokenIndex756 := position, tokenIndex
+			{
+				position757 := position
+				if buffer[position] != rune('&') {
+					goto l756
+				}
+				position++
+				if buffer[position] != rune('&') {
+					goto l756
+				}
+				position++
+				add(ruleAndOperator, position757)
+			}
+			return true
+		l756:
+			position, tokenIndex = position756, tokenIndex756
+			return false
+		},
+		/* 75 CmpOperator <- <(('=' '=') / ('!' '=') / ('<' '=') / ('>' '=') / ('<' !'-') / '>')> */
+		func() bool {
+			position758, tokenIndex758 := position, tokenIndex
+			{
+				position759 := position
+				{
+					position760, tokenIndex760 := position, tokenIndex
+					if buffer[position] != rune('=') {
+						goto l761
+					}
+					position++
+					if buffer[position] != rune('=') {
+						goto l761
+					}
+					position++
+					goto l760
+				l761:
+					position, tokenIndex = position760, tokenIndex760
+					if buffer[position] != rune('!') {
+						goto l762
+					}
+					position++
+					if buffer[position] != rune('=') {
+						goto l762
+					}
+					position++
+					goto l760
+				l762:
+					position, tokenIndex = position760, tokenIndex760
+					if buffer[position] != rune('<') {
+						goto l763
+					}
+					position++
+					if buffer[position] != rune('=') {
+						goto l763
+					}
+					position++
+					goto l760
+				l763:
+					position, tokenIndex = position760, tokenIndex760
+					if buffer[position] != rune('>') {
+						goto l764
+					}
+					position++
+					if buffer[position] != rune('=') {
+						goto l764
+					}
+					position++
+					goto l760
+				l764:
+					position, tokenIndex = position760, tokenIndex760
+					if buffer[position] != rune('<') {
+						goto l765
+					}
+					position++
+					{
+						position766, tokenIndex766 := position, tokenIndex
+						if buffer[position] != rune('-') {
+							goto l766
+						}
+						position++
+						goto l765
+					l766:
+						position, tokenIndex = position766, tokenIndex766
+					}
+					goto l760
+				l765:
+					position, tokenIndex = position760, tokenIndex760
+					if buffer[position] != rune('>') {
+						goto l758
+					}
+					position++
+				}
+			l760:
+				add(ruleCmpOperator, position759)
+			}
+			return true
+		l758:
+			position, tokenIndex = position758, tokenIndex758
+			return false
+		},
+		/* 76 SumOperator <- <(('+' / '-' / '|' / '^') !('=' / '|'))> */
+		func() bool {
+			position767, tokenIndex767 := position, tokenIndex
+			{
+				position768 := position
+				{
+					position769, tokenIndex769 := position, tokenIndex
+					if buffer[position] != rune('+') {
+						goto l770
+					}
+					position++
+					goto l769
+				l770:
+					position, tokenIndex = position769, tokenIndex769
+					if buffer[position] != rune('-') {
+						goto l771
+					}
+					position++
+					goto l769
+				l771:
+					position, tokenIndex = position769, tokenIndex769
+					if buffer[position] != rune('|') {
+						goto l772
+					}
+					position++
+					goto l769
+				l772:
+					position, tokenIndex = position769, tokenIndex769
+					if buffer[position] != rune('^') {
+						goto l767
+					}
+					position++
+				}
+			l769:
+				{
+					position773, tokenIndex773 := position, tokenIndex
+					{
+						position774, tokenIndex774 := position, tokenIndex
+						if buffer[position] != rune('=') {
+							goto l775
+						}
+						position++
+						goto l774
+					l775:
+						position, tokenIndex = position774, tokenIndex774
+						if buffer[position] != rune('|') {
+							goto l773
+						}
+						position++
+					}
+				l774:
+					goto l767
+				l773:
+					position, tokenIndex = position773, tokenIndex773
+				}
+				add(ruleSumOperator, position768)
+			}
+			return true
+		l767:
+			position, tokenIndex = position767, tokenIndex767
+			return false
+		},
+		/* 77 ProductOperator <- <((('<' '<') / ('>' '>') / ('&' '^') / '*' / '/' / '%' / '&') !('=' / '&'))> */
+		func() bool {
+			position776, tokenIndex776 := position, tokenIndex
+			{
+				position777 := position
+				{
+					position778, tokenIndex778 := position, tokenIndex
+					if buffer[position] != rune('<') {
+						goto l779
+					}
+					position++
+					if buffer[position] != rune('<') {
+						goto l779
+					}
+					position++
+					goto l778
+				l779:
+					position, tokenIndex = position778, tokenIndex778
+					if buffer[position] != rune('>') {
+						goto l780
+					}
+					position++
+					if buffer[position] != rune('>') {
+						goto l780
+					}
+					position++
+					goto l778
+				l780:
+					position, tokenIndex = position778, tokenIndex778
+					if buffer[position] != rune('&') {
+						goto l781
+					}
+					position++
+					if buffer[position] != rune('^') {
+						goto l781
+					}
+					position++
+					goto l778
+				l781:
+					position, tokenIndex = position778, tokenIndex778
+					if buffer[position] != rune('*') {
+						goto l782
+					}
+					position++
+					goto l778
+				l782:
+					position, tokenIndex = position778, tokenIndex778
+					if buffer[position] != rune('/') {
+						goto l783
+					}
+					position++
+					goto l778
+				l783:
+					position, tokenIndex = position778, tokenIndex778
+					if buffer[position] != rune('%') {
+						goto l784
+					}
+					position++
+					goto l778
+				l784:
+					position, tokenIndex = position778, tokenIndex778
+					if buffer[position] != rune('&') {
+						goto l776
+					}
+					position++
+				}
+			l778:
+				{
+					position785, tokenIndex785 := position, tokenIndex
+					{
+						position786, tokenIndex786 := position, tokenIndex
+						if buffer[position] != rune('=') {
+							goto l787
+						}
+						position++
+						goto l786
+					l787:
+						position, tokenIndex = position786, tokenIndex786
+						if buffer[position] != rune('&') {
+							goto l785
+						}
+						position++
+					}
+				l786:
+					goto l776
+				l785:
+					position, tokenIndex = position785, tokenIndex785
+				}
+				add(ruleProductOperator, position777)
+			}
+			return true
+		l776:
+			position, tokenIndex = position776, tokenIndex776
+			return false
+		},
+		/* 78 UnaryOperator <- <('+' / '-' / '!' / '^' / '&')> */
+		func() bool {
+			position788, tokenIndex788 := position, tokenIndex
+			{
+				position789 := position
+				{
+					position790, tokenIndex790 := position, tokenIndex
+					if buffer[position] != rune('+') {
+						goto l791
+					}
+					position++
+					goto l790
+				l791:
+					position, tokenIndex = position790, tokenIndex790
+					if buffer[position] != rune('-') {
+						goto l792
+					}
+					position++
+					goto l790
+				l792:
+					position, tokenIndex = position790, tokenIndex790
+					if buffer[position] != rune('!') {
+						goto l793
+					}
+					position++
+					goto l790
+				l793:
+					position, tokenIndex = position790, tokenIndex790
+					if buffer[position] != rune('^') {
+						goto l794
+					}
+					position++
+					goto l790
+				l794:
+					position, tokenIndex = position790, tokenIndex790
+					if buffer[position] != rune('&') {
+						goto l788
+					}
+					position++
+				}
+			l790:
+				add(ruleUnaryOperator, position789)
+			}
+			return true
+		l788:
+			position, tokenIndex = position788, tokenIndex788
+			return false
+		},
+		/* 79 Simple <- <(List / Constant / Label / Number / String / Error)> */
+		func() bool {
+			position795, tokenIndex795 := position, tokenIndex
+			{
+				position796 := position
+				{
+					position797, tokenIndex797 := position, tokenIndex
+					if !_rules[ruleList]() {
+						goto l798
+					}
+					goto l797
+				l798:
+					position, tokenIndex = position797, tokenIndex797
+					if !_rules[ruleConstant]() {
+						goto l799
+					}
+					goto l797
+				l799:
+					position, tokenIndex = position797, tokenIndex797
+					if !_rules[ruleLabel]() {
+						goto l800
+					}
+					goto l797
+				l800:
+					position, tokenIndex = position797, tokenIndex797
+					if !_rules[ruleNumber]() {
+						goto l801
+					}
+					goto l797
+				l801:
+					position, tokenIndex = position797, tokenIndex797
+					if !_rules[ruleString]() {
+						goto l802
+					}
+					goto l797
+				l802:
+					position, tokenIndex = position797, tokenIndex797
+					if !_rules[ruleError]() {
+						goto l795
+					}
+				}
+			l797:
+				add(ruleSimple, position796)
+			}
+			return true
+		l795:
+			position, tokenIndex = position795, tokenIndex795
+			return false
+		},
+		/* 80 List <- <('[' Gap? (Expression ',' Gap?)* Expression? Gap? ']')> */
+		func() bool {
+			position803, tokenIndex803 := position, tokenIndex
+			{
+				position804 := position
+				if buffer[position] != rune('[') {
+					goto l803
+				}
+				position++
+				{
+					position805, tokenIndex805 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l805
+					}
+					goto l806
+				l805:
+					position, tokenIndex = position805, tokenIndex805
+				}
+			l806:
+			l807:
+				{
+					position808, tokenIndex808 := position, tokenIndex
+					if !_rules[ruleExpression]() {
+						goto l808
+					}
+					if buffer[position] != rune(',') {
+						goto l808
+					}
+					position++
+					{
+						position809, tokenIndex809 := position, tokenIndex
+						if !_rules[ruleGap]() {
+							goto l809
+						}
+						goto l810
+					l809:
+						position, tokenIndex = position809, tokenIndex809
+					}
+				l810:
+					goto l807
+				l808:
+					position, tokenIndex = position808, tokenIndex808
+				}
+				{
+					position811, tokenIndex811 := position, tokenIndex
+					if !_rules[ruleExpression]() {
+						goto l811
+					}
+					goto l812
+				l811:
+					position, tokenIndex = position811, tokenIndex811
+				}
+			l812:
+				{
+					position813, tokenIndex813 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l813
+					}
+					goto l814
+				l813:
+					position, tokenIndex = position813, tokenIndex813
+				}
+			l814:
+				if buffer[position] != rune(']') {
+					goto l803
+				}
+				position++
+				add(ruleList, position804)
+			}
+			return true
+		l803:
+			position, tokenIndex = position803, tokenIndex803
+			return false
+		},
+		/* 81 MethodCall <- <((Selector / Simple) '.' Label '(' Gap? (Expression ',' Gap?)* Expression? Gap? ')')> */
+		func() bool {
+			position815, tokenIndex815 := position, tokenIndex
+			{
+				position816 := position
+				{
+					position817, tokenIndex817 := position, tokenIndex
+					if !_rules[ruleSelector]() {
+						goto l818
+					}
+					goto l817
+				l818:
+					position, tokenIndex = position817, tokenIndex817
+					if !_rules[ruleSimple]() {
+						goto l815
+					}
+				}
+			l817:
+				if buffer[position] != rune('.') {
+					goto l815
+				}
+				position++
+				if !_rules[ruleLabel]() {
+					goto l815
+				}
+				if buffer[position] != rune('(') {
+					goto l815
+				}
+				position++
+				{
+					position819, tokenIndex819 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l819
+					}
+					goto l820
+				l819:
+					position, tokenIndex = position819, tokenIndex819
+				}
+			l820:
+			l821:
+				{
+					position822, tokenIndex822 := position, tokenIndex
+					if !_rules[ruleExpression]() {
+						goto l822
+					}
+					if buffer[position] != rune(',') {
+						goto l822
+					}
+					position++
+					{
+						position823, tokenIndex823 := position, tokenIndex
+						if !_rules[ruleGap]() {
+							goto l823
+						}
+						goto l824
+					l823:
+						position, tokenIndex = position823, tokenIndex823
+					}
+				l824:
+					goto l821
+				l822:
+					position, tokenIndex = position822, tokenIndex822
+				}
+				{
+					position825, tokenIndex825 := position, tokenIndex
+					if !_rules[ruleExpression]() {
+						goto l825
+					}
+					goto l826
+				l825:
+					position, tokenIndex = position825, tokenIndex825
+				}
+			l826:
+				{
+					position827, tokenIndex827 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l827
+					}
+					goto l828
+				l827:
+					position, tokenIndex = position827, tokenIndex827
+				}
+			l828:
+				if buffer[position] != rune(')') {
+					goto l815
+				}
+				position++
+				add(ruleMethodCall, position816)
+			}
+			return true
+		l815:
+			position, tokenIndex = position815, tokenIndex815
+			return false
+		},
+		/* 82 Call <- <(BuiltinCall / FunCall / MethodCall)> */
+		func() bool {
+			position829, tokenIndex829 := position, tokenIndex
+			{
+				position830 := position
+				{
+					position831, tokenIndex831 := position, tokenIndex
+					if !_rules[ruleBuiltinCall]() {
+						goto l832
+					}
+					goto l831
+				l832:
+					position, tokenIndex = position831, tokenIndex831
+					if !_rules[ruleFunCall]() {
+						goto l833
+					}
+					goto l831
+				l833:
+					position, tokenIndex = position831, tokenIndex831
+					if !_rules[ruleMethodCall]() {
+						goto l829
+					}
+				}
+			l831:
+				add(ruleCall, position830)
+			}
+			return true
+		l829:
+			position, tokenIndex = position829, tokenIndex829
+			return false
+		},
+		/* 83 BuiltinCall <- <(BuiltinFun '(' Gap? (BuiltinArg ',' Gap?)* BuiltinArg? Gap? ')')> */
+		func() bool {
+			position834, tokenIndex834 := position, tokenIndex
+			{
+				position835 := position
+				if !_rules[ruleBuiltinFun]() {
+					goto l834
+				}
+				if buffer[position] != rune('(') {
+					goto l834
+				}
+				position++
+				{
+					position836, tokenIndex836 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l836
+					}
+					goto l837
+				l836:
+					position, tokenIndex = position836, tokenIndex836
+				}
+			l837:
+			l838:
+				{
+					position839, tokenIndex839 := position, tokenIndex
+					if !_rules[ruleBuiltinArg]() {
+						goto l839
+					}
+					if buffer[position] != rune(',') {
+						goto l839
+					}
+					position++
+					{
+						position840, tokenIndex840 := position, tokenIndex
+						if !_rules[ruleGap]() {
+							goto l840
+						}
+						goto l841
+					l840:
+						position, tokenIndex = position840, tokenIndex840
+					}
+				l841:
+					goto l838
+				l839:
+					position, tokenIndex = position839, tokenIndex839
+				}
+				{
+					position842, tokenIndex842 := position, tokenIndex
+					if !_rules[ruleBuiltinArg]() {
+						goto l842
+					}
+					goto l843
+				l842:
+					position, tokenIndex = position842, tokenIndex842
+				}
+			l843:
+				{
+					position844, tokenIndex844 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l844
+					}
+					goto l845
+				l844:
+					position, tokenIndex = position844, tokenIndex844
+				}
+			l845:
+				if buffer[position] != rune(')') {
+					goto l834
+				}
+				position++
+				add(ruleBuiltinCall, position835)
+			}
+			return true
+		l834:
+			position, tokenIndex = position834, tokenIndex834
+			return false
+		},
+		/* 84 BuiltinFun <- <(('m' / 'M') ('a' / 'A') ('k' / 'K') ('e' / 'E'))> */
+		func() bool {
+			position846, tokenIndex846 := position, tokenIndex
+			{
+				position847 := position
+				{
+					position848, tokenIndex848 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l849
+					}
+					position++
+					goto l848
+				l849:
+					position, tokenIndex = position848, tokenIndex848
+					if buffer[position] != rune('M') {
+						goto l846
+					}
+					position++
+				}
+			l848:
+				{
+					position850, tokenIndex850 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l851
+					}
+					position++
+					goto l850
+				l851:
+					position, tokenIndex = position850, tokenIndex850
+					if buffer[position] != rune('A') {
+						goto l846
+					}
+					position++
+				}
+			l850:
+				{
+					position852, tokenIndex852 := position, tokenIndex
+					if buffer[position] != rune('k') {
+						goto l853
+					}
+					position++
+					goto l852
+				l853:
+					position, tokenIndex = position852, tokenIndex852
+					if buffer[position] != rune('K') {
+						goto l846
+					}
+					position++
+				}
+			l852:
+				{
+					position854, tokenIndex854 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l855
+					}
+					position++
+					goto l854
+				l855:
+					position, tokenIndex = position854, tokenIndex854
+					if buffer[position] != rune('E') {
+						goto l846
+					}
+					position++
+				}
+			l854:
+				add(ruleBuiltinFun, position847)
+			}
+			return true
+		l846:
+			position, tokenIndex = position846, tokenIndex846
+			return false
+		},
+		/* 85 BuiltinArg <- <(Type / Expression)> */
+		func() bool {
+			position856, tokenIndex856 := position, tokenIndex
+			{
+				position857 := position
+				{
+					position858, tokenIndex858 := position, tokenIndex
+					if !_rules[ruleType]() {
+						goto l859
+					}
+					goto l858
+				l859:
+					position, tokenIndex = position858, tokenIndex858
+					if !_rules[ruleExpression]() {
+						goto l856
+					}
+				}
+			l858:
+				add(ruleBuiltinArg, position857)
+			}
+			return true
+		l856:
+			position, tokenIndex = position856, tokenIndex856
+			return false
+		},
+		/* 86 FunCall <- <(FunLabel '(' Gap? (Expression ',' Gap?)* Expression? Gap? ')')> */
+		func() bool {
+			position860, tokenIndex860 := position, tokenIndex
+			{
+				position861 := position
+				if !_rules[ruleFunLabel]() {
+					goto l860
+				}
+				if buffer[position] != rune('(') {
+					goto l860
+				}
+				position++
+				{
+					position862, tokenIndex862 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l862
+					}
+					goto l863
+				l862:
+					position, tokenIndex = position862, tokenIndex862
+				}
+			l863:
+			l864:
+				{
+					position865, tokenIndex865 := position, tokenIndex
+					if !_rules[ruleExpression]() {
+						goto l865
+					}
+					if buffer[position] != rune(',') {
+						goto l865
+					}
+					position++
+					{
+						position866, tokenIndex866 := position, tokenIndex
+						if !_rules[ruleGap]() {
+							goto l866
+						}
+						goto l867
+					l866:
+						position, tokenIndex = position866, tokenIndex866
+					}
+				l867:
+					goto l864
+				l865:
+					position, tokenIndex = position865, tokenIndex865
+				}
+				{
+					position868, tokenIndex868 := position, tokenIndex
+					if !_rules[ruleExpression]() {
+						goto l868
+					}
+					goto l869
+				l868:
+					position, tokenIndex = position868, tokenIndex868
+				}
+			l869:
+				{
+					position870, tokenIndex870 := position, tokenIndex
+					if !_rules[ruleGap]() {
+						goto l870
+					}
+					goto l871
+				l870:
+					position, tokenIndex = position870, tokenIndex870
+				}
+			l871:
+				if buffer[position] != rune(')') {
+					goto l860
+				}
+				position++
+				add(ruleFunCall, position861)
+			}
+			return true
+		l860:
+			position, tokenIndex = position860, tokenIndex860
+			return false
+		},
+		/* 87 If <- <(('i' / 'I') ('f' / 'F') Whitespace Expression ':' Newline Indent Code Elif* Else?)> */
+		func() bool {
+			position872, tokenIndex872 := position, tokenIndex
+			{
+				position873 := position
+				{
+					position874, tokenIndex874 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l875
+					}
+					position++
+					goto l874
+				l875:
+					position, tokenIndex = position874, tokenIndex874
+					if buffer[position] != rune('I') {
+						goto l872
+					}
+					position++
+				}
+			l874:
+				{
+					position876, tokenIndex876 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l877
+					}
+					position++
+					goto l876
+				l877:
+					position, tokenIndex = position876, tokenIndex876
+					if buffer[position] != rune('F') {
+						goto l872
+					}
+					position++
+				}
+			l876:
+				if !_rules[ruleWhitespace]() {
+					goto l872
+				}
+				if !_rules[ruleExpression]() {
+					goto l872
+				}
+				if buffer[position] != rune(':') {
+					goto l872
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l872
+				}
+				if !_rules[ruleIndent]() {
+					goto l872
+				}
+				if !_rules[ruleCode]() {
+					goto l872
+				}
+			l878:
+				{
+					position879, tokenIndex879 := position, tokenIndex
+					if !_rules[ruleElif]() {
+						goto l879
+					}
+					goto l878
+				l879:
+					position, tokenIndex = position879, tokenIndex879
+				}
+				{
+					position880, tokenIndex880 := position, tokenIndex
+					if !_rules[ruleElse]() {
+						goto l880
+					}
+					goto l881
+				l880:
+					position, tokenIndex = position880, tokenIndex880
+				}
+			l881:
+				add(ruleIf, position873)
+			}
+			return true
+		l872:
+			position, tokenIndex = position872, tokenIndex872
+			return false
+		},
+		/* 88 Elif <- <(Newline (('e' / 'E') ('l' / 'L') ('i' / 'I') ('f' / 'F')) Whitespace Expression ':' Newline Indent Code)> */
+		func() bool {
+			position882, tokenIndex882 := position, tokenIndex
+			{
+				position883 := position
+				if !_rules[ruleNewline]() {
+					goto l882
+				}
+				{
+					position884, tokenIndex884 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l885
+					}
+					position++
+					goto l884
+				l885:
+					position, tokenIndex = position884, tokenIndex884
+					if buffer[position] != rune('E') {
+						goto l882
+					}
+					position++
+				}
+			l884:
+				{
+					position886, tokenIndex886 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l887
+					}
+					position++
+					goto l886
+				l887:
+					position, tokenIndex = position886, tokenIndex886
+					if buffer[position] != rune('L') {
+						goto l882
+					}
+					position++
+				}
+			l886:
+				{
+					position888, tokenIndex888 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l889
+					}
+					position++
+					goto l888
+				l889:
+					position, tokenIndex = position888, tokenIndex888
+					if buffer[position] != rune('I') {
+						goto l882
+					}
+					position++
+				}
+			l888:
+				{
+					position890, tokenIndex890 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l891
+					}
+					position++
+					goto l890
+				l891:
+					position, tokenIndex = position890, tokenIndex890
+					if buffer[position] != rune('F') {
+						goto l882
+					}
+					position++
+				}
+			l890:
+				if !_rules[ruleWhitespace]() {
+					goto l882
+				}
+				if !_rules[ruleExpression]() {
+					goto l882
+				}
+				if buffer[position] != rune(':') {
+					goto l882
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l882
+				}
+				if !_rules[ruleIndent]() {
+					goto l882
+				}
+				if !_rules[ruleCode]() {
+					goto l882
+				}
+				add(ruleElif, position883)
+			}
+			return true
+		l882:
+			position, tokenIndex = position882, tokenIndex882
+			return false
+		},
+		/* 89 Else <- <(Newline (('e' / 'E') ('l' / 'L') ('s' / 'S') ('e' / 'E')) ':' Newline Indent Code)> */
+		func() bool {
+			position892, tokenIndex892 := position, tokenIndex
+			{
+				position893 := position
+				if !_rules[ruleNewline]() {
+					goto l892
+				}
+				{
+					position894, tokenIndex894 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l895
+					}
+					position++
+					goto l894
+				l895:
+					position, tokenIndex = position894, tokenIndex894
+					if buffer[position] != rune('E') {
+						goto l892
+					}
+					position++
+				}
+			l894:
+				{
+					position896, tokenIndex896 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l897
+					}
+					position++
+					goto l896
+				l897:
+					position, tokenIndex = position896, tokenIndex896
+					if buffer[position] != rune('L') {
+						goto l892
+					}
+					position++
+				}
+			l896:
+				{
+					position898, tokenIndex898 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l899
+					}
+					position++
+					goto l898
+				l899:
+					position, tokenIndex = position898, tokenIndex898
+					if buffer[position] != rune('S') {
+						goto l892
+					}
+					position++
+				}
+			l898:
+				{
+					position900, tokenIndex900 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l901
+					}
+					position++
+					goto l900
+				l901:
+					position, tokenIndex = position900, tokenIndex900
+					if buffer[position] != rune('E') {
+						goto l892
+					}
+					position++
+				}
+			l900:
+				if buffer[position] != rune(':') {
+					goto l892
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l892
+				}
+				if !_rules[ruleIndent]() {
+					goto l892
+				}
+				if !_rules[ruleCode]() {
+					goto l892
+				}
+				add(ruleElse, position893)
+			}
+			return true
+		l892:
+			position, tokenIndex = position892, tokenIndex892
+			return false
+		},
+		/* 90 For <- <(ForIn / ForLoop)> */
+		func() bool {
+			position902, tokenIndex902 := position, tokenIndex
+			{
+				position903 := position
+				{
+					position904, tokenIndex904 := position, tokenIndex
+					if !_rules[ruleForIn]() {
+						goto l905
+					}
+					goto l904
+				l905:
+					position, tokenIndex = position904, tokenIndex904
+					if !_rules[ruleForLoop]() {
+						goto l902
+					}
+				}
+			l904:
+				add(ruleFor, position903)
+			}
+			return true
+		l902:
+			position, tokenIndex = position902, tokenIndex902
+			return false
+		},
+		/* 91 ForIn <- <(('f' / 'F') ('o' / 'O') ('r' / 'R') Whitespace (LowerLabel ',' Whitespace?)* LowerLabel Whitespace ('i' 'n') Whitespace Expression ':' Newline Indent Code)> */
+		func() bool {
+			position906, tokenIndex906 := position, tokenIndex
+			{
+				position907 := position
+				{
+					position908, tokenIndex908 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l909
+					}
+					position++
+					goto l908
+				l909:
+					position, tokenIndex = position908, tokenIndex908
+					if buffer[position] != rune('F') {
+						goto l906
+					}
+					position++
+				}
+			l908:
+				{
+					position910, tokenIndex910 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l911
+					}
+					position++
+					goto l910
+				l911:
+					position, tokenIndex = position910, tokenIndex910
+					if buffer[position] != rune('O') {
+						goto l906
+					}
+					position++
+				}
+			l910:
+				{
+					position912, tokenIndex912 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l913
+					}
+					position++
+					goto l912
+				l913:
+					position, tokenIndex = position912, tokenIndex912
+					if buffer[position] != rune('R') {
+						goto l906
+					}
+					position++
+				}
+			l912:
+				if !_rules[ruleWhitespace]() {
+					goto l906
+				}
+			l914:
+				{
+					position915, tokenIndex915 := position, tokenIndex
+					if !_rules[ruleLowerLabel]() {
+						goto l915
+					}
+					if buffer[position] != rune(',') {
+						goto l915
+					}
+					position++
+					{
+						position916, tokenIndex916 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l916
+						}
+						goto l917
+					l916:
+						position, tokenIndex = position916, tokenIndex916
+					}
+				l917:
+					goto l914
+				l915:
+					position, tokenIndex = position915, tokenIndex915
+				}
+				if !_rules[ruleLowerLabel]() {
+					goto l906
+				}
+				if !_rules[ruleWhitespace]() {
+					goto l906
+				}
+				if buffer[position] != rune('i') {
+					goto l906
+				}
+				position++
+				if buffer[position] != rune('n') {
+					goto l906
+				}
+				position++
+				if !_rules[ruleWhitespace]() {
+					goto l906
+				}
+				if !_rules[ruleExpression]() {
+					goto l906
+				}
+				if buffer[position] != rune(':') {
+					goto l906
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l906
+				}
+				if !_rules[ruleIndent]() {
+					goto l906
+				}
+				if !_rules[ruleCode]() {
+					goto l906
+				}
+				add(ruleForIn, position907)
+			}
+			return true
+		l906:
+			position, tokenIndex = position906, tokenIndex906
+			return false
+		},
+		/* 92 ForLoop <- <(('f' / 'F') ('o' / 'O') ('r' / 'R') Whitespace LowerLabel Whitespace ('i' 'n') Whitespace Range ':' Newline Indent Code)> */
+		func() bool {
+			position918, tokenIndex918 := position, tokenIndex
+			{
+				position919 := position
+				{
+					position920, tokenIndex920 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l921
+					}
+					position++
+					goto l920
+				l921:
+					position, tokenIndex = position920, tokenIndex920
+					if buffer[position] != rune('F') {
+						goto l918
+					}
+					position++
+				}
+			l920:
+				{
+					position922, tokenIndex922 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l923
+					}
+					position++
+					goto l922
+				l923:
+					position, tokenIndex = position922, tokenIndex922
+					if buffer[position] != rune('O') {
+						goto l918
+					}
+					position++
+				}
+			l922:
+				{
+					position924, tokenIndex924 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l925
+					}
+					position++
+					goto l924
+				l925:
+					position, tokenIndex = position924, tokenIndex924
+					if buffer[position] != rune('R') {
+						goto l918
+					}
+					position++
+				}
+			l924:
+				if !_rules[ruleWhitespace]() {
+					goto l918
+				}
+				if !_rules[ruleLowerLabel]() {
+					goto l918
+				}
+				if !_rules[ruleWhitespace]() {
+					goto l918
+				}
+				if buffer[position] != rune('i') {
+					goto l918
+				}
+				position++
+				if buffer[position] != rune('n') {
+					goto l918
+				}
+				position++
+				if !_rules[ruleWhitespace]() {
+					goto l918
+				}
+				if !_rules[ruleRange]() {
+					goto l918
+				}
+				if buffer[position] != rune(':') {
+					goto l918
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l918
+				}
+				if !_rules[ruleIndent]() {
+					goto l918
+				}
+				if !_rules[ruleCode]() {
+					goto l918
+				}
+				add(ruleForLoop, position919)
+			}
+			return true
+		l918:
+			position, tokenIndex = position918, tokenIndex918
+			return false
+		},
+		/* 93 Range <- <(Integer RangeOperator Integer)> */
+		func() bool {
+			position926, tokenIndex926 := position, tokenIndex
+			{
+				position927 := position
+				if !_rules[ruleInteger]() {
+					goto l926
+				}
+				if !_rules[ruleRangeOperator]() {
+					goto l926
+				}
+				if !_rules[ruleInteger]() {
+					goto l926
+				}
+				add(ruleRange, position927)
+			}
+			return true
+		l926:
+			position, tokenIndex = position926, tokenIndex926
+			return false
+		},
+		/* 94 RangeOperator <- <(('.' '.' '.') / ('.' '.'))> */
+		func() bool {
+			position928, tokenIndex928 := position, tokenIndex
+			{
+				position929 := position
+				{
+					position930, tokenIndex930 := position, tokenIndex
+					if buffer[position] != rune('.') {
+						goto l931
+					}
+					position++
+					if buffer[position] != rune('.') {
+						goto l931
+					}
+					position++
+					if buffer[position] != rune('.') {
+						goto l931
+					}
+					position++
+					goto l930
+				l931:
+					position, tokenIndex = position930, tokenIndex930
+					if buffer[position] != rune('.') {
+						goto l928
+					}
+					position++
+					if buffer[position] != rune('.') {
+						goto l928
+					}
+					position++
+				}
+			l930:
+				add(ruleRangeOperator, position929)
+			}
+			return true
+		l928:
+			position, tokenIndex = position928, tokenIndex928
+			return false
+		},
+		/* 95 Loop <- <(('l' / 'L') ('o' / 'O') ('o' / 'O') ('p' / 'P') ':' Newline Indent Code)> */
+		func() bool {
+			position932, tokenIndex932 := position, tokenIndex
+			{
+				position933 := position
+				{
+					position934, tokenIndex934 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l935
+					}
+					position++
+					goto l934
+				l935:
+					position, tokenIndex = position934, tokenIndex934
+					if buffer[position] != rune('L') {
+						goto l932
+					}
+					position++
+				}
+			l934:
+				{
+					position936, tokenIndex936 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l937
+					}
+					position++
+					goto l936
+				l937:
+					position, tokenIndex = position936, tokenIndex936
+					if buffer[position] != rune('O') {
+						goto l932
+					}
+					position++
+				}
+			l936:
+				{
+					position938, tokenIndex938 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l939
+					}
+					position++
+					goto l938
+				l939:
+					position, tokenIndex = position938, tokenIndex938
+					if buffer[position] != rune('O') {
+						goto l932
+					}
+					position++
+				}
+			l938:
+				{
+					position940, tokenIndex940 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l941
+					}
+					position++
+					goto l940
+				l941:
+					position, tokenIndex = position940, tokenIndex940
+					if buffer[position] != rune('P') {
+						goto l932
+					}
+					position++
+				}
+			l940:
+				if buffer[position] != rune(':') {
+					goto l932
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l932
+				}
+				if !_rules[ruleIndent]() {
+					goto l932
+				}
+				if !_rules[ruleCode]() {
+					goto l932
+				}
+				add(ruleLoop, position933)
+			}
+			return true
+		l932:
+			position, tokenIndex = position932, tokenIndex932
+			return false
+		},
+		/* 96 Break <- <(('b' / 'B') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('k' / 'K'))> */
+		func() bool {
+			position942, tokenIndex942 := position, tokenIndex
+			{
+				position943 := position
+				{
+					position944, tokenIndex944 := position, tokenIndex
+					if buffer[position] != rune('b') {
+						goto l945
+					}
+					position++
+					goto l944
+				l945:
+					position, tokenIndex = position944, tokenIndex944
+					if buffer[position] != rune('B') {
+						goto l942
+					}
+					position++
+				}
+			l944:
+				{
+					position946, tokenIndex946 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l947
+					}
+					position++
+					goto l946
+				l947:
+					position, tokenIndex = position946, tokenIndex946
+					if buffer[position] != rune('R') {
+						goto l942
+					}
+					position++
+				}
+			l946:
+				{
+					position948, tokenIndex948 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l949
+					}
+					position++
+					goto l948
+				l949:
+					position, tokenIndex = position948, tokenIndex948
+					if buffer[position] != rune('E') {
+						goto l942
+					}
+					position++
+				}
+			l948:
+				{
+					position950, tokenIndex950 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l951
+					}
+					position++
+					goto l950
+				l951:
+					position, tokenIndex = position950, tokenIndex950
+					if buffer[position] != rune('A') {
+						goto l942
+					}
+					position++
+				}
+			l950:
+				{
+					position952, tokenIndex952 := position, tokenIndex
+					if buffer[position] != rune('k') {
+						goto l953
+					}
+					position++
+					goto l952
+				l953:
+					position, tokenIndex = position952, tokenIndex952
+					if buffer[position] != rune('K') {
+						goto l942
+					}
+					position++
+				}
+			l952:
+				add(ruleBreak, position943)
+			}
+			return true
+		l942:
+			position, tokenIndex = position942, tokenIndex942
+			return false
+		},
+		/* 97 Send <- <(Expression Whitespace? ('<' '-') Whitespace? Expression)> */
+		func() bool {
+			position954, tokenIndex954 := position, tokenIndex
+			{
+				position955 := position
+				if !_rules[ruleExpression]() {
+					goto l954
+				}
+				{
+					position956, tokenIndex956 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l956
+					}
+					goto l957
+				l956:
+					position, tokenIndex = position956, tokenIndex956
+				}
+			l957:
+				if buffer[position] != rune('<') {
+					goto l954
+				}
+				position++
+				if buffer[position] != rune('-') {
+					goto l954
+				}
+				position++
+				{
+					position958, tokenIndex958 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l958
+					}
+					goto l959
+				l958:
+					position, tokenIndex = position958, tokenIndex958
+				}
+			l959:
+				if !_rules[ruleExpression]() {
+					goto l954
+				}
+				add(ruleSend, position955)
+			}
+			return true
+		l954:
+			position, tokenIndex = position954, tokenIndex954
+			return false
+		},
+		/* 98 Receive <- <('<' '-' Whitespace? Unary)> */
+		func() bool {
+			position960, tokenIndex960 := position, tokenIndex
+			{
+				position961 := position
+				if buffer[position] != rune('<') {
+					goto l960
+				}
+				position++
+				if buffer[position] != rune('-') {
+					goto l960
+				}
+				position++
+				{
+					position962, tokenIndex962 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l962
+					}
+					goto l963
+				l962:
+					position, tokenIndex = position962, tokenIndex962
+				}
+			l963:
+				if !_rules[ruleUnary]() {
+					goto l960
+				}
+				add(ruleReceive, position961)
+			}
+			return true
+		l960:
+			position, tokenIndex = position960, tokenIndex960
+			return false
+		},
+		/* 99 Go <- <(('g' / 'G') ('o' / 'O') Whitespace (GoFunc / Call))> */
+		func() bool {
+			position964, tokenIndex964 := position, tokenIndex
+			{
+				position965 := position
+				{
+					position966, tokenIndex966 := position, tokenIndex
+					if buffer[position] != rune('g') {
+						goto l967
+					}
+					position++
+					goto l966
+				l967:
+					position, tokenIndex = position966, tokenIndex966
+					if buffer[position] != rune('G') {
+						goto l964
+					}
+					position++
+				}
+			l966:
+				{
+					position968, tokenIndex968 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l969
+					}
+					position++
+					goto l968
+				l969:
+					position, tokenIndex = position968, tokenIndex968
+					if buffer[position] != rune('O') {
+						goto l964
+					}
+					position++
+				}
+			l968:
+				if !_rules[ruleWhitespace]() {
+					goto l964
+				}
+				{
+					position970, tokenIndex970 := position, tokenIndex
+					if !_rules[ruleGoFunc]() {
+						goto l971
+					}
+					goto l970
+				l971:
+					position, tokenIndex = position970, tokenIndex970
+					if !_rules[ruleCall]() {
+						goto l964
+					}
+				}
+			l970:
+				add(ruleGo, position965)
+			}
+			return true
+		l964:
+			position, tokenIndex = position964, tokenIndex964
+			return false
+		},
+		/* 100 GoFunc <- <(('-' '>' Whitespace?)? (('f' / 'F') ('u' / 'U') ('n' / 'N') ('c' / 'C')) Whitespace? ('(' ')') '!'? ':' Newline Indent Code)> */
+		func() bool {
+			position972, tokenIndex972 := position, tokenIndex
+			{
+				position973 := position
+				{
+					position974, tokenIndex974 := position, tokenIndex
+					if buffer[position] != rune('-') {
+						goto l974
+					}
+					position++
+					if buffer[position] != rune('>') {
+						goto l974
+					}
+					position++
+					{
+						position976, tokenIndex976 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l976
+						}
+						goto l977
+					l976:
+						position, tokenIndex = position976, tokenIndex976
+					}
+				l977:
+					goto l975
+				l974:
+					position, tokenIndex = position974, tokenIndex974
+				}
+			l975:
+				{
+					position978, tokenIndex978 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l979
+					}
+					position++
+					goto l978
+				l979:
+					position, tokenIndex = position978, tokenIndex978
+					if buffer[position] != rune('F') {
+						goto l972
+					}
+					position++
+				}
+			l978:
+				{
+					position980, tokenIndex980 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l981
+					}
+					position++
+					goto l980
+				l981:
+					position, tokenIndex = position980, tokenIndex980
+					if buffer[position] != rune('U') {
+						goto l972
+					}
+					position++
+				}
+			l980:
+				{
+					position982, tokenIndex982 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l983
+					}
+					position++
+					goto l982
+				l983:
+					position, tokenIndex = position982, tokenIndex982
+					if buffer[position] != rune('N') {
+						goto l972
+					}
+					position++
+				}
+			l982:
+				{
+					position984, tokenIndex984 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l985
+					}
+					position++
+					goto l984
+				l985:
+					position, tokenIndex = position984, tokenIndex984
+					if buffer[position] != rune('C') {
+						goto l972
+					}
+					position++
+				}
+			l984:
+				{
+					position986, tokenIndex986 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l986
+					}
+					goto l987
+				l986:
+					position, tokenIndex = position986, tokenIndex986
+				}
+			l987:
+				if buffer[position] != rune('(') {
+					goto l972
+				}
+				position++
+				if buffer[position] != rune(')') {
+					goto l972
+				}
+				position++
+				{
+					position988, tokenIndex988 := position, tokenIndex
+					if buffer[position] != rune('!') {
+						goto l988
+					}
+					position++
+					goto l989
+				l988:
+					position, tokenIndex = position988, tokenIndex988
+				}
+			l989:
+				if buffer[position] != rune(':') {
+					goto l972
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l972
+				}
+				if !_rules[ruleIndent]() {
+					goto l972
+				}
+				if !_rules[ruleCode]() {
+					goto l972
+				}
+				add(ruleGoFunc, position973)
+			}
+			return true
+		l972:
+			position, tokenIndex = position972, tokenIndex972
+			return false
+		},
+		/* 101 Spawn <- <(('s' / 'S') ('p' / 'P') ('a' / 'A') ('w' / 'W') ('n' / 'N') Whitespace LowerLabel ':' Newline Indent Code)> */
+		func() bool {
+			position990, tokenIndex990 := position, tokenIndex
+			{
+				position991 := position
+				{
+					position992, tokenIndex992 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l993
+					}
+					position++
+					goto l992
+				l993:
+					position, tokenIndex = position992, tokenIndex992
+					if buffer[position] != rune('S') {
+						goto l990
+					}
+					position++
+				}
+			l992:
+				{
+					position994, tokenIndex994 := position, tokenIndex
+					if buffer[position] != rune('p') {
+						goto l995
+					}
+					position++
+					goto l994
+				l995:
+					position, tokenIndex = position994, tokenIndex994
+					if buffer[position] != rune('P') {
+						goto l990
+					}
+					position++
+				}
+			l994:
+				{
+					position996, tokenIndex996 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l997
+					}
+					position++
+					goto l996
+				l997:
+					position, tokenIndex = position996, tokenIndex996
+					if buffer[position] != rune('A') {
+						goto l990
+					}
+					position++
+				}
+			l996:
+				{
+					position998, tokenIndex998 := position, tokenIndex
+					if buffer[position] != rune('w') {
+						goto l999
+					}
+					position++
+					goto l998
+				l999:
+					position, tokenIndex = position998, tokenIndex998
+					if buffer[position] != rune('W') {
+						goto l990
+					}
+					position++
+				}
+			l998:
+				{
+					position1000, tokenIndex1000 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1001
+					}
+					position++
+					goto l1000
+				l1001:
+					position, tokenIndex = position1000, tokenIndex1000
+					if buffer[position] != rune('N') {
+						goto l990
+					}
+					position++
+				}
+			l1000:
+				if !_rules[ruleWhitespace]() {
+					goto l990
+				}
+				if !_rules[ruleLowerLabel]() {
+					goto l990
+				}
+				if buffer[position] != rune(':') {
+					goto l990
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l990
+				}
+				if !_rules[ruleIndent]() {
+					goto l990
+				}
+				if !_rules[ruleCode]() {
+					goto l990
+				}
+				add(ruleSpawn, position991)
+			}
+			return true
+		l990:
+			position, tokenIndex = position990, tokenIndex990
+			return false
+		},
+		/* 102 Select <- <(('s' / 'S') ('e' / 'E') ('l' / 'L') ('e' / 'E') ('c' / 'C') ('t' / 'T') ':' Newline Indent SelectCase (Newline SelectCase)* Newline Dedent)> */
+		func() bool {
+			position1002, tokenIndex1002 := position, tokenIndex
+			{
+				position1003 := position
+				{
+					position1004, tokenIndex1004 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1005
+					}
+					position++
+					goto l1004
+				l1005:
+					position, tokenIndex = position1004, tokenIndex1004
+					if buffer[position] != rune('S') {
+						goto l1002
+					}
+					position++
+				}
+			l1004:
+				{
+					position1006, tokenIndex1006 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1007
+					}
+					position++
+					goto l1006
+				l1007:
+					position, tokenIndex = position1006, tokenIndex1006
+					if buffer[position] != rune('E') {
+						goto l1002
+					}
+					position++
+				}
+			l1006:
+				{
+					position1008, tokenIndex1008 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1009
+					}
+					position++
+					goto l1008
+				l1009:
+					position, tokenIndex = position1008, tokenIndex1008
+					if buffer[position] != rune('L') {
+						goto l1002
+					}
+					position++
+				}
+			l1008:
+				{
+					position1010, tokenIndex1010 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1011
+					}
+					position++
+					goto l1010
+				l1011:
+					position, tokenIndex = position1010, tokenIndex1010
+					if buffer[position] != rune('E') {
+						goto l1002
+					}
+					position++
+				}
+			l1010:
+				{
+					position1012, tokenIndex1012 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l1013
+					}
+					position++
+					goto l1012
+				l1013:
+					position, tokenIndex = position1012, tokenIndex1012
+					if buffer[position] != rune('C') {
+						goto l1002
+					}
+					position++
+				}
+			l1012:
+				{
+					position1014, tokenIndex1014 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1015
+					}
+					position++
+					goto l1014
+				l1015:
+					position, tokenIndex = position1014, tokenIndex1014
+					if buffer[position] != rune('T') {
+						goto l1002
+					}
+					position++
+				}
+			l1014:
+				if buffer[position] != rune(':') {
+					goto l1002
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l1002
+				}
+				if !_rules[ruleIndent]() {
+					goto l1002
+				}
+				if !_rules[ruleSelectCase]() {
+					goto l1002
+				}
+			l1016:
+				{
+					position1017, tokenIndex1017 := position, tokenIndex
+					if !_rules[ruleNewline]() {
+						goto l1017
+					}
+					if !_rules[ruleSelectCase]() {
+						goto l1017
+					}
+					goto l1016
+				l1017:
+					position, tokenIndex = position1017, tokenIndex1017
+				}
+				if !_rules[ruleNewline]() {
+					goto l1002
+				}
+				if !_rules[ruleDedent]() {
+					goto l1002
+				}
+				add(ruleSelect, position1003)
+			}
+			return true
+		l1002:
+			position, tokenIndex = position1002, tokenIndex1002
+			return false
+		},
+		/* 103 SelectCase <- <('?' Whitespace (SelectDefault / SelectReceive / Send) ':' Newline Indent Code)> */
+		func() bool {
+			position1018, tokenIndex1018 := position, tokenIndex
+			{
+				position1019 := position
+				if buffer[position] != rune('?') {
+					goto l1018
+				}
+				position++
+				if !_rules[ruleWhitespace]() {
+					goto l1018
+				}
+				{
+					position1020, tokenIndex1020 := position, tokenIndex
+					if !_rules[ruleSelectDefault]() {
+						goto l1021
+					}
+					goto l1020
+				l1021:
+					position, tokenIndex = position1020, tokenIndex1020
+					if !_rules[ruleSelectReceive]() {
+						goto l1022
+					}
+					goto l1020
+				l1022:
+					position, tokenIndex = position1020, tokenIndex1020
+					if !_rules[ruleSend]() {
+						goto l1018
+					}
+				}
+			l1020:
+				if buffer[position] != rune(':') {
+					goto l1018
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l1018
+				}
+				if !_rules[ruleIndent]() {
+					goto l1018
+				}
+				if !_rules[ruleCode]() {
+					goto l1018
+				}
+				add(ruleSelectCase, position1019)
+			}
+			return true
+		l1018:
+			position, tokenIndex = position1018, tokenIndex1018
+			return false
+		},
+		/* 104 SelectReceive <- <((LowerLabel Whitespace ':'? '=' Whitespace)? Receive)> */
+		func() bool {
+			position1023, tokenIndex1023 := position, tokenIndex
+			{
+				position1024 := position
+				{
+					position1025, tokenIndex1025 := position, tokenIndex
+					if !_rules[ruleLowerLabel]() {
+						goto l1025
+					}
+					if !_rules[ruleWhitespace]() {
+						goto l1025
+					}
+					{
+						position1027, tokenIndex1027 := position, tokenIndex
+						if buffer[position] != rune(':') {
+							goto l1027
+						}
+						position++
+						goto l1028
+					l1027:
+						position, tokenIndex = position1027, tokenIndex1027
+					}
+				l1028:
+					if buffer[position] != rune('=') {
+						goto l1025
+					}
+					position++
+					if !_rules[ruleWhitespace]() {
+						goto l1025
+					}
+					goto l1026
+				l1025:
+					position, tokenIndex = position1025, tokenIndex1025
+				}
+			l1026:
+				if !_rules[ruleReceive]() {
+					goto l1023
+				}
+				add(ruleSelectReceive, position1024)
+			}
+			return true
+		l1023:
+			position, tokenIndex = position1023, tokenIndex1023
+			return false
+		},
+		/* 105 SelectDefault <- <(('d' / 'D') ('e' / 'E') ('f' / 'F') ('a' / 'A') ('u' / 'U') ('l' / 'L') ('t' / 'T'))> */
+		func() bool {
+			position1029, tokenIndex1029 := position, tokenIndex
+			{
+				position1030 := position
+				{
+					position1031, tokenIndex1031 := position, tokenIndex
+					if buffer[position] != rune('d') {
+						goto l1032
+					}
+					position++
+					goto l1031
+				l1032:
+					position, tokenIndex = position1031, tokenIndex1031
+					if buffer[position] != rune('D') {
+						goto l1029
+					}
+					position++
+				}
+			l1031:
+				{
+					position1033, tokenIndex1033 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1034
+					}
+					position++
+					goto l1033
+				l1034:
+					position, tokenIndex = position1033, tokenIndex1033
+					if buffer[position] != rune('E') {
+						goto l1029
+					}
+					position++
+				}
+			l1033:
+				{
+					position1035, tokenIndex1035 := position, tokenIndex
+					if buffer[position] != rune('f') {
+						goto l1036
+					}
+					position++
+					goto l1035
+				l1036:
+					position, tokenIndex = position1035, tokenIndex1035
+					if buffer[position] != rune('F') {
+						goto l1029
+					}
+					position++
+				}
+			l1035:
+				{
+					position1037, tokenIndex1037 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l1038
+					}
+					position++
+					goto l1037
+				l1038:
+					position, tokenIndex = position1037, tokenIndex1037
+					if buffer[position] != rune('A') {
+						goto l1029
+					}
+					position++
+				}
+			l1037:
+				{
+					position1039, tokenIndex1039 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l1040
+					}
+					position++
+					goto l1039
+				l1040:
+					position, tokenIndex = position1039, tokenIndex1039
+					if buffer[position] != rune('U') {
+						goto l1029
+					}
+					position++
+				}
+			l1039:
+				{
+					position1041, tokenIndex1041 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1042
+					}
+					position++
+					goto l1041
+				l1042:
+					position, tokenIndex = position1041, tokenIndex1041
+					if buffer[position] != rune('L') {
+						goto l1029
+					}
+					position++
+				}
+			l1041:
+				{
+					position1043, tokenIndex1043 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1044
+					}
+					position++
+					goto l1043
+				l1044:
+					position, tokenIndex = position1043, tokenIndex1043
+					if buffer[position] != rune('T') {
+						goto l1029
+					}
+					position++
+				}
+			l1043:
+				add(ruleSelectDefault, position1030)
+			}
+			return true
+		l1029:
+			position, tokenIndex = position1029, tokenIndex1029
+			return false
+		},
+		/* 106 Match <- <(('m' / 'M') ('a' / 'A') ('t' / 'T') ('c' / 'C') ('h' / 'H') Whitespace Expression ':' Newline Indent MatchArm (Newline MatchArm)* Newline Dedent)> */
+		func() bool {
+			position1045, tokenIndex1045 := position, tokenIndex
+			{
+				position1046 := position
+				{
+					position1047, tokenIndex1047 := position, tokenIndex
+					if buffer[position] != rune('m') {
+						goto l1048
+					}
+					position++
+					goto l1047
+				l1048:
+					position, tokenIndex = position1047, tokenIndex1047
+					if buffer[position] != rune('M') {
+						goto l1045
+					}
+					position++
+				}
+			l1047:
+				{
+					position1049, tokenIndex1049 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l1050
+					}
+					position++
+					goto l1049
+				l1050:
+					position, tokenIndex = position1049, tokenIndex1049
+					if buffer[position] != rune('A') {
+						goto l1045
+					}
+					position++
+				}
+			l1049:
+				{
+					position1051, tokenIndex1051 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1052
+					}
+					position++
+					goto l1051
+				l1052:
+					position, tokenIndex = position1051, tokenIndex1051
+					if buffer[position] != rune('T') {
+						goto l1045
+					}
+					position++
+				}
+			l1051:
+				{
+					position1053, tokenIndex1053 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l1054
+					}
+					position++
+					goto l1053
+				l1054:
+					position, tokenIndex = position1053, tokenIndex1053
+					if buffer[position] != rune('C') {
+						goto l1045
+					}
+					position++
+				}
+			l1053:
+				{
+					position1055, tokenIndex1055 := position, tokenIndex
+					if buffer[position] != rune('h') {
+						goto l1056
+					}
+					position++
+					goto l1055
+				l1056:
+					position, tokenIndex = position1055, tokenIndex1055
+					if buffer[position] != rune('H') {
+						goto l1045
+					}
+					position++
+				}
+			l1055:
+				if !_rules[ruleWhitespace]() {
+					goto l1045
+				}
+				if !_rules[ruleExpression]() {
+					goto l1045
+				}
+				if buffer[position] != rune(':') {
+					goto l1045
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l1045
+				}
+				if !_rules[ruleIndent]() {
+					goto l1045
+				}
+				if !_rules[ruleMatchArm]() {
+					goto l1045
+				}
+			l1057:
+				{
+					position1058, tokenIndex1058 := position, tokenIndex
+					if !_rules[ruleNewline]() {
+						goto l1058
+					}
+					if !_rules[ruleMatchArm]() {
+						goto l1058
+					}
+					goto l1057
+				l1058:
+					position, tokenIndex = position1058, tokenIndex1058
+				}
+				if !_rules[ruleNewline]() {
+					goto l1045
+				}
+				if !_rules[ruleDedent]() {
+					goto l1045
 				}
-			l188:
-				add(ruleFunLabel, position181)
+				add(ruleMatch, position1046)
 			}
 			return true
-		l180:
-			position, tokenIndex = position180, tokenIndex180
+		l1045:
+			position, tokenIndex = position1045, tokenIndex1045
 			return false
 		},
-		/* 20 Type <- <(PointerType / FunType / GenericType / CapitalLabel / BuiltinType)> */
+		/* 107 MatchArm <- <('?' Whitespace Pattern (Whitespace (('i' / 'I') ('f' / 'F')) Whitespace Expression)? ':' Newline Indent Code)> */
 		func() bool {
-			position191, tokenIndex191 := position, tokenIndex
+			position1059, tokenIndex1059 := position, tokenIndex
 			{
-				position192 := position
+				position1060 := position
+				if buffer[position] != rune('?') {
+					goto l1059
+				}
+				position++
+				if !_rules[ruleWhitespace]() {
+					goto l1059
+				}
+				if !_rules[rulePattern]() {
+					goto l1059
+				}
 				{
-					position193, tokenIndex193 := position, tokenIndex
+					position1061, tokenIndex1061 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l1061
+					}
 					{
-						position195 := position
-						if buffer[position] != rune('*') {
-							goto l194
+						position1063, tokenIndex1063 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l1064
 						}
 						position++
-						if !_rules[ruleType]() {
-							goto l194
+						goto l1063
+					l1064:
+						position, tokenIndex = position1063, tokenIndex1063
+						if buffer[position] != rune('I') {
+							goto l1061
 						}
-						add(rulePointerType, position195)
+						position++
 					}
-					goto l193
-				l194:
-					position, tokenIndex = position193, tokenIndex193
+				l1063:
 					{
-						position197 := position
-					l198:
-						{
-							position199, tokenIndex199 := position, tokenIndex
-							if !_rules[ruleTypeExceptFun]() {
-								goto l199
-							}
-							if buffer[position] != rune(',') {
-								goto l199
-							}
-							position++
-							{
-								position200, tokenIndex200 := position, tokenIndex
-								if !_rules[ruleWhitespace]() {
-									goto l200
-								}
-								goto l201
-							l200:
-								position, tokenIndex = position200, tokenIndex200
-							}
-						l201:
-							goto l198
-						l199:
-							position, tokenIndex = position199, tokenIndex199
-						}
-						if !_rules[ruleTypeExceptFun]() {
-							goto l196
-						}
-						if !_rules[ruleWhitespace]() {
-							goto l196
-						}
-						if buffer[position] != rune('-') {
-							goto l196
+						position1065, tokenIndex1065 := position, tokenIndex
+						if buffer[position] != rune('f') {
+							goto l1066
 						}
 						position++
-						if buffer[position] != rune('>') {
-							goto l196
+						goto l1065
+					l1066:
+						position, tokenIndex = position1065, tokenIndex1065
+						if buffer[position] != rune('F') {
+							goto l1061
 						}
 						position++
-						if !_rules[ruleWhitespace]() {
-							goto l196
+					}
+				l1065:
+					if !_rules[ruleWhitespace]() {
+						goto l1061
+					}
+					if !_rules[ruleExpression]() {
+						goto l1061
+					}
+					goto l1062
+				l1061:
+					position, tokenIndex = position1061, tokenIndex1061
+				}
+			l1062:
+				if buffer[position] != rune(':') {
+					goto l1059
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l1059
+				}
+				if !_rules[ruleIndent]() {
+					goto l1059
+				}
+				if !_rules[ruleCode]() {
+					goto l1059
+				}
+				add(ruleMatchArm, position1060)
+			}
+			return true
+		l1059:
+			position, tokenIndex = position1059, tokenIndex1059
+			return false
+		},
+		/* 108 Pattern <- <(RecordPattern / VariantPattern / SubPattern)> */
+		func() bool {
+			position1067, tokenIndex1067 := position, tokenIndex
+			{
+				position1068 := position
+				{
+					position1069, tokenIndex1069 := position, tokenIndex
+					if !_rules[ruleRecordPattern]() {
+						goto l1070
+					}
+					goto l1069
+				l1070:
+					position, tokenIndex = position1069, tokenIndex1069
+					if !_rules[ruleVariantPattern]() {
+						goto l1071
+					}
+					goto l1069
+				l1071:
+					position, tokenIndex = position1069, tokenIndex1069
+					if !_rules[ruleSubPattern]() {
+						goto l1067
+					}
+				}
+			l1069:
+				add(rulePattern, position1068)
+			}
+			return true
+		l1067:
+			position, tokenIndex = position1067, tokenIndex1067
+			return false
+		},
+		/* 109 VariantPattern <- <(CapitalLabel ('(' (SubPattern ',' Whitespace?)* SubPattern? ')')?)> */
+		func() bool {
+			position1072, tokenIndex1072 := position, tokenIndex
+			{
+				position1073 := position
+				if !_rules[ruleCapitalLabel]() {
+					goto l1072
+				}
+				{
+					position1074, tokenIndex1074 := position, tokenIndex
+					if buffer[position] != rune('(') {
+						goto l1074
+					}
+					position++
+				l1076:
+					{
+						position1077, tokenIndex1077 := position, tokenIndex
+						if !_rules[ruleSubPattern]() {
+							goto l1077
 						}
-						if !_rules[ruleTypeExceptFun]() {
-							goto l196
+						if buffer[position] != rune(',') {
+							goto l1077
 						}
-						add(ruleFunType, position197)
-					}
-					goto l193
-				l196:
-					position, tokenIndex = position193, tokenIndex193
-					if !_rules[ruleGenericType]() {
-						goto l202
+						position++
+						{
+							position1078, tokenIndex1078 := position, tokenIndex
+							if !_rules[ruleWhitespace]() {
+								goto l1078
+							}
+							goto l1079
+						l1078:
+							position, tokenIndex = position1078, tokenIndex1078
+						}
+					l1079:
+						goto l1076
+					l1077:
+						position, tokenIndex = position1077, tokenIndex1077
 					}
-					goto l193
-				l202:
-					position, tokenIndex = position193, tokenIndex193
-					if !_rules[ruleCapitalLabel]() {
-						goto l203
+					{
+						position1080, tokenIndex1080 := position, tokenIndex
+						if !_rules[ruleSubPattern]() {
+							goto l1080
+						}
+						goto l1081
+					l1080:
+						position, tokenIndex = position1080, tokenIndex1080
 					}
-					goto l193
-				l203:
-					position, tokenIndex = position193, tokenIndex193
-					if !_rules[ruleBuiltinType]() {
-						goto l191
+				l1081:
+					if buffer[position] != rune(')') {
+						goto l1074
 					}
+					position++
+					goto l1075
+				l1074:
+					position, tokenIndex = position1074, tokenIndex1074
 				}
-			l193:
-				add(ruleType, position192)
+			l1075:
+				add(ruleVariantPattern, position1073)
 			}
 			return true
-		l191:
-			position, tokenIndex = position191, tokenIndex191
+		l1072:
+			position, tokenIndex = position1072, tokenIndex1072
 			return false
 		},
-		/* 21 PointerType <- <('*' Type)> */
-		nil,
-		/* 22 FunType <- <((TypeExceptFun ',' Whitespace?)* TypeExceptFun Whitespace ('-' '>') Whitespace TypeExceptFun)> */
-		nil,
-		/* 23 GenericType <- <(CapitalLabel '<' (CapitalLabel ',' Whitespace?)* CapitalLabel '>')> */
+		/* 110 RecordPattern <- <(CapitalLabel '{' (FieldPattern ',' Whitespace?)* FieldPattern? '}')> */
 		func() bool {
-			position206, tokenIndex206 := position, tokenIndex
+			position1082, tokenIndex1082 := position, tokenIndex
 			{
-				position207 := position
+				position1083 := position
 				if !_rules[ruleCapitalLabel]() {
-					goto l206
+					goto l1082
 				}
-				if buffer[position] != rune('<') {
-					goto l206
+				if buffer[position] != rune('{') {
+					goto l1082
 				}
 				position++
-			l208:
+			l1084:
 				{
-					position209, tokenIndex209 := position, tokenIndex
-					if !_rules[ruleCapitalLabel]() {
-						goto l209
+					position1085, tokenIndex1085 := position, tokenIndex
+					if !_rules[ruleFieldPattern]() {
+						goto l1085
 					}
 					if buffer[position] != rune(',') {
-						goto l209
+						goto l1085
 					}
 					position++
 					{
-						position210, tokenIndex210 := position, tokenIndex
+						position1086, tokenIndex1086 := position, tokenIndex
 						if !_rules[ruleWhitespace]() {
-							goto l210
+							goto l1086
 						}
-						goto l211
-					l210:
-						position, tokenIndex = position210, tokenIndex210
+						goto l1087
+					l1086:
+						position, tokenIndex = position1086, tokenIndex1086
 					}
-				l211:
-					goto l208
-				l209:
-					position, tokenIndex = position209, tokenIndex209
+				l1087:
+					goto l1084
+				l1085:
+					position, tokenIndex = position1085, tokenIndex1085
 				}
-				if !_rules[ruleCapitalLabel]() {
-					goto l206
+				{
+					position1088, tokenIndex1088 := position, tokenIndex
+					if !_rules[ruleFieldPattern]() {
+						goto l1088
+					}
+					goto l1089
+				l1088:
+					position, tokenIndex = position1088, tokenIndex1088
 				}
-				if buffer[position] != rune('>') {
-					goto l206
+			l1089:
+				if buffer[position] != rune('}') {
+					goto l1082
 				}
 				position++
-				add(ruleGenericType, position207)
+				add(ruleRecordPattern, position1083)
 			}
 			return true
-		l206:
-			position, tokenIndex = position206, tokenIndex206
+		l1082:
+			position, tokenIndex = position1082, tokenIndex1082
 			return false
 		},
-		/* 24 BuiltinType <- <(BuiltinSlice / ((&('M' | 'm') BuiltinMap) | (&('[') BuiltinArray) | (&('I' | 'R' | 'S' | 'i' | 'r' | 's') BuiltinSimple)))> */
+		/* 111 FieldPattern <- <((CapitalLabel / LowerLabel) (':' Whitespace? SubPattern)?)> */
 		func() bool {
-			position212, tokenIndex212 := position, tokenIndex
+			position1090, tokenIndex1090 := position, tokenIndex
 			{
-				position213 := position
+				position1091 := position
+				{
+					position1092, tokenIndex1092 := position, tokenIndex
+					if !_rules[ruleCapitalLabel]() {
+						goto l1093
+					}
+					goto l1092
+				l1093:
+					position, tokenIndex = position1092, tokenIndex1092
+					if !_rules[ruleLowerLabel]() {
+						goto l1090
+					}
+				}
+			l1092:
+				{
+					position1094, tokenIndex1094 := position, tokenIndex
+					if buffer[position] != rune(':') {
+						goto l1094
+					}
+					position++
+					{
+						position1096, tokenIndex1096 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l1096
+						}
+						goto l1097
+					l1096:
+						position, tokenIndex = position1096, tokenIndex1096
+					}
+				l1097:
+					if !_rules[ruleSubPattern]() {
+						goto l1094
+					}
+					goto l1095
+				l1094:
+					position, tokenIndex = position1094, tokenIndex1094
+				}
+			l1095:
+				add(ruleFieldPattern, position1091)
+			}
+			return true
+		l1090:
+			position, tokenIndex = position1090, tokenIndex1090
+			return false
+		},
+		/* 112 SubPattern <- <(Wildcard / PatternLiteral / LowerLabel)> */
+		func() bool {
+			position1098, tokenIndex1098 := position, tokenIndex
+			{
+				position1099 := position
+				{
+					position1100, tokenIndex1100 := position, tokenIndex
+					if !_rules[ruleWildcard]() {
+						goto l1101
+					}
+					goto l1100
+				l1101:
+					position, tokenIndex = position1100, tokenIndex1100
+					if !_rules[rulePatternLiteral]() {
+						goto l1102
+					}
+					goto l1100
+				l1102:
+					position, tokenIndex = position1100, tokenIndex1100
+					if !_rules[ruleLowerLabel]() {
+						goto l1098
+					}
+				}
+			l1100:
+				add(ruleSubPattern, position1099)
+			}
+			return true
+		l1098:
+			position, tokenIndex = position1098, tokenIndex1098
+			return false
+		},
+		/* 113 Wildcard <- <('_' !([a-z] / [0-9] / '_'))> */
+		func() bool {
+			position1103, tokenIndex1103 := position, tokenIndex
+			{
+				position1104 := position
+				if buffer[position] != rune('_') {
+					goto l1103
+				}
+				position++
+				{
+					position1105, tokenIndex1105 := position, tokenIndex
+					{
+						position1106, tokenIndex1106 := position, tokenIndex
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l1107
+						}
+						position++
+						goto l1106
+					l1107:
+						position, tokenIndex = position1106, tokenIndex1106
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l1108
+						}
+						position++
+						goto l1106
+					l1108:
+						position, tokenIndex = position1106, tokenIndex1106
+						if buffer[position] != rune('_') {
+							goto l1105
+						}
+						position++
+					}
+				l1106:
+					goto l1103
+				l1105:
+					position, tokenIndex = position1105, tokenIndex1105
+				}
+				add(ruleWildcard, position1104)
+			}
+			return true
+		l1103:
+			position, tokenIndex = position1103, tokenIndex1103
+			return false
+		},
+		/* 114 PatternLiteral <- <(('-'? Number) / String / Constant)> */
+		func() bool {
+			position1109, tokenIndex1109 := position, tokenIndex
+			{
+				position1110 := position
+				{
+					position1111, tokenIndex1111 := position, tokenIndex
+					{
+						position1113, tokenIndex1113 := position, tokenIndex
+						if buffer[position] != rune('-') {
+							goto l1113
+						}
+						position++
+						goto l1114
+					l1113:
+						position, tokenIndex = position1113, tokenIndex1113
+					}
+				l1114:
+					if !_rules[ruleNumber]() {
+						goto l1112
+					}
+					goto l1111
+				l1112:
+					position, tokenIndex = position1111, tokenIndex1111
+					if !_rules[ruleString]() {
+						goto l1115
+					}
+					goto l1111
+				l1115:
+					position, tokenIndex = position1111, tokenIndex1111
+					if !_rules[ruleConstant]() {
+						goto l1109
+					}
+				}
+			l1111:
+				add(rulePatternLiteral, position1110)
+			}
+			return true
+		l1109:
+			position, tokenIndex = position1109, tokenIndex1109
+			return false
+		},
+		/* 115 On <- <(('o' / 'O') ('n' / 'N') Whitespace FunLabel (Whitespace (('a' / 'A') ('s' / 'S')) Whitespace CapitalLabel)? ':' Newline Indent Code)> */
+		func() bool {
+			position1116, tokenIndex1116 := position, tokenIndex
+			{
+				position1117 := position
+				{
+					position1118, tokenIndex1118 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l1119
+					}
+					position++
+					goto l1118
+				l1119:
+					position, tokenIndex = position1118, tokenIndex1118
+					if buffer[position] != rune('O') {
+						goto l1116
+					}
+					position++
+				}
+			l1118:
+				{
+					position1120, tokenIndex1120 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1121
+					}
+					position++
+					goto l1120
+				l1121:
+					position, tokenIndex = position1120, tokenIndex1120
+					if buffer[position] != rune('N') {
+						goto l1116
+					}
+					position++
+				}
+			l1120:
+				if !_rules[ruleWhitespace]() {
+					goto l1116
+				}
+				if !_rules[ruleFunLabel]() {
+					goto l1116
+				}
 				{
-					position214, tokenIndex214 := position, tokenIndex
+					position1122, tokenIndex1122 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l1122
+					}
 					{
-						position216 := position
-						if buffer[position] != rune('[') {
-							goto l215
+						position1124, tokenIndex1124 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l1125
 						}
 						position++
-						if buffer[position] != rune(']') {
-							goto l215
+						goto l1124
+					l1125:
+						position, tokenIndex = position1124, tokenIndex1124
+						if buffer[position] != rune('A') {
+							goto l1122
 						}
 						position++
-						if !_rules[ruleType]() {
-							goto l215
-						}
-						add(ruleBuiltinSlice, position216)
 					}
-					goto l214
-				l215:
-					position, tokenIndex = position214, tokenIndex214
+				l1124:
 					{
-						switch buffer[position] {
-						case 'M', 'm':
-							{
-								position218 := position
-								{
-									position219, tokenIndex219 := position, tokenIndex
-									if buffer[position] != rune('m') {
-										goto l220
-									}
-									position++
-									goto l219
-								l220:
-									position, tokenIndex = position219, tokenIndex219
-									if buffer[position] != rune('M') {
-										goto l212
-									}
-									position++
-								}
-							l219:
-								{
-									position221, tokenIndex221 := position, tokenIndex
-									if buffer[position] != rune('a') {
-										goto l222
-									}
-									position++
-									goto l221
-								l222:
-									position, tokenIndex = position221, tokenIndex221
-									if buffer[position] != rune('A') {
-										goto l212
-									}
-									position++
-								}
-							l221:
-								{
-									position223, tokenIndex223 := position, tokenIndex
-									if buffer[position] != rune('p') {
-										goto l224
-									}
-									position++
-									goto l223
-								l224:
-									position, tokenIndex = position223, tokenIndex223
-									if buffer[position] != rune('P') {
-										goto l212
-									}
-									position++
-								}
-							l223:
-								if buffer[position] != rune('[') {
-									goto l212
-								}
-								position++
-								if !_rules[ruleType]() {
-									goto l212
-								}
-								if buffer[position] != rune(']') {
-									goto l212
-								}
-								position++
-								if !_rules[ruleType]() {
-									goto l212
-								}
-								add(ruleBuiltinMap, position218)
-							}
-							break
-						case '[':
-							{
-								position225 := position
-								if buffer[position] != rune('[') {
-									goto l212
-								}
-								position++
-								if !_rules[ruleInteger]() {
-									goto l212
-								}
-								if buffer[position] != rune(']') {
-									goto l212
-								}
-								position++
-								if !_rules[ruleType]() {
-									goto l212
-								}
-								add(ruleBuiltinArray, position225)
-							}
-							break
-						default:
-							{
-								position226 := position
-								{
-									switch buffer[position] {
-									case 'S', 's':
-										{
-											position228, tokenIndex228 := position, tokenIndex
-											if buffer[position] != rune('s') {
-												goto l229
-											}
-											position++
-											goto l228
-										l229:
-											position, tokenIndex = position228, tokenIndex228
-											if buffer[position] != rune('S') {
-												goto l212
-											}
-											position++
-										}
-									l228:
-										{
-											position230, tokenIndex230 := position, tokenIndex
-											if buffer[position] != rune('t') {
-												goto l231
-											}
-											position++
-											goto l230
-										l231:
-											position, tokenIndex = position230, tokenIndex230
-											if buffer[position] != rune('T') {
-												goto l212
-											}
-											position++
-										}
-									l230:
-										{
-											position232, tokenIndex232 := position, tokenIndex
-											if buffer[position] != rune('r') {
-												goto l233
-											}
-											position++
-											goto l232
-										l233:
-											position, tokenIndex = position232, tokenIndex232
-											if buffer[position] != rune('R') {
-												goto l212
-											}
-											position++
-										}
-									l232:
-										{
-											position234, tokenIndex234 := position, tokenIndex
-											if buffer[position] != rune('i') {
-												goto l235
-											}
-											position++
-											goto l234
-										l235:
-											position, tokenIndex = position234, tokenIndex234
-											if buffer[position] != rune('I') {
-												goto l212
-											}
-											position++
-										}
-									l234:
-										{
-											position236, tokenIndex236 := position, tokenIndex
-											if buffer[position] != rune('n') {
-												goto l237
-											}
-											position++
-											goto l236
-										l237:
-											position, tokenIndex = position236, tokenIndex236
-											if buffer[position] != rune('N') {
-												goto l212
-											}
-											position++
-										}
-									l236:
-										{
-											position238, tokenIndex238 := position, tokenIndex
-											if buffer[position] != rune('g') {
-												goto l239
-											}
-											position++
-											goto l238
-										l239:
-											position, tokenIndex = position238, tokenIndex238
-											if buffer[position] != rune('G') {
-												goto l212
-											}
-											position++
-										}
-									l238:
-										break
-									case 'R', 'r':
-										{
-											position240, tokenIndex240 := position, tokenIndex
-											if buffer[position] != rune('r') {
-												goto l241
-											}
-											position++
-											goto l240
-										l241:
-											position, tokenIndex = position240, tokenIndex240
-											if buffer[position] != rune('R') {
-												goto l212
-											}
-											position++
-										}
-									l240:
-										{
-											position242, tokenIndex242 := position, tokenIndex
-											if buffer[position] != rune('e') {
-												goto l243
-											}
-											position++
-											goto l242
-										l243:
-											position, tokenIndex = position242, tokenIndex242
-											if buffer[position] != rune('E') {
-												goto l212
-											}
-											position++
-										}
-									l242:
-										{
-											position244, tokenIndex244 := position, tokenIndex
-											if buffer[position] != rune('a') {
-												goto l245
-											}
-											position++
-											goto l244
-										l245:
-											position, tokenIndex = position244, tokenIndex244
-											if buffer[position] != rune('A') {
-												goto l212
-											}
-											position++
-										}
-									l244:
-										{
-											position246, tokenIndex246 := position, tokenIndex
-											if buffer[position] != rune('l') {
-												goto l247
-											}
-											position++
-											goto l246
-										l247:
-											position, tokenIndex = position246, tokenIndex246
-											if buffer[position] != rune('L') {
-												goto l212
-											}
-											position++
-										}
-									l246:
-										break
-									default:
-										{
-											position248, tokenIndex248 := position, tokenIndex
-											if buffer[position] != rune('i') {
-												goto l249
-											}
-											position++
-											goto l248
-										l249:
-											position, tokenIndex = position248, tokenIndex248
-											if buffer[position] != rune('I') {
-												goto l212
-											}
-											position++
-										}
-									l248:
-										{
-											position250, tokenIndex250 := position, tokenIndex
-											if buffer[position] != rune('n') {
-												goto l251
-											}
-											position++
-											goto l250
-										l251:
-											position, tokenIndex = position250, tokenIndex250
-											if buffer[position] != rune('N') {
-												goto l212
-											}
-											position++
-										}
-									l250:
-										{
-											position252, tokenIndex252 := position, tokenIndex
-											if buffer[position] != rune('t') {
-												goto l253
-											}
-											position++
-											goto l252
-										l253:
-											position, tokenIndex = position252, tokenIndex252
-											if buffer[position] != rune('T') {
-												goto l212
-											}
-											position++
-										}
-									l252:
-										break
-									}
-								}
-
-								add(ruleBuiltinSimple, position226)
-							}
-							break
+						position1126, tokenIndex1126 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l1127
+						}
+						position++
+						goto l1126
+					l1127:
+						position, tokenIndex = position1126, tokenIndex1126
+						if buffer[position] != rune('S') {
+							goto l1122
 						}
+						position++
 					}
-
+				l1126:
+					if !_rules[ruleWhitespace]() {
+						goto l1122
+					}
+					if !_rules[ruleCapitalLabel]() {
+						goto l1122
+					}
+					goto l1123
+				l1122:
+					position, tokenIndex = position1122, tokenIndex1122
 				}
-			l214:
-				add(ruleBuiltinType, position213)
+			l1123:
+				if buffer[position] != rune(':') {
+					goto l1116
+				}
+				position++
+				if !_rules[ruleNewline]() {
+					goto l1116
+				}
+				if !_rules[ruleIndent]() {
+					goto l1116
+				}
+				if !_rules[ruleCode]() {
+					goto l1116
+				}
+				add(ruleOn, position1117)
 			}
 			return true
-		l212:
-			position, tokenIndex = position212, tokenIndex212
+		l1116:
+			position, tokenIndex = position1116, tokenIndex1116
 			return false
 		},
-		/* 25 BuiltinSimple <- <((&('S' | 's') (('s' / 'S') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('n' / 'N') ('g' / 'G'))) | (&('R' | 'r') (('r' / 'R') ('e' / 'E') ('a' / 'A') ('l' / 'L'))) | (&('I' | 'i') (('i' / 'I') ('n' / 'N') ('t' / 'T'))))> */
-		nil,
-		/* 26 BuiltinSlice <- <('[' ']' Type)> */
-		nil,
-		/* 27 BuiltinArray <- <('[' Integer ']' Type)> */
-		nil,
-		/* 28 BuiltinMap <- <(('m' / 'M') ('a' / 'A') ('p' / 'P') '[' Type ']' Type)> */
-		nil,
-		/* 29 TypeExceptFun <- <(GenericType / BuiltinType / CapitalLabel)> */
+		/* 116 Return <- <(ReturnValue / ReturnError / Escalator)> */
 		func() bool {
-			position258, tokenIndex258 := position, tokenIndex
+			position1128, tokenIndex1128 := position, tokenIndex
 			{
-				position259 := position
+				position1129 := position
 				{
-					position260, tokenIndex260 := position, tokenIndex
-					if !_rules[ruleGenericType]() {
-						goto l261
+					position1130, tokenIndex1130 := position, tokenIndex
+					if !_rules[ruleReturnValue]() {
+						goto l1131
 					}
-					goto l260
-				l261:
-					position, tokenIndex = position260, tokenIndex260
-					if !_rules[ruleBuiltinType]() {
-						goto l262
+					goto l1130
+				l1131:
+					position, tokenIndex = position1130, tokenIndex1130
+					if !_rules[ruleReturnError]() {
+						goto l1132
 					}
-					goto l260
-				l262:
-					position, tokenIndex = position260, tokenIndex260
-					if !_rules[ruleCapitalLabel]() {
-						goto l258
+					goto l1130
+				l1132:
+					position, tokenIndex = position1130, tokenIndex1130
+					if !_rules[ruleEscalator]() {
+						goto l1128
 					}
 				}
-			l260:
-				add(ruleTypeExceptFun, position259)
+			l1130:
+				add(ruleReturn, position1129)
 			}
 			return true
-		l258:
-			position, tokenIndex = position258, tokenIndex258
+		l1128:
+			position, tokenIndex = position1128, tokenIndex1128
 			return false
 		},
-		/* 30 Code <- <((Line Newline)+ Dedent)> */
+		/* 117 ReturnValue <- <(('r' / 'R') ('e' / 'E') ('t' / 'T') ('u' / 'U') ('r' / 'R') ('n' / 'N') (Whitespace? Expression)?)> */
 		func() bool {
-			position263, tokenIndex263 := position, tokenIndex
+			position1133, tokenIndex1133 := position, tokenIndex
 			{
-				position264 := position
+				position1134 := position
 				{
-					position267 := position
-					{
-						position268, tokenIndex268 := position, tokenIndex
-						{
-							position270 := position
-							if !_rules[ruleExpression]() {
-								goto l269
-							}
-							if buffer[position] != rune('[') {
-								goto l269
-							}
-							position++
-							if !_rules[ruleExpression]() {
-								goto l269
-							}
-							if buffer[position] != rune(']') {
-								goto l269
-							}
-							position++
-							if !_rules[ruleWhitespace]() {
-								goto l269
-							}
-							if buffer[position] != rune('=') {
-								goto l269
-							}
-							position++
-							if !_rules[ruleWhitespace]() {
-								goto l269
-							}
-							if !_rules[ruleExpression]() {
-								goto l269
-							}
-							add(ruleIndexAssignment, position270)
-						}
-						goto l268
-					l269:
-						position, tokenIndex = position268, tokenIndex268
-						{
-							position272 := position
-							if !_rules[ruleLowerLabel]() {
-								goto l271
-							}
-							if !_rules[ruleWhitespace]() {
-								goto l271
-							}
-							if buffer[position] != rune('=') {
-								goto l271
-							}
-							position++
-							if !_rules[ruleWhitespace]() {
-								goto l271
-							}
-							if !_rules[ruleExpression]() {
-								goto l271
-							}
-							add(ruleAssignment, position272)
-						}
-						goto l268
-					l271:
-						position, tokenIndex = position268, tokenIndex268
-						if !_rules[ruleBinaryOperation]() {
-							goto l273
-						}
-						goto l268
-					l273:
-						position, tokenIndex = position268, tokenIndex268
-						if !_rules[ruleCall]() {
-							goto l274
-						}
-						goto l268
-					l274:
-						position, tokenIndex = position268, tokenIndex268
-						{
-							switch buffer[position] {
-							case 'O', 'o':
-								{
-									position276 := position
-									{
-										position277, tokenIndex277 := position, tokenIndex
-										if buffer[position] != rune('o') {
-											goto l278
-										}
-										position++
-										goto l277
-									l278:
-										position, tokenIndex = position277, tokenIndex277
-										if buffer[position] != rune('O') {
-											goto l263
-										}
-										position++
-									}
-								l277:
-									{
-										position279, tokenIndex279 := position, tokenIndex
-										if buffer[position] != rune('n') {
-											goto l280
-										}
-										position++
-										goto l279
-									l280:
-										position, tokenIndex = position279, tokenIndex279
-										if buffer[position] != rune('N') {
-											goto l263
-										}
-										position++
-									}
-								l279:
-									if !_rules[ruleWhitespace]() {
-										goto l263
-									}
-									if !_rules[ruleFunLabel]() {
-										goto l263
-									}
-									if buffer[position] != rune(':') {
-										goto l263
-									}
-									position++
-									if !_rules[ruleNewline]() {
-										goto l263
-									}
-									if !_rules[ruleIndent]() {
-										goto l263
-									}
-									if !_rules[ruleCode]() {
-										goto l263
-									}
-									add(ruleOn, position276)
-								}
-								break
-							case 'F', 'f':
-								{
-									position281 := position
-									{
-										position282, tokenIndex282 := position, tokenIndex
-										{
-											position284 := position
-											{
-												position285, tokenIndex285 := position, tokenIndex
-												if buffer[position] != rune('f') {
-													goto l286
-												}
-												position++
-												goto l285
-											l286:
-												position, tokenIndex = position285, tokenIndex285
-												if buffer[position] != rune('F') {
-													goto l283
-												}
-												position++
-											}
-										l285:
-											{
-												position287, tokenIndex287 := position, tokenIndex
-												if buffer[position] != rune('o') {
-													goto l288
-												}
-												position++
-												goto l287
-											l288:
-												position, tokenIndex = position287, tokenIndex287
-												if buffer[position] != rune('O') {
-													goto l283
-												}
-												position++
-											}
-										l287:
-											{
-												position289, tokenIndex289 := position, tokenIndex
-												if buffer[position] != rune('r') {
-													goto l290
-												}
-												position++
-												goto l289
-											l290:
-												position, tokenIndex = position289, tokenIndex289
-												if buffer[position] != rune('R') {
-													goto l283
-												}
-												position++
-											}
-										l289:
-											if !_rules[ruleWhitespace]() {
-												goto l283
-											}
-										l291:
-											{
-												position292, tokenIndex292 := position, tokenIndex
-												if !_rules[ruleLowerLabel]() {
-													goto l292
-												}
-												if buffer[position] != rune(',') {
-													goto l292
-												}
-												position++
-												{
-													position293, tokenIndex293 := position, tokenIndex
-													if !_rules[ruleWhitespace]() {
-														goto l293
-													}
-													goto l294
-												l293:
-													position, tokenIndex = position293, tokenIndex293
-												}
-											l294:
-												goto l291
-											l292:
-												position, tokenIndex = position292, tokenIndex292
-											}
-											if !_rules[ruleLowerLabel]() {
-												goto l283
-											}
-											if !_rules[ruleWhitespace]() {
-												goto l283
-											}
-											if buffer[position] != rune('i') {
-												goto l283
-											}
-											position++
-											if buffer[position] != rune('n') {
-												goto l283
-											}
-											position++
-											if !_rules[ruleWhitespace]() {
-												goto l283
-											}
-											if !_rules[ruleExpression]() {
-												goto l283
-											}
-											if buffer[position] != rune(':') {
-												goto l283
-											}
-											position++
-											if !_rules[ruleNewline]() {
-												goto l283
-											}
-											if !_rules[ruleIndent]() {
-												goto l283
-											}
-											if !_rules[ruleCode]() {
-												goto l283
-											}
-											add(ruleForIn, position284)
-										}
-										goto l282
-									l283:
-										position, tokenIndex = position282, tokenIndex282
-										{
-											position295 := position
-											{
-												position296, tokenIndex296 := position, tokenIndex
-												if buffer[position] != rune('f') {
-													goto l297
-												}
-												position++
-												goto l296
-											l297:
-												position, tokenIndex = position296, tokenIndex296
-												if buffer[position] != rune('F') {
-													goto l263
-												}
-												position++
-											}
-										l296:
-											{
-												position298, tokenIndex298 := position, tokenIndex
-												if buffer[position] != rune('o') {
-													goto l299
-												}
-												position++
-												goto l298
-											l299:
-												position, tokenIndex = position298, tokenIndex298
-												if buffer[position] != rune('O') {
-													goto l263
-												}
-												position++
-											}
-										l298:
-											{
-												position300, tokenIndex300 := position, tokenIndex
-												if buffer[position] != rune('r') {
-													goto l301
-												}
-												position++
-												goto l300
-											l301:
-												position, tokenIndex = position300, tokenIndex300
-												if buffer[position] != rune('R') {
-													goto l263
-												}
-												position++
-											}
-										l300:
-											if !_rules[ruleWhitespace]() {
-												goto l263
-											}
-											if !_rules[ruleLowerLabel]() {
-												goto l263
-											}
-											if !_rules[ruleWhitespace]() {
-												goto l263
-											}
-											if buffer[position] != rune('i') {
-												goto l263
-											}
-											position++
-											if buffer[position] != rune('n') {
-												goto l263
-											}
-											position++
-											if !_rules[ruleWhitespace]() {
-												goto l263
-											}
-											{
-												position302 := position
-												if !_rules[ruleInteger]() {
-													goto l263
-												}
-												{
-													position303 := position
-													{
-														position304, tokenIndex304 := position, tokenIndex
-														if buffer[position] != rune('.') {
-															goto l305
-														}
-														position++
-														if buffer[position] != rune('.') {
-															goto l305
-														}
-														position++
-														if buffer[position] != rune('.') {
-															goto l305
-														}
-														position++
-														goto l304
-													l305:
-														position, tokenIndex = position304, tokenIndex304
-														if buffer[position] != rune('.') {
-															goto l263
-														}
-														position++
-														if buffer[position] != rune('.') {
-															goto l263
-														}
-														position++
-													}
-												l304:
-													add(ruleRangeOperator, position303)
-												}
-												if !_rules[ruleInteger]() {
-													goto l263
-												}
-												add(ruleRange, position302)
-											}
-											if buffer[position] != rune(':') {
-												goto l263
-											}
-											position++
-											if !_rules[ruleNewline]() {
-												goto l263
-											}
-											if !_rules[ruleIndent]() {
-												goto l263
-											}
-											if !_rules[ruleCode]() {
-												goto l263
-											}
-											add(ruleForLoop, position295)
-										}
-									}
-								l282:
-									add(ruleFor, position281)
-								}
-								break
-							case '+', '-':
-								if !_rules[ruleUnaryOperation]() {
-									goto l263
-								}
-								break
-							default:
-								{
-									position306 := position
-									{
-										switch buffer[position] {
-										case 'E', 'e':
-											{
-												position308 := position
-												{
-													position309, tokenIndex309 := position, tokenIndex
-													if buffer[position] != rune('e') {
-														goto l310
-													}
-													position++
-													goto l309
-												l310:
-													position, tokenIndex = position309, tokenIndex309
-													if buffer[position] != rune('E') {
-														goto l263
-													}
-													position++
-												}
-											l309:
-												{
-													position311, tokenIndex311 := position, tokenIndex
-													if buffer[position] != rune('s') {
-														goto l312
-													}
-													position++
-													goto l311
-												l312:
-													position, tokenIndex = position311, tokenIndex311
-													if buffer[position] != rune('S') {
-														goto l263
-													}
-													position++
-												}
-											l311:
-												{
-													position313, tokenIndex313 := position, tokenIndex
-													if buffer[position] != rune('c') {
-														goto l314
-													}
-													position++
-													goto l313
-												l314:
-													position, tokenIndex = position313, tokenIndex313
-													if buffer[position] != rune('C') {
-														goto l263
-													}
-													position++
-												}
-											l313:
-												{
-													position315, tokenIndex315 := position, tokenIndex
-													if buffer[position] != rune('a') {
-														goto l316
-													}
-													position++
-													goto l315
-												l316:
-													position, tokenIndex = position315, tokenIndex315
-													if buffer[position] != rune('A') {
-														goto l263
-													}
-													position++
-												}
-											l315:
-												{
-													position317, tokenIndex317 := position, tokenIndex
-													if buffer[position] != rune('l') {
-														goto l318
-													}
-													position++
-													goto l317
-												l318:
-													position, tokenIndex = position317, tokenIndex317
-													if buffer[position] != rune('L') {
-														goto l263
-													}
-													position++
-												}
-											l317:
-												{
-													position319, tokenIndex319 := position, tokenIndex
-													if buffer[position] != rune('a') {
-														goto l320
-													}
-													position++
-													goto l319
-												l320:
-													position, tokenIndex = position319, tokenIndex319
-													if buffer[position] != rune('A') {
-														goto l263
-													}
-													position++
-												}
-											l319:
-												{
-													position321, tokenIndex321 := position, tokenIndex
-													if buffer[position] != rune('t') {
-														goto l322
-													}
-													position++
-													goto l321
-												l322:
-													position, tokenIndex = position321, tokenIndex321
-													if buffer[position] != rune('T') {
-														goto l263
-													}
-													position++
-												}
-											l321:
-												{
-													position323, tokenIndex323 := position, tokenIndex
-													if buffer[position] != rune('e') {
-														goto l324
-													}
-													position++
-													goto l323
-												l324:
-													position, tokenIndex = position323, tokenIndex323
-													if buffer[position] != rune('E') {
-														goto l263
-													}
-													position++
-												}
-											l323:
-												if !_rules[ruleWhitespace]() {
-													goto l263
-												}
-											l325:
-												{
-													position326, tokenIndex326 := position, tokenIndex
-													if !_rules[ruleFunLabel]() {
-														goto l326
-													}
-													if buffer[position] != rune(',') {
-														goto l326
-													}
-													position++
-													{
-														position327, tokenIndex327 := position, tokenIndex
-														if !_rules[ruleWhitespace]() {
-															goto l327
-														}
-														goto l328
-													l327:
-														position, tokenIndex = position327, tokenIndex327
-													}
-												l328:
-													goto l325
-												l326:
-													position, tokenIndex = position326, tokenIndex326
-												}
-												if !_rules[ruleFunLabel]() {
-													goto l263
-												}
-												add(ruleEscalator, position308)
-											}
-											break
-										case '!':
-											{
-												position329 := position
-												if buffer[position] != rune('!') {
-													goto l263
-												}
-												position++
-												if buffer[position] != rune('!') {
-													goto l263
-												}
-												position++
-												{
-													position330, tokenIndex330 := position, tokenIndex
-													if !_rules[ruleWhitespace]() {
-														goto l330
-													}
-													goto l331
-												l330:
-													position, tokenIndex = position330, tokenIndex330
-												}
-											l331:
-												if !_rules[ruleExpression]() {
-													goto l263
-												}
-												add(ruleReturnError, position329)
-											}
-											break
-										default:
-											{
-												position332 := position
-												{
-													position333, tokenIndex333 := position, tokenIndex
-													if buffer[position] != rune('r') {
-														goto l334
-													}
-													position++
-													goto l333
-												l334:
-													position, tokenIndex = position333, tokenIndex333
-													if buffer[position] != rune('R') {
-														goto l263
-													}
-													position++
-												}
-											l333:
-												{
-													position335, tokenIndex335 := position, tokenIndex
-													if buffer[position] != rune('e') {
-														goto l336
-													}
-													position++
-													goto l335
-												l336:
-													position, tokenIndex = position335, tokenIndex335
-													if buffer[position] != rune('E') {
-														goto l263
-													}
-													position++
-												}
-											l335:
-												{
-													position337, tokenIndex337 := position, tokenIndex
-													if buffer[position] != rune('t') {
-														goto l338
-													}
-													position++
-													goto l337
-												l338:
-													position, tokenIndex = position337, tokenIndex337
-													if buffer[position] != rune('T') {
-														goto l263
-													}
-													position++
-												}
-											l337:
-												{
-													position339, tokenIndex339 := position, tokenIndex
-													if buffer[position] != rune('u') {
-														goto l340
-													}
-													position++
-													goto l339
-												l340:
-													position, tokenIndex = position339, tokenIndex339
-													if buffer[position] != rune('U') {
-														goto l263
-													}
-													position++
-												}
-											l339:
-												{
-													position341, tokenIndex341 := position, tokenIndex
-													if buffer[position] != rune('r') {
-														goto l342
-													}
-													position++
-													goto l341
-												l342:
-													position, tokenIndex = position341, tokenIndex341
-													if buffer[position] != rune('R') {
-														goto l263
-													}
-													position++
-												}
-											l341:
-												{
-													position343, tokenIndex343 := position, tokenIndex
-													if buffer[position] != rune('n') {
-														goto l344
-													}
-													position++
-													goto l343
-												l344:
-													position, tokenIndex = position343, tokenIndex343
-													if buffer[position] != rune('N') {
-														goto l263
-													}
-													position++
-												}
-											l343:
-												{
-													position345, tokenIndex345 := position, tokenIndex
-													if !_rules[ruleWhitespace]() {
-														goto l345
-													}
-													goto l346
-												l345:
-													position, tokenIndex = position345, tokenIndex345
-												}
-											l346:
-												if !_rules[ruleExpression]() {
-													goto l263
-												}
-												add(ruleReturnValue, position332)
-											}
-											break
-										}
-									}
-
-									add(ruleReturn, position306)
-								}
-								break
-							}
-						}
-
+					position1135, tokenIndex1135 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l1136
+					}
+					position++
+					goto l1135
+				l1136:
+					position, tokenIndex = position1135, tokenIndex1135
+					if buffer[position] != rune('R') {
+						goto l1133
 					}
-				l268:
-					add(ruleLine, position267)
+					position++
 				}
-				if !_rules[ruleNewline]() {
-					goto l263
+			l1135:
+				{
+					position1137, tokenIndex1137 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1138
+					}
+					position++
+					goto l1137
+				l1138:
+					position, tokenIndex = position1137, tokenIndex1137
+					if buffer[position] != rune('E') {
+						goto l1133
+					}
+					position++
 				}
-			l265:
+			l1137:
 				{
-					position266, tokenIndex266 := position, tokenIndex
+					position1139, tokenIndex1139 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1140
+					}
+					position++
+					goto l1139
+				l1140:
+					position, tokenIndex = position1139, tokenIndex1139
+					if buffer[position] != rune('T') {
+						goto l1133
+					}
+					position++
+				}
+			l1139:
+				{
+					position1141, tokenIndex1141 := position, tokenIndex
+					if buffer[position] != rune('u') {
+						goto l1142
+					}
+					position++
+					goto l1141
+				l1142:
+					position, tokenIndex = position1141, tokenIndex1141
+					if buffer[position] != rune('U') {
+						goto l1133
+					}
+					position++
+				}
+			l1141:
+				{
+					position1143, tokenIndex1143 := position, tokenIndex
+					if buffer[position] != rune('r') {
+						goto l1144
+					}
+					position++
+					goto l1143
+				l1144:
+					position, tokenIndex = position1143, tokenIndex1143
+					if buffer[position] != rune('R') {
+						goto l1133
+					}
+					position++
+				}
+			l1143:
+				{
+					position1145, tokenIndex1145 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l1146
+					}
+					position++
+					goto l1145
+				l1146:
+					position, tokenIndex = position1145, tokenIndex1145
+					if buffer[position] != rune('N') {
+						goto l1133
+					}
+					position++
+				}
+			l1145:
+				{
+					position1147, tokenIndex1147 := position, tokenIndex
 					{
-						position347 := position
-						{
-							position348, tokenIndex348 := position, tokenIndex
-							{
-								position350 := position
-								if !_rules[ruleExpression]() {
-									goto l349
-								}
-								if buffer[position] != rune('[') {
-									goto l349
-								}
-								position++
-								if !_rules[ruleExpression]() {
-									goto l349
-								}
-								if buffer[position] != rune(']') {
-									goto l349
-								}
-								position++
-								if !_rules[ruleWhitespace]() {
-									goto l349
-								}
-								if buffer[position] != rune('=') {
-									goto l349
-								}
-								position++
-								if !_rules[ruleWhitespace]() {
-									goto l349
-								}
-								if !_rules[ruleExpression]() {
-									goto l349
-								}
-								add(ruleIndexAssignment, position350)
-							}
-							goto l348
-						l349:
-							position, tokenIndex = position348, tokenIndex348
-							{
-								position352 := position
-								if !_rules[ruleLowerLabel]() {
-									goto l351
-								}
-								if !_rules[ruleWhitespace]() {
-									goto l351
-								}
-								if buffer[position] != rune('=') {
-									goto l351
-								}
-								position++
-								if !_rules[ruleWhitespace]() {
-									goto l351
-								}
-								if !_rules[ruleExpression]() {
-									goto l351
-								}
-								add(ruleAssignment, position352)
-							}
-							goto l348
-						l351:
-							position, tokenIndex = position348, tokenIndex348
-							if !_rules[ruleBinaryOperation]() {
-								goto l353
-							}
-							goto l348
-						l353:
-							position, tokenIndex = position348, tokenIndex348
-							if !_rules[ruleCall]() {
-								goto l354
-							}
-							goto l348
-						l354:
-							position, tokenIndex = position348, tokenIndex348
-							{
-								switch buffer[position] {
-								case 'O', 'o':
-									{
-										position356 := position
-										{
-											position357, tokenIndex357 := position, tokenIndex
-											if buffer[position] != rune('o') {
-												goto l358
-											}
-											position++
-											goto l357
-										l358:
-											position, tokenIndex = position357, tokenIndex357
-											if buffer[position] != rune('O') {
-												goto l266
-											}
-											position++
-										}
-									l357:
-										{
-											position359, tokenIndex359 := position, tokenIndex
-											if buffer[position] != rune('n') {
-												goto l360
-											}
-											position++
-											goto l359
-										l360:
-											position, tokenIndex = position359, tokenIndex359
-											if buffer[position] != rune('N') {
-												goto l266
-											}
-											position++
-										}
-									l359:
-										if !_rules[ruleWhitespace]() {
-											goto l266
-										}
-										if !_rules[ruleFunLabel]() {
-											goto l266
-										}
-										if buffer[position] != rune(':') {
-											goto l266
-										}
-										position++
-										if !_rules[ruleNewline]() {
-											goto l266
-										}
-										if !_rules[ruleIndent]() {
-											goto l266
-										}
-										if !_rules[ruleCode]() {
-											goto l266
-										}
-										add(ruleOn, position356)
-									}
-									break
-								case 'F', 'f':
-									{
-										position361 := position
-										{
-											position362, tokenIndex362 := position, tokenIndex
-											{
-												position364 := position
-												{
-													position365, tokenIndex365 := position, tokenIndex
-													if buffer[position] != rune('f') {
-														goto l366
-													}
-													position++
-													goto l365
-												l366:
-													position, tokenIndex = position365, tokenIndex365
-													if buffer[position] != rune('F') {
-														goto l363
-													}
-													position++
-												}
-											l365:
-												{
-													position367, tokenIndex367 := position, tokenIndex
-													if buffer[position] != rune('o') {
-														goto l368
-													}
-													position++
-													goto l367
-												l368:
-													position, tokenIndex = position367, tokenIndex367
-													if buffer[position] != rune('O') {
-														goto l363
-													}
-													position++
-												}
-											l367:
-												{
-													position369, tokenIndex369 := position, tokenIndex
-													if buffer[position] != rune('r') {
-														goto l370
-													}
-													position++
-													goto l369
-												l370:
-													position, tokenIndex = position369, tokenIndex369
-													if buffer[position] != rune('R') {
-														goto l363
-													}
-													position++
-												}
-											l369:
-												if !_rules[ruleWhitespace]() {
-													goto l363
-												}
-											l371:
-												{
-													position372, tokenIndex372 := position, tokenIndex
-													if !_rules[ruleLowerLabel]() {
-														goto l372
-													}
-													if buffer[position] != rune(',') {
-														goto l372
-													}
-													position++
-													{
-														position373, tokenIndex373 := position, tokenIndex
-														if !_rules[ruleWhitespace]() {
-															goto l373
-														}
-														goto l374
-													l373:
-														position, tokenIndex = position373, tokenIndex373
-													}
-												l374:
-													goto l371
-												l372:
-													position, tokenIndex = position372, tokenIndex372
-												}
-												if !_rules[ruleLowerLabel]() {
-													goto l363
-												}
-												if !_rules[ruleWhitespace]() {
-													goto l363
-												}
-												if buffer[position] != rune('i') {
-													goto l363
-												}
-												position++
-												if buffer[position] != rune('n') {
-													goto l363
-												}
-												position++
-												if !_rules[ruleWhitespace]() {
-													goto l363
-												}
-												if !_rules[ruleExpression]() {
-													goto l363
-												}
-												if buffer[position] != rune(':') {
-													goto l363
-												}
-												position++
-												if !_rules[ruleNewline]() {
-													goto l363
-												}
-												if !_rules[ruleIndent]() {
-													goto l363
-												}
-												if !_rules[ruleCode]() {
-													goto l363
-												}
-												add(ruleForIn, position364)
-											}
-											goto l362
-										l363:
-											position, tokenIndex = position362, tokenIndex362
-											{
-												position375 := position
-												{
-													position376, tokenIndex376 := position, tokenIndex
-													if buffer[position] != rune('f') {
-														goto l377
-													}
-													position++
-													goto l376
-												l377:
-													position, tokenIndex = position376, tokenIndex376
-													if buffer[position] != rune('F') {
-														goto l266
-													}
-													position++
-												}
-											l376:
-												{
-													position378, tokenIndex378 := position, tokenIndex
-													if buffer[position] != rune('o') {
-														goto l379
-													}
-													position++
-													goto l378
-												l379:
-													position, tokenIndex = position378, tokenIndex378
-													if buffer[position] != rune('O') {
-														goto l266
-													}
-													position++
-												}
-											l378:
-												{
-													position380, tokenIndex380 := position, tokenIndex
-													if buffer[position] != rune('r') {
-														goto l381
-													}
-													position++
-													goto l380
-												l381:
-													position, tokenIndex = position380, tokenIndex380
-													if buffer[position] != rune('R') {
-														goto l266
-													}
-													position++
-												}
-											l380:
-												if !_rules[ruleWhitespace]() {
-													goto l266
-												}
-												if !_rules[ruleLowerLabel]() {
-													goto l266
-												}
-												if !_rules[ruleWhitespace]() {
-													goto l266
-												}
-												if buffer[position] != rune('i') {
-													goto l266
-												}
-												position++
-												if buffer[position] != rune('n') {
-													goto l266
-												}
-												position++
-												if !_rules[ruleWhitespace]() {
-													goto l266
-												}
-												{
-													position382 := position
-													if !_rules[ruleInteger]() {
-														goto l266
-													}
-													{
-														position383 := position
-														{
-															position384, tokenIndex384 := position, tokenIndex
-															if buffer[position] != rune('.') {
-																goto l385
-															}
-															position++
-															if buffer[position] != rune('.') {
-																goto l385
-															}
-															position++
-															if buffer[position] != rune('.') {
-																goto l385
-															}
-															position++
-															goto l384
-														l385:
-															position, tokenIndex = position384, tokenIndex384
-															if buffer[position] != rune('.') {
-																goto l266
-															}
-															position++
-															if buffer[position] != rune('.') {
-																goto l266
-															}
-															position++
-														}
-													l384:
-														add(ruleRangeOperator, position383)
-													}
-													if !_rules[ruleInteger]() {
-														goto l266
-													}
-													add(ruleRange, position382)
-												}
-												if buffer[position] != rune(':') {
-													goto l266
-												}
-												position++
-												if !_rules[ruleNewline]() {
-													goto l266
-												}
-												if !_rules[ruleIndent]() {
-													goto l266
-												}
-												if !_rules[ruleCode]() {
-													goto l266
-												}
-												add(ruleForLoop, position375)
-											}
-										}
-									l362:
-										add(ruleFor, position361)
-									}
-									break
-								case '+', '-':
-									if !_rules[ruleUnaryOperation]() {
-										goto l266
-									}
-									break
-								default:
-									{
-										position386 := position
-										{
-											switch buffer[position] {
-											case 'E', 'e':
-												{
-													position388 := position
-													{
-														position389, tokenIndex389 := position, tokenIndex
-														if buffer[position] != rune('e') {
-															goto l390
-														}
-														position++
-														goto l389
-													l390:
-														position, tokenIndex = position389, tokenIndex389
-														if buffer[position] != rune('E') {
-															goto l266
-														}
-														position++
-													}
-												l389:
-													{
-														position391, tokenIndex391 := position, tokenIndex
-														if buffer[position] != rune('s') {
-															goto l392
-														}
-														position++
-														goto l391
-													l392:
-														position, tokenIndex = position391, tokenIndex391
-														if buffer[position] != rune('S') {
-															goto l266
-														}
-														position++
-													}
-												l391:
-													{
-														position393, tokenIndex393 := position, tokenIndex
-														if buffer[position] != rune('c') {
-															goto l394
-														}
-														position++
-														goto l393
-													l394:
-														position, tokenIndex = position393, tokenIndex393
-														if buffer[position] != rune('C') {
-															goto l266
-														}
-														position++
-													}
-												l393:
-													{
-														position395, tokenIndex395 := position, tokenIndex
-														if buffer[position] != rune('a') {
-															goto l396
-														}
-														position++
-														goto l395
-													l396:
-														position, tokenIndex = position395, tokenIndex395
-														if buffer[position] != rune('A') {
-															goto l266
-														}
-														position++
-													}
-												l395:
-													{
-														position397, tokenIndex397 := position, tokenIndex
-														if buffer[position] != rune('l') {
-															goto l398
-														}
-														position++
-														goto l397
-													l398:
-														position, tokenIndex = position397, tokenIndex397
-														if buffer[position] != rune('L') {
-															goto l266
-														}
-														position++
-													}
-												l397:
-													{
-														position399, tokenIndex399 := position, tokenIndex
-														if buffer[position] != rune('a') {
-															goto l400
-														}
-														position++
-														goto l399
-													l400:
-														position, tokenIndex = position399, tokenIndex399
-														if buffer[position] != rune('A') {
-															goto l266
-														}
-														position++
-													}
-												l399:
-													{
-														position401, tokenIndex401 := position, tokenIndex
-														if buffer[position] != rune('t') {
-															goto l402
-														}
-														position++
-														goto l401
-													l402:
-														position, tokenIndex = position401, tokenIndex401
-														if buffer[position] != rune('T') {
-															goto l266
-														}
-														position++
-													}
-												l401:
-													{
-														position403, tokenIndex403 := position, tokenIndex
-														if buffer[position] != rune('e') {
-															goto l404
-														}
-														position++
-														goto l403
-													l404:
-														position, tokenIndex = position403, tokenIndex403
-														if buffer[position] != rune('E') {
-															goto l266
-														}
-														position++
-													}
-												l403:
-													if !_rules[ruleWhitespace]() {
-														goto l266
-													}
-												l405:
-													{
-														position406, tokenIndex406 := position, tokenIndex
-														if !_rules[ruleFunLabel]() {
-															goto l406
-														}
-														if buffer[position] != rune(',') {
-															goto l406
-														}
-														position++
-														{
-															position407, tokenIndex407 := position, tokenIndex
-															if !_rules[ruleWhitespace]() {
-																goto l407
-															}
-															goto l408
-														l407:
-															position, tokenIndex = position407, tokenIndex407
-														}
-													l408:
-														goto l405
-													l406:
-														position, tokenIndex = position406, tokenIndex406
-													}
-													if !_rules[ruleFunLabel]() {
-														goto l266
-													}
-													add(ruleEscalator, position388)
-												}
-												break
-											case '!':
-												{
-													position409 := position
-													if buffer[position] != rune('!') {
-														goto l266
-													}
-													position++
-													if buffer[position] != rune('!') {
-														goto l266
-													}
-													position++
-													{
-														position410, tokenIndex410 := position, tokenIndex
-														if !_rules[ruleWhitespace]() {
-															goto l410
-														}
-														goto l411
-													l410:
-														position, tokenIndex = position410, tokenIndex410
-													}
-												l411:
-													if !_rules[ruleExpression]() {
-														goto l266
-													}
-													add(ruleReturnError, position409)
-												}
-												break
-											default:
-												{
-													position412 := position
-													{
-														position413, tokenIndex413 := position, tokenIndex
-														if buffer[position] != rune('r') {
-															goto l414
-														}
-														position++
-														goto l413
-													l414:
-														position, tokenIndex = position413, tokenIndex413
-														if buffer[position] != rune('R') {
-															goto l266
-														}
-														position++
-													}
-												l413:
-													{
-														position415, tokenIndex415 := position, tokenIndex
-														if buffer[position] != rune('e') {
-															goto l416
-														}
-														position++
-														goto l415
-													l416:
-														position, tokenIndex = position415, tokenIndex415
-														if buffer[position] != rune('E') {
-															goto l266
-														}
-														position++
-													}
-												l415:
-													{
-														position417, tokenIndex417 := position, tokenIndex
-														if buffer[position] != rune('t') {
-															goto l418
-														}
-														position++
-														goto l417
-													l418:
-														position, tokenIndex = position417, tokenIndex417
-														if buffer[position] != rune('T') {
-															goto l266
-														}
-														position++
-													}
-												l417:
-													{
-														position419, tokenIndex419 := position, tokenIndex
-														if buffer[position] != rune('u') {
-															goto l420
-														}
-														position++
-														goto l419
-													l420:
-														position, tokenIndex = position419, tokenIndex419
-														if buffer[position] != rune('U') {
-															goto l266
-														}
-														position++
-													}
-												l419:
-													{
-														position421, tokenIndex421 := position, tokenIndex
-														if buffer[position] != rune('r') {
-															goto l422
-														}
-														position++
-														goto l421
-													l422:
-														position, tokenIndex = position421, tokenIndex421
-														if buffer[position] != rune('R') {
-															goto l266
-														}
-														position++
-													}
-												l421:
-													{
-														position423, tokenIndex423 := position, tokenIndex
-														if buffer[position] != rune('n') {
-															goto l424
-														}
-														position++
-														goto l423
-													l424:
-														position, tokenIndex = position423, tokenIndex423
-														if buffer[position] != rune('N') {
-															goto l266
-														}
-														position++
-													}
-												l423:
-													{
-														position425, tokenIndex425 := position, tokenIndex
-														if !_rules[ruleWhitespace]() {
-															goto l425
-														}
-														goto l426
-													l425:
-														position, tokenIndex = position425, tokenIndex425
-													}
-												l426:
-													if !_rules[ruleExpression]() {
-														goto l266
-													}
-													add(ruleReturnValue, position412)
-												}
-												break
-											}
-										}
-
-										add(ruleReturn, position386)
-									}
-									break
-								}
-							}
-
+						position1149, tokenIndex1149 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l1149
 						}
-					l348:
-						add(ruleLine, position347)
+						goto l1150
+					l1149:
+						position, tokenIndex = position1149, tokenIndex1149
 					}
-					if !_rules[ruleNewline]() {
-						goto l266
+				l1150:
+					if !_rules[ruleExpression]() {
+						goto l1147
 					}
-					goto l265
-				l266:
-					position, tokenIndex = position266, tokenIndex266
-				}
-				if !_rules[ruleDedent]() {
-					goto l263
+					goto l1148
+				l1147:
+					position, tokenIndex = position1147, tokenIndex1147
 				}
-				add(ruleCode, position264)
+			l1148:
+				add(ruleReturnValue, position1134)
 			}
 			return true
-		l263:
-			position, tokenIndex = position263, tokenIndex263
+		l1133:
+			position, tokenIndex = position1133, tokenIndex1133
 			return false
 		},
-		/* 31 Indent <- <('@' '@' ('i' / 'I') ('n' / 'N') ('d' / 'D') ('e' / 'E') ('n' / 'N') ('t' / 'T') '@' '@')> */
+		/* 118 ReturnError <- <('!' '!' Whitespace? Expression Wrapping?)> */
 		func() bool {
-			position427, tokenIndex427 := position, tokenIndex
+			position1151, tokenIndex1151 := position, tokenIndex
 			{
-				position428 := position
-				if buffer[position] != rune('@') {
-					goto l427
+				position1152 := position
+				if buffer[position] != rune('!') {
+					goto l1151
 				}
 				position++
-				if buffer[position] != rune('@') {
-					goto l427
+				if buffer[position] != rune('!') {
+					goto l1151
 				}
 				position++
 				{
-					position429, tokenIndex429 := position, tokenIndex
-					if buffer[position] != rune('i') {
-						goto l430
+					position1153, tokenIndex1153 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l1153
+					}
+					goto l1154
+				l1153:
+					position, tokenIndex = position1153, tokenIndex1153
+				}
+			l1154:
+				if !_rules[ruleExpression]() {
+					goto l1151
+				}
+				{
+					position1155, tokenIndex1155 := position, tokenIndex
+					if !_rules[ruleWrapping]() {
+						goto l1155
+					}
+					goto l1156
+				l1155:
+					position, tokenIndex = position1155, tokenIndex1155
+				}
+			l1156:
+				add(ruleReturnError, position1152)
+			}
+			return true
+		l1151:
+			position, tokenIndex = position1151, tokenIndex1151
+			return false
+		},
+		/* 119 Escalator <- <(('e' / 'E') ('s' / 'S') ('c' / 'C') ('a' / 'A') ('l' / 'L') ('a' / 'A') ('t' / 'T') ('e' / 'E') Whitespace (FunLabel ',' Whitespace?)* FunLabel Wrapping?)> */
+		func() bool {
+			position1157, tokenIndex1157 := position, tokenIndex
+			{
+				position1158 := position
+				{
+					position1159, tokenIndex1159 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1160
 					}
 					position++
-					goto l429
-				l430:
-					position, tokenIndex = position429, tokenIndex429
-					if buffer[position] != rune('I') {
-						goto l427
+					goto l1159
+				l1160:
+					position, tokenIndex = position1159, tokenIndex1159
+					if buffer[position] != rune('E') {
+						goto l1157
 					}
 					position++
 				}
-			l429:
+			l1159:
 				{
-					position431, tokenIndex431 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l432
+					position1161, tokenIndex1161 := position, tokenIndex
+					if buffer[position] != rune('s') {
+						goto l1162
 					}
 					position++
-					goto l431
-				l432:
-					position, tokenIndex = position431, tokenIndex431
-					if buffer[position] != rune('N') {
-						goto l427
+					goto l1161
+				l1162:
+					position, tokenIndex = position1161, tokenIndex1161
+					if buffer[position] != rune('S') {
+						goto l1157
 					}
 					position++
 				}
-			l431:
+			l1161:
 				{
-					position433, tokenIndex433 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l434
+					position1163, tokenIndex1163 := position, tokenIndex
+					if buffer[position] != rune('c') {
+						goto l1164
 					}
 					position++
-					goto l433
-				l434:
-					position, tokenIndex = position433, tokenIndex433
-					if buffer[position] != rune('D') {
-						goto l427
+					goto l1163
+				l1164:
+					position, tokenIndex = position1163, tokenIndex1163
+					if buffer[position] != rune('C') {
+						goto l1157
 					}
 					position++
 				}
-			l433:
+			l1163:
 				{
-					position435, tokenIndex435 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l436
+					position1165, tokenIndex1165 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l1166
 					}
 					position++
-					goto l435
-				l436:
-					position, tokenIndex = position435, tokenIndex435
-					if buffer[position] != rune('E') {
-						goto l427
+					goto l1165
+				l1166:
+					position, tokenIndex = position1165, tokenIndex1165
+					if buffer[position] != rune('A') {
+						goto l1157
 					}
 					position++
 				}
-			l435:
+			l1165:
 				{
-					position437, tokenIndex437 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l438
+					position1167, tokenIndex1167 := position, tokenIndex
+					if buffer[position] != rune('l') {
+						goto l1168
 					}
 					position++
-					goto l437
-				l438:
-					position, tokenIndex = position437, tokenIndex437
-					if buffer[position] != rune('N') {
-						goto l427
+					goto l1167
+				l1168:
+					position, tokenIndex = position1167, tokenIndex1167
+					if buffer[position] != rune('L') {
+						goto l1157
 					}
 					position++
 				}
-			l437:
+			l1167:
 				{
-					position439, tokenIndex439 := position, tokenIndex
+					position1169, tokenIndex1169 := position, tokenIndex
+					if buffer[position] != rune('a') {
+						goto l1170
+					}
+					position++
+					goto l1169
+				l1170:
+					position, tokenIndex = position1169, tokenIndex1169
+					if buffer[position] != rune('A') {
+						goto l1157
+					}
+					position++
+				}
+			l1169:
+				{
+					position1171, tokenIndex1171 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l440
+						goto l1172
 					}
 					position++
-					goto l439
-				l440:
-					position, tokenIndex = position439, tokenIndex439
+					goto l1171
+				l1172:
+					position, tokenIndex = position1171, tokenIndex1171
 					if buffer[position] != rune('T') {
-						goto l427
+						goto l1157
 					}
 					position++
 				}
-			l439:
-				if buffer[position] != rune('@') {
-					goto l427
-				}
-				position++
-				if buffer[position] != rune('@') {
-					goto l427
-				}
-				position++
-				add(ruleIndent, position428)
-			}
-			return true
-		l427:
-			position, tokenIndex = position427, tokenIndex427
-			return false
-		},
-		/* 32 Dedent <- <('@' '@' ('d' / 'D') ('e' / 'E') ('d' / 'D') ('e' / 'E') ('n' / 'N') ('t' / 'T') '@' '@')> */
-		func() bool {
-			position441, tokenIndex441 := position, tokenIndex
-			{
-				position442 := position
-				if buffer[position] != rune('@') {
-					goto l441
+			l1171:
+				{
+					position1173, tokenIndex1173 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l1174
+					}
+					position++
+					goto l1173
+				l1174:
+					position, tokenIndex = position1173, tokenIndex1173
+					if buffer[position] != rune('E') {
+						goto l1157
+					}
+					position++
 				}
-				position++
-				if buffer[position] != rune('@') {
-					goto l441
+			l1173:
+				if !_rules[ruleWhitespace]() {
+					goto l1157
 				}
-				position++
+			l1175:
 				{
-					position443, tokenIndex443 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l444
+					position1176, tokenIndex1176 := position, tokenIndex
+					if !_rules[ruleFunLabel]() {
+						goto l1176
 					}
-					position++
-					goto l443
-				l444:
-					position, tokenIndex = position443, tokenIndex443
-					if buffer[position] != rune('D') {
-						goto l441
+					if buffer[position] != rune(',') {
+						goto l1176
 					}
 					position++
+					{
+						position1177, tokenIndex1177 := position, tokenIndex
+						if !_rules[ruleWhitespace]() {
+							goto l1177
+						}
+						goto l1178
+					l1177:
+						position, tokenIndex = position1177, tokenIndex1177
+					}
+				l1178:
+					goto l1175
+				l1176:
+					position, tokenIndex = position1176, tokenIndex1176
+				}
+				if !_rules[ruleFunLabel]() {
+					goto l1157
 				}
-			l443:
 				{
-					position445, tokenIndex445 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l446
-					}
-					position++
-					goto l445
-				l446:
-					position, tokenIndex = position445, tokenIndex445
-					if buffer[position] != rune('E') {
-						goto l441
+					position1179, tokenIndex1179 := position, tokenIndex
+					if !_rules[ruleWrapping]() {
+						goto l1179
 					}
-					position++
+					goto l1180
+				l1179:
+					position, tokenIndex = position1179, tokenIndex1179
+				}
+			l1180:
+				add(ruleEscalator, position1158)
+			}
+			return true
+		l1157:
+			position, tokenIndex = position1157, tokenIndex1157
+			return false
+		},
+		/* 120 Wrapping <- <(Whitespace (('w' / 'W') ('i' / 'I') ('t' / 'T') ('h' / 'H')) Whitespace Expression)> */
+		func() bool {
+			position1181, tokenIndex1181 := position, tokenIndex
+			{
+				position1182 := position
+				if !_rules[ruleWhitespace]() {
+					goto l1181
 				}
-			l445:
 				{
-					position447, tokenIndex447 := position, tokenIndex
-					if buffer[position] != rune('d') {
-						goto l448
+					position1183, tokenIndex1183 := position, tokenIndex
+					if buffer[position] != rune('w') {
+						goto l1184
 					}
 					position++
-					goto l447
-				l448:
-					position, tokenIndex = position447, tokenIndex447
-					if buffer[position] != rune('D') {
-						goto l441
+					goto l1183
+				l1184:
+					position, tokenIndex = position1183, tokenIndex1183
+					if buffer[position] != rune('W') {
+						goto l1181
 					}
 					position++
 				}
-			l447:
+			l1183:
 				{
-					position449, tokenIndex449 := position, tokenIndex
-					if buffer[position] != rune('e') {
-						goto l450
+					position1185, tokenIndex1185 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l1186
 					}
 					position++
-					goto l449
-				l450:
-					position, tokenIndex = position449, tokenIndex449
-					if buffer[position] != rune('E') {
-						goto l441
+					goto l1185
+				l1186:
+					position, tokenIndex = position1185, tokenIndex1185
+					if buffer[position] != rune('I') {
+						goto l1181
 					}
 					position++
 				}
-			l449:
+			l1185:
 				{
-					position451, tokenIndex451 := position, tokenIndex
-					if buffer[position] != rune('n') {
-						goto l452
+					position1187, tokenIndex1187 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l1188
 					}
 					position++
-					goto l451
-				l452:
-					position, tokenIndex = position451, tokenIndex451
-					if buffer[position] != rune('N') {
-						goto l441
+					goto l1187
+				l1188:
+					position, tokenIndex = position1187, tokenIndex1187
+					if buffer[position] != rune('T') {
+						goto l1181
 					}
 					position++
 				}
-			l451:
+			l1187:
 				{
-					position453, tokenIndex453 := position, tokenIndex
-					if buffer[position] != rune('t') {
-						goto l454
+					position1189, tokenIndex1189 := position, tokenIndex
+					if buffer[position] != rune('h') {
+						goto l1190
 					}
 					position++
-					goto l453
-				l454:
-					position, tokenIndex = position453, tokenIndex453
-					if buffer[position] != rune('T') {
-						goto l441
+					goto l1189
+				l1190:
+					position, tokenIndex = position1189, tokenIndex1189
+					if buffer[position] != rune('H') {
+						goto l1181
 					}
 					position++
 				}
-			l453:
-				if buffer[position] != rune('@') {
-					goto l441
+			l1189:
+				if !_rules[ruleWhitespace]() {
+					goto l1181
 				}
-				position++
-				if buffer[position] != rune('@') {
-					goto l441
+				if !_rules[ruleExpression]() {
+					goto l1181
 				}
-				position++
-				add(ruleDedent, position442)
+				add(ruleWrapping, position1182)
 			}
 			return true
-		l441:
-			position, tokenIndex = position441, tokenIndex441
+		l1181:
+			position, tokenIndex = position1181, tokenIndex1181
 			return false
 		},
-		/* 33 Line <- <(IndexAssignment / Assignment / BinaryOperation / Call / ((&('O' | 'o') On) | (&('F' | 'f') For) | (&('+' | '-') UnaryOperation) | (&('!' | 'E' | 'R' | 'e' | 'r') Return)))> */
-		nil,
-		/* 34 IndexAssignment <- <(Expression '[' Expression ']' Whitespace '=' Whitespace Expression)> */
-		nil,
-		/* 35 Assignment <- <(LowerLabel Whitespace '=' Whitespace Expression)> */
-		nil,
-		/* 36 Expression <- <(BinaryOperation / UnaryOperation / Call / Simple)> */
+		/* 121 LowerLabel <- <([a-z] ([A-Z] / [a-z] / [0-9] / '_')*)> */
 		func() bool {
-			position458, tokenIndex458 := position, tokenIndex
+			position1191, tokenIndex1191 := position, tokenIndex
 			{
-				position459 := position
+				position1192 := position
+				if c := buffer[position]; c < rune('a') || c > rune('z') {
+					goto l1191
+				}
+				position++
+			l1193:
 				{
-					position460, tokenIndex460 := position, tokenIndex
-					if !_rules[ruleBinaryOperation]() {
-						goto l461
-					}
-					goto l460
-				l461:
-					position, tokenIndex = position460, tokenIndex460
-					if !_rules[ruleUnaryOperation]() {
-						goto l462
-					}
-					goto l460
-				l462:
-					position, tokenIndex = position460, tokenIndex460
-					if !_rules[ruleCall]() {
-						goto l463
-					}
-					goto l460
-				l463:
-					position, tokenIndex = position460, tokenIndex460
-					if !_rules[ruleSimple]() {
-						goto l458
+					position1194, tokenIndex1194 := position, tokenIndex
+					{
+						position1195, tokenIndex1195 := position, tokenIndex
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l1196
+						}
+						position++
+						goto l1195
+					l1196:
+						position, tokenIndex = position1195, tokenIndex1195
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l1197
+						}
+						position++
+						goto l1195
+					l1197:
+						position, tokenIndex = position1195, tokenIndex1195
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l1198
+						}
+						position++
+						goto l1195
+					l1198:
+						position, tokenIndex = position1195, tokenIndex1195
+						if buffer[position] != rune('_') {
+							goto l1194
+						}
+						position++
 					}
+				l1195:
+					goto l1193
+				l1194:
+					position, tokenIndex = position1194, tokenIndex1194
 				}
-			l460:
-				add(ruleExpression, position459)
+				add(ruleLowerLabel, position1192)
 			}
 			return true
-		l458:
-			position, tokenIndex = position458, tokenIndex458
+		l1191:
+			position, tokenIndex = position1191, tokenIndex1191
 			return false
 		},
-		/* 37 Simple <- <(Label / ((&('$') Error) | (&('"') String) | (&('F' | 'N' | 'T' | 'f' | 'n' | 't') Constant) | (&('[') List) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') Number)))> */
+		/* 122 CapitalLabel <- <([A-Z] ([A-Z] / [a-z] / [0-9] / '_')*)> */
 		func() bool {
-			position464, tokenIndex464 := position, tokenIndex
+			position1199, tokenIndex1199 := position, tokenIndex
 			{
-				position465 := position
+				position1200 := position
+				if c := buffer[position]; c < rune('A') || c > rune('Z') {
+					goto l1199
+				}
+				position++
+			l1201:
 				{
-					position466, tokenIndex466 := position, tokenIndex
-					if !_rules[ruleLabel]() {
-						goto l467
-					}
-					goto l466
-				l467:
-					position, tokenIndex = position466, tokenIndex466
+					position1202, tokenIndex1202 := position, tokenIndex
 					{
-						switch buffer[position] {
-						case '$':
-							{
-								position469 := position
-								if buffer[position] != rune('$') {
-									goto l464
-								}
-								position++
-								if !_rules[ruleLabel]() {
-									goto l464
-								}
-								add(ruleError, position469)
-							}
-							break
-						case '"':
-							if !_rules[ruleString]() {
-								goto l464
-							}
-							break
-						case 'F', 'N', 'T', 'f', 'n', 't':
-							{
-								position470 := position
-								{
-									switch buffer[position] {
-									case 'F', 'f':
-										{
-											position472, tokenIndex472 := position, tokenIndex
-											if buffer[position] != rune('f') {
-												goto l473
-											}
-											position++
-											goto l472
-										l473:
-											position, tokenIndex = position472, tokenIndex472
-											if buffer[position] != rune('F') {
-												goto l464
-											}
-											position++
-										}
-									l472:
-										{
-											position474, tokenIndex474 := position, tokenIndex
-											if buffer[position] != rune('a') {
-												goto l475
-											}
-											position++
-											goto l474
-										l475:
-											position, tokenIndex = position474, tokenIndex474
-											if buffer[position] != rune('A') {
-												goto l464
-											}
-											position++
-										}
-									l474:
-										{
-											position476, tokenIndex476 := position, tokenIndex
-											if buffer[position] != rune('l') {
-												goto l477
-											}
-											position++
-											goto l476
-										l477:
-											position, tokenIndex = position476, tokenIndex476
-											if buffer[position] != rune('L') {
-												goto l464
-											}
-											position++
-										}
-									l476:
-										{
-											position478, tokenIndex478 := position, tokenIndex
-											if buffer[position] != rune('s') {
-												goto l479
-											}
-											position++
-											goto l478
-										l479:
-											position, tokenIndex = position478, tokenIndex478
-											if buffer[position] != rune('S') {
-												goto l464
-											}
-											position++
-										}
-									l478:
-										{
-											position480, tokenIndex480 := position, tokenIndex
-											if buffer[position] != rune('e') {
-												goto l481
-											}
-											position++
-											goto l480
-										l481:
-											position, tokenIndex = position480, tokenIndex480
-											if buffer[position] != rune('E') {
-												goto l464
-											}
-											position++
-										}
-									l480:
-										break
-									case 'T', 't':
-										{
-											position482, tokenIndex482 := position, tokenIndex
-											if buffer[position] != rune('t') {
-												goto l483
-											}
-											position++
-											goto l482
-										l483:
-											position, tokenIndex = position482, tokenIndex482
-											if buffer[position] != rune('T') {
-												goto l464
-											}
-											position++
-										}
-									l482:
-										{
-											position484, tokenIndex484 := position, tokenIndex
-											if buffer[position] != rune('r') {
-												goto l485
-											}
-											position++
-											goto l484
-										l485:
-											position, tokenIndex = position484, tokenIndex484
-											if buffer[position] != rune('R') {
-												goto l464
-											}
-											position++
-										}
-									l484:
-										{
-											position486, tokenIndex486 := position, tokenIndex
-											if buffer[position] != rune('u') {
-												goto l487
-											}
-											position++
-											goto l486
-										l487:
-											position, tokenIndex = position486, tokenIndex486
-											if buffer[position] != rune('U') {
-												goto l464
-											}
-											position++
-										}
-									l486:
-										{
-											position488, tokenIndex488 := position, tokenIndex
-											if buffer[position] != rune('e') {
-												goto l489
-											}
-											position++
-											goto l488
-										l489:
-											position, tokenIndex = position488, tokenIndex488
-											if buffer[position] != rune('E') {
-												goto l464
-											}
-											position++
-										}
-									l488:
-										break
-									default:
-										{
-											position490, tokenIndex490 := position, tokenIndex
-											if buffer[position] != rune('n') {
-												goto l491
-											}
-											position++
-											goto l490
-										l491:
-											position, tokenIndex = position490, tokenIndex490
-											if buffer[position] != rune('N') {
-												goto l464
-											}
-											position++
-										}
-									l490:
-										{
-											position492, tokenIndex492 := position, tokenIndex
-											if buffer[position] != rune('i') {
-												goto l493
-											}
-											position++
-											goto l492
-										l493:
-											position, tokenIndex = position492, tokenIndex492
-											if buffer[position] != rune('I') {
-												goto l464
-											}
-											position++
-										}
-									l492:
-										{
-											position494, tokenIndex494 := position, tokenIndex
-											if buffer[position] != rune('l') {
-												goto l495
-											}
-											position++
-											goto l494
-										l495:
-											position, tokenIndex = position494, tokenIndex494
-											if buffer[position] != rune('L') {
-												goto l464
-											}
-											position++
-										}
-									l494:
-										break
-									}
-								}
-
-								add(ruleConstant, position470)
-							}
-							break
-						case '[':
-							{
-								position496 := position
-								if buffer[position] != rune('[') {
-									goto l464
-								}
-								position++
-							l497:
-								{
-									position498, tokenIndex498 := position, tokenIndex
-									if !_rules[ruleExpression]() {
-										goto l498
-									}
-									if buffer[position] != rune(',') {
-										goto l498
-									}
-									position++
-									{
-										position499, tokenIndex499 := position, tokenIndex
-										if !_rules[ruleWhitespace]() {
-											goto l499
-										}
-										goto l500
-									l499:
-										position, tokenIndex = position499, tokenIndex499
-									}
-								l500:
-									goto l497
-								l498:
-									position, tokenIndex = position498, tokenIndex498
-								}
-								{
-									position501, tokenIndex501 := position, tokenIndex
-									if !_rules[ruleExpression]() {
-										goto l501
-									}
-									goto l502
-								l501:
-									position, tokenIndex = position501, tokenIndex501
-								}
-							l502:
-								if buffer[position] != rune(']') {
-									goto l464
-								}
-								position++
-								add(ruleList, position496)
-							}
-							break
-						default:
-							{
-								position503 := position
-								{
-									position504, tokenIndex504 := position, tokenIndex
-									{
-										position506 := position
-										if c := buffer[position]; c < rune('0') || c > rune('9') {
-											goto l505
-										}
-										position++
-									l507:
-										{
-											position508, tokenIndex508 := position, tokenIndex
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l508
-											}
-											position++
-											goto l507
-										l508:
-											position, tokenIndex = position508, tokenIndex508
-										}
-										if buffer[position] != rune('.') {
-											goto l505
-										}
-										position++
-										if c := buffer[position]; c < rune('0') || c > rune('9') {
-											goto l505
-										}
-										position++
-									l509:
-										{
-											position510, tokenIndex510 := position, tokenIndex
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l510
-											}
-											position++
-											goto l509
-										l510:
-											position, tokenIndex = position510, tokenIndex510
-										}
-										add(ruleFloat, position506)
-									}
-									goto l504
-								l505:
-									position, tokenIndex = position504, tokenIndex504
-									if !_rules[ruleInteger]() {
-										goto l464
-									}
-								}
-							l504:
-								add(ruleNumber, position503)
-							}
-							break
+						position1203, tokenIndex1203 := position, tokenIndex
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l1204
+						}
+						position++
+						goto l1203
+					l1204:
+						position, tokenIndex = position1203, tokenIndex1203
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l1205
+						}
+						position++
+						goto l1203
+					l1205:
+						position, tokenIndex = position1203, tokenIndex1203
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l1206
+						}
+						position++
+						goto l1203
+					l1206:
+						position, tokenIndex = position1203, tokenIndex1203
+						if buffer[position] != rune('_') {
+							goto l1202
 						}
+						position++
 					}
-
+				l1203:
+					goto l1201
+				l1202:
+					position, tokenIndex = position1202, tokenIndex1202
 				}
-			l466:
-				add(ruleSimple, position465)
+				add(ruleCapitalLabel, position1200)
 			}
 			return true
-		l464:
-			position, tokenIndex = position464, tokenIndex464
+		l1199:
+			position, tokenIndex = position1199, tokenIndex1199
 			return false
 		},
-		/* 38 List <- <('[' (Expression ',' Whitespace?)* Expression? ']')> */
-		nil,
-		/* 39 BinaryOperation <- <(ExpressionExceptBinaryOperation Whitespace BinaryOperator Whitespace ExpressionExceptBinaryOperation)> */
+		/* 123 FunLowerLabel <- <([a-z] ([a-z] / [0-9] / '`' / '_')* ('?' / '!')?)> */
 		func() bool {
-			position512, tokenIndex512 := position, tokenIndex
+			position1207, tokenIndex1207 := position, tokenIndex
 			{
-				position513 := position
-				if !_rules[ruleExpressionExceptBinaryOperation]() {
-					goto l512
-				}
-				if !_rules[ruleWhitespace]() {
-					goto l512
+				position1208 := position
+				if c := buffer[position]; c < rune('a') || c > rune('z') {
+					goto l1207
 				}
+				position++
+			l1209:
 				{
-					position514 := position
+					position1210, tokenIndex1210 := position, tokenIndex
 					{
-						switch buffer[position] {
-						case '/':
-							if buffer[position] != rune('/') {
-								goto l512
-							}
-							position++
-							break
-						case '*':
-							if buffer[position] != rune('*') {
-								goto l512
-							}
-							position++
-							break
-						case '-':
-							if buffer[position] != rune('-') {
-								goto l512
-							}
-							position++
-							break
-						default:
-							if buffer[position] != rune('+') {
-								goto l512
-							}
-							position++
-							break
+						position1211, tokenIndex1211 := position, tokenIndex
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l1212
+						}
+						position++
+						goto l1211
+					l1212:
+						position, tokenIndex = position1211, tokenIndex1211
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l1213
+						}
+						position++
+						goto l1211
+					l1213:
+						position, tokenIndex = position1211, tokenIndex1211
+						if buffer[position] != rune('`') {
+							goto l1214
+						}
+						position++
+						goto l1211
+					l1214:
+						position, tokenIndex = position1211, tokenIndex1211
+						if buffer[position] != rune('_') {
+							goto l1210
 						}
+						position++
 					}
-
-					add(ruleBinaryOperator, position514)
-				}
-				if !_rules[ruleWhitespace]() {
-					goto l512
+				l1211:
+					goto l1209
+				l1210:
+					position, tokenIndex = position1210, tokenIndex1210
 				}
-				if !_rules[ruleExpressionExceptBinaryOperation]() {
-					goto l512
+				{
+					position1215, tokenIndex1215 := position, tokenIndex
+					{
+						position1217, tokenIndex1217 := position, tokenIndex
+						if buffer[position] != rune('?') {
+							goto l1218
+						}
+						position++
+						goto l1217
+					l1218:
+						position, tokenIndex = position1217, tokenIndex1217
+						if buffer[position] != rune('!') {
+							goto l1215
+						}
+						position++
+					}
+				l1217:
+					goto l1216
+				l1215:
+					position, tokenIndex = position1215, tokenIndex1215
 				}
-				add(ruleBinaryOperation, position513)
+			l1216:
+				add(ruleFunLowerLabel, position1208)
 			}
 			return true
-		l512:
-			position, tokenIndex = position512, tokenIndex512
+		l1207:
+			position, tokenIndex = position1207, tokenIndex1207
 			return false
 		},
-		/* 40 ExpressionExceptBinaryOperation <- <(Simple / UnaryOperation / Call)> */
+		/* 124 Label <- <(FunLabel / CapitalLabel / LowerLabel)> */
 		func() bool {
-			position516, tokenIndex516 := position, tokenIndex
+			position1219, tokenIndex1219 := position, tokenIndex
 			{
-				position517 := position
+				position1220 := position
 				{
-					position518, tokenIndex518 := position, tokenIndex
-					if !_rules[ruleSimple]() {
-						goto l519
+					position1221, tokenIndex1221 := position, tokenIndex
+					if !_rules[ruleFunLabel]() {
+						goto l1222
 					}
-					goto l518
-				l519:
-					position, tokenIndex = position518, tokenIndex518
-					if !_rules[ruleUnaryOperation]() {
-						goto l520
+					goto l1221
+				l1222:
+					position, tokenIndex = position1221, tokenIndex1221
+					if !_rules[ruleCapitalLabel]() {
+						goto l1223
 					}
-					goto l518
-				l520:
-					position, tokenIndex = position518, tokenIndex518
-					if !_rules[ruleCall]() {
-						goto l516
+					goto l1221
+				l1223:
+					position, tokenIndex = position1221, tokenIndex1221
+					if !_rules[ruleLowerLabel]() {
+						goto l1219
 					}
 				}
-			l518:
-				add(ruleExpressionExceptBinaryOperation, position517)
+			l1221:
+				add(ruleLabel, position1220)
 			}
 			return true
-		l516:
-			position, tokenIndex = position516, tokenIndex516
+		l1219:
+			position, tokenIndex = position1219, tokenIndex1219
 			return false
 		},
-		/* 41 BinaryOperator <- <((&('/') '/') | (&('*') '*') | (&('-') '-') | (&('+') '+'))> */
-		nil,
-		/* 42 UnaryOperation <- <(UnaryOperator ExpressionExceptOperation)> */
+		/* 125 Float <- <([0-9]+ '.' [0-9]+)> */
 		func() bool {
-			position522, tokenIndex522 := position, tokenIndex
+			position1224, tokenIndex1224 := position, tokenIndex
 			{
-				position523 := position
+				position1225 := position
+				if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l1224
+				}
+				position++
+			l1226:
 				{
-					position524 := position
-					{
-						position525, tokenIndex525 := position, tokenIndex
-						if buffer[position] != rune('+') {
-							goto l526
-						}
-						position++
-						goto l525
-					l526:
-						position, tokenIndex = position525, tokenIndex525
-						if buffer[position] != rune('-') {
-							goto l522
-						}
-						position++
+					position1227, tokenIndex1227 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l1227
 					}
-				l525:
-					add(ruleUnaryOperator, position524)
+					position++
+					goto l1226
+				l1227:
+					position, tokenIndex = position1227, tokenIndex1227
+				}
+				if buffer[position] != rune('.') {
+					goto l1224
 				}
+				position++
+				if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l1224
+				}
+				position++
+			l1228:
 				{
-					position527 := position
-					{
-						position528, tokenIndex528 := position, tokenIndex
-						if !_rules[ruleSimple]() {
-							goto l529
-						}
-						goto l528
-					l529:
-						position, tokenIndex = position528, tokenIndex528
-						if !_rules[ruleCall]() {
-							goto l522
-						}
+					position1229, tokenIndex1229 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l1229
 					}
-				l528:
-					add(ruleExpressionExceptOperation, position527)
+					position++
+					goto l1228
+				l1229:
+					position, tokenIndex = position1229, tokenIndex1229
 				}
-				add(ruleUnaryOperation, position523)
+				add(ruleFloat, position1225)
 			}
 			return true
-		l522:
-			position, tokenIndex = position522, tokenIndex522
+		l1224:
+			position, tokenIndex = position1224, tokenIndex1224
 			return false
 		},
-		/* 43 UnaryOperator <- <('+' / '-')> */
-		nil,
-		/* 44 ExpressionExceptOperation <- <(Simple / Call)> */
-		nil,
-		/* 45 MethodCall <- <(Simple '.' Label '(' (Expression ',' Whitespace?)* Expression? ')')> */
-		nil,
-		/* 46 Call <- <(BuiltinCall / FunCall / MethodCall)> */
+		/* 126 Integer <- <[0-9]+> */
+		func() bool {
+			position1230, tokenIndex1230 := position, tokenIndex
+			{
+				position1231 := position
+				if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l1230
+				}
+				position++
+			l1232:
+				{
+					position1233, tokenIndex1233 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l1233
+					}
+					position++
+					goto l1232
+				l1233:
+					position, tokenIndex = position1233, tokenIndex1233
+				}
+				add(ruleInteger, position1231)
+			}
+			return true
+		l1230:
+			position, tokenIndex = position1230, tokenIndex1230
+			return false
+		},
+		/* 127 Number <- <(Float / Integer)> */
+		func() bool {
+			position1234, tokenIndex1234 := position, tokenIndex
+			{
+				position1235 := position
+				{
+					position1236, tokenIndex1236 := position, tokenIndex
+					if !_rules[ruleFloat]() {
+						goto l1237
+					}
+					goto l1236
+				l1237:
+					position, tokenIndex = position1236, tokenIndex1236
+					if !_rules[ruleInteger]() {
+						goto l1234
+					}
+				}
+			l1236:
+				add(ruleNumber, position1235)
+			}
+			return true
+		l1234:
+			position, tokenIndex = position1234, tokenIndex1234
+			return false
+		},
+		/* 128 Constant <- <(((('n' / 'N') ('i' / 'I') ('l' / 'L')) / (('t' / 'T') ('r' / 'R') ('u' / 'U') ('e' / 'E')) / (('f' / 'F') ('a' / 'A') ('l' / 'L') ('s' / 'S') ('e' / 'E'))) !([A-Z] / [a-z] / [0-9] / '_' / '`' / '?' / '!'))> */
 		func() bool {
-			position533, tokenIndex533 := position, tokenIndex
+			position1238, tokenIndex1238 := position, tokenIndex
 			{
-				position534 := position
+				position1239 := position
 				{
-					position535, tokenIndex535 := position, tokenIndex
+					position1240, tokenIndex1240 := position, tokenIndex
 					{
-						position537 := position
-						{
-							position538 := position
-							{
-								position539, tokenIndex539 := position, tokenIndex
-								if buffer[position] != rune('m') {
-									goto l540
-								}
-								position++
-								goto l539
-							l540:
-								position, tokenIndex = position539, tokenIndex539
-								if buffer[position] != rune('M') {
-									goto l536
-								}
-								position++
-							}
-						l539:
-							{
-								position541, tokenIndex541 := position, tokenIndex
-								if buffer[position] != rune('a') {
-									goto l542
-								}
-								position++
-								goto l541
-							l542:
-								position, tokenIndex = position541, tokenIndex541
-								if buffer[position] != rune('A') {
-									goto l536
-								}
-								position++
-							}
-						l541:
-							{
-								position543, tokenIndex543 := position, tokenIndex
-								if buffer[position] != rune('k') {
-									goto l544
-								}
-								position++
-								goto l543
-							l544:
-								position, tokenIndex = position543, tokenIndex543
-								if buffer[position] != rune('K') {
-									goto l536
-								}
-								position++
-							}
-						l543:
-							{
-								position545, tokenIndex545 := position, tokenIndex
-								if buffer[position] != rune('e') {
-									goto l546
-								}
-								position++
-								goto l545
-							l546:
-								position, tokenIndex = position545, tokenIndex545
-								if buffer[position] != rune('E') {
-									goto l536
-								}
-								position++
-							}
-						l545:
-							add(ruleBuiltinFun, position538)
+						position1242, tokenIndex1242 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l1243
 						}
-						if buffer[position] != rune('(') {
-							goto l536
+						position++
+						goto l1242
+					l1243:
+						position, tokenIndex = position1242, tokenIndex1242
+						if buffer[position] != rune('N') {
+							goto l1241
 						}
 						position++
-					l547:
-						{
-							position548, tokenIndex548 := position, tokenIndex
-							if !_rules[ruleBuiltinArg]() {
-								goto l548
-							}
-							if buffer[position] != rune(',') {
-								goto l548
-							}
-							position++
-							{
-								position549, tokenIndex549 := position, tokenIndex
-								if !_rules[ruleWhitespace]() {
-									goto l549
-								}
-								goto l550
-							l549:
-								position, tokenIndex = position549, tokenIndex549
-							}
-						l550:
-							goto l547
-						l548:
-							position, tokenIndex = position548, tokenIndex548
+					}
+				l1242:
+					{
+						position1244, tokenIndex1244 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l1245
 						}
-						{
-							position551, tokenIndex551 := position, tokenIndex
-							if !_rules[ruleBuiltinArg]() {
-								goto l551
-							}
-							goto l552
-						l551:
-							position, tokenIndex = position551, tokenIndex551
+						position++
+						goto l1244
+					l1245:
+						position, tokenIndex = position1244, tokenIndex1244
+						if buffer[position] != rune('I') {
+							goto l1241
+						}
+						position++
+					}
+				l1244:
+					{
+						position1246, tokenIndex1246 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l1247
+						}
+						position++
+						goto l1246
+					l1247:
+						position, tokenIndex = position1246, tokenIndex1246
+						if buffer[position] != rune('L') {
+							goto l1241
+						}
+						position++
+					}
+				l1246:
+					goto l1240
+				l1241:
+					position, tokenIndex = position1240, tokenIndex1240
+					{
+						position1249, tokenIndex1249 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l1250
+						}
+						position++
+						goto l1249
+					l1250:
+						position, tokenIndex = position1249, tokenIndex1249
+						if buffer[position] != rune('T') {
+							goto l1248
+						}
+						position++
+					}
+				l1249:
+					{
+						position1251, tokenIndex1251 := position, tokenIndex
+						if buffer[position] != rune('r') {
+							goto l1252
+						}
+						position++
+						goto l1251
+					l1252:
+						position, tokenIndex = position1251, tokenIndex1251
+						if buffer[position] != rune('R') {
+							goto l1248
+						}
+						position++
+					}
+				l1251:
+					{
+						position1253, tokenIndex1253 := position, tokenIndex
+						if buffer[position] != rune('u') {
+							goto l1254
+						}
+						position++
+						goto l1253
+					l1254:
+						position, tokenIndex = position1253, tokenIndex1253
+						if buffer[position] != rune('U') {
+							goto l1248
+						}
+						position++
+					}
+				l1253:
+					{
+						position1255, tokenIndex1255 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l1256
+						}
+						position++
+						goto l1255
+					l1256:
+						position, tokenIndex = position1255, tokenIndex1255
+						if buffer[position] != rune('E') {
+							goto l1248
+						}
+						position++
+					}
+				l1255:
+					goto l1240
+				l1248:
+					position, tokenIndex = position1240, tokenIndex1240
+					{
+						position1257, tokenIndex1257 := position, tokenIndex
+						if buffer[position] != rune('f') {
+							goto l1258
+						}
+						position++
+						goto l1257
+					l1258:
+						position, tokenIndex = position1257, tokenIndex1257
+						if buffer[position] != rune('F') {
+							goto l1238
+						}
+						position++
+					}
+				l1257:
+					{
+						position1259, tokenIndex1259 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l1260
 						}
-					l552:
-						if buffer[position] != rune(')') {
-							goto l536
+						position++
+						goto l1259
+					l1260:
+						position, tokenIndex = position1259, tokenIndex1259
+						if buffer[position] != rune('A') {
+							goto l1238
 						}
 						position++
-						add(ruleBuiltinCall, position537)
 					}
-					goto l535
-				l536:
-					position, tokenIndex = position535, tokenIndex535
+				l1259:
 					{
-						position554 := position
-						if !_rules[ruleFunLabel]() {
-							goto l553
+						position1261, tokenIndex1261 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l1262
 						}
-						if buffer[position] != rune('(') {
-							goto l553
+						position++
+						goto l1261
+					l1262:
+						position, tokenIndex = position1261, tokenIndex1261
+						if buffer[position] != rune('L') {
+							goto l1238
 						}
 						position++
-					l555:
-						{
-							position556, tokenIndex556 := position, tokenIndex
-							if !_rules[ruleExpression]() {
-								goto l556
-							}
-							if buffer[position] != rune(',') {
-								goto l556
-							}
-							position++
-							{
-								position557, tokenIndex557 := position, tokenIndex
-								if !_rules[ruleWhitespace]() {
-									goto l557
-								}
-								goto l558
-							l557:
-								position, tokenIndex = position557, tokenIndex557
-							}
-						l558:
-							goto l555
-						l556:
-							position, tokenIndex = position556, tokenIndex556
+					}
+				l1261:
+					{
+						position1263, tokenIndex1263 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l1264
 						}
-						{
-							position559, tokenIndex559 := position, tokenIndex
-							if !_rules[ruleExpression]() {
-								goto l559
-							}
-							goto l560
-						l559:
-							position, tokenIndex = position559, tokenIndex559
+						position++
+						goto l1263
+					l1264:
+						position, tokenIndex = position1263, tokenIndex1263
+						if buffer[position] != rune('S') {
+							goto l1238
+						}
+						position++
+					}
+				l1263:
+					{
+						position1265, tokenIndex1265 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l1266
 						}
-					l560:
-						if buffer[position] != rune(')') {
-							goto l553
+						position++
+						goto l1265
+					l1266:
+						position, tokenIndex = position1265, tokenIndex1265
+						if buffer[position] != rune('E') {
+							goto l1238
 						}
 						position++
-						add(ruleFunCall, position554)
 					}
-					goto l535
-				l553:
-					position, tokenIndex = position535, tokenIndex535
+				l1265:
+				}
+			l1240:
+				{
+					position1267, tokenIndex1267 := position, tokenIndex
 					{
-						position561 := position
-						if !_rules[ruleSimple]() {
-							goto l533
+						position1268, tokenIndex1268 := position, tokenIndex
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l1269
 						}
-						if buffer[position] != rune('.') {
-							goto l533
+						position++
+						goto l1268
+					l1269:
+						position, tokenIndex = position1268, tokenIndex1268
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l1270
 						}
 						position++
-						if !_rules[ruleLabel]() {
-							goto l533
+						goto l1268
+					l1270:
+						position, tokenIndex = position1268, tokenIndex1268
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l1271
 						}
-						if buffer[position] != rune('(') {
-							goto l533
+						position++
+						goto l1268
+					l1271:
+						position, tokenIndex = position1268, tokenIndex1268
+						if buffer[position] != rune('_') {
+							goto l1272
 						}
 						position++
-					l562:
-						{
-							position563, tokenIndex563 := position, tokenIndex
-							if !_rules[ruleExpression]() {
-								goto l563
-							}
-							if buffer[position] != rune(',') {
-								goto l563
-							}
-							position++
-							{
-								position564, tokenIndex564 := position, tokenIndex
-								if !_rules[ruleWhitespace]() {
-									goto l564
-								}
-								goto l565
-							l564:
-								position, tokenIndex = position564, tokenIndex564
-							}
-						l565:
-							goto l562
-						l563:
-							position, tokenIndex = position563, tokenIndex563
+						goto l1268
+					l1272:
+						position, tokenIndex = position1268, tokenIndex1268
+						if buffer[position] != rune('`') {
+							goto l1273
 						}
-						{
-							position566, tokenIndex566 := position, tokenIndex
-							if !_rules[ruleExpression]() {
-								goto l566
-							}
-							goto l567
-						l566:
-							position, tokenIndex = position566, tokenIndex566
+						position++
+						goto l1268
+					l1273:
+						position, tokenIndex = position1268, tokenIndex1268
+						if buffer[position] != rune('?') {
+							goto l1274
 						}
-					l567:
-						if buffer[position] != rune(')') {
-							goto l533
+						position++
+						goto l1268
+					l1274:
+						position, tokenIndex = position1268, tokenIndex1268
+						if buffer[position] != rune('!') {
+							goto l1267
 						}
 						position++
-						add(ruleMethodCall, position561)
 					}
+				l1268:
+					goto l1238
+				l1267:
+					position, tokenIndex = position1267, tokenIndex1267
 				}
-			l535:
-				add(ruleCall, position534)
+				add(ruleConstant, position1239)
 			}
 			return true
-		l533:
-			position, tokenIndex = position533, tokenIndex533
+		l1238:
+			position, tokenIndex = position1238, tokenIndex1238
 			return false
 		},
-		/* 47 BuiltinCall <- <(BuiltinFun '(' (BuiltinArg ',' Whitespace?)* BuiltinArg? ')')> */
-		nil,
-		/* 48 BuiltinFun <- <(('m' / 'M') ('a' / 'A') ('k' / 'K') ('e' / 'E'))> */
-		nil,
-		/* 49 BuiltinArg <- <(Type / Expression)> */
+		/* 129 String <- <(Template / Text)> */
 		func() bool {
-			position570, tokenIndex570 := position, tokenIndex
+			position1275, tokenIndex1275 := position, tokenIndex
 			{
-				position571 := position
+				position1276 := position
 				{
-					position572, tokenIndex572 := position, tokenIndex
-					if !_rules[ruleType]() {
-						goto l573
+					position1277, tokenIndex1277 := position, tokenIndex
+					if !_rules[ruleTemplate]() {
+						goto l1278
 					}
-					goto l572
-				l573:
-					position, tokenIndex = position572, tokenIndex572
-					if !_rules[ruleExpression]() {
-						goto l570
+					goto l1277
+				l1278:
+					position, tokenIndex = position1277, tokenIndex1277
+					if !_rules[ruleText]() {
+						goto l1275
 					}
 				}
-			l572:
-				add(ruleBuiltinArg, position571)
+			l1277:
+				add(ruleString, position1276)
 			}
 			return true
-		l570:
-			position, tokenIndex = position570, tokenIndex570
+		l1275:
+			position, tokenIndex = position1275, tokenIndex1275
 			return false
 		},
-		/* 50 FunCall <- <(FunLabel '(' (Expression ',' Whitespace?)* Expression? ')')> */
-		nil,
-		/* 51 For <- <(ForIn / ForLoop)> */
-		nil,
-		/* 52 ForIn <- <(('f' / 'F') ('o' / 'O') ('r' / 'R') Whitespace (LowerLabel ',' Whitespace?)* LowerLabel Whitespace ('i' 'n') Whitespace Expression ':' Newline Indent Code)> */
-		nil,
-		/* 53 ForLoop <- <(('f' / 'F') ('o' / 'O') ('r' / 'R') Whitespace LowerLabel Whitespace ('i' 'n') Whitespace Range ':' Newline Indent Code)> */
-		nil,
-		/* 54 Range <- <(Integer RangeOperator Integer)> */
-		nil,
-		/* 55 RangeOperator <- <(('.' '.' '.') / ('.' '.'))> */
-		nil,
-		/* 56 On <- <(('o' / 'O') ('n' / 'N') Whitespace FunLabel ':' Newline Indent Code)> */
-		nil,
-		/* 57 Return <- <((&('E' | 'e') Escalator) | (&('!') ReturnError) | (&('R' | 'r') ReturnValue))> */
-		nil,
-		/* 58 ReturnValue <- <(('r' / 'R') ('e' / 'E') ('t' / 'T') ('u' / 'U') ('r' / 'R') ('n' / 'N') Whitespace? Expression)> */
-		nil,
-		/* 59 ReturnError <- <('!' '!' Whitespace? Expression)> */
-		nil,
-		/* 60 Escalator <- <(('e' / 'E') ('s' / 'S') ('c' / 'C') ('a' / 'A') ('l' / 'L') ('a' / 'A') ('t' / 'T') ('e' / 'E') Whitespace (FunLabel ',' Whitespace?)* FunLabel)> */
-		nil,
-		/* 61 LowerLabel <- <([a-z] ((&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> */
+		/* 130 Template <- <('"' (Segment Slot)+ Q '"')> */
 		func() bool {
-			position585, tokenIndex585 := position, tokenIndex
+			position1279, tokenIndex1279 := position, tokenIndex
 			{
-				position586 := position
-				if c := buffer[position]; c < rune('a') || c > rune('z') {
-					goto l585
+				position1280 := position
+				if buffer[position] != rune('"') {
+					goto l1279
 				}
 				position++
-			l587:
+				if !_rules[ruleSegment]() {
+					goto l1279
+				}
+				if !_rules[ruleSlot]() {
+					goto l1279
+				}
+			l1281:
 				{
-					position588, tokenIndex588 := position, tokenIndex
-					{
-						switch buffer[position] {
-						case '_':
-							if buffer[position] != rune('_') {
-								goto l588
-							}
-							position++
-							break
-						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l588
-							}
-							position++
-							break
-						default:
-							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l588
-							}
-							position++
-							break
-						}
+					position1282, tokenIndex1282 := position, tokenIndex
+					if !_rules[ruleSegment]() {
+						goto l1282
 					}
-
-					goto l587
-				l588:
-					position, tokenIndex = position588, tokenIndex588
+					if !_rules[ruleSlot]() {
+						goto l1282
+					}
+					goto l1281
+				l1282:
+					position, tokenIndex = position1282, tokenIndex1282
+				}
+				if !_rules[ruleQ]() {
+					goto l1279
 				}
-				add(ruleLowerLabel, position586)
+				if buffer[position] != rune('"') {
+					goto l1279
+				}
+				position++
+				add(ruleTemplate, position1280)
 			}
 			return true
-		l585:
-			position, tokenIndex = position585, tokenIndex585
+		l1279:
+			position, tokenIndex = position1279, tokenIndex1279
 			return false
 		},
-		/* 62 CapitalLabel <- <([A-Z] ((&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]))*)> */
+		/* 131 Segment <- <(!('#' / '"') .)*> */
 		func() bool {
-			position590, tokenIndex590 := position, tokenIndex
 			{
-				position591 := position
-				if c := buffer[position]; c < rune('A') || c > rune('Z') {
-					goto l590
-				}
-				position++
-			l592:
+				position1284 := position
+			l1285:
 				{
-					position593, tokenIndex593 := position, tokenIndex
+					position1286, tokenIndex1286 := position, tokenIndex
 					{
-						switch buffer[position] {
-						case '_':
-							if buffer[position] != rune('_') {
-								goto l593
-							}
-							position++
-							break
-						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l593
-							}
-							position++
-							break
-						case 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
-							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l593
+						position1287, tokenIndex1287 := position, tokenIndex
+						{
+							position1288, tokenIndex1288 := position, tokenIndex
+							if buffer[position] != rune('#') {
+								goto l1289
 							}
 							position++
-							break
-						default:
-							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l593
+							goto l1288
+						l1289:
+							position, tokenIndex = position1288, tokenIndex1288
+							if buffer[position] != rune('"') {
+								goto l1287
 							}
 							position++
-							break
 						}
+					l1288:
+						goto l1286
+					l1287:
+						position, tokenIndex = position1287, tokenIndex1287
 					}
-
-					goto l592
-				l593:
-					position, tokenIndex = position593, tokenIndex593
+					if !matchDot() {
+						goto l1286
+					}
+					goto l1285
+				l1286:
+					position, tokenIndex = position1286, tokenIndex1286
 				}
-				add(ruleCapitalLabel, position591)
+				add(ruleSegment, position1284)
 			}
 			return true
-		l590:
-			position, tokenIndex = position590, tokenIndex590
-			return false
 		},
-		/* 63 FunLowerLabel <- <([a-z] ((&('_') '_') | (&('`') '`') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))* ('?' / '!')?)> */
+		/* 132 Q <- <(!'"' .)*> */
 		func() bool {
-			position595, tokenIndex595 := position, tokenIndex
 			{
-				position596 := position
-				if c := buffer[position]; c < rune('a') || c > rune('z') {
-					goto l595
-				}
-				position++
-			l597:
+				position1291 := position
+			l1292:
 				{
-					position598, tokenIndex598 := position, tokenIndex
+					position1293, tokenIndex1293 := position, tokenIndex
 					{
-						switch buffer[position] {
-						case '_':
-							if buffer[position] != rune('_') {
-								goto l598
-							}
-							position++
-							break
-						case '`':
-							if buffer[position] != rune('`') {
-								goto l598
-							}
-							position++
-							break
-						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l598
-							}
-							position++
-							break
-						default:
-							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l598
-							}
-							position++
-							break
+						position1294, tokenIndex1294 := position, tokenIndex
+						if buffer[position] != rune('"') {
+							goto l1294
 						}
+						position++
+						goto l1293
+					l1294:
+						position, tokenIndex = position1294, tokenIndex1294
 					}
-
-					goto l597
-				l598:
-					position, tokenIndex = position598, tokenIndex598
+					if !matchDot() {
+						goto l1293
+					}
+					goto l1292
+				l1293:
+					position, tokenIndex = position1293, tokenIndex1293
+				}
+				add(ruleQ, position1291)
+			}
+			return true
+		},
+		/* 133 Text <- <('"' (!'"' .)* '"')> */
+		func() bool {
+			position1295, tokenIndex1295 := position, tokenIndex
+			{
+				position1296 := position
+				if buffer[position] != rune('"') {
+					goto l1295
 				}
+				position++
+			l1297:
 				{
-					position600, tokenIndex600 := position, tokenIndex
+					position1298, tokenIndex1298 := position, tokenIndex
 					{
-						position602, tokenIndex602 := position, tokenIndex
-						if buffer[position] != rune('?') {
-							goto l603
-						}
-						position++
-						goto l602
-					l603:
-						position, tokenIndex = position602, tokenIndex602
-						if buffer[position] != rune('!') {
-							goto l600
+						position1299, tokenIndex1299 := position, tokenIndex
+						if buffer[position] != rune('"') {
+							goto l1299
 						}
 						position++
+						goto l1298
+					l1299:
+						position, tokenIndex = position1299, tokenIndex1299
 					}
-				l602:
-					goto l601
-				l600:
-					position, tokenIndex = position600, tokenIndex600
+					if !matchDot() {
+						goto l1298
+					}
+					goto l1297
+				l1298:
+					position, tokenIndex = position1298, tokenIndex1298
 				}
-			l601:
-				add(ruleFunLowerLabel, position596)
+				if buffer[position] != rune('"') {
+					goto l1295
+				}
+				position++
+				add(ruleText, position1296)
 			}
 			return true
-		l595:
-			position, tokenIndex = position595, tokenIndex595
+		l1295:
+			position, tokenIndex = position1295, tokenIndex1295
 			return false
 		},
-		/* 64 Label <- <(FunLabel / CapitalLabel / LowerLabel)> */
+		/* 134 Error <- <('$' Label)> */
 		func() bool {
-			position604, tokenIndex604 := position, tokenIndex
+			position1300, tokenIndex1300 := position, tokenIndex
 			{
-				position605 := position
-				{
-					position606, tokenIndex606 := position, tokenIndex
-					if !_rules[ruleFunLabel]() {
-						goto l607
-					}
-					goto l606
-				l607:
-					position, tokenIndex = position606, tokenIndex606
-					if !_rules[ruleCapitalLabel]() {
-						goto l608
-					}
-					goto l606
-				l608:
-					position, tokenIndex = position606, tokenIndex606
-					if !_rules[ruleLowerLabel]() {
-						goto l604
-					}
+				position1301 := position
+				if buffer[position] != rune('$') {
+					goto l1300
+				}
+				position++
+				if !_rules[ruleLabel]() {
+					goto l1300
 				}
-			l606:
-				add(ruleLabel, position605)
+				add(ruleError, position1301)
 			}
 			return true
-		l604:
-			position, tokenIndex = position604, tokenIndex604
+		l1300:
+			position, tokenIndex = position1300, tokenIndex1300
 			return false
 		},
-		/* 65 Float <- <([0-9]+ '.' [0-9]+)> */
-		nil,
-		/* 66 Integer <- <[0-9]+> */
+		/* 135 Slot <- <('#' '{' Expression '}')> */
 		func() bool {
-			position610, tokenIndex610 := position, tokenIndex
+			position1302, tokenIndex1302 := position, tokenIndex
 			{
-				position611 := position
-				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l610
+				position1303 := position
+				if buffer[position] != rune('#') {
+					goto l1302
+				}
+				position++
+				if buffer[position] != rune('{') {
+					goto l1302
+				}
+				position++
+				if !_rules[ruleExpression]() {
+					goto l1302
+				}
+				if buffer[position] != rune('}') {
+					goto l1302
 				}
 				position++
-			l612:
+				add(ruleSlot, position1303)
+			}
+			return true
+		l1302:
+			position, tokenIndex = position1302, tokenIndex1302
+			return false
+		},
+		/* 136 Whitespace <- <' '+> */
+		func() bool {
+			position1304, tokenIndex1304 := position, tokenIndex
+			{
+				position1305 := position
+				if buffer[position] != rune(' ') {
+					goto l1304
+				}
+				position++
+			l1306:
 				{
-					position613, tokenIndex613 := position, tokenIndex
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l613
+					position1307, tokenIndex1307 := position, tokenIndex
+					if buffer[position] != rune(' ') {
+						goto l1307
 					}
 					position++
-					goto l612
-				l613:
-					position, tokenIndex = position613, tokenIndex613
+					goto l1306
+				l1307:
+					position, tokenIndex = position1307, tokenIndex1307
 				}
-				add(ruleInteger, position611)
+				add(ruleWhitespace, position1305)
 			}
 			return true
-		l610:
-			position, tokenIndex = position610, tokenIndex610
+		l1304:
+			position, tokenIndex = position1304, tokenIndex1304
 			return false
 		},
-		/* 67 Number <- <(Float / Integer)> */
-		nil,
-		/* 68 Constant <- <((&('F' | 'f') (('f' / 'F') ('a' / 'A') ('l' / 'L') ('s' / 'S') ('e' / 'E'))) | (&('T' | 't') (('t' / 'T') ('r' / 'R') ('u' / 'U') ('e' / 'E'))) | (&('N' | 'n') (('n' / 'N') ('i' / 'I') ('l' / 'L'))))> */
-		nil,
-		/* 69 String <- <(Template / Text)> */
+		/* 137 Gap <- <(' ' / '\n')+> */
 		func() bool {
-			position616, tokenIndex616 := position, tokenIndex
+			position1308, tokenIndex1308 := position, tokenIndex
 			{
-				position617 := position
+				position1309 := position
 				{
-					position618, tokenIndex618 := position, tokenIndex
-					{
-						position620 := position
-						if buffer[position] != rune('"') {
-							goto l619
-						}
-						position++
-						{
-							position623 := position
-						l624:
-							{
-								position625, tokenIndex625 := position, tokenIndex
-								{
-									position626, tokenIndex626 := position, tokenIndex
-									if buffer[position] != rune('#') {
-										goto l626
-									}
-									position++
-									goto l625
-								l626:
-									position, tokenIndex = position626, tokenIndex626
-								}
-								if !matchDot() {
-									goto l625
-								}
-								goto l624
-							l625:
-								position, tokenIndex = position625, tokenIndex625
-							}
-							add(ruleSegment, position623)
-						}
-						{
-							position627 := position
-							if buffer[position] != rune('#') {
-								goto l619
-							}
-							position++
-							if buffer[position] != rune('{') {
-								goto l619
-							}
-							position++
-							if !_rules[ruleExpression]() {
-								goto l619
-							}
-							if buffer[position] != rune('}') {
-								goto l619
-							}
-							position++
-							add(ruleSlot, position627)
-						}
-					l621:
-						{
-							position622, tokenIndex622 := position, tokenIndex
-							{
-								position628 := position
-							l629:
-								{
-									position630, tokenIndex630 := position, tokenIndex
-									{
-										position631, tokenIndex631 := position, tokenIndex
-										if buffer[position] != rune('#') {
-											goto l631
-										}
-										position++
-										goto l630
-									l631:
-										position, tokenIndex = position631, tokenIndex631
-									}
-									if !matchDot() {
-										goto l630
-									}
-									goto l629
-								l630:
-									position, tokenIndex = position630, tokenIndex630
-								}
-								add(ruleSegment, position628)
-							}
-							{
-								position632 := position
-								if buffer[position] != rune('#') {
-									goto l622
-								}
-								position++
-								if buffer[position] != rune('{') {
-									goto l622
-								}
-								position++
-								if !_rules[ruleExpression]() {
-									goto l622
-								}
-								if buffer[position] != rune('}') {
-									goto l622
-								}
-								position++
-								add(ruleSlot, position632)
-							}
-							goto l621
-						l622:
-							position, tokenIndex = position622, tokenIndex622
-						}
-						{
-							position633 := position
-						l634:
-							{
-								position635, tokenIndex635 := position, tokenIndex
-								{
-									position636, tokenIndex636 := position, tokenIndex
-									if buffer[position] != rune('"') {
-										goto l636
-									}
-									position++
-									goto l635
-								l636:
-									position, tokenIndex = position636, tokenIndex636
-								}
-								if !matchDot() {
-									goto l635
-								}
-								goto l634
-							l635:
-								position, tokenIndex = position635, tokenIndex635
-							}
-							add(ruleQ, position633)
-						}
-						if buffer[position] != rune('"') {
-							goto l619
-						}
-						position++
-						add(ruleTemplate, position620)
+					position1312, tokenIndex1312 := position, tokenIndex
+					if buffer[position] != rune(' ') {
+						goto l1313
 					}
-					goto l618
-				l619:
-					position, tokenIndex = position618, tokenIndex618
+					position++
+					goto l1312
+				l1313:
+					position, tokenIndex = position1312, tokenIndex1312
+					if buffer[position] != rune('\n') {
+						goto l1308
+					}
+					position++
+				}
+			l1312:
+			l1310:
+				{
+					position1311, tokenIndex1311 := position, tokenIndex
 					{
-						position637 := position
-						if buffer[position] != rune('"') {
-							goto l616
+						position1314, tokenIndex1314 := position, tokenIndex
+						if buffer[position] != rune(' ') {
+							goto l1315
 						}
 						position++
-					l638:
-						{
-							position639, tokenIndex639 := position, tokenIndex
-							{
-								position640, tokenIndex640 := position, tokenIndex
-								if buffer[position] != rune('"') {
-									goto l640
-								}
-								position++
-								goto l639
-							l640:
-								position, tokenIndex = position640, tokenIndex640
-							}
-							if !matchDot() {
-								goto l639
-							}
-							goto l638
-						l639:
-							position, tokenIndex = position639, tokenIndex639
-						}
-						if buffer[position] != rune('"') {
-							goto l616
+						goto l1314
+					l1315:
+						position, tokenIndex = position1314, tokenIndex1314
+						if buffer[position] != rune('\n') {
+							goto l1311
 						}
 						position++
-						add(ruleText, position637)
 					}
+				l1314:
+					goto l1310
+				l1311:
+					position, tokenIndex = position1311, tokenIndex1311
 				}
-			l618:
-				add(ruleString, position617)
+				add(ruleGap, position1309)
 			}
 			return true
-		l616:
-			position, tokenIndex = position616, tokenIndex616
+		l1308:
+			position, tokenIndex = position1308, tokenIndex1308
 			return false
 		},
-		/* 70 Template <- <('"' (Segment Slot)+ Q '"')> */
-		nil,
-		/* 71 Segment <- <(!'#' .)*> */
-		nil,
-		/* 72 Q <- <(!'"' .)*> */
-		nil,
-		/* 73 Text <- <('"' (!'"' .)* '"')> */
-		nil,
-		/* 74 Error <- <('$' Label)> */
-		nil,
-		/* 75 Slot <- <('#' '{' Expression '}')> */
-		nil,
-		/* 76 Whitespace <- <' '+> */
+		/* 138 Newline <- <'\n'+> */
 		func() bool {
-			position647, tokenIndex647 := position, tokenIndex
+			position1316, tokenIndex1316 := position, tokenIndex
 			{
-				position648 := position
-				if buffer[position] != rune(' ') {
-					goto l647
+				position1317 := position
+				if buffer[position] != rune('\n') {
+					goto l1316
 				}
 				position++
-			l649:
+			l1318:
 				{
-					position650, tokenIndex650 := position, tokenIndex
-					if buffer[position] != rune(' ') {
-						goto l650
+					position1319, tokenIndex1319 := position, tokenIndex
+					if buffer[position] != rune('\n') {
+						goto l1319
 					}
 					position++
-					goto l649
-				l650:
-					position, tokenIndex = position650, tokenIndex650
+					goto l1318
+				l1319:
+					position, tokenIndex = position1319, tokenIndex1319
 				}
-				add(ruleWhitespace, position648)
+				add(ruleNewline, position1317)
 			}
 			return true
-		l647:
-			position, tokenIndex = position647, tokenIndex647
+		l1316:
+			position, tokenIndex = position1316, tokenIndex1316
 			return false
 		},
-		/* 77 Newline <- <'\n'+> */
+		/* 139 EOT <- <!.> */
 		func() bool {
-			position651, tokenIndex651 := position, tokenIndex
+			position1320, tokenIndex1320 := position, tokenIndex
 			{
-				position652 := position
-				if buffer[position] != rune('\n') {
-					goto l651
-				}
-				position++
-			l653:
+				position1321 := position
 				{
-					position654, tokenIndex654 := position, tokenIndex
-					if buffer[position] != rune('\n') {
-						goto l654
+					position1322, tokenIndex1322 := position, tokenIndex
+					if !matchDot() {
+						goto l1322
 					}
-					position++
-					goto l653
-				l654:
-					position, tokenIndex = position654, tokenIndex654
+					goto l1320
+				l1322:
+					position, tokenIndex = position1322, tokenIndex1322
 				}
-				add(ruleNewline, position652)
+				add(ruleEOT, position1321)
 			}
 			return true
-		l651:
-			position, tokenIndex = position651, tokenIndex651
+		l1320:
+			position, tokenIndex = position1320, tokenIndex1320
 			return false
 		},
-		/* 78 EOT <- <!.> */
-		nil,
 	}
 	p.rules = _rules
+	return nil
 }
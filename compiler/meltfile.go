@@ -0,0 +1,279 @@
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const MeltfileName = "Meltfile.yaml"
+
+// Meltfile is the project manifest in the project root
+type Meltfile struct {
+	// Root is the directory of the manifest
+	Root    string
+	File    string
+	Name    string
+	Version string
+	Author  string
+	Email   string
+	// Source are the package directories, relative to the root
+	Source []string
+	// Output is the directory of the generated go files
+	Output string
+	// Module is the go module path of the output
+	Module string
+	// SafeName numbers the generic instances: Map0, Map1
+	// otherwise they are named after their types: MapOfInt
+	SafeName bool
+	// Vendor is the directory of dependencies given only with a version
+	Vendor string
+	Deps   []Dependency
+}
+
+// Dependency on a melt package
+// It's found in Path if given, otherwise in the vendor directory
+type Dependency struct {
+	Name    string
+	Version string
+	Path    string
+	Line    int
+}
+
+// ManifestError is a Meltfile error with its yaml line
+type ManifestError struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *ManifestError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// Diagnostic is the error as a diagnostic of the Meltfile
+func (e *ManifestError) Diagnostic() *Diagnostic {
+	return &Diagnostic{
+		LocationInfo: LocationInfo{File: e.File, Line: e.Line, Column: 1},
+		Code:         CodeManifest,
+		Severity:     SeverityError,
+		Message:      e.Message}
+}
+
+// FindMeltfile returns the path of the nearest Meltfile in dir or its parents
+func FindMeltfile(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, MeltfileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", &os.PathError{Op: "find", Path: MeltfileName, Err: os.ErrNotExist}
+		}
+		dir = parent
+	}
+}
+
+func LoadMeltfile(path string) (*Meltfile, error) {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMeltfile(path, source)
+}
+
+func ParseMeltfile(path string, source []byte) (*Meltfile, error) {
+	m := Meltfile{
+		Root:     filepath.Dir(path),
+		File:     path,
+		Source:   []string{"."},
+		Output:   "out",
+		SafeName: true,
+		Vendor:   "vendor",
+		Deps:     []Dependency{}}
+
+	var document yaml.Node
+	err := yaml.Unmarshal(source, &document)
+	if err != nil {
+		// syntax errors look like yaml: line 3: message
+		line, message := 0, err.Error()
+		fmt.Sscanf(message, "yaml: line %d:", &line)
+		return nil, &ManifestError{File: path, Line: line, Message: message}
+	}
+	if len(document.Content) == 0 {
+		return &m, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, m.errorAt(root, "expected a map")
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "name":
+			err = m.scalar(value, &m.Name)
+		case "version":
+			err = m.scalar(value, &m.Version)
+		case "author":
+			err = m.scalar(value, &m.Author)
+		case "email":
+			err = m.scalar(value, &m.Email)
+		case "output":
+			err = m.scalar(value, &m.Output)
+		case "module":
+			err = m.scalar(value, &m.Module)
+		case "vendor":
+			err = m.scalar(value, &m.Vendor)
+		case "source":
+			m.Source, err = m.list(value)
+		case "safe_name":
+			err = value.Decode(&m.SafeName)
+			if err != nil || value.Kind != yaml.ScalarNode {
+				err = m.errorAt(value, "safe_name: expected true or false")
+			}
+		case "deps":
+			err = m.loadDeps(value)
+		default:
+			err = m.errorAt(key, fmt.Sprintf("unknown field %s", key.Value))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if m.Module == "" {
+		m.Module = m.Name
+	}
+	return &m, nil
+}
+
+func (m *Meltfile) errorAt(node *yaml.Node, message string) error {
+	return &ManifestError{File: m.File, Line: node.Line, Message: message}
+}
+
+func (m *Meltfile) scalar(node *yaml.Node, value *string) error {
+	if node.Kind != yaml.ScalarNode {
+		return m.errorAt(node, "expected a string")
+	}
+	*value = node.Value
+	return nil
+}
+
+// list accepts a single string too
+func (m *Meltfile) list(node *yaml.Node) ([]string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return []string{node.Value}, nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nil, m.errorAt(node, "expected a list of strings")
+	}
+	values := []string{}
+	for _, item := range node.Content {
+		var value string
+		err := m.scalar(item, &value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// loadDeps reads
+//
+//	deps:
+//	  melt:
+//	    parser: 2.3.0
+//	    collections:
+//	      path: ../collections
+func (m *Meltfile) loadDeps(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return m.errorAt(node, "deps: expected a map")
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value != "melt" {
+			return m.errorAt(key, fmt.Sprintf("deps: unknown kind %s, expected melt", key.Value))
+		}
+		if value.Kind != yaml.MappingNode {
+			return m.errorAt(value, "deps: melt: expected a map")
+		}
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			name, spec := value.Content[j], value.Content[j+1]
+			dep := Dependency{Name: name.Value, Line: name.Line}
+			switch spec.Kind {
+			case yaml.ScalarNode:
+				dep.Version = spec.Value
+			case yaml.MappingNode:
+				for k := 0; k+1 < len(spec.Content); k += 2 {
+					field, fieldValue := spec.Content[k], spec.Content[k+1]
+					var err error
+					switch field.Value {
+					case "version":
+						err = m.scalar(fieldValue, &dep.Version)
+					case "path":
+						err = m.scalar(fieldValue, &dep.Path)
+					default:
+						err = m.errorAt(field, fmt.Sprintf("deps: %s: unknown field %s", dep.Name, field.Value))
+					}
+					if err != nil {
+						return err
+					}
+				}
+			default:
+				return m.errorAt(spec, fmt.Sprintf("deps: %s: expected a version or a map", dep.Name))
+			}
+			if dep.Version == "" && dep.Path == "" {
+				return m.errorAt(spec, fmt.Sprintf("deps: %s: expected a version or a path", dep.Name))
+			}
+			m.Deps = append(m.Deps, dep)
+		}
+	}
+	return nil
+}
+
+// ResolveDeps returns the directory of each dependency
+// A version is looked up as vendor/name@version and then vendor/name
+func (m *Meltfile) ResolveDeps() (map[string]string, error) {
+	dirs := make(map[string]string)
+	for _, dep := range m.Deps {
+		candidates := []string{}
+		if dep.Path != "" {
+			candidates = append(candidates, dep.Path)
+		} else {
+			vendor := filepath.Join(m.Root, m.Vendor)
+			candidates = append(candidates,
+				filepath.Join(vendor, fmt.Sprintf("%s@%s", dep.Name, dep.Version)),
+				filepath.Join(vendor, dep.Name))
+		}
+
+		found := false
+		for _, dir := range candidates {
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(m.Root, dir)
+			}
+			info, err := os.Stat(dir)
+			if err == nil && info.IsDir() {
+				dirs[dep.Name] = dir
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, &ManifestError{
+				File:    m.File,
+				Line:    dep.Line,
+				Message: fmt.Sprintf("dependency %s not found in %s", dep.Name, candidates[0])}
+		}
+	}
+	return dirs, nil
+}
@@ -1,33 +1,41 @@
 package compiler
 
-import (
-	"fmt"
-	// "go/ast"
-)
-
 // Module node
 // A single file corresponds to it
 type Module struct {
+	File string
+	// Source are the lines of the file, used to show diagnostics
+	Source     []string
 	Package    string
 	Imports    *MeltImport
 	Functions  []*Function
 	Interfaces []*Interface
 	Records    []*Record
+	Unions     []*Union
 
 	Info
 }
 
 func (self *Module) TypeCheck(ctx *Context) error {
-	fmt.Println("%s\n", self)
-	err := ctx.CollectTypes(*self)
-	if err != nil {
-		return err
-	}
+	Tracef(TraceTypeCheck, "module %s", self.File)
+	ctx.Report(self.Imports.TypeCheck(ctx))
+	ctx.Report(ctx.CollectTypes(*self))
+	ctx.CollectMethods([]*Module{self})
+	ctx.ResolveSignatures(*self)
+	ctx.Report(self.TypeCheckFunctions(ctx))
+
+	ctx.Diagnostics.Sort()
+	return self.Explain(ctx.Diagnostics.Err())
+}
 
+// TypeCheckFunctions checks the functions after the types of
+// the module are collected
+// A broken function is reported and the next one is checked
+func (self *Module) TypeCheckFunctions(ctx *Context) error {
 	for _, f := range self.Functions {
-		err = f.TypeCheck(ctx)
+		err := f.TypeCheck(ctx)
 		if err != nil {
-			return err
+			ctx.Report(Locate(err, f))
 		}
 	}
 
@@ -8,7 +8,9 @@ type Nil struct {
 	Info
 }
 
+// TypeCheck types nil as nil, pointers, slices, maps, channels,
+// functions, interfaces and errors accept it
 func (n *Nil) TypeCheck(ctx *Context) error {
-	n.ZType = types.Empty{}
+	n.ZType = types.Nil{}
 	return nil
 }
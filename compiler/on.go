@@ -1,44 +1,133 @@
 package compiler
 
 import (
-	"errors"
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
+// On node: on f: runs its handler if a call of f before it failed
+// on f as NotFound: runs it if the error is a NotFound, the arms
+// right after it handle the other errors of f
 type On struct {
-	Label   *Label
+	Label *Label
+	// Type is the record an arm handles, nil for on f:
+	Type    *Label
 	Handler *Code
+	// Arms are the arms after the first one, on f as Denied: or on f:
+	Arms []*On
+	// Errors are the variables of the errors of the calls it handles, in order
+	Errors []string
+	// Clear is true if a later handler can take the errors on another path,
+	// the handler clears them
+	Clear bool
 
 	Info
 }
 
 func (o *On) TypeCheck(ctx *Context) error {
-	label, err := ctx.Get(o.Label.Label)
+	name := BareLabel(o.Label.Label)
+	label, err := ctx.Get(name)
 	if err != nil {
-		return err
+		// a failing method isn't a label: on Atoi handles strconv.Atoi!(..)
+		if !ctx.Failures.Pending(name) {
+			return Locate(err, o.Label)
+		}
+		label = types.Function{Error: types.Fail}
 	}
 
 	if function, ok := label.(types.Function); ok {
 		if function.Error != types.Correct {
-			_, ok := (*ctx.Unhandled)[o.Label.Label]
+			handler, ok := (*ctx.Handled)[name]
 			if ok {
-				return errors.New("Already handled error")
+				err = Errorf(o.Label, CodeHandled, "Already handled error of %s", name)
+				return Relate(err, handler, "the error of %s is handled here", name)
 			} else {
-				system := NewContextIn(ctx)
-				delete(*ctx.Unhandled, o.Label.Label)
-				system.Set("$err", types.Error{Label: "err"})
-				err = o.Handler.TypeCheck(system)
-				if err != nil {
-					return err
+				(*ctx.Handled)[name] = o
+				o.Errors = []string{}
+				for _, failure := range ctx.Take(name, o) {
+					failure.First = failure.Loops > ctx.Loops && !failure.Spawn
+					o.Errors = append(o.Errors, failure.Variable())
 				}
-				return nil
+				return o.typeCheckArms(function, ctx)
 			}
 		} else {
-			return fmt.Errorf("%s can't return an error", o.Label.Label)
+			return Errorf(o.Label, CodeCantFail, "%s can't return an error", o.Label.Label)
 		}
 	} else {
-		return fmt.Errorf("%s is not a function", o.Label.Label)
+		return Errorf(o.Label, CodeNotFunction, "%s is not a function", o.Label.Label)
+	}
+}
+
+// typeCheckArms checks the handler of each arm with $err of its type,
+// together they have to handle every error of the function
+func (o *On) typeCheckArms(function types.Function, ctx *Context) error {
+	name := BareLabel(o.Label.Label)
+	handled := map[string]*On{}
+	for _, arm := range append([]*On{o}, o.Arms...) {
+		system := NewContextIn(ctx)
+		if arm.Type == nil {
+			system.Set("$err", types.Error{Label: "err"})
+		} else {
+			t, err := errorType(arm.Type, ctx)
+			if err != nil {
+				return err
+			}
+			if len(function.ErrorTypes) > 0 && !declares(function.ErrorTypes, arm.Type.Label) {
+				return Errorf(arm.Type, CodeErrorKind, "%s fails only with %s, not %s", name, errorTypeList(function.ErrorTypes), arm.Type.Label)
+			} else if previous, ok := handled[arm.Type.Label]; ok {
+				err = Errorf(arm.Type, CodeHandled, "Already handled %s errors of %s", arm.Type.Label, name)
+				return Relate(err, previous.Type, "the %s errors of %s are handled here", arm.Type.Label, name)
+			}
+			handled[arm.Type.Label] = arm
+			arm.Type.ZType = t
+			system.Set("$err", t)
+		}
+		err := arm.Handler.TypeCheck(system)
+		if err != nil {
+			return err
+		}
+	}
+
+	last := o
+	if len(o.Arms) > 0 {
+		last = o.Arms[len(o.Arms)-1]
+	}
+	if last.Type == nil {
+		return nil
+	} else if len(function.ErrorTypes) == 0 {
+		return Errorf(last.Type, CodeExhaustive, "%s doesn't declare its errors, its other errors need an on %s: after on %s as %s:", name, name, name, last.Type.Label)
+	}
+	missing := []types.Type{}
+	for _, t := range function.ErrorTypes {
+		if _, ok := handled[errorLabel(t)]; !ok {
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) > 0 {
+		return Errorf(last.Type, CodeExhaustive, "%s errors of %s aren't handled, they need an on %s as %s: or an on %s:", errorTypeList(missing), name, name, errorLabel(missing[0]), name)
+	}
+	return nil
+}
+
+// chainArms moves the arms right after an on f as NotFound: to it,
+// they're checked and generated as one handler
+// A later on f: or an arm after on f: handles f again
+func chainArms(e []Ast) []Ast {
+	result := []Ast{}
+	var head *On
+	for _, node := range e {
+		o, ok := node.(*On)
+		if ok && head != nil && o.Label.Label == head.Label.Label {
+			head.Arms = append(head.Arms, o)
+			if o.Type == nil {
+				head = nil
+			}
+			continue
+		}
+		head = nil
+		if ok && o.Type != nil {
+			head = o
+		}
+		result = append(result, node)
 	}
+	return result
 }
@@ -0,0 +1,152 @@
+package compiler
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"unicode"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// Package node
+// All the melt files of a directory, checked in one shared context
+type Package struct {
+	Name    string
+	Dir     string
+	Modules []*Module
+	// Path is the melt import path, empty for the main package
+	Path string
+	// GoPath is the go import path of the generated package
+	GoPath  string
+	Context *Context
+	// Warnings are found by a check, they don't fail it
+	Warnings DiagnosticList
+}
+
+// LoadPackage parses every .melt file in dir
+func LoadPackage(dir string) (*Package, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.melt"))
+	if err != nil {
+		return &Package{}, err
+	}
+	if len(files) == 0 {
+		return &Package{}, &os.PathError{Op: "load", Path: dir, Err: errors.New("no melt files")}
+	}
+	p, err := ParsePackage(files)
+	if err != nil {
+		return p, err
+	}
+	p.Dir = dir
+	return p, nil
+}
+
+// ParsePackage parses the files, which have to declare the same package
+func ParsePackage(files []string) (*Package, error) {
+	if len(files) == 0 {
+		return &Package{}, errors.New("no melt files")
+	}
+	sort.Strings(files)
+
+	// a syntax error stops a file, the next files are still parsed
+	p := &Package{Dir: filepath.Dir(files[0]), Modules: []*Module{}}
+	diagnostics := DiagnosticList{}
+	for _, filename := range files {
+		source, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return p, err
+		}
+
+		m, err := ParseFile(filename, string(source))
+		if err != nil {
+			diagnostics = append(diagnostics, m.Explain(err).(*Diagnostic))
+			continue
+		}
+
+		if p.Name == "" {
+			p.Name = m.Package
+		} else if m.Package != p.Name {
+			return p, m.Explain(Failf(CodePackageName, "package %s, expected %s", m.Package, p.Name))
+		}
+		p.Modules = append(p.Modules, &m)
+	}
+	if len(diagnostics) > 0 {
+		return p, diagnostics
+	}
+	return p, nil
+}
+
+// TypeCheck collects the declarations of all modules first,
+// so functions can use records and functions from other files
+// All errors are collected and returned sorted by position
+func (p *Package) TypeCheck(ctx *Context) error {
+	for _, m := range p.Modules {
+		ctx.Report(m.Imports.TypeCheck(ctx))
+	}
+
+	for _, m := range p.Modules {
+		ctx.Report(ctx.CollectTypes(*m))
+	}
+	ctx.CollectMethods(p.Modules)
+
+	for _, m := range p.Modules {
+		ctx.ResolveSignatures(*m)
+	}
+
+	for _, m := range p.Modules {
+		ctx.Report(m.TypeCheckFunctions(ctx))
+	}
+	p.checkEscalated(ctx)
+
+	ctx.Diagnostics.Sort()
+	for _, m := range p.Modules {
+		m.Explain(*ctx.Diagnostics)
+	}
+	p.Warnings = ctx.Diagnostics.Warnings()
+	return ctx.Diagnostics.Err()
+}
+
+// Instantiate expands the generic functions of the whole package
+// Each instance goes to the module defining the generic function
+func (p *Package) Instantiate(ctx *Context) error {
+	return instantiateModules(p.Modules, ctx)
+}
+
+// Type returns the exported functions, records and interfaces
+// of a checked package
+func (p *Package) Type() types.Package {
+	t := types.NewPackage(p.Name, p.Path)
+	for _, m := range p.Modules {
+		for _, f := range m.Functions {
+			if IsExported(f.Label.Label) {
+				t.Members[f.Label.Label] = f.MeltType()
+			}
+		}
+		for _, r := range m.Records {
+			if IsExported(r.Label.Label) {
+				t.Members[r.Label.Label] = r.MeltType()
+			}
+		}
+		for _, u := range m.Unions {
+			if IsExported(u.Label.Label) {
+				t.Members[u.Label.Label] = u.MeltType()
+			}
+		}
+		for _, i := range m.Interfaces {
+			if IsExported(i.Label.Label) {
+				t.Members[i.Label.Label] = i.MeltType()
+			}
+		}
+	}
+	return t
+}
+
+// IsExported is true for labels starting with a capital letter, like in go
+func IsExported(label string) bool {
+	for _, c := range label {
+		return unicode.IsUpper(c)
+	}
+	return false
+}
@@ -5,42 +5,144 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-
-	"github.com/davecgh/go-spew/spew"
+	"unicode"
 
 	"gitlab.com/alehander42/melt/types"
 )
 
 func Parse(source string) (Module, error) {
-	indented, err := Preprocess(source)
+	return ParseFile("", source)
+}
+
+// ParseFile parses a melt file, the positions of the nodes are in it
+func ParseFile(file string, source string) (Module, error) {
+	indented, positions, err := PreprocessFile(file, source)
 	if err != nil {
-		return Module{}, err
+		return Module{File: file, Source: positions.Lines}, err
 	}
-	melt := &MeltParser{Buffer: indented}
+	melt := &MeltParser{Buffer: indented, Positions: positions}
 	melt.Init()
 	err = melt.Parse()
 	if err != nil {
-		return Module{}, err
+		return Module{File: file, Source: positions.Lines}, melt.SyntaxError(err)
 	} else {
 		sexp, err := Load(melt)
+		sexp.File = file
+		sexp.Source = positions.Lines
 		if err != nil {
-			return Module{}, err
+			return sexp, err
 		}
-		spew.Dump(err)
+		Tracef(TraceParse, "parsed %s: %d functions, %d records, %d interfaces",
+			file, len(sexp.Functions), len(sexp.Records), len(sexp.Interfaces))
 		return sexp, nil
 	}
 }
 
+// SyntaxError turns a parse error into a diagnostic
+// at the furthest position the parser reached
+func (melt *MeltParser) SyntaxError(err error) error {
+	e, ok := err.(*parseError)
+	if !ok || melt.Positions == nil {
+		return err
+	}
+
+	offset := int(e.max.end)
+	rest := []rune{}
+	if offset < len(melt.buffer) {
+		rest = melt.buffer[offset:]
+	}
+
+	var message string
+	switch {
+	case len(rest) == 0:
+		message = "unexpected end of file"
+	case strings.HasPrefix(string(rest), "@@indent@@"):
+		message = "unexpected indentation"
+	case strings.HasPrefix(string(rest), "@@dedent@@"):
+		message = "unexpected end of block"
+	case rest[0] == '\n':
+		message = "unexpected end of line"
+	default:
+		word := func(c rune) bool {
+			return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_'
+		}
+		end := 1
+		if word(rest[0]) {
+			for end < len(rest) && word(rest[end]) {
+				end++
+			}
+		}
+		message = fmt.Sprintf("unexpected %q", string(rest[:end]))
+	}
+
+	location := melt.Positions.Position(offset)
+	return &Diagnostic{
+		LocationInfo: location,
+		Code:         CodeSyntax,
+		Message:      fmt.Sprintf("syntax error: %s", message),
+		Source:       melt.Positions.Line(location.Line)}
+}
+
+// ErrorAt gives an error without a position the position of the parse node
+func (melt *MeltParser) ErrorAt(err error, ast *node32) error {
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) || melt.Positions == nil {
+		return err
+	}
+	return &Diagnostic{LocationInfo: melt.Range(ast), Code: CodeUnsupported, Message: err.Error()}
+}
+
+// Locate gives a node the position of its parse node
+func (melt *MeltParser) Locate(node Ast, ast *node32) {
+	if melt.Positions == nil || ast == nil || node.Location().Line != 0 {
+		return
+	}
+	node.SetLocation(melt.Range(ast))
+}
+
+// Range is the position of a parse node, from its beginning to its last character
+// The newlines and dedents after a block aren't part of it
+func (melt *MeltParser) Range(ast *node32) LocationInfo {
+	location := melt.Positions.Position(int(ast.begin))
+	dedent := len("@@dedent@@")
+	begin, end := int(ast.begin), int(ast.end)
+	for end > begin {
+		if c := melt.buffer[end-1]; c == '\n' || c == ' ' || c == '\t' {
+			end--
+		} else if end-begin >= dedent && string(melt.buffer[end-dedent:end]) == "@@dedent@@" {
+			end -= dedent
+		} else {
+			break
+		}
+	}
+	if end > begin {
+		last := melt.Positions.Position(end - 1)
+		location.EndLine, location.EndColumn = last.Line, last.Column+1
+	}
+	return location
+}
+
 func Load(melt *MeltParser) (Module, error) {
 	ast := melt.AST()
 	m, err := LoadModule(ast, melt)
+	melt.Locate(m, ast)
 	return *m, err
 	// ast.up.Print(melt.Buffer)
 	// fmt.Println(ast.up.next.next.up.next.token32.String())
 	// return Module{}, nil
 }
 
+// LoadNode loads a node with the position of the source
 func LoadNode(ast *node32, melt *MeltParser) (Ast, error) {
+	node, err := loadNode(ast, melt)
+	if err != nil {
+		return node, melt.ErrorAt(err, ast)
+	}
+	melt.Locate(node, ast)
+	return node, nil
+}
+
+func loadNode(ast *node32, melt *MeltParser) (Ast, error) {
 	switch Kind(ast) {
 	case "Assignment":
 		return LoadAssignment(ast, melt)
@@ -56,8 +158,43 @@ func LoadNode(ast *node32, melt *MeltParser) (Ast, error) {
 		return LoadMethodCall(ast, melt)
 	case "For":
 		return LoadFor(ast.up, melt)
+	case "If":
+		return LoadIf(ast, melt)
 	case "On":
 		return LoadOn(ast, melt)
+	case "Loop":
+		code, err := LoadCode(child(ast.up, "Code"), melt)
+		if err != nil {
+			return &Loop{}, err
+		}
+		return &Loop{Code: &code}, nil
+	case "Break":
+		return &Break{}, nil
+	case "Send":
+		return LoadSend(ast, melt)
+	case "Receive":
+		channel, err := LoadNode(child(ast.up, "Unary"), melt)
+		if err != nil {
+			return &Receive{}, err
+		}
+		return &Receive{Channel: channel}, nil
+	case "Go":
+		return LoadGo(ast, melt)
+	case "Select":
+		return LoadSelect(ast, melt)
+	case "Match":
+		return LoadMatch(ast, melt)
+	case "Lambda", "ShortLambda":
+		return LoadLambda(ast, melt)
+	case "Spawn":
+		label := child(ast.up, "LowerLabel")
+		l := ToLabel(melt.Buffer[label.begin:label.end])
+		melt.Locate(l, label)
+		code, err := LoadCode(child(label, "Code"), melt)
+		if err != nil {
+			return &Spawn{}, err
+		}
+		return &Spawn{Label: l, Code: &code}, nil
 	case "Return":
 		return LoadNode(ast.up, melt)
 	case "ReturnValue":
@@ -70,6 +207,12 @@ func LoadNode(ast *node32, melt *MeltParser) (Ast, error) {
 		return LoadNode(ast.up, melt)
 	case "Label":
 		return ToLabel(melt.Buffer[ast.begin:ast.end]), nil
+	case "Integer":
+		a, err := strconv.ParseInt(melt.Buffer[ast.begin:ast.end], 10, 64)
+		if err != nil {
+			return &Module{}, err
+		}
+		return ToInteger(a), nil
 	case "Number":
 		if rul3s[ast.up.pegRule] == "Integer" {
 			a, err := strconv.ParseInt(melt.Buffer[ast.begin:ast.end], 10, 64)
@@ -101,68 +244,63 @@ func LoadNode(ast *node32, melt *MeltParser) (Ast, error) {
 		}
 	case "Error":
 		a := ToLabel(melt.Buffer[ast.up.begin:ast.up.end])
+		melt.Locate(a, ast.up)
 		return &Error{Label: a}, nil
 	case "Line":
 		return LoadNode(ast.up, melt)
-	case "BinaryOperation":
-		node := ast.up.up
-		a, err := LoadNode(node, melt)
-		if err != nil {
-			return &Module{}, err
+	case "Disjunction", "Conjunction", "Comparison", "Sum", "Product":
+		return LoadOperation(ast, melt)
+	case "Unary":
+		if Kind(ast.up) != "UnaryOperator" {
+			return LoadNode(ast.up, melt)
 		}
-		op := ast.up.next.next
-		operator := AddOp
-		switch melt.Buffer[op.begin:op.end] {
-		case "+":
-			operator = AddOp
-		case "-":
-			operator = SubOp
-		case "*":
-			operator = MultOp
-		case "/":
-			operator = DivideOp
-		}
-		node = op.next.next.up
-		b, err := LoadNode(node, melt)
+		operator, _ := ToUnaryOperator(melt.Buffer[ast.up.begin:ast.up.end])
+		z, err := LoadNode(ast.up.next, melt)
 		if err != nil {
 			return &Module{}, err
 		}
-		return &BinaryOperation{Left: &a, Right: &b, Op: operator}, nil
-	case "UnaryOperation":
-		node := ast.up
-		operator := PlusOp
-		if melt.Buffer[node.begin:node.end] == "-" {
-			operator = MinusOp
-		}
-		b := node.next.up
-		z, err := LoadNode(b, melt)
-		if err != nil {
-			return &Module{}, nil
-		}
 		return &UnaryOperation{Op: operator, Expression: &z}, nil
-	case "IndexAssignment":
+	case "Cast":
+		return LoadCast(ast, melt)
+	case "Primary":
+		return LoadNode(ast.up, melt)
+	case "Parens":
 		node := ast.up
-		collection, err := LoadNode(node, melt)
-		if err != nil {
-			return &IndexAssignment{}, err
+		for Kind(node) != "Expression" {
+			node = node.next
 		}
-
-		node = node.next
-		index, err := LoadNode(node, melt)
+		return LoadNode(node, melt)
+	case "CompoundAssignment":
+		return LoadCompoundAssignment(ast, melt)
+	case "Selector":
+		return LoadSelector(ast, melt)
+	case "SelectorObject":
+		return LoadNode(ast.up, melt)
+	case "FieldAssignment":
+		return LoadFieldAssignment(ast, melt)
+	case "RecordLiteral":
+		return LoadRecordLiteral(ast, melt)
+	case "Index":
+		return LoadIndex(ast, melt)
+	case "IndexObject":
+		return LoadNode(ast.up, melt)
+	case "IndexAssignment":
+		// xs[i][j] = value assigns the index j of xs[i]
+		target, err := LoadIndex(ast.up, melt)
 		if err != nil {
 			return &IndexAssignment{}, err
 		}
 
-		node = node.next.next.next
-		value, err := LoadNode(node, melt)
+		value, err := LoadNode(child(ast.up.next, "Expression"), melt)
 		if err != nil {
 			return &IndexAssignment{}, err
 		}
 
-		return &IndexAssignment{Collection: &collection, Index: &index, Value: &value}, nil
+		return &IndexAssignment{Collection: &target.Collection, Index: &target.Index, Value: &value}, nil
 	case "Interface":
 		label := ast.up.next
 		l := ToLabel(melt.Buffer[label.begin:label.end])
+		melt.Locate(l, label)
 		args := label.next
 		t := []types.GenericVar{}
 		if args != nil && Kind(args) == "GenericArgs" {
@@ -183,27 +321,30 @@ func LoadNode(ast *node32, melt *MeltParser) (Ast, error) {
 				} else {
 					f := code.up
 					g := ToLabel(melt.Buffer[f.begin:f.end])
+					melt.Locate(g, f)
 					node := f.next
 					typez := []types.Type{}
-					node.Print(melt.Buffer)
-					fmt.Printf("WWWWWWWW")
+					var z types.Type = types.Empty{}
 					for node != nil {
-						if Kind(node) == "Z" {
+						if Kind(node) == "Type" {
+							h, err := LoadType(node, melt)
+							if err != nil {
+								return &Interface{}, err
+							}
+
+							typez = append(typez, h)
+						} else if Kind(node) == "Z" {
 							typeNode := node.up.next
 							h, err := LoadType(typeNode, melt)
 							if err != nil {
 								return &Interface{}, err
 							}
 
-							typez = append(typez, h)
+							z = h
 						}
 						node = node.next
 					}
-					if len(typez) == 0 {
-						typez = append(typez, types.Empty{})
-					}
-
-					fmt.Printf("%s", typez)
+					typez = append(typez, z)
 
 					args := typez[:len(typez)-1]
 					returnType := typez[len(typez)-1]
@@ -213,7 +354,10 @@ func LoadNode(ast *node32, melt *MeltParser) (Ast, error) {
 					} else if g.Label[len(g.Label)-1] == '?' {
 						er = types.Maybe
 					}
+					// the method is found by its bare label, like the methods of records
+					g.Label = BareLabel(g.Label)
 					methods = append(methods, InterfaceMethod{Label: g, Type: types.Function{Args: args, Return: returnType, GenericVars: t, InstanceVars: make([]types.Type, len(t)), Error: er}})
+					methods[len(methods)-1].SetLocation(g.Location())
 					vesela = append(vesela, types.Method{Label: g.Label, Function: methods[len(methods)-1].Type})
 					code = code.next
 				}
@@ -226,6 +370,7 @@ func LoadNode(ast *node32, melt *MeltParser) (Ast, error) {
 	case "Record":
 		node := ast.up.next
 		label := ToLabel(melt.Buffer[node.begin:node.end])
+		melt.Locate(label, node)
 		node = node.next
 		t := []types.GenericVar{}
 		if node != nil && Kind(node) == "GenericArgs" {
@@ -234,51 +379,63 @@ func LoadNode(ast *node32, melt *MeltParser) (Ast, error) {
 		}
 		fields := []Field{}
 		typeFields := make(map[string]types.Type)
+		order := []string{}
 		if node != nil {
 			node = node.up.next.next
 			for node != nil {
+				if Kind(node) == "Newline" {
+					node = node.next
+					continue
+				}
 				sex := node.up
 				if sex == nil {
 					break
 				}
 				fieldLabel := ToLabel(melt.Buffer[sex.begin:sex.end])
+				melt.Locate(fieldLabel, sex)
 				fieldType, err := LoadType(sex.next.next, melt)
 
 				if err != nil {
 					return &Record{}, err
 				}
 
-				last := Field{Label: fieldLabel, Info: Info{MType: MType{ZType: fieldType}}}
+				last := Field{Label: fieldLabel, Info: Info{LocationInfo: fieldLabel.Location(), MType: MType{ZType: fieldType}}}
 				fields = append(fields, last)
 				typeFields[last.Label.Label] = fieldType
+				order = append(order, last.Label.Label)
 				node = node.next
 			}
 		}
-		recordType := types.Record{GenericVars: t, InstanceVars: make([]types.Type, len(t)), Fields: typeFields, Label: label.Label}
+		recordType := types.Record{GenericVars: t, InstanceVars: make([]types.Type, len(t)), Fields: typeFields, Order: order, Label: label.Label}
 		return &Record{Info: Info{MType: MType{ZType: recordType}}, Fields: fields, Label: label}, nil
+	case "Union":
+		return LoadUnion(ast, melt)
 	case "Top":
 		return LoadNode(ast.up, melt)
-	default:
-		fmt.Println("%s", Kind(ast))
 	}
-	return &Module{}, errors.New("wtf")
+	return &Module{}, Failf(CodeUnsupported, "%s is not supported here", Kind(ast))
 }
 
 func LoadModule(ast *node32, melt *MeltParser) (*Module, error) {
 	meltPackage := ast.up
 	packageLabel := melt.Buffer[meltPackage.up.next.begin:meltPackage.up.next.end]
 
-	next := meltPackage.next.next
+	// a file can have only its package
+	var next *node32
+	if meltPackage.next != nil {
+		next = meltPackage.next.next
+	}
 
 	var imports MeltImport
 
 	var err error
 
-	if Kind(next) == "Import" {
+	if next != nil && Kind(next) == "Import" {
 		imports, err = LoadImport(next, melt)
 		if err != nil {
 			return &Module{}, err
 		}
+		melt.Locate(&imports, next)
 		next = next.next
 		if Kind(next) == "Newline" {
 			next = next.next
@@ -289,7 +446,8 @@ func LoadModule(ast *node32, melt *MeltParser) (*Module, error) {
 	functions := []*Function{}
 	interfaces := []*Interface{}
 	records := []*Record{}
-	for {
+	unions := []*Union{}
+	for next != nil {
 		if Kind(next) == "Newline" {
 			if melt.Buffer[next.begin:next.end] == "\n\n" {
 				break
@@ -298,9 +456,6 @@ func LoadModule(ast *node32, melt *MeltParser) (*Module, error) {
 				continue
 			}
 		}
-		if next == nil {
-			break
-		}
 		if Kind(next) != "Top" {
 			next = next.next
 			continue
@@ -333,23 +488,136 @@ func LoadModule(ast *node32, melt *MeltParser) (*Module, error) {
 			} else {
 				return &Module{}, errors.New("wat")
 			}
+		} else if Kind(next.up) == "Union" {
+			u, err := LoadUnion(next.up, melt)
+			if err != nil {
+				return &Module{}, err
+			}
+			unions = append(unions, u)
 		}
 		next = next.next
 	}
-	return &Module{Package: packageLabel, Imports: &imports, Interfaces: interfaces, Records: records, Functions: functions}, nil
+	return &Module{Package: packageLabel, Imports: &imports, Interfaces: interfaces, Records: records, Unions: unions, Functions: functions}, nil
+}
+
+// LoadUnion loads the variants of a union and its types.Sum
+func LoadUnion(ast *node32, melt *MeltParser) (*Union, error) {
+	node := child(ast.up, "CapitalLabel")
+	label := ToLabel(melt.Buffer[node.begin:node.end])
+	melt.Locate(label, node)
+	genericVars := []types.GenericVar{}
+	if args := child(node, "GenericArgs"); args != nil {
+		genericVars = LoadGenericArgs(args, melt)
+	}
+
+	union := &Union{Label: label, Variants: []*Variant{}}
+	variants := []types.Variant{}
+	for node = child(node, "Variant"); node != nil; node = child(node.next, "Variant") {
+		labelNode := node.up
+		variant := &Variant{Label: ToLabel(melt.Buffer[labelNode.begin:labelNode.end]), Fields: []Field{}}
+		melt.Locate(variant.Label, labelNode)
+		melt.Locate(variant, node)
+		fields := []types.VariantField{}
+		count := 0
+		for f := child(labelNode, "VariantField"); f != nil; f = child(f.next, "VariantField") {
+			count++
+		}
+		for f := child(labelNode, "VariantField"); f != nil; f = child(f.next, "VariantField") {
+			var fieldLabel *Label
+			typeNode := child(f.up, "Type")
+			if Kind(f.up) == "LowerLabel" {
+				fieldLabel = ToLabel(melt.Buffer[f.up.begin:f.up.end])
+				melt.Locate(fieldLabel, f.up)
+			} else if count == 1 {
+				fieldLabel = ToLabel("Value")
+				melt.Locate(fieldLabel, f)
+			} else {
+				fieldLabel = ToLabel(fmt.Sprintf("Value%d", len(fields)))
+				melt.Locate(fieldLabel, f)
+			}
+			fieldType, err := LoadType(typeNode, melt)
+			if err != nil {
+				return nil, err
+			}
+			variant.Fields = append(variant.Fields, Field{Label: fieldLabel, Info: Info{LocationInfo: fieldLabel.Location(), MType: MType{ZType: fieldType}}})
+			fields = append(fields, types.VariantField{Label: fieldLabel.Label, Type: fieldType})
+		}
+		union.Variants = append(union.Variants, variant)
+		variants = append(variants, types.Variant{Label: variant.Label.Label, Fields: fields})
+	}
+
+	union.ZType = types.Sum{Label: label.Label, Variants: variants, GenericVars: genericVars, InstanceVars: make([]types.Type, len(genericVars))}
+	melt.Locate(union, ast)
+	return union, nil
 }
 
 func LoadImport(ast *node32, melt *MeltParser) (MeltImport, error) {
-	return MeltImport{}, nil
+	imports := MeltImport{Go: []Import{}, Melt: []Import{}}
+	for node := ast.up; node != nil; node = node.next {
+		switch Kind(node) {
+		case "GoImport":
+			imports.Go = append(imports.Go, LoadImportLines(node, melt)...)
+		case "MeltImport":
+			imports.Melt = append(imports.Melt, LoadImportLines(node, melt)...)
+		case "ImportLine":
+			imports.Go = append(imports.Go, LoadImportLine(node, melt))
+		}
+	}
+	return imports, nil
+}
+
+func LoadImportLines(ast *node32, melt *MeltParser) []Import {
+	imports := []Import{}
+	for node := ast.up; node != nil; node = node.next {
+		if Kind(node) == "ImportLine" {
+			imports = append(imports, LoadImportLine(node, melt))
+		}
+	}
+	return imports
+}
+
+func LoadImportLine(ast *node32, melt *MeltParser) Import {
+	path := ast.up
+	i := Import{Package: strings.Trim(melt.Buffer[path.begin:path.end], "\"")}
+	melt.Locate(&i, path)
+	for node := path.next; node != nil; node = node.next {
+		if Kind(node) == "LowerLabel" {
+			i.Alias = melt.Buffer[node.begin:node.end]
+		}
+	}
+	return i
 }
 
 func LoadAssignment(ast *node32, melt *MeltParser) (*Set, error) {
 	label := ToLabel(melt.Buffer[ast.up.begin:ast.up.end])
-	value, err := LoadNode(ast.up.next.next.next, melt)
+	melt.Locate(label, ast.up)
+	set := &Set{Label: label}
+	if node := child(ast.up, "Type"); node != nil {
+		t, err := LoadType(node, melt)
+		if err != nil {
+			return set, err
+		}
+		set.Type = t
+	}
+	value, err := LoadNode(child(ast.up, "Expression"), melt)
+	if err != nil {
+		return set, err
+	}
+	set.Value = &value
+	return set, nil
+}
+
+func LoadCast(ast *node32, melt *MeltParser) (Ast, error) {
+	value, err := LoadNode(ast.up, melt)
+	typeNode := child(ast.up, "Type")
+	if err != nil || typeNode == nil {
+		return value, err
+	}
+	t, err := LoadType(typeNode, melt)
 	if err != nil {
-		return &Set{}, err
+		return &Cast{}, err
 	}
-	return &Set{Label: label, Value: &value}, nil
+	return &Cast{Value: value, Type: t}, nil
 }
 
 func LoadBuiltinCall(ast *node32, melt *MeltParser) (*Make, error) {
@@ -375,12 +643,13 @@ func LoadBuiltinCall(ast *node32, melt *MeltParser) (*Make, error) {
 
 func LoadFunCall(ast *node32, melt *MeltParser) (*Call, error) {
 	label := ToLabel(melt.Buffer[ast.up.begin:ast.up.end])
+	melt.Locate(label, ast.up)
 	a := ast.up.next
 	args := []Ast{}
 	for {
 		if a == nil {
 			break
-		} else if rul3s[a.pegRule] == "Whitespace" {
+		} else if rul3s[a.pegRule] == "Whitespace" || rul3s[a.pegRule] == "Gap" {
 			a = a.next
 			continue
 		} else {
@@ -404,12 +673,13 @@ func LoadMethodCall(ast *node32, melt *MeltParser) (*MethodCall, error) {
 	}
 	method := simple.next
 	label := ToLabel(melt.Buffer[method.begin:method.end])
+	melt.Locate(label, method)
 	args := []Ast{}
 	a := method.next
 	for {
 		if a == nil {
 			break
-		} else if rul3s[a.pegRule] == "Whitespace" {
+		} else if rul3s[a.pegRule] == "Whitespace" || rul3s[a.pegRule] == "Gap" {
 			a = a.next
 			continue
 		} else {
@@ -428,6 +698,7 @@ func LoadFor(ast *node32, melt *MeltParser) (Ast, error) {
 	a := rul3s[ast.pegRule]
 	if a == "ForLoop" {
 		index := ToLabel(melt.Buffer[ast.up.next.begin:ast.up.next.end])
+		melt.Locate(index, ast.up.next)
 		in := ast.up.next.next.next.next
 		a := in.up
 		op := a.next
@@ -446,13 +717,15 @@ func LoadFor(ast *node32, melt *MeltParser) (Ast, error) {
 			return &ForLoop{}, err
 		}
 
-		return &ForLoop{Index: index, Begin: &begin, End: &end, Code: &c}, nil
+		inclusive := melt.Buffer[op.begin:op.end] == ".."
+		return &ForLoop{Index: index, Begin: &begin, End: &end, Inclusive: inclusive, Code: &c}, nil
 	} else {
 		node := ast.up.next
 		index := []Label{}
 		for rul3s[node.pegRule] != "Expression" {
 			if rul3s[node.pegRule] != "Whitespace" {
 				a := ToLabel(melt.Buffer[node.begin:node.end])
+				melt.Locate(a, node)
 				index = append(index, *a)
 			}
 			node = node.next
@@ -474,30 +747,497 @@ func LoadEscalate(ast *node32, melt *MeltParser) (*Escalate, error) {
 	node := ast.up.next
 	args := []*Label{}
 
+	var message Ast
 	for node != nil {
-		if Kind(node) != "Whitespace" {
-			args = append(args, ToLabel(melt.Buffer[node.begin:node.end]))
+		if Kind(node) == "Wrapping" {
+			wrapping, err := LoadWrapping(node, melt)
+			if err != nil {
+				return &Escalate{}, err
+			}
+			message = wrapping
+		} else if Kind(node) != "Whitespace" {
+			arg := ToLabel(melt.Buffer[node.begin:node.end])
+			melt.Locate(arg, node)
+			args = append(args, arg)
 		}
 		node = node.next
 	}
 
-	return &Escalate{Args: args}, nil
+	return &Escalate{Args: args, Message: message}, nil
 }
 
+// LoadWrapping loads the expression after with: the error of !! "message" with $err
+// or the message of escalate f with "message"
+func LoadWrapping(node *node32, melt *MeltParser) (Ast, error) {
+	return LoadNode(child(node.up, "Expression"), melt)
+}
+
+// LoadOn loads on f: or an arm on f as NotFound:
 func LoadOn(ast *node32, melt *MeltParser) (*On, error) {
 	node := ast.up.next
 	label := ToLabel(melt.Buffer[node.begin:node.end])
-	code := node.next.next.next
-	c, err := LoadCode(code, melt)
+	melt.Locate(label, node)
+	var errorType *Label
+	if capital := child(node, "CapitalLabel"); capital != nil {
+		errorType = ToLabel(melt.Buffer[capital.begin:capital.end])
+		melt.Locate(errorType, capital)
+	}
+	c, err := LoadCode(child(node, "Code"), melt)
 	if err != nil {
 		return &On{}, err
 	}
 
-	return &On{Label: label, Handler: &c}, nil
+	return &On{Label: label, Type: errorType, Handler: &c}, nil
 }
 
-func LoadReturnValue(ast *node32, melt *MeltParser) (*Return, error) {
+func LoadSend(ast *node32, melt *MeltParser) (*Send, error) {
+	node := child(ast.up, "Expression")
+	channel, err := LoadNode(node, melt)
+	if err != nil {
+		return &Send{}, err
+	}
+	value, err := LoadNode(child(node.next, "Expression"), melt)
+	if err != nil {
+		return &Send{}, err
+	}
+	return &Send{Channel: channel, Value: value}, nil
+}
+
+func LoadGo(ast *node32, melt *MeltParser) (*Go, error) {
 	node := ast.up.next
+	if Kind(node) == "GoFunc" {
+		newline := child(node.up, "Newline")
+		fail := strings.Contains(melt.Buffer[node.begin:newline.begin], "!")
+		code, err := LoadCode(child(newline, "Code"), melt)
+		if err != nil {
+			return &Go{}, err
+		}
+		return &Go{Code: &code, Fail: fail}, nil
+	}
+	call, err := LoadNode(node, melt)
+	if err != nil {
+		return &Go{}, err
+	}
+	return &Go{Call: call}, nil
+}
+
+func LoadSelect(ast *node32, melt *MeltParser) (*Select, error) {
+	cases := []*SelectCase{}
+	for node := ast.up; node != nil; node = node.next {
+		if Kind(node) != "SelectCase" {
+			continue
+		}
+		c := &SelectCase{}
+		melt.Locate(c, node)
+		kind := node.up.next
+		switch Kind(kind) {
+		case "Send":
+			send, err := LoadNode(kind, melt)
+			if err != nil {
+				return &Select{}, err
+			}
+			c.Send = send.(*Send)
+		case "SelectReceive":
+			if Kind(kind.up) == "LowerLabel" {
+				c.Label = ToLabel(melt.Buffer[kind.up.begin:kind.up.end])
+				melt.Locate(c.Label, kind.up)
+			}
+			receive, err := LoadNode(child(kind.up, "Receive"), melt)
+			if err != nil {
+				return &Select{}, err
+			}
+			c.Receive = receive.(*Receive)
+		}
+		code, err := LoadCode(child(kind, "Code"), melt)
+		if err != nil {
+			return &Select{}, err
+		}
+		c.Code = &code
+		cases = append(cases, c)
+	}
+	return &Select{Cases: cases}, nil
+}
+
+func LoadMatch(ast *node32, melt *MeltParser) (*MatchBlock, error) {
+	value, err := LoadNode(child(ast.up, "Expression"), melt)
+	if err != nil {
+		return &MatchBlock{}, err
+	}
+	arms := []*MatchArm{}
+	for node := child(ast.up, "MatchArm"); node != nil; node = child(node.next, "MatchArm") {
+		arm := &MatchArm{}
+		melt.Locate(arm, node)
+		arm.Pattern, err = LoadPattern(child(node.up, "Pattern"), melt)
+		if err != nil {
+			return &MatchBlock{}, err
+		}
+		if guard := child(node.up, "Expression"); guard != nil {
+			arm.Guard, err = LoadNode(guard, melt)
+			if err != nil {
+				return &MatchBlock{}, err
+			}
+		}
+		code, err := LoadCode(child(node.up, "Code"), melt)
+		if err != nil {
+			return &MatchBlock{}, err
+		}
+		arm.Code = &code
+		arms = append(arms, arm)
+	}
+	return &MatchBlock{Value: value, Arms: arms}, nil
+}
+
+// LoadPattern loads a Pattern or a SubPattern
+func LoadPattern(ast *node32, melt *MeltParser) (*Pattern, error) {
+	pattern := &Pattern{Text: melt.Buffer[ast.begin:ast.end]}
+	melt.Locate(pattern, ast)
+	node := ast.up
+	if Kind(node) == "SubPattern" {
+		node = node.up
+	}
+	switch Kind(node) {
+	case "LowerLabel":
+		pattern.Binding = ToLabel(melt.Buffer[node.begin:node.end])
+		melt.Locate(pattern.Binding, node)
+	case "PatternLiteral":
+		number := child(node.up, "Number")
+		if number != nil && melt.Buffer[node.begin] == '-' {
+			literal, err := LoadNode(number, melt)
+			if err != nil {
+				return nil, err
+			}
+			if integer, ok := literal.(*Integer); ok {
+				integer.Value = -integer.Value
+			} else if float, ok := literal.(*Float); ok {
+				float.Value = -float.Value
+			}
+			pattern.Literal = literal
+		} else {
+			literal, err := LoadNode(node.up, melt)
+			if err != nil {
+				return nil, err
+			}
+			pattern.Literal = literal
+		}
+		melt.Locate(pattern.Literal, node)
+	case "VariantPattern":
+		pattern.Constructor = ToLabel(melt.Buffer[node.up.begin:node.up.end])
+		melt.Locate(pattern.Constructor, node.up)
+		// Circle without parens matches any fields
+		if strings.Contains(melt.Buffer[node.begin:node.end], "(") {
+			pattern.Fields = []*Pattern{}
+		}
+		for field := child(node.up, "SubPattern"); field != nil; field = child(field.next, "SubPattern") {
+			p, err := LoadPattern(field, melt)
+			if err != nil {
+				return nil, err
+			}
+			pattern.Fields = append(pattern.Fields, p)
+		}
+	case "RecordPattern":
+		pattern.Constructor = ToLabel(melt.Buffer[node.up.begin:node.up.end])
+		melt.Locate(pattern.Constructor, node.up)
+		pattern.Record = true
+		pattern.Fields = []*Pattern{}
+		pattern.Labels = []string{}
+		for field := child(node.up, "FieldPattern"); field != nil; field = child(field.next, "FieldPattern") {
+			label := melt.Buffer[field.up.begin:field.up.end]
+			var p *Pattern
+			if sub := child(field.up, "SubPattern"); sub != nil {
+				var err error
+				p, err = LoadPattern(sub, melt)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				// Point{x} binds the field x to x
+				p = &Pattern{Text: label, Binding: ToLabel(label)}
+				melt.Locate(p, field.up)
+				melt.Locate(p.Binding, field.up)
+			}
+			pattern.Fields = append(pattern.Fields, p)
+			pattern.Labels = append(pattern.Labels, label)
+		}
+	}
+	return pattern, nil
+}
+
+func LoadLambda(ast *node32, melt *MeltParser) (*Lambda, error) {
+	lambda := &Lambda{Args: []Arg{}, Error: types.Correct}
+	for node := ast.up; node != nil; node = node.next {
+		switch Kind(node) {
+		case "LambdaArgs":
+			for arg := child(node.up, "LambdaArg"); arg != nil; arg = child(arg.next, "LambdaArg") {
+				a, err := LoadLambdaArg(arg, melt)
+				if err != nil {
+					return &Lambda{}, err
+				}
+				lambda.Args = append(lambda.Args, a)
+			}
+		case "LambdaArg":
+			a, err := LoadLambdaArg(node, melt)
+			if err != nil {
+				return &Lambda{}, err
+			}
+			lambda.Args = append(lambda.Args, a)
+		case "LambdaError":
+			if melt.Buffer[node.begin:node.end] == "!" {
+				lambda.Error = types.Fail
+			} else {
+				lambda.Error = types.Maybe
+			}
+		case "Type":
+			t, err := LoadType(node, melt)
+			if err != nil {
+				return &Lambda{}, err
+			}
+			lambda.Return = t
+		case "Code":
+			code, err := LoadCode(node, melt)
+			if err != nil {
+				return &Lambda{}, err
+			}
+			lambda.Code = &code
+		case "Expression":
+			body, err := LoadNode(node, melt)
+			if err != nil {
+				return &Lambda{}, err
+			}
+			lambda.Body = body
+		}
+	}
+	return lambda, nil
+}
+
+// LoadLambdaArg loads an arg, its type is nil if it's inferred
+func LoadLambdaArg(ast *node32, melt *MeltParser) (Arg, error) {
+	id := ToLabel(melt.Buffer[ast.up.begin:ast.up.end])
+	melt.Locate(id, ast.up)
+	arg := Arg{ID: id, Info: Info{LocationInfo: id.Location()}}
+	if t := child(ast.up, "Type"); t != nil {
+		var err error
+		arg.Type, err = LoadType(t, melt)
+		if err != nil {
+			return Arg{}, err
+		}
+		arg.ID.ZType = arg.Type
+	}
+	return arg, nil
+}
+
+// child is the first node of this kind from node on
+func child(node *node32, kind string) *node32 {
+	for node != nil && Kind(node) != kind {
+		node = node.next
+	}
+	return node
+}
+
+// LoadOperation folds the operands of a precedence level to the left:
+// a - b - c is (a - b) - c
+func LoadOperation(ast *node32, melt *MeltParser) (Ast, error) {
+	var left Ast
+	var operator *node32
+	for node := ast.up; node != nil; node = node.next {
+		switch Kind(node) {
+		case "Whitespace":
+		case "OrOperator", "AndOperator", "CmpOperator", "SumOperator", "ProductOperator":
+			operator = node
+		default:
+			right, err := LoadNode(node, melt)
+			if err != nil {
+				return &Module{}, err
+			}
+			if left == nil {
+				left = right
+				continue
+			}
+
+			token := melt.Buffer[operator.begin:operator.end]
+			var operation Ast
+			if Kind(operator) == "CmpOperator" {
+				op, _ := ToOperator(token)
+				operation = &Cmp{Op: op, Left: left, Right: right}
+			} else {
+				op, _ := ToBinaryOperator(token)
+				a, b := left, right
+				operation = &BinaryOperation{Op: op, Left: &a, Right: &b}
+			}
+			operation.SetLocation(span(left, right))
+			left = operation
+		}
+	}
+	return left, nil
+}
+
+// span is the position from the beginning of a node to the end of another
+func span(first Ast, last Ast) LocationInfo {
+	location := first.Location()
+	end := last.Location()
+	location.EndLine, location.EndColumn = end.EndLine, end.EndColumn
+	return location
+}
+
+// LoadSelector nests the selectors: a.b.c is the selector c of a.b
+func LoadSelector(ast *node32, melt *MeltParser) (*Selector, error) {
+	object, err := LoadNode(ast.up, melt)
+	if err != nil {
+		return &Selector{}, err
+	}
+	var selector *Selector
+	for node := ast.up.next; node != nil; node = node.next {
+		if Kind(node) != "FieldLabel" {
+			continue
+		}
+		label := ToLabel(melt.Buffer[node.begin:node.end])
+		melt.Locate(label, node)
+		selector = &Selector{Object: object, Field: label}
+		selector.SetLocation(span(object, label))
+		object = selector
+	}
+	return selector, nil
+}
+
+// LoadIndex nests the indexes: m[key][0] is the index 0 of m[key]
+func LoadIndex(ast *node32, melt *MeltParser) (*Index, error) {
+	collection, err := LoadNode(ast.up, melt)
+	if err != nil {
+		return &Index{}, err
+	}
+	var index *Index
+	for node := ast.up.next; node != nil; node = node.next {
+		key, err := LoadNode(child(node.up, "Expression"), melt)
+		if err != nil {
+			return &Index{}, err
+		}
+		index = &Index{Collection: collection, Index: key}
+		if melt.Positions != nil {
+			location := collection.Location()
+			end := melt.Range(node)
+			location.EndLine, location.EndColumn = end.EndLine, end.EndColumn
+			index.SetLocation(location)
+		}
+		collection = index
+	}
+	return index, nil
+}
+
+func LoadFieldAssignment(ast *node32, melt *MeltParser) (*FieldAssignment, error) {
+	target, err := LoadSelector(ast.up, melt)
+	if err != nil {
+		return &FieldAssignment{}, err
+	}
+	value, err := LoadNode(child(ast.up.next, "Expression"), melt)
+	if err != nil {
+		return &FieldAssignment{}, err
+	}
+	return &FieldAssignment{Target: target, Value: value}, nil
+}
+
+// LoadRecordLiteral loads Point{x: 0, y: 2}, the positional Point{0, 2} has no fields
+func LoadRecordLiteral(ast *node32, melt *MeltParser) (*RecordLiteral, error) {
+	t, err := LoadType(ast.up, melt)
+	if err != nil {
+		return &RecordLiteral{}, err
+	}
+	literal := &RecordLiteral{Type: t, Values: []Ast{}}
+	for node := ast.up.next; node != nil; node = node.next {
+		switch Kind(node) {
+		case "LiteralField":
+			label := ToLabel(melt.Buffer[node.up.begin:node.up.end])
+			melt.Locate(label, node.up)
+			value, err := LoadNode(child(node.up.next, "Expression"), melt)
+			if err != nil {
+				return &RecordLiteral{}, err
+			}
+			literal.Fields = append(literal.Fields, label)
+			literal.Values = append(literal.Values, value)
+		case "Expression":
+			value, err := LoadNode(node, melt)
+			if err != nil {
+				return &RecordLiteral{}, err
+			}
+			literal.Values = append(literal.Values, value)
+		}
+	}
+	return literal, nil
+}
+
+func LoadCompoundAssignment(ast *node32, melt *MeltParser) (*CompoundAssignment, error) {
+	var target Ast
+	if Kind(ast.up) == "LowerLabel" {
+		label := ToLabel(melt.Buffer[ast.up.begin:ast.up.end])
+		melt.Locate(label, ast.up)
+		target = label
+	} else {
+		var err error
+		target, err = LoadNode(ast.up, melt)
+		if err != nil {
+			return &CompoundAssignment{}, err
+		}
+	}
+	node := ast.up.next
+	if Kind(node) == "Whitespace" {
+		node = node.next
+	}
+	token := melt.Buffer[node.begin : node.end-1]
+	operator, _ := ToBinaryOperator(token)
+	node = node.next
+	if Kind(node) == "Whitespace" {
+		node = node.next
+	}
+	value, err := LoadNode(node, melt)
+	if err != nil {
+		return &CompoundAssignment{}, err
+	}
+	return &CompoundAssignment{Target: target, Op: operator, Value: &value}, nil
+}
+
+// LoadIf loads if, elif and else blocks
+// An elif is an If which is the only node of the else block of the previous one
+func LoadIf(ast *node32, melt *MeltParser) (*If, error) {
+	var first, last *If
+	for node := ast.up; node != nil; node = node.next {
+		switch Kind(node) {
+		case "Expression", "Elif":
+			branch := &If{}
+			test := node
+			if Kind(node) == "Elif" {
+				test = node.up.next.next
+				melt.Locate(branch, node)
+			}
+			condition, err := LoadNode(test, melt)
+			if err != nil {
+				return &If{}, err
+			}
+			code, err := LoadCode(test.next.next.next, melt)
+			if err != nil {
+				return &If{}, err
+			}
+			branch.Test = condition
+			branch.Code = &code
+			if first == nil {
+				first = branch
+			} else {
+				last.Otherwise = &Code{E: []Ast{branch}, Info: Info{LocationInfo: branch.Location()}}
+			}
+			last = branch
+		case "Else":
+			code, err := LoadCode(node.up.next.next.next, melt)
+			if err != nil {
+				return &If{}, err
+			}
+			last.Otherwise = &code
+		}
+	}
+	return first, nil
+}
+
+func LoadReturnValue(ast *node32, melt *MeltParser) (*Return, error) {
+	node := child(ast.up, "Expression")
+	if node == nil {
+		return &Return{}, nil
+	}
 	as, err := LoadNode(node, melt)
 	if err != nil {
 		return &Return{}, err
@@ -507,12 +1247,18 @@ func LoadReturnValue(ast *node32, melt *MeltParser) (*Return, error) {
 
 func LoadReturnError(node *node32, melt *MeltParser) (*ReturnError, error) {
 	if node != nil {
-		node := node.up.next
-		as, err := LoadNode(node, melt)
+		as, err := LoadNode(child(node.up, "Expression"), melt)
 		if err != nil {
 			return &ReturnError{}, err
 		}
-		return &ReturnError{Value: &as}, nil
+		var wrapped Ast
+		if wrapping := child(node.up, "Wrapping"); wrapping != nil {
+			wrapped, err = LoadWrapping(wrapping, melt)
+			if err != nil {
+				return &ReturnError{}, err
+			}
+		}
+		return &ReturnError{Value: &as, Wrapped: wrapped}, nil
 	}
 	return &ReturnError{}, errors.New("err")
 }
@@ -522,8 +1268,7 @@ func LoadCode(ast *node32, melt *MeltParser) (Code, error) {
 	e := []Ast{}
 	for node != nil {
 		if rul3s[node.pegRule] == "Line" {
-			node = node.up
-			result, err := LoadNode(node, melt)
+			result, err := LoadNode(node.up, melt)
 			if err != nil {
 				return Code{}, err
 			}
@@ -531,14 +1276,22 @@ func LoadCode(ast *node32, melt *MeltParser) (Code, error) {
 		}
 		node = node.next
 	}
-	return Code{E: e}, nil
+	c := Code{E: chainArms(e)}
+	melt.Locate(&c, ast)
+	return c, nil
 }
 func LoadTemplate(ast *node32, melt *MeltParser) (*Template, error) {
 	node := ast.up
 	text := []string{}
 	args := []Ast{}
+	// an empty segment has no node, a slot follows each segment
+	segment := false
 	for node != nil && rul3s[node.pegRule] != "Q" {
 		if rul3s[node.pegRule] == "Slot" {
+			if !segment {
+				text = append(text, "")
+			}
+			segment = false
 			e := node.up
 			object, err := LoadNode(e, melt)
 			if err != nil {
@@ -547,6 +1300,7 @@ func LoadTemplate(ast *node32, melt *MeltParser) (*Template, error) {
 			args = append(args, object)
 		} else {
 			text = append(text, melt.Buffer[node.begin:node.end])
+			segment = true
 		}
 		node = node.next
 	}
@@ -571,8 +1325,23 @@ func LoadGenericArgs(ast *node32, melt *MeltParser) []types.GenericVar {
 }
 
 func LoadFunction(ast *node32, melt *MeltParser) (Function, error) {
-	label := melt.Buffer[ast.up.next.begin:ast.up.next.end]
-	args := ast.up.next.next
+	node := ast.up.next
+	var receiver *Arg
+	if Kind(node) == "Receiver" {
+		r, err := LoadReceiver(node, melt)
+		if err != nil {
+			return Function{}, err
+		}
+		receiver = &r
+		node = node.next.next
+	}
+	label := melt.Buffer[node.begin:node.end]
+	args := node.next
+	errorTypes := []types.Type{}
+	if Kind(args) == "ErrorTypes" {
+		errorTypes = LoadErrorTypes(args, melt)
+		args = args.next
+	}
 	functionArgs := []Arg{}
 	var funArgs *Signature
 	var returnType *node32
@@ -587,7 +1356,7 @@ func LoadFunction(ast *node32, melt *MeltParser) (Function, error) {
 	} else {
 		arg := args.up
 		funArgs = &Signature{Args: []types.Type{}}
-		for {
+		for arg != nil {
 			a := arg.up.up
 			b := melt.Buffer[a.begin:a.end]
 			c := a.next.next
@@ -599,7 +1368,7 @@ func LoadFunction(ast *node32, melt *MeltParser) (Function, error) {
 			if b[len(b)-1] == '!' || b[len(b)-1] == '?' {
 				f, ok := e.(types.Function)
 				if !ok {
-					return Function{}, fmt.Errorf("%s is not a function", b)
+					return Function{}, Failf(CodeNotFunction, "%s is not a function", b)
 				}
 
 				if b[len(b)-1] == '!' {
@@ -612,12 +1381,10 @@ func LoadFunction(ast *node32, melt *MeltParser) (Function, error) {
 			}
 
 			funArgs.Args = append(funArgs.Args, e)
-			functionArgs = append(functionArgs, Arg{ID: ToLabel(b), Type: e})
-			if arg.next == nil {
-				break
-			} else {
-				arg = arg.next
-			}
+			id := ToLabel(b)
+			melt.Locate(id, a)
+			functionArgs = append(functionArgs, Arg{ID: id, Type: e, Info: Info{LocationInfo: id.Location()}})
+			arg = arg.next
 		}
 		returnType = args.next
 	}
@@ -638,9 +1405,10 @@ func LoadFunction(ast *node32, melt *MeltParser) (Function, error) {
 	}
 	code := &Code{E: []Ast{}}
 	nodeCode = nodeCode.up
+	melt.Locate(code, nodeCode)
 	// spew.Dump(funArgs)
 	// spew.Dump(functionArgs)
-	// ast.Print(melt.Buffer)
+	// ast.Print(os.Stdout, melt.Buffer)
 
 	for {
 		if rul3s[nodeCode.pegRule] == "Dedent" {
@@ -657,6 +1425,7 @@ func LoadFunction(ast *node32, melt *MeltParser) (Function, error) {
 			nodeCode = nodeCode.next
 		}
 	}
+	code.E = chainArms(code.E)
 
 	er := types.Correct
 	if label[len(label)-1] == '?' {
@@ -668,15 +1437,47 @@ func LoadFunction(ast *node32, melt *MeltParser) (Function, error) {
 	}
 
 	f := types.Function{Args: funArgs.Args, Return: funArgs.Return, Error: er, GenericVars: t, InstanceVars: make([]types.Type, len(t))}
+	if len(errorTypes) > 0 {
+		f.ErrorTypes = errorTypes
+	}
+	functionLabel := ToLabel(label)
+	melt.Locate(functionLabel, node)
 	return Function{
-		Label: ToLabel(label),
+		Label: functionLabel,
+		Receiver: receiver,
 		Signature: funArgs,
-		Info: Info{MType: MType{ZType: f}},
+		Info: Info{LocationInfo: functionLabel.Location(), MType: MType{ZType: f}},
 		Args: functionArgs,
 		Code: code}, nil
 
 }
 
+// LoadErrorTypes loads the records of <NotFound | Denied> after a failing label,
+// they're resolved when the function is checked
+func LoadErrorTypes(ast *node32, melt *MeltParser) []types.Type {
+	errorTypes := []types.Type{}
+	for node := ast.up; node != nil; node = node.next {
+		if Kind(node) == "CapitalLabel" {
+			errorTypes = append(errorTypes, types.Basic{Label: melt.Buffer[node.begin:node.end]})
+		}
+	}
+	return errorTypes
+}
+
+// LoadReceiver loads (s *Stack<T>), the type is left as written
+// until the methods are collected
+func LoadReceiver(ast *node32, melt *MeltParser) (Arg, error) {
+	id := ToLabel(melt.Buffer[ast.up.begin:ast.up.end])
+	melt.Locate(id, ast.up)
+	t, err := LoadType(child(ast.up, "Type"), melt)
+	if err != nil {
+		return Arg{}, err
+	}
+	receiver := Arg{ID: id, Type: t}
+	melt.Locate(&receiver, ast)
+	return receiver, nil
+}
+
 func LoadList(ast *node32, melt *MeltParser) (*List, error) {
 	node := ast
 	next := node.up
@@ -695,17 +1496,57 @@ func LoadList(ast *node32, melt *MeltParser) (*List, error) {
 }
 
 func LoadType(ast *node32, melt *MeltParser) (types.Type, error) {
-	// node.Print(melt.Buffer)
+	// node.Print(os.Stdout, melt.Buffer)
 	if Kind(ast) == "Type" || Kind(ast) == "TypeExceptFun" || Kind(ast) == "BuiltinType" || Kind(ast) == "BuiltinArg" {
 		return LoadType(ast.up, melt)
 	} else if Kind(ast) == "BuiltinSimple" {
-		return types.Basic{Label: melt.Buffer[ast.begin:ast.end]}, nil
+		label := melt.Buffer[ast.begin:ast.end]
+		if label == "Int" {
+			label = "int"
+		}
+		return types.Basic{Label: label}, nil
 	} else if Kind(ast) == "BuiltinSlice" {
 		t, err := LoadType(ast.up, melt)
 		if err != nil {
 			return types.SliceBuiltin{}, err
 		}
 		return types.SliceBuiltin{Element: t}, nil
+	} else if Kind(ast) == "BuiltinArray" {
+		length, err := strconv.ParseInt(melt.Buffer[ast.up.begin:ast.up.end], 10, 64)
+		if err != nil {
+			return types.Array{}, err
+		}
+		t, err := LoadType(ast.up.next, melt)
+		if err != nil {
+			return types.Array{}, err
+		}
+		return types.Array{Element: t, Length: length}, nil
+	} else if Kind(ast) == "BuiltinMap" {
+		key, err := LoadType(ast.up, melt)
+		if err != nil {
+			return types.MapBuiltin{}, err
+		}
+		value, err := LoadType(ast.up.next, melt)
+		if err != nil {
+			return types.MapBuiltin{}, err
+		}
+		return types.MapBuiltin{Key: key, Value: value}, nil
+	} else if Kind(ast) == "ChannelType" {
+		node := ast.up
+		dir := types.Both
+		if Kind(node) == "ChannelDir" {
+			if melt.Buffer[node.begin:node.end] == "send" {
+				dir = types.Send
+			} else {
+				dir = types.Receive
+			}
+			node = node.next
+		}
+		element, err := LoadType(child(node, "Type"), melt)
+		if err != nil {
+			return types.Channel{}, err
+		}
+		return types.Channel{Element: element, Dir: dir}, nil
 	} else if Kind(ast) == "PointerType" {
 		object, err := LoadType(ast.up, melt)
 		if err != nil {
@@ -713,21 +1554,27 @@ func LoadType(ast *node32, melt *MeltParser) (types.Type, error) {
 		}
 		p := types.Pointer{Object: object}
 		return p, nil
-	} else if Kind(ast) == "CapitalLabel" {
+	} else if Kind(ast) == "CapitalLabel" || Kind(ast) == "TypeLabel" {
 		return types.Basic{Label: melt.Buffer[ast.begin:ast.end]}, nil
 	} else if Kind(ast) == "GenericType" {
 		node := ast.up
 		label := melt.Buffer[node.begin:node.end]
 		genericVars := []types.GenericVar{}
+		instanceVars := []types.Type{}
 		node = node.next
 		for node != nil {
-			if Kind(node) != "Whitespace" {
+			if Kind(node) == "Type" {
 				a := types.GenericVar{Label: melt.Buffer[node.begin:node.end]}
 				genericVars = append(genericVars, a)
+				t, err := LoadType(node, melt)
+				if err != nil {
+					return types.Interface{}, err
+				}
+				instanceVars = append(instanceVars, t)
 			}
 			node = node.next
 		}
-		return types.Interface{Label: label, GenericVars: genericVars}, nil
+		return types.Interface{Label: label, GenericVars: genericVars, InstanceVars: instanceVars}, nil
 	} else if Kind(ast) == "FunType" {
 		node := ast.up
 		args := []types.Type{}
@@ -744,9 +1591,7 @@ func LoadType(ast *node32, melt *MeltParser) (types.Type, error) {
 		returnType, args := args[len(args)-1], args[:len(args)-1]
 		return types.Function{Args: args, Return: returnType, Error: types.Correct}, nil
 	}
-	fmt.Println("ast")
-	ast.Print(melt.Buffer)
-	return types.Nil{}, errors.New("No type")
+	return types.Nil{}, fmt.Errorf("%s is not a type", Kind(ast))
 }
 
 func Kind(ast *node32) string {
@@ -754,43 +1599,113 @@ func Kind(ast *node32) string {
 }
 
 func Preprocess(source string) (string, error) {
+	result, _, err := PreprocessFile("", source)
+	return result, err
+}
+
+// PreprocessFile rewrites the indentation of a file with @@indent@@ and @@dedent@@
+// and maps the result back to the file
+// A line inside of open brackets continues the line before it, its indentation is dropped
+func PreprocessFile(file string, source string) (string, *SourceMap, error) {
 	lines := strings.Split(source, "\n")
+	positions := NewSourceMap(file, lines)
 	var level uint
 	level = 0
 	var z []string
+	brackets := 0
 	for a, line := range lines {
-		trimmed := strings.TrimRight(strings.Trim(line, " "), "\t")
-		if len(trimmed) == 0 || trimmed[0] == '#' {
+		trimmed := strings.TrimRight(line, " \t")
+		if len(trimmed) == 0 || strings.TrimLeft(trimmed, " \t")[0] == '#' {
 			continue
 		}
 
 		new_level := IndentLevel(trimmed)
-		if new_level > level+1 {
-			return "", errors.New(fmt.Sprintf("line %d: indented too much\n%s", a+1, line))
+		indentation := len(trimmed) - len(strings.TrimLeft(trimmed, " \t"))
+		continued := brackets > 0
+		brackets += openBrackets(trimmed)
+		if brackets < 0 {
+			brackets = 0
+		}
+		if continued {
+			z = append(z, line[indentation:])
+			positions.add(a+1, indentation, indentation)
+		} else if new_level > level+1 {
+			return "", positions, &Diagnostic{
+				LocationInfo: LocationInfo{File: file, Line: a + 1, Column: indentation + 1},
+				Code:         CodeIndentation,
+				Message:      "indented too much",
+				Source:       line}
 		} else if new_level == level+1 {
-			z = append(z, fmt.Sprintf("@@indent@@%s", line[new_level:]))
+			z = append(z, fmt.Sprintf("@@indent@@%s", line[indentation:]))
+			positions.add(a+1, indentation-len("@@indent@@"), indentation)
 			level += 1
 		} else if new_level == level {
-			z = append(z, line[new_level:])
+			z = append(z, line[indentation:])
+			positions.add(a+1, indentation, indentation)
 		} else {
 			y := strings.Repeat("@@dedent@@\n", int(level-new_level))
-			z = append(z, fmt.Sprintf("%s%s", y, line[new_level:]))
+			z = append(z, fmt.Sprintf("%s%s", y, line[indentation:]))
+			for i := new_level; i < level; i++ {
+				positions.add(a+1, indentation-len("@@dedent@@"), indentation)
+			}
+			positions.add(a+1, indentation, indentation)
 			level = new_level
 		}
 	}
 	z = append(z, strings.Repeat("@@dedent@@\n", int(level)))
 	result := strings.Join(z, "\n") + "\n"
-	fmt.Println(result)
-	return result, nil
+	positions.index(result)
+	Tracef(TraceParse, "preprocessed %s\n%s", file, result)
+	return result, positions, nil
+}
+
+// openBrackets is the number of brackets a line opens minus the ones it closes,
+// the brackets in strings don't count
+func openBrackets(line string) int {
+	open := 0
+	quote := rune(0)
+	escaped := false
+	for _, c := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && c == '\\':
+			escaped = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			open++
+		case c == ')' || c == ']' || c == '}':
+			open--
+		}
+	}
+	return open
 }
 
+// IndentLevel counts a tab or four spaces as a level
 func IndentLevel(line string) uint {
-	for a, c := range line {
-		if c != '\t' {
-			return uint(a)
+	var level uint
+	spaces := 0
+	for _, c := range line {
+		switch c {
+		case '\t':
+			level++
+			spaces = 0
+		case ' ':
+			spaces++
+			if spaces == 4 {
+				level++
+				spaces = 0
+			}
+		default:
+			return level
 		}
 	}
-	return 0
+	return level
 }
 
 //Parse melt to ast
@@ -1,8 +1,14 @@
 package compiler
 
+import "gitlab.com/alehander42/melt/types"
+
+// Record node
+// Methods are the functions with a receiver of the record,
+// they're added when the types are collected
 type Record struct {
-	Label  *Label
-	Fields []Field
+	Label   *Label
+	Fields  []Field
+	Methods []*Function
 
 	Info
 }
@@ -20,3 +26,75 @@ func (r *Record) TypeCheck(ctx *Context) error {
 func (f *Field) TypeCheck(ctx *Context) error {
 	return nil
 }
+
+// receiverObject is the record in the type of a receiver: Stack<T> in *Stack<T>
+func receiverObject(t types.Type) types.Type {
+	if pointer, ok := t.(types.Pointer); ok {
+		return pointer.Object
+	}
+	return t
+}
+
+// receiverLabel is the label of the record of a receiver
+func receiverLabel(t types.Type) string {
+	switch object := receiverObject(t).(type) {
+	case types.Basic:
+		return object.Label
+	case types.Interface:
+		return object.Label
+	default:
+		return object.ToString()
+	}
+}
+
+// InstanceFields are the fields of a record with its instance vars, taken
+// from its definition with the named types resolved: the Node<T> field
+// of a Stack<T> is a Node<int> in Stack<int>
+func InstanceFields(record types.Record, ctx *Context) map[string]types.Type {
+	t, err := ctx.Get(record.Label)
+	definition, ok := t.(types.Record)
+	if err != nil || !ok {
+		return record.Fields
+	}
+	genericMap := NewGenericMap()
+	for i, v := range definition.GenericVars {
+		if i < len(record.InstanceVars) && record.InstanceVars[i] != nil {
+			genericMap.Types[v.Label] = record.InstanceVars[i]
+		}
+	}
+	fields := make(map[string]types.Type)
+	for label, field := range definition.Fields {
+		fields[label] = ReplaceGenericVars(ResolveType(field, ctx), genericMap)
+	}
+	return fields
+}
+
+// IsGenericMethod is true for a method of a generic record,
+// it's generated with each instance of the record
+func (f *Function) IsGenericMethod() bool {
+	if f.Receiver == nil {
+		return false
+	}
+	_, ok := receiverObject(f.Receiver.Type).(types.Interface)
+	return ok
+}
+
+// ExpandMethod is the method of a generic record for an instance of it
+func ExpandMethod(method *Function, record types.Record) (Function, error) {
+	placeholder := receiverObject(method.Receiver.Type).(types.Interface)
+	genericMap := NewGenericMap()
+	for i, v := range placeholder.GenericVars {
+		genericMap.Types[v.Label] = record.InstanceVars[i]
+	}
+	expanded, err := ExpandInstance(*method, method.Label.Label, genericMap)
+	if err != nil {
+		return Function{}, err
+	}
+	receiver := *expanded.Receiver
+	receiver.Type = record
+	if _, ok := method.Receiver.Type.(types.Pointer); ok {
+		receiver.Type = types.Pointer{Object: record}
+	}
+	expanded.Receiver = &receiver
+	return expanded, nil
+}
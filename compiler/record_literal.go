@@ -0,0 +1,155 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// RecordLiteral node: Point{x: 0, y: 2} or Point{0, 2}
+// Fields is nil for the positional form
+type RecordLiteral struct {
+	Type   types.Type
+	Fields []*Label
+	Values []Ast
+
+	Info
+}
+
+func (self *RecordLiteral) ToString(depth int) string {
+	values := make([]string, len(self.Values))
+	for i, value := range self.Values {
+		values[i] = value.ToString(depth + 1)
+	}
+	return fmt.Sprintf("%sRecordLiteral %s:\n%s", Indent(depth), self.Type.ToString(), strings.Join(values, "\n"))
+}
+
+// lookupRecord finds the record of a literal, sync.Mutex is found in its package
+func lookupRecord(t types.Type, ctx *Context) (types.Record, bool) {
+	if basic, ok := t.(types.Basic); ok {
+		if dot := strings.Index(basic.Label, "."); dot != -1 {
+			p, err := ctx.Get(basic.Label[:dot])
+			if pkg, ok := p.(types.Package); err == nil && ok {
+				t = pkg.Members[basic.Label[dot+1:]]
+			}
+		} else if generic, _, ok := lookupGeneric(basic.Label, ctx); ok {
+			t = generic
+		}
+	}
+	record, ok := ResolveType(t, ctx).(types.Record)
+	return record, ok
+}
+
+// typeLabel is the name of a record or of a generic record in a literal
+func typeLabel(t types.Type) string {
+	switch other := t.(type) {
+	case types.Basic:
+		return other.Label
+	case types.Interface:
+		return other.Label
+	}
+	return ""
+}
+
+// TypeCheck checks the values against the fields of the record
+// A melt record has to get all its fields, Point{} is its zero value,
+// a go record can omit some, like in go.
+// The vars of a generic record without type args come from the values
+func (self *RecordLiteral) TypeCheck(ctx *Context) error {
+	record, ok := lookupRecord(self.Type, ctx)
+	if !ok && ctx.PoisonedName(typeLabel(self.Type)) {
+		return Poisoned
+	} else if !ok {
+		return Errorf(self, CodeUndefined, "%s is not a record", self.Type.ToString())
+	}
+	for _, value := range self.Values {
+		err := value.TypeCheck(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	labels := self.Fields
+	if labels == nil && len(self.Values) > 0 {
+		if len(record.Order) == 0 {
+			return Errorf(self, CodeField, "%s has to be built with named fields", record.ToString())
+		}
+		if len(self.Values) != len(record.Order) {
+			return Errorf(self, CodeField, "%s has %d fields, received %d", record.ToString(), len(record.Order), len(self.Values))
+		}
+		labels = make([]*Label, len(self.Values))
+		for i, value := range self.Values {
+			labels[i] = &Label{Label: record.Order[i], Info: Info{LocationInfo: value.Location()}}
+		}
+	}
+
+	seen := make(map[string]*Label)
+	for _, label := range labels {
+		if _, ok := record.Fields[label.Label]; !ok {
+			return Errorf(label, CodeField, "%s doesn't have a field %s", record.ToString(), label.Label)
+		}
+		if first, ok := seen[label.Label]; ok {
+			return Relate(Errorf(label, CodeRedefined, "%s is given twice", label.Label), first, "first given here")
+		}
+		seen[label.Label] = label
+	}
+	if len(record.Order) > 0 && len(labels) > 0 {
+		for _, field := range record.Order {
+			if _, ok := seen[field]; !ok {
+				return Errorf(self, CodeField, "%s is missing the field %s", record.ToString(), field)
+			}
+		}
+	}
+
+	if record.IsGeneric() && !instantiated(record) {
+		if len(labels) == 0 {
+			return Errorf(self, CodeInfer, "%s needs type args", record.Label)
+		}
+		genericMap := NewGenericMap()
+		for _, v := range record.GenericVars {
+			genericMap.Types[v.Label] = types.Empty{}
+		}
+		for i, label := range labels {
+			err := Match(&genericMap, self.Values[i].MeltType(), ResolveType(record.Fields[label.Label], ctx), ctx)
+			if err != nil {
+				return Locate(err, self.Values[i])
+			}
+		}
+		for _, v := range record.GenericVars {
+			if _, ok := genericMap.Types[v.Label].(types.Empty); ok {
+				return Errorf(self, CodeInfer, "%s of %s can't be inferred", v.Label, record.Label)
+			}
+		}
+		record = ReplaceGenericVars(record, genericMap).(types.Record)
+	}
+
+	fields := InstanceFields(record, ctx)
+	for i, label := range labels {
+		field, value := fields[label.Label], self.Values[i]
+		if reason, ok := implements(field, value.MeltType()); ok {
+			return Errorf(value, CodeMethod, "Can't build %s: %s", record.ToString(), reason)
+		} else if !field.Accepts(value.MeltType()) && !untyped(value, field) {
+			return Errorf(value, CodeMismatch, "%s is %s, received %s", label.Label, field.ToString(), value.MeltType().ToString())
+		}
+		settle(value, field)
+		label.ZType = field
+	}
+	self.Fields = labels
+	self.Type = record
+	self.ZType = record
+	return nil
+}
+
+// instantiated is true if all the vars of a record are known
+func instantiated(record types.Record) bool {
+	for i := range record.GenericVars {
+		if i >= len(record.InstanceVars) || record.InstanceVars[i] == nil {
+			return false
+		}
+		if _, ok := record.InstanceVars[i].(types.GenericVar); ok {
+			return false
+		}
+	}
+	return true
+}
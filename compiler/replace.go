@@ -1,8 +1,6 @@
 package compiler
 
 import (
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
@@ -46,7 +44,8 @@ func ReplaceGenericVars(a types.Type, genericMap GenericMap) types.Type {
 				Return:       ret,
 				Error:        e,
 				GenericVars:  other.GenericVars,
-				InstanceVars: instance}
+				InstanceVars: instance,
+				ErrorTypes:   other.ErrorTypes}
 		}
 	}
 	return replaceInternalGenericVars(a, &i, genericMap)
@@ -63,7 +62,6 @@ func replaceInternalGenericVars(t types.Type, errors *int, genericMap GenericMap
 	case types.Function:
 		e := other.Error
 		if other.Error == types.Maybe {
-			fmt.Printf("%s %d\n", other.ToString(), *errors)
 			e = genericMap.Errors[*errors]
 			*errors += 1
 		}
@@ -81,7 +79,8 @@ func replaceInternalGenericVars(t types.Type, errors *int, genericMap GenericMap
 			Return:       ret,
 			Error:        e,
 			GenericVars:  other.GenericVars,
-			InstanceVars: instance}
+			InstanceVars: instance,
+			ErrorTypes:   other.ErrorTypes}
 	case types.Pointer:
 		object := replaceInternalGenericVars(other.Object, errors, genericMap)
 		return types.Pointer{Object: object}
@@ -93,18 +92,23 @@ func replaceInternalGenericVars(t types.Type, errors *int, genericMap GenericMap
 		methods := []types.Method{}
 		for _, method := range other.Methods() {
 			function, _ := replaceInternalGenericVars(method.Function, errors, genericMap).(types.Function)
-			methods = append(methods, types.Method{Label: method.Label, Function: function})
+			methods = append(methods, types.Method{Label: method.Label, Function: function, Pointer: method.Pointer})
 		}
 		instance := []types.Type{}
-		for _, v := range other.GenericVars {
-			instance = append(instance, genericMap.Types[v.Label])
+		for i, v := range other.GenericVars {
+			if i < len(other.InstanceVars) && other.InstanceVars[i] != nil {
+				instance = append(instance, replaceInternalGenericVars(other.InstanceVars[i], errors, genericMap))
+			} else {
+				instance = append(instance, genericMap.Types[v.Label])
+			}
 		}
-		fmt.Println("ok\n")
 		r := types.Record{
 			Label:        other.Label,
 			Fields:       fields,
+			Order:        other.Order,
 			GenericVars:  other.GenericVars,
-			InstanceVars: instance}
+			InstanceVars: instance,
+			Package:      other.Package}
 		r.ReplaceMethods(methods)
 		return r
 	case types.Interface:
@@ -124,6 +128,29 @@ func replaceInternalGenericVars(t types.Type, errors *int, genericMap GenericMap
 		i.Extend(methods)
 		return i
 
+	case types.Sum:
+		variants := []types.Variant{}
+		for _, variant := range other.Variants {
+			fields := []types.VariantField{}
+			for _, field := range variant.Fields {
+				fields = append(fields, types.VariantField{Label: field.Label, Type: replaceInternalGenericVars(field.Type, errors, genericMap)})
+			}
+			variants = append(variants, types.Variant{Label: variant.Label, Fields: fields})
+		}
+		instance := []types.Type{}
+		for i, v := range other.GenericVars {
+			if i < len(other.InstanceVars) && other.InstanceVars[i] != nil {
+				instance = append(instance, replaceInternalGenericVars(other.InstanceVars[i], errors, genericMap))
+			} else {
+				instance = append(instance, genericMap.Types[v.Label])
+			}
+		}
+		return types.Sum{
+			Label:        other.Label,
+			Variants:     variants,
+			GenericVars:  other.GenericVars,
+			InstanceVars: instance}
+
 	case types.SliceBuiltin:
 		methods := []types.Method{}
 		for _, method := range other.Methods() {
@@ -1,43 +1,62 @@
 package compiler
 
 import (
-	"errors"
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
-// Return node
+// Return node, Value is nil for a bare return
 type Return struct {
 	Value *Ast
+	// Fails is true in a failing function, it returns a nil error with the value
+	Fails bool
 
 	Info
 }
 
 func (r *Return) TypeCheck(ctx *Context) error {
+	// the path ends here even if the value is wrong,
+	// so the function isn't reported as missing a return too
+	defer ctx.Leave(r, "return")
+	if r.Value == nil {
+		if _, ok := ctx.ReturnType.(types.Empty); !ok {
+			return Errorf(r, CodeMismatch, "Return type %s needs a value", ctx.ReturnType.ToString())
+		}
+		r.Fails = ctx.Z != types.Correct
+		r.ZType = types.Empty{}
+		return nil
+	}
 	err := (*r.Value).TypeCheck(ctx)
 	if err != nil {
 		return err
 	}
 
-	if !ctx.ReturnType.Accepts((*r.Value).MeltType()) {
-		return fmt.Errorf("Return type %s != %s", ctx.ReturnType.ToString(), (*r.Value).MeltType().ToString())
+	if reason, ok := implements(ctx.ReturnType, (*r.Value).MeltType()); ok {
+		return Errorf(*r.Value, CodeMethod, "Can't return %s: %s", (*r.Value).MeltType().ToString(), reason)
+	} else if !ctx.ReturnType.Accepts((*r.Value).MeltType()) && !untyped(*r.Value, ctx.ReturnType) {
+		return Errorf(*r.Value, CodeMismatch, "Return type %s != %s", ctx.ReturnType.ToString(), (*r.Value).MeltType().ToString())
 	} else {
+		settle(*r.Value, ctx.ReturnType)
+		r.Fails = ctx.Z != types.Correct
 		r.ZType = types.Empty{}
 		return nil
 	}
 }
 
-// ReturnError node
+// ReturnError node: !! "message" returns an error with the zero value
+// of the return type, !! err returns an error value and
+// !! "message" with err wraps err with the message
 type ReturnError struct {
-	Value *Ast
+	Value   *Ast
+	Wrapped Ast
+	Return  types.Type
 
 	Info
 }
 
 func (r *ReturnError) TypeCheck(ctx *Context) error {
+	defer ctx.Leave(r, "!!")
 	if ctx.Z == types.Correct {
-		return errors.New("Function has to be marked with ? or ! to fail")
+		return Errorf(r, CodeCantFail, "Function has to be marked with ? or ! to fail")
 	}
 	// if Maybe handler?
 
@@ -46,17 +65,47 @@ func (r *ReturnError) TypeCheck(ctx *Context) error {
 		return err
 	}
 
-	s, ok := (*r.Value).MeltType().(types.Basic)
-	if ok {
-		if s.Label != "string" {
-			return errors.New("!! expects string")
-		} else {
-			r.ZType = types.Empty{}
-			return nil
+	value := (*r.Value).MeltType()
+	if r.Wrapped != nil {
+		if !IsString(value) {
+			return Errorf(*r.Value, CodeErrValue, "!! .. with expects a string message, got %s", value.ToString())
 		}
-	} else {
-		return errors.New("!! expects simple")
+		err = r.Wrapped.TypeCheck(ctx)
+		if err != nil {
+			return err
+		}
+		if !IsError(r.Wrapped.MeltType()) {
+			return Errorf(r.Wrapped, CodeErrValue, "!! .. with expects an error, got %s", r.Wrapped.MeltType().ToString())
+		}
+	} else if !IsString(value) && !IsError(value) {
+		return Errorf(*r.Value, CodeErrValue, "!! expects a string or an error, got %s", value.ToString())
 	}
+	if len(ctx.ErrorTypes) > 0 {
+		// the error a function declares can be returned or wrapped
+		node := *r.Value
+		if r.Wrapped != nil {
+			node = r.Wrapped
+		}
+		if !declaredError(ctx.ErrorTypes, node.MeltType()) {
+			return Errorf(node, CodeErrValue, "%s fails only with %s, got %s", ctx.Label, errorTypeList(ctx.ErrorTypes), node.MeltType().ToString())
+		}
+	}
+	r.Return = ctx.ReturnType
+	r.ZType = types.Empty{}
+	return nil
+}
+
+// goError is go's error interface
+var goError = types.NewInterface("error", []types.Method{
+	{Label: "Error", Function: types.Function{Args: []types.Type{}, Return: types.Basic{Label: "string"}, Error: types.Correct}}},
+	[]types.GenericVar{})
+
+// IsError is true for $err, a go error and a value with an Error() string method
+func IsError(t types.Type) bool {
+	if _, ok := t.(types.Error); ok {
+		return true
+	}
+	return goError.Accepts(t)
 }
 
 // Error node
@@ -66,11 +115,17 @@ type Error struct {
 	Info
 }
 
+// TypeCheck gives $err the type of the errors of its handler:
+// an error in on f: and a NotFound in on f as NotFound:
 func (e *Error) TypeCheck(ctx *Context) error {
 	if e.Label.Label != "err" {
-		return errors.New("Only $err defined")
+		return Errorf(e, CodeErrValue, "Only $err defined")
 	}
 
-	e.ZType = types.Error{}
+	t, err := ctx.Get("$err")
+	if err != nil {
+		return Errorf(e, CodeErrValue, "$err is defined only in on handlers")
+	}
+	e.ZType = t
 	return nil
 }
@@ -0,0 +1,90 @@
+package compiler
+
+import (
+	"fmt"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// Selector node: a.b, a.b.c is the selector c of a.b
+// The object is a record, a pointer to one or a package
+type Selector struct {
+	Object Ast
+	Field  *Label
+
+	Info
+}
+
+// FieldAssignment node: a.b = value
+type FieldAssignment struct {
+	Target *Selector
+	Value  Ast
+
+	Info
+}
+
+func (self *Selector) ToString(depth int) string {
+	return fmt.Sprintf("%sSelector %s:\n%s", Indent(depth), self.Field.Label, self.Object.ToString(depth+1))
+}
+
+func (self *FieldAssignment) ToString(depth int) string {
+	return fmt.Sprintf("%sFieldAssignment:\n%s\n%s", Indent(depth), self.Target.ToString(depth+1), self.Value.ToString(depth+1))
+}
+
+// TypeCheck finds the field, a pointer is followed like in go
+func (self *Selector) TypeCheck(ctx *Context) error {
+	err := self.Object.TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+	object := ResolveType(self.Object.MeltType(), ctx)
+	if pointer, ok := object.(types.Pointer); ok {
+		object = ResolveType(pointer.Object, ctx)
+	}
+
+	label := self.Field.Label
+	switch kind := object.(type) {
+	case types.Record:
+		field, ok := InstanceFields(kind, ctx)[label]
+		if !ok {
+			return Errorf(self.Field, CodeField, "%s doesn't have a field %s", kind.ToString(), label)
+		}
+		self.ZType = field
+	case types.Package:
+		member, ok := kind.Members[label]
+		if !ok {
+			return Errorf(self.Field, CodeUndefined, "%s doesn't have %s", kind.ToString(), label)
+		}
+		self.ZType = member
+	default:
+		return Errorf(self.Field, CodeField, "%s doesn't have fields", object.ToString())
+	}
+	self.Field.ZType = self.ZType
+	return nil
+}
+
+// TypeCheck checks the value against the type of the field,
+// the members of a package can't be assigned
+func (self *FieldAssignment) TypeCheck(ctx *Context) error {
+	self.ZType = types.Empty{}
+	err := self.Target.TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := self.Target.Object.MeltType().(types.Package); ok {
+		return Errorf(self.Target, CodeMismatch, "%s of %s can't be assigned", self.Target.Field.Label, self.Target.Object.MeltType().ToString())
+	}
+	err = self.Value.TypeCheck(ctx)
+	if err != nil {
+		return err
+	}
+
+	field := self.Target.MeltType()
+	if reason, ok := implements(field, self.Value.MeltType()); ok {
+		return Errorf(self.Value, CodeMethod, "Can't assign %s to %s: %s", self.Value.MeltType().ToString(), self.Target.Field.Label, reason)
+	} else if !field.Accepts(self.Value.MeltType()) {
+		return Errorf(self.Value, CodeMismatch, "%s is %s, can't assign %s", self.Target.Field.Label, field.ToString(), self.Value.MeltType().ToString())
+	}
+	settle(self.Value, field)
+	return nil
+}
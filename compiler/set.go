@@ -1,37 +1,73 @@
 package compiler
 
 import (
-	"errors"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
 type Set struct {
 	Label *Label
 	Value *Ast
+	// Define is true if the set defines the label in its scope
+	Define bool
+	// Type is declared by x : T = value, it's nil for x = value
+	Type types.Type
 
 	Info
 }
 
 func (s *Set) TypeCheck(ctx *Context) error {
 	err := (*s.Value).TypeCheck(ctx)
+	if err == nil && IsPoisoned((*s.Value).MeltType()) {
+		err = Errorf(*s.Value, CodeNoValue, "%s doesn't have a value", (*s.Value).ToString(0))
+	}
 	if err != nil {
+		if _, defined := ctx.Get(s.Label.Label); defined != nil {
+			ctx.Poison(s.Label.Label)
+		}
 		return err
 	}
 
+	if s.Type != nil {
+		return s.declare(ctx)
+	}
+
 	target, err := ctx.Get(s.Label.Label)
-	if err != nil {
+	if sum, ok := (*s.Value).MeltType().(types.Sum); ok && err != nil && open(sum) {
+		ctx.Poison(s.Label.Label)
+		return Errorf(*s.Value, CodeInfer, "Can't infer the type of %s from %s, it needs a typed value", s.Label.Label, sum.ToString())
+	} else if _, ok := (*s.Value).MeltType().(types.Nil); ok && err != nil {
+		ctx.Poison(s.Label.Label)
+		return Errorf(*s.Value, CodeInfer, "Can't infer the type of %s from nil, it needs a typed value", s.Label.Label)
+	} else if err != nil {
 		ctx.Set(s.Label.Label, (*s.Value).MeltType())
+		s.Define = true
 		s.Label.ZType = (*s.Value).MeltType()
 		s.ZType = types.Empty{}
 		return nil
 	} else {
-		if target.Accepts((*s.Value).MeltType()) {
+		if target.Accepts((*s.Value).MeltType()) || untyped(*s.Value, target) {
+			settle(*s.Value, target)
 			s.Label.ZType = target
 			s.ZType = types.Empty{}
 			return nil
 		} else {
-			return errors.New("fail")
+			return Errorf(*s.Value, CodeMismatch, "%s is %s, can't assign %s", s.Label.Label, target.ToString(), (*s.Value).MeltType().ToString())
 		}
 	}
 }
+
+// declare defines the label with its declared type: ops : int64 = 0
+func (s *Set) declare(ctx *Context) error {
+	declared := ResolveType(s.Type, ctx)
+	if !declared.Accepts((*s.Value).MeltType()) && !untyped(*s.Value, declared) {
+		ctx.Poison(s.Label.Label)
+		return Errorf(*s.Value, CodeMismatch, "%s is %s, can't assign %s", s.Label.Label, declared.ToString(), (*s.Value).MeltType().ToString())
+	}
+	settle(*s.Value, declared)
+	s.Type = declared
+	ctx.Set(s.Label.Label, declared)
+	s.Define = true
+	s.Label.ZType = declared
+	s.ZType = types.Empty{}
+	return nil
+}
@@ -0,0 +1,75 @@
+package compiler
+
+import (
+	"sort"
+)
+
+// SourceMap maps offsets in the preprocessed buffer back to the melt file
+// Preprocess drops empty lines and comments, strips the indentation
+// and adds @@indent@@ and @@dedent@@ markers
+type SourceMap struct {
+	File string
+	// Lines of the melt file
+	Lines []string
+	// shifts has the source line of each buffer line
+	shifts []lineShift
+	// starts has the offset of each buffer line in runes, like the parser
+	starts []int
+}
+
+// lineShift maps a buffer line: its columns are moved by shift
+// and can't be before min, the indentation of the source line
+type lineShift struct {
+	line  int
+	shift int
+	min   int
+}
+
+func NewSourceMap(file string, lines []string) *SourceMap {
+	return &SourceMap{File: file, Lines: lines, shifts: []lineShift{}, starts: []int{}}
+}
+
+func (m *SourceMap) add(line int, shift int, min int) {
+	m.shifts = append(m.shifts, lineShift{line: line, shift: shift, min: min})
+}
+
+// index finds the start of the buffer lines
+func (m *SourceMap) index(buffer string) {
+	m.starts = []int{0}
+	for i, c := range []rune(buffer) {
+		if c == '\n' {
+			m.starts = append(m.starts, i+1)
+		}
+	}
+}
+
+// Position of a rune offset in the buffer
+func (m *SourceMap) Position(offset int) LocationInfo {
+	i := sort.Search(len(m.starts), func(i int) bool { return m.starts[i] > offset }) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(m.shifts) {
+		// the dedents at the end belong to the last line
+		last := len(m.Lines)
+		for last > 1 && len(m.Lines[last-1]) == 0 {
+			last--
+		}
+		return LocationInfo{File: m.File, Line: last, Column: 1}
+	}
+
+	s := m.shifts[i]
+	column := offset - m.starts[i] + s.shift
+	if column < s.min {
+		column = s.min
+	}
+	return LocationInfo{File: m.File, Line: s.line, Column: column + 1}
+}
+
+// Line is the source of a line, starting from 1
+func (m *SourceMap) Line(line int) string {
+	if line < 1 || line > len(m.Lines) {
+		return ""
+	}
+	return m.Lines[line-1]
+}
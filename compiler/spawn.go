@@ -0,0 +1,72 @@
+package compiler
+
+import (
+	"fmt"
+	go_types "go/types"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// Spawn node: spawn label: waits for the goroutines started in its code
+// Inside the code label is the context.Context of the goroutines,
+// it's cancelled when one of them fails.
+// After it label is a failing call: its error is the first error
+// of the goroutines and it's handled with on or escalate
+type Spawn struct {
+	Label *Label
+	Code  *Code
+	// Fails if a goroutine can fail
+	Fails bool
+
+	Info
+}
+
+func (self *Spawn) ToString(depth int) string {
+	return fmt.Sprintf("%sSpawn %s:\n%s", Indent(depth), self.Label.Label, self.Code.ToString(depth+1))
+}
+
+func (self *Spawn) TypeCheck(ctx *Context) error {
+	self.ZType = types.Empty{}
+	label := self.Label.Label
+	if _, err := ctx.Get(label); err == nil {
+		return Errorf(self.Label, CodeRedefined, "Can't redefine %s", label)
+	}
+
+	context, err := goContext(ctx)
+	if err != nil {
+		return Locate(err, self)
+	}
+	c := NewContextIn(ctx)
+	c.Spawn = self
+	c.Set(label, context)
+	self.Label.ZType = context
+	err = self.Code.TypeCheck(c)
+	if err != nil {
+		return err
+	}
+
+	// after the block it's like a call of label!()
+	if self.Fails {
+		ctx.Set(label, types.Function{Args: []types.Type{}, Return: types.Empty{}, Error: types.Fail})
+		ctx.Fail(self, label).Spawn = true
+	} else {
+		ctx.Set(label, types.Function{Args: []types.Type{}, Return: types.Empty{}, Error: types.Correct})
+	}
+	return nil
+}
+
+// goContext is the melt type of go's context.Context
+func goContext(ctx *Context) (types.Type, error) {
+	var goImporter go_types.Importer
+	if ctx.Loader != nil {
+		goImporter = ctx.Loader.GoImporter()
+	} else {
+		goImporter = NewGoImporter()
+	}
+
+	p, err := ImportGo(goImporter, "context")
+	if err != nil {
+		return nil, err
+	}
+	return p.Members["Context"], nil
+}
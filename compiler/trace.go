@@ -0,0 +1,70 @@
+package compiler
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// TraceChannel is a part of the compiler which can be traced
+type TraceChannel string
+
+const (
+	TraceParse       TraceChannel = "parse"
+	TraceTypeCheck   TraceChannel = "typecheck"
+	TraceInstantiate TraceChannel = "instantiate"
+	TraceGenerate    TraceChannel = "generate"
+)
+
+// TraceChannels are all the channels, in the order of the passes
+var TraceChannels = []TraceChannel{TraceParse, TraceTypeCheck, TraceInstantiate, TraceGenerate}
+
+// Tracer writes the messages of the enabled channels
+// Everything is silent by default
+type Tracer struct {
+	Out      io.Writer
+	channels map[TraceChannel]bool
+}
+
+// Trace is the tracer of the compiler and the generator
+var Trace = &Tracer{Out: os.Stderr, channels: make(map[TraceChannel]bool)}
+
+// Enable turns on a comma separated list of channels, all for "all"
+func (t *Tracer) Enable(list string) error {
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		} else if name == "all" {
+			for _, channel := range TraceChannels {
+				t.channels[channel] = true
+			}
+			continue
+		}
+		found := false
+		for _, channel := range TraceChannels {
+			if TraceChannel(name) == channel {
+				t.channels[channel] = true
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown trace channel %s, expected parse, typecheck, instantiate, generate or all", name)
+		}
+	}
+	return nil
+}
+
+// On is true if the channel is enabled, used to skip building expensive messages
+func (t *Tracer) On(channel TraceChannel) bool {
+	return t.channels[channel]
+}
+
+// Tracef writes a line to the channel if it's enabled
+func Tracef(channel TraceChannel, format string, args ...interface{}) {
+	if !Trace.On(channel) {
+		return
+	}
+	fmt.Fprintf(Trace.Out, "[%s] %s\n", channel, strings.TrimRight(fmt.Sprintf(format, args...), "\n"))
+}
@@ -1,63 +1,149 @@
 package compiler
 
 import (
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
 func (self *Context) CollectTypes(ast Module) error {
-	a := []string{}
+	a := []*Label{}
 	types := []types.Type{}
 	for _, i := range ast.Interfaces {
-		a = append(a, i.Label.Label)
+		a = append(a, i.Label)
 		types = append(types, i.MeltType())
 	}
-	err := self.collectFrom(a, types, "Interface")
-	if err != nil {
-		return err
-	}
+	self.collectFrom(a, types, "Interface")
 
 	a = a[:0]
 	types = types[:0]
 
 	for _, r := range ast.Records {
-		a = append(a, r.Label.Label)
+		self.ownRecord(r)
+		a = append(a, r.Label)
 		types = append(types, r.MeltType())
 	}
-	err = self.collectFrom(a, types, "Record")
-	if err != nil {
-		return err
+	self.collectFrom(a, types, "Record")
+
+	a = a[:0]
+	types = types[:0]
+	for _, u := range ast.Unions {
+		a = append(a, u.Label)
+		types = append(types, u.MeltType())
+	}
+	self.collectFrom(a, types, "Union")
+
+	// the variants are the constructors of their union
+	a = a[:0]
+	types = types[:0]
+	for _, u := range ast.Unions {
+		self.Report(u.TypeCheck(self))
+		sum := u.Sum()
+		for i, v := range u.Variants {
+			a = append(a, v.Label)
+			types = append(types, Constructor(sum, sum.Variants[i]))
+		}
 	}
+	self.collectFrom(a, types, "Variant")
 
 	a = a[:0]
 	types = types[:0]
 	for _, f := range ast.Functions {
-		a = append(a, f.Label.Label)
-		types = append(types, f.MeltType())
-		if len(f.Args) > 1 {
-			fmt.Printf("%s\n", f.MeltType().ToString())
-			g := x(f.MeltType())
-			fmt.Printf("%s\n", g.Methods())
+		// the methods are collected with their records
+		if f.Receiver != nil {
+			continue
 		}
+		a = append(a, f.Label)
+		types = append(types, f.MeltType())
+		Tracef(TraceTypeCheck, "function %s: %s", f.Label.Label, f.MeltType().ToString())
 	}
-	err = self.collectFrom(a, types, "Function")
-	return err
+	self.collectFrom(a, types, "Function")
+	return nil
 }
 
-func (self *Context) collectFrom(nodes []string, types []types.Type, label string) error {
+// ownRecord marks a record with the package defining it,
+// the generated code names the records of other packages with their package
+func (self *Context) ownRecord(r *Record) {
+	if record, ok := r.MeltType().(types.Record); ok {
+		record.Package = self.Path
+		r.ZType = record
+	}
+}
+
+// collectFrom reports redefinitions, the first definition is kept
+func (self *Context) collectFrom(nodes []*Label, types []types.Type, label string) {
 	for i, node := range nodes {
-		if !self.Contains(node) {
-			self.Set(node, types[i])
+		if !self.Contains(node.Label) {
+			self.Set(node.Label, types[i])
 		} else {
-			return fmt.Errorf("%s %s can't be redefined", label, node)
+			self.Report(Errorf(node, CodeRedefined, "%s %s can't be redefined", label, node.Label))
 		}
 	}
-	return nil
 }
 
-func x(t types.Type) types.Interface {
-	g, _ := t.(types.Function)
-	g2, _ := g.Args[1].(types.Interface)
-	return g2
+// CollectMethods adds the methods of the modules to the types of their records,
+// after the types of all modules are collected: a method can be in another file
+func (self *Context) CollectMethods(modules []*Module) {
+	records := make(map[string]*Record)
+	for _, m := range modules {
+		for _, r := range m.Records {
+			if _, ok := records[r.Label.Label]; !ok {
+				records[r.Label.Label] = r
+			}
+		}
+	}
+	for _, m := range modules {
+		for _, f := range m.Functions {
+			if f.Receiver != nil {
+				self.Report(self.collectMethod(f, records))
+			}
+		}
+	}
+}
+
+func (self *Context) collectMethod(f *Function, records map[string]*Record) error {
+	label := receiverLabel(f.Receiver.Type)
+	r, ok := records[label]
+	if !ok {
+		return Errorf(f.Receiver, CodeUndefined, "%s is not a record of this package", label)
+	}
+	record := r.MeltType().(types.Record)
+	function := f.MeltType().(types.Function)
+
+	vars := []types.GenericVar{}
+	if placeholder, ok := receiverObject(f.Receiver.Type).(types.Interface); ok {
+		vars = placeholder.GenericVars
+	}
+	if len(vars) != len(record.GenericVars) {
+		return Errorf(f.Receiver, CodeTypeArgs, "%s expects %d type args", label, len(record.GenericVars))
+	}
+	// the method can label the generic vars of the record differently
+	genericMap := NewGenericMap()
+	for i, v := range vars {
+		if v.Label != record.GenericVars[i].Label {
+			genericMap.Types[v.Label] = types.Basic{Label: record.GenericVars[i].Label}
+		}
+	}
+	if len(genericMap.Types) > 0 {
+		function = ReplaceGenericVars(function, genericMap).(types.Function)
+	}
+
+	name := f.Label.Label
+	for _, field := range r.Fields {
+		if field.Label.Label == name {
+			err := Errorf(f.Label, CodeRedefined, "%s has a field %s, it can't have a method %s", label, name, name)
+			return Relate(err, field.Label, "the field %s is here", name)
+		}
+	}
+	for _, method := range r.Methods {
+		if method.Label.Label == name {
+			err := Errorf(f.Label, CodeRedefined, "Method %s.%s can't be redefined", label, name)
+			return Relate(err, method.Label, "the first %s is here", name)
+		}
+	}
+
+	_, pointer := f.Receiver.Type.(types.Pointer)
+	r.Methods = append(r.Methods, f)
+	record.ReplaceMethods(append(record.Methods(), types.Method{Label: name, Function: function, Pointer: pointer}))
+	r.ZType = record
+	self.Set(label, record)
+	return nil
 }
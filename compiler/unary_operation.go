@@ -1,21 +1,41 @@
 package compiler
 
 import (
-	"fmt"
-
 	"gitlab.com/alehander42/melt/types"
 )
 
-//UnaryOperator binary
+//UnaryOperator unary
 type UnaryOperator int
 
 const (
-	//ZeroOp +
+	//PlusOp +
 	PlusOp UnaryOperator = 1
-	//UnaryOp -
+	//MinusOp -
 	MinusOp UnaryOperator = 0
+	//NotOp !
+	NotOp UnaryOperator = 2
+	//ComplementOp ^
+	ComplementOp UnaryOperator = 3
+	//AddressOp &
+	AddressOp UnaryOperator = 4
 )
 
+var unaryOperators = map[UnaryOperator]string{PlusOp: "+", MinusOp: "-", NotOp: "!", ComplementOp: "^", AddressOp: "&"}
+
+func (op UnaryOperator) String() string {
+	return unaryOperators[op]
+}
+
+// ToUnaryOperator is the operator of a token
+func ToUnaryOperator(token string) (UnaryOperator, bool) {
+	for op, s := range unaryOperators {
+		if s == token {
+			return op, true
+		}
+	}
+	return 0, false
+}
+
 // UnaryOperation -
 type UnaryOperation struct {
 	Op         UnaryOperator
@@ -30,21 +50,21 @@ func (self *UnaryOperation) TypeCheck(ctx *Context) error {
 		return err
 	}
 
-	if m, ok := (*self.Expression).MeltType().(types.Basic); ok {
-		if m.Label == "float" || m.Label == "int" {
-			self.ZType = m
+	t := (*self.Expression).MeltType()
+	if self.Op == AddressOp {
+		switch (*self.Expression).(type) {
+		case *Label, *Selector, *RecordLiteral:
+			self.ZType = types.Pointer{Object: t}
 			return nil
 		}
+		return Errorf(self, CodeOperand, "Can't take the address of %s", t.ToString())
 	}
-	return fmt.Errorf("%s expected float or int, got: %s",
-		self.OpText(),
-		(*self.Expression).MeltType().ToString())
-}
-
-func (self *UnaryOperation) OpText() string {
-	if self.Op == PlusOp {
-		return "+"
-	} else {
-		return "-"
+	switch {
+	case (self.Op == PlusOp || self.Op == MinusOp) && IsNumeric(t),
+		self.Op == NotOp && IsBool(t),
+		self.Op == ComplementOp && IsInteger(t):
+		self.ZType = t
+		return nil
 	}
+	return Errorf(self, CodeOperand, "%s isn't defined for %s", self.Op, t.ToString())
 }
@@ -0,0 +1,221 @@
+package compiler
+
+import (
+	"fmt"
+
+	"gitlab.com/alehander42/melt/types"
+)
+
+// Union node: union Shape: Circle(r float) | Rect(w float, h float)
+// Its type is a types.Sum, each variant is a constructor returning it
+type Union struct {
+	Label    *Label
+	Variants []*Variant
+
+	Info
+}
+
+// Variant node: Circle(r float), a positional field
+// is labeled Value or Value0, Value1.. if there are more
+type Variant struct {
+	Label  *Label
+	Fields []Field
+
+	Info
+}
+
+func (self *Union) ToString(depth int) string {
+	return fmt.Sprintf("%sUnion %s", Indent(depth), self.Label.Label)
+}
+
+func (self *Variant) ToString(depth int) string {
+	return fmt.Sprintf("%sVariant %s", Indent(depth), self.Label.Label)
+}
+
+// Sum is the type of the union
+func (self *Union) Sum() types.Sum {
+	sum, _ := self.ZType.(types.Sum)
+	return sum
+}
+
+// TypeCheck rejects a field defined twice in a variant
+func (self *Union) TypeCheck(ctx *Context) error {
+	for _, variant := range self.Variants {
+		err := variant.TypeCheck(ctx)
+		if err != nil {
+			ctx.Report(err)
+		}
+	}
+	return nil
+}
+
+func (self *Variant) TypeCheck(ctx *Context) error {
+	fields := make(map[string]*Label)
+	for _, field := range self.Fields {
+		if first, ok := fields[field.Label.Label]; ok {
+			err := Errorf(field.Label, CodeRedefined, "%s has more than one field %s", self.Label.Label, field.Label.Label)
+			return Relate(err, first, "the first %s is here", field.Label.Label)
+		}
+		fields[field.Label.Label] = field.Label
+	}
+	return nil
+}
+
+// Constructor is the type of the function making a variant of sum
+func Constructor(sum types.Sum, variant types.Variant) types.Function {
+	args := []types.Type{}
+	for _, field := range variant.Fields {
+		args = append(args, field.Type)
+	}
+	instance := sum
+	instance.InstanceVars = []types.Type{}
+	for _, v := range sum.GenericVars {
+		instance.InstanceVars = append(instance.InstanceVars, types.Basic{Label: v.Label})
+	}
+	return types.Function{Args: args, Return: instance, GenericVars: sum.GenericVars, Error: types.Correct}
+}
+
+// IsConstructor is true if the call makes a variant of a sum
+func IsConstructor(label string, function types.Function) bool {
+	sum, ok := function.Return.(types.Sum)
+	if !ok {
+		return false
+	}
+	_, ok = sum.Variant(BareLabel(label))
+	return ok
+}
+
+// ResolveType replaces the name of a sum type, a record or an interface
+// with its type and Option<int> with the instance of Option
+func ResolveType(t types.Type, ctx *Context) types.Type {
+	switch other := t.(type) {
+	case types.Basic:
+		if named, ok := lookupNamed(other.Label, ctx); ok {
+			return named
+		}
+	case types.Interface:
+		// the placeholder of a generic type
+		generic, vars, ok := lookupGeneric(other.Label, ctx)
+		if !ok || len(vars) != len(other.GenericVars) {
+			return t
+		}
+		genericMap := NewGenericMap()
+		for i, v := range vars {
+			var arg types.Type = types.Basic{Label: other.GenericVars[i].Label}
+			if i < len(other.InstanceVars) && other.InstanceVars[i] != nil {
+				arg = other.InstanceVars[i]
+			}
+			genericMap.Types[v.Label] = ResolveType(arg, ctx)
+		}
+		return ReplaceGenericVars(generic, genericMap)
+	case types.SliceBuiltin:
+		return types.SliceBuiltin{Element: ResolveType(other.Element, ctx)}
+	case types.MapBuiltin:
+		return types.MapBuiltin{Key: ResolveType(other.Key, ctx), Value: ResolveType(other.Value, ctx)}
+	case types.Pointer:
+		return types.Pointer{Object: ResolveType(other.Object, ctx)}
+	case types.Channel:
+		return types.Channel{Element: ResolveType(other.Element, ctx), Dir: other.Dir}
+	case types.Function:
+		args := []types.Type{}
+		for _, arg := range other.Args {
+			args = append(args, ResolveType(arg, ctx))
+		}
+		other.Args = args
+		other.Return = ResolveType(other.Return, ctx)
+		return other
+	}
+	return t
+}
+
+// lookupNamed finds a sum, a record or an interface which isn't generic
+func lookupNamed(label string, ctx *Context) (types.Type, bool) {
+	t, err := ctx.Get(label)
+	if err != nil {
+		return nil, false
+	}
+	switch named := t.(type) {
+	case types.Sum:
+		return named, !named.IsGeneric()
+	case types.Record:
+		return named, !named.IsGeneric()
+	case types.Interface:
+		return named, !named.IsGeneric()
+	}
+	return nil, false
+}
+
+// lookupGeneric finds a generic sum or record and its generic vars
+func lookupGeneric(label string, ctx *Context) (types.Type, []types.GenericVar, bool) {
+	t, err := ctx.Get(label)
+	if err != nil {
+		return nil, nil, false
+	}
+	switch generic := t.(type) {
+	case types.Sum:
+		return generic, generic.GenericVars, generic.IsGeneric()
+	case types.Record:
+		return generic, generic.GenericVars, generic.IsGeneric()
+	}
+	return nil, nil, false
+}
+
+// ResolveSignatures resolves the named types in the signatures of the methods
+// and the functions, after the types of the package are collected
+func (self *Context) ResolveSignatures(m Module) {
+	for _, r := range m.Records {
+		record, ok := r.MeltType().(types.Record)
+		if !ok || len(record.Methods()) == 0 {
+			continue
+		}
+		methods := []types.Method{}
+		for _, method := range record.Methods() {
+			method.Function = ResolveType(method.Function, self).(types.Function)
+			methods = append(methods, method)
+		}
+		record.ReplaceMethods(methods)
+		r.ZType = record
+		self.Set(r.Label.Label, record)
+	}
+
+	for _, f := range m.Functions {
+		function, ok := f.MeltType().(types.Function)
+		if !ok {
+			continue
+		}
+		function = ResolveType(function, self).(types.Function)
+		for i := range f.Args {
+			f.Args[i].Type = ResolveType(f.Args[i].Type, self)
+		}
+		f.ZType = function
+		if f.Receiver != nil {
+			continue
+		}
+		if t, err := self.Get(f.Label.Label); err == nil {
+			if _, ok := t.(types.Function); ok {
+				self.Set(f.Label.Label, function)
+			}
+		}
+	}
+}
+
+// settle gives a value with an open sum like None() the type expected for it
+func settle(value Ast, expected types.Type) {
+	sum, ok := value.MeltType().(types.Sum)
+	if !ok || !open(sum) {
+		return
+	}
+	if other, ok := expected.(types.Sum); ok && !open(other) && other.Accepts(sum) {
+		value.ChangeMeltType(other)
+	}
+}
+
+// open is true if some generic var of the sum isn't known
+func open(sum types.Sum) bool {
+	for i := range sum.InstanceVars {
+		if sum.Open(i) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,41 +1,46 @@
 package compiler
 
 import (
-	"errors"
 	"fmt"
 
 	"gitlab.com/alehander42/melt/types"
 )
 
 // If node
+// An elif is an If alone in the Otherwise of the previous one
 type If struct {
-	Test      *Cmp
+	Test      Ast
 	Code      *Code
 	Otherwise *Code
+
+	Info
 }
 
+// TypeCheck checks each branch in its own scope
+// The branches are checked even with a broken test
+// An error handled in only one of them isn't handled after the if
 func (self *If) TypeCheck(ctx *Context) error {
 	t := self.Test.TypeCheck(ctx)
 	if t != nil {
-		return t
-	} else {
-		if a, ok := self.Test.MeltType().(types.Basic); ok {
-			if a.Label != "bool" {
-				return errors.New("if expect bool test")
-			}
-		} else {
-			return errors.New("if expect bool test")
-		}
+		ctx.Report(Locate(t, self.Test))
+	} else if a, ok := self.Test.MeltType().(types.Basic); !ok || a.Label != "bool" {
+		ctx.Report(Errorf(self.Test, CodeCondition, "if expects a bool test, got %s", self.Test.MeltType().ToString()))
 	}
 
-	err := self.Code.TypeCheck(ctx)
+	branches := []*Context{ctx.Branch()}
+	err := self.Code.TypeCheck(branches[0])
 	if err != nil {
 		return err
 	}
-	other := self.Otherwise.TypeCheck(ctx)
-	if other != nil {
-		return other
+	if self.Otherwise != nil {
+		branches = append(branches, ctx.Branch())
+		other := self.Otherwise.TypeCheck(branches[1])
+		if other != nil {
+			return other
+		}
 	}
+	ctx.Join(branches, self.Otherwise != nil)
+	self.ZType = types.Nil{}
 	return nil
 }
 
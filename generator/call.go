@@ -4,25 +4,92 @@ import (
 	"go/ast"
 
 	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
 )
 
 func GenerateCall(c *comp.Call, ctx *comp.Context) (ast.Expr, error) {
-  f, err := GenerateLabel(c.Function, ctx)
+	call, err := generateCall(c, ctx)
+	if err != nil || !failing(c) {
+		return call, err
+	}
+	return lowerCall(c.Failure, call, c.MeltType(), ctx)
+}
+
+func generateCall(c *comp.Call, ctx *comp.Context) (ast.Expr, error) {
+	if function, ok := c.Function.MeltType().(types.Function); ok && comp.IsConstructor(c.Function.Label, function) {
+		return GenerateConstructor(c, ctx)
+	}
+	var f ast.Expr
+	var err error
+	if comp.IsNumeric(c.Function.MeltType()) {
+		// a conversion names the go type: float(n) is float64(n)
+		f, err = GenerateType(c.MeltType(), ctx)
+	} else {
+		f, err = GenerateLabel(c.Function, ctx)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	expressions := []ast.Expr{}
-  for _, arg := range c.Args {
-      expression, err := GenerateExpr(arg, ctx)
-      if err != nil {
-          return nil, err
-      }
+	if c.Instance != nil {
+		name, ok := ctx.InstanceName(comp.BareLabel(c.Function.Label), *c.Instance)
+		if ok {
+			f = ToIdent(name)
+		}
+	}
 
-      expressions = append(expressions, expression)
-  }
+	args, err := GenerateArgs(c.Args, ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	return &ast.CallExpr{
-		Args: expressions,
-		Fun: f}, nil
+		Args: args,
+		Fun:  f}, nil
+}
+
+func GenerateMethodCall(m *comp.MethodCall, ctx *comp.Context) (ast.Expr, error) {
+	call, err := generateMethodCall(m, ctx)
+	if err != nil || m.Failure == nil {
+		return call, err
+	}
+	return lowerCall(m.Failure, call, m.MeltType(), ctx)
+}
+
+func generateMethodCall(m *comp.MethodCall, ctx *comp.Context) (ast.Expr, error) {
+	receiver, err := GenerateExpr(*m.Receiver, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	method := ToIdent(comp.BareLabel(m.Method.Label))
+	if m.Instance != nil {
+		imported := ctx.Imports[(*m.Receiver).(*comp.Label).Label]
+		name, ok := imported.Context.InstanceName(method.Name, *m.Instance)
+		if ok {
+			method = ToIdent(name)
+		}
+	}
+
+	args, err := GenerateArgs(m.Args, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.CallExpr{
+		Args: args,
+		Fun:  &ast.SelectorExpr{X: receiver, Sel: method}}, nil
+}
+
+func GenerateArgs(args []comp.Ast, ctx *comp.Context) ([]ast.Expr, error) {
+	expressions := []ast.Expr{}
+	for _, arg := range args {
+		expression, err := GenerateExpr(arg, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		expressions = append(expressions, expression)
+	}
+	return expressions, nil
 }
@@ -0,0 +1,26 @@
+package generator
+
+import (
+	"go/ast"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+)
+
+// GenerateCast is a go conversion: 0 as int64 is int64(0)
+func GenerateCast(c *comp.Cast, ctx *comp.Context) (ast.Expr, error) {
+	t, err := GenerateType(c.Type, ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, err := GenerateExpr(c.Value, ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch t.(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+	default:
+		// (*Box)(b), a pointer or a func type needs parens
+		t = &ast.ParenExpr{X: t}
+	}
+	return &ast.CallExpr{Fun: t, Args: []ast.Expr{value}}, nil
+}
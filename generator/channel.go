@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+)
+
+func GenerateSend(s *comp.Send, ctx *comp.Context) (ast.Stmt, error) {
+	channel, err := GenerateExpr(s.Channel, ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, err := GenerateExpr(s.Value, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.SendStmt{Chan: channel, Value: value}, nil
+}
+
+func GenerateReceive(r *comp.Receive, ctx *comp.Context) (ast.Expr, error) {
+	channel, err := GenerateExpr(r.Channel, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.UnaryExpr{Op: token.ARROW, X: channel}, nil
+}
+
+// GenerateGo runs a go func(): block as a closure called right away
+func GenerateGo(g *comp.Go, ctx *comp.Context) (ast.Stmt, error) {
+	if g.Call != nil {
+		call, err := GenerateExpr(g.Call, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.GoStmt{Call: call.(*ast.CallExpr)}, nil
+	}
+
+	body, err := GenerateCode(g.Code, ctx)
+	if err != nil {
+		return nil, err
+	}
+	declareErrors(g.Errors, body)
+	return &ast.GoStmt{Call: &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{}},
+			Body: body}}}, nil
+}
+
+func GenerateLoop(l *comp.Loop, ctx *comp.Context) (ast.Stmt, error) {
+	body, err := GenerateCode(l.Code, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ForStmt{Body: body}, nil
+}
+
+// GenerateBreak leaves the loop with its label in a select or a switch
+func GenerateBreak(b *comp.Break, ctx *comp.Context) (ast.Stmt, error) {
+	if b.Label != "" {
+		return &ast.BranchStmt{Tok: token.BREAK, Label: ToIdent(b.Label)}, nil
+	}
+	return &ast.BranchStmt{Tok: token.BREAK}, nil
+}
+
+// labelLoop labels a loop left by a break in a select or a switch
+func labelLoop(exit comp.Exit) func(ast.Stmt, error) (ast.Stmt, error) {
+	return func(loop ast.Stmt, err error) (ast.Stmt, error) {
+		if err != nil || exit.Label == "" {
+			return loop, err
+		}
+		return &ast.LabeledStmt{Label: ToIdent(exit.Label), Stmt: loop}, nil
+	}
+}
+
+func GenerateSelect(s *comp.Select, ctx *comp.Context) (ast.Stmt, error) {
+	clauses := []ast.Stmt{}
+	for _, c := range s.Cases {
+		clause, err := generateSelectCase(c, ctx)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+	return &ast.SelectStmt{Body: &ast.BlockStmt{List: clauses}}, nil
+}
+
+func generateSelectCase(c *comp.SelectCase, ctx *comp.Context) (*ast.CommClause, error) {
+	body, err := GenerateCode(c.Code, ctx)
+	if err != nil {
+		return nil, err
+	}
+	clause := &ast.CommClause{Body: body.List}
+	if c.Send != nil {
+		clause.Comm, err = GenerateSend(c.Send, ctx)
+	} else if c.Receive != nil {
+		var receive ast.Expr
+		receive, err = GenerateReceive(c.Receive, ctx)
+		if c.Label != nil {
+			clause.Comm = &ast.AssignStmt{
+				Lhs: []ast.Expr{ToIdent(c.Label.Label)},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{receive}}
+		} else {
+			clause.Comm = &ast.ExprStmt{X: receive}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return clause, nil
+}
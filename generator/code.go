@@ -1,7 +1,6 @@
 package generator
 
 import (
-	"fmt"
 	"go/ast"
 
 	comp "gitlab.com/alehander42/melt/compiler"
@@ -10,15 +9,13 @@ import (
 func GenerateCode(c *comp.Code, ctx *comp.Context) (*ast.BlockStmt, error) {
 	list := []ast.Stmt{}
 	for _, code := range c.E {
-		fmt.Printf("%s\n", code.MeltType())
-		expr, err := GenerateNode(code, ctx)
+		statements, err := lowerIn(func() ([]ast.Stmt, error) {
+			return GenerateStatements(code, ctx)
+		})
 		if err != nil {
 			return nil, err
 		}
-		if expr == nil {
-			continue
-		}
-		list = append(list, expr)
+		list = append(list, statements...)
 	}
 	return &ast.BlockStmt{
 		List: list}, nil
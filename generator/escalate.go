@@ -3,21 +3,47 @@ package generator
 import (
 	"go/ast"
 	"go/token"
+
 	comp "gitlab.com/alehander42/melt/compiler"
 )
 
-func GenerateEscalate(e *comp.Escalate, ctx *comp.Context) (ast.Stmt, error) {
-	  // return &ast.ReturnStmt{Results: []ast.Expr{&ast.Ident{Name: "nil"}}}, nil
+// GenerateEscalate returns the first error of the escalated calls:
+//
+//	if readErr0 != nil {
+//		return 0, readErr0
+//	}
+//
+// escalate read with "message" returns fmt.Errorf("message: %w", readErr0)
+func GenerateEscalate(e *comp.Escalate, ctx *comp.Context) ([]ast.Stmt, error) {
+	if !e.Fails {
+		return nil, nil
+	}
+	statements := []ast.Stmt{}
+	for _, variable := range e.Errors {
+		statement, err := escalateError(e, variable, ctx)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
 
-		return &ast.IfStmt{
-			Cond: &ast.BinaryExpr{
-				X: &ast.Ident{Name: "err"},
-				Y: &ast.Ident{Name: "nil"},
-				Op: token.NEQ},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.Ident{Name: "nil"},
-							&ast.Ident{Name: "err"}}}}}}, nil
+// escalateError returns the error in variable if there is one
+func escalateError(e *comp.Escalate, variable string, ctx *comp.Context) (ast.Stmt, error) {
+	var value ast.Expr = ToIdent(variable)
+	if e.Message != nil {
+		var err error
+		value, err = wrapError(e.Message, value, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result, err := returnError(value, e.Return, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: ToIdent(variable), Op: token.NEQ, Y: ToIdent("nil")},
+		Body: &ast.BlockStmt{List: []ast.Stmt{result}}}, nil
 }
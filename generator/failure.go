@@ -0,0 +1,259 @@
+package generator
+
+import (
+	"errors"
+	"go/ast"
+	"go/token"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
+)
+
+// lowered are the statements calling the failing functions of the statement
+// being generated, they go before it
+var lowered *[]ast.Stmt
+
+// lowerIn generates statements with the failing calls in them lowered before them
+func lowerIn(generate func() ([]ast.Stmt, error)) ([]ast.Stmt, error) {
+	outer := lowered
+	calls := []ast.Stmt{}
+	lowered = &calls
+	statements, err := generate()
+	lowered = outer
+	if err != nil {
+		return nil, err
+	}
+	return append(calls, statements...), nil
+}
+
+// lowerCall moves a failing call before its statement, the value goes to a temp
+// and the error to the variable of its handler:
+//
+//	var halfValue0 int
+//	halfValue0, halfErr0 = half(4)
+//	var a int = halfValue0 + 1
+func lowerCall(failure *comp.Failure, call ast.Expr, t types.Type, ctx *comp.Context) (ast.Expr, error) {
+	if lowered == nil {
+		return nil, errors.New("failing call outside of a function")
+	}
+	if _, ok := t.(types.Empty); ok {
+		*lowered = append(*lowered, failingCall(failure, call, nil))
+		return nil, escalateIn(failure, ctx)
+	}
+	temp := ToIdent(failure.Temp())
+	if failure.Taken {
+		goType, err := GenerateType(t, ctx)
+		if err != nil {
+			return nil, err
+		}
+		*lowered = append(*lowered, declare(temp.Name, goType))
+	}
+	*lowered = append(*lowered, failingCall(failure, call, temp))
+	return temp, escalateIn(failure, ctx)
+}
+
+// escalateIn returns the error of a failing call in a loop right after it,
+// if it's escalated after the loop
+func escalateIn(failure *comp.Failure, ctx *comp.Context) error {
+	if failure.Escalate == nil {
+		return nil
+	}
+	statement, err := escalateError(failure.Escalate, failure.Variable(), ctx)
+	if err != nil {
+		return err
+	}
+	*lowered = append(*lowered, statement)
+	return nil
+}
+
+// failingCall assigns the value and the error of a call,
+// an error without a handler is dropped, a nil value isn't used.
+// In a loop with the handler after it, the first error is kept:
+//
+//	{
+//		var halfLoopErr0 error
+//		halfValue0, halfLoopErr0 = half(i)
+//		if halfErr0 == nil {
+//			halfErr0 = halfLoopErr0
+//		}
+//	}
+func failingCall(failure *comp.Failure, call ast.Expr, value ast.Expr) ast.Stmt {
+	if !failure.Taken {
+		if value == nil {
+			return &ast.ExprStmt{X: call}
+		}
+		return &ast.AssignStmt{Lhs: []ast.Expr{value, ToIdent("_")}, Tok: token.DEFINE, Rhs: []ast.Expr{call}}
+	}
+	variable := ToIdent(failure.Variable())
+	if failure.First {
+		variable = ToIdent(failure.Iteration())
+	}
+	lhs := []ast.Expr{variable}
+	if value != nil {
+		lhs = []ast.Expr{value, variable}
+	}
+	assign := &ast.AssignStmt{Lhs: lhs, Tok: token.ASSIGN, Rhs: []ast.Expr{call}}
+	if !failure.First {
+		return assign
+	}
+	return &ast.BlockStmt{List: []ast.Stmt{
+		declare(variable.Name, ToIdent("error")),
+		assign,
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: ToIdent(failure.Variable()), Op: token.EQL, Y: ToIdent("nil")},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.AssignStmt{
+				Lhs: []ast.Expr{ToIdent(failure.Variable())},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{variable}}}}}}}
+}
+
+// GenerateFailingStatement generates a failing call which value isn't used
+func GenerateFailingStatement(node comp.Ast, failure *comp.Failure, ctx *comp.Context) (ast.Stmt, error) {
+	var call ast.Expr
+	var err error
+	switch kind := node.(type) {
+	case *comp.Call:
+		call, err = generateCall(kind, ctx)
+	case *comp.MethodCall:
+		call, err = generateMethodCall(kind, ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	statement := failingCall(failure, call, ToIdent("_"))
+	if _, ok := node.MeltType().(types.Empty); ok || !failure.Taken {
+		statement = failingCall(failure, call, nil)
+	}
+	if failure.Escalate == nil {
+		return statement, nil
+	}
+	*lowered = append(*lowered, statement)
+	return escalateError(failure.Escalate, failure.Variable(), ctx)
+}
+
+// failing is true if the call returns an error,
+// a call of a generic f? fails only in some instances
+func failing(c *comp.Call) bool {
+	function, ok := c.Function.MeltType().(types.Function)
+	return c.Failure != nil && (!ok || function.Error != types.Correct)
+}
+
+// GenerateOn runs the handler with the first error of the calls it handles:
+//
+//	if err := halfErr0; err != nil {
+//		..
+//	}
+func GenerateOn(o *comp.On, ctx *comp.Context) (ast.Stmt, error) {
+	if len(o.Errors) == 0 {
+		return nil, nil
+	}
+	handler, err := generateArms(o, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if o.Clear {
+		clear := &ast.AssignStmt{Tok: token.ASSIGN}
+		for _, variable := range o.Errors {
+			clear.Lhs = append(clear.Lhs, ToIdent(variable))
+			clear.Rhs = append(clear.Rhs, ToIdent("nil"))
+		}
+		handler.List = append([]ast.Stmt{clear}, handler.List...)
+	}
+	check := &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: ToIdent("err"), Op: token.NEQ, Y: ToIdent("nil")},
+		Body: handler}
+	first := &ast.AssignStmt{Lhs: []ast.Expr{ToIdent("err")}, Tok: token.DEFINE, Rhs: []ast.Expr{ToIdent(o.Errors[0])}}
+	if len(o.Errors) == 1 {
+		check.Init = first
+		return check, nil
+	}
+
+	list := []ast.Stmt{first}
+	for _, variable := range o.Errors[1:] {
+		list = append(list, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: ToIdent("err"), Op: token.EQL, Y: ToIdent("nil")},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.AssignStmt{Lhs: []ast.Expr{ToIdent("err")}, Tok: token.ASSIGN, Rhs: []ast.Expr{ToIdent(variable)}}}}})
+	}
+	return &ast.BlockStmt{List: append(list, check)}, nil
+}
+
+// generateArms generates the handler of on f: or an errors.As check
+// for each arm of on f as NotFound:
+//
+//	if notFoundErr := (NotFound{}); errors.As(err, &notFoundErr) {
+//		..
+//	} else if deniedErr := (*Denied)(nil); errors.As(err, &deniedErr) {
+//		..
+//	} else {
+//		..
+//	}
+func generateArms(o *comp.On, ctx *comp.Context) (*ast.BlockStmt, error) {
+	if o.Type == nil {
+		return GenerateCode(o.Handler, ctx)
+	}
+	var first *ast.IfStmt
+	var last *ast.IfStmt
+	for _, arm := range append([]*comp.On{o}, o.Arms...) {
+		handler, err := GenerateCode(arm.Handler, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if arm.Type == nil {
+			last.Else = handler
+			break
+		}
+		check, err := errorsAs(arm, handler, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = check
+		} else {
+			last.Else = check
+		}
+		last = check
+	}
+	return &ast.BlockStmt{List: []ast.Stmt{first}}, nil
+}
+
+// errorsAs runs the handler of an arm if errors.As finds an error of its type
+func errorsAs(arm *comp.On, handler *ast.BlockStmt, ctx *comp.Context) (*ast.IfStmt, error) {
+	// the target has the type of $err, a record or a pointer to it
+	t := arm.Type.MeltType()
+	goType, err := GenerateType(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	// a literal needs parens in an if
+	var target ast.Expr = &ast.ParenExpr{X: &ast.CompositeLit{Type: goType}}
+	if _, ok := t.(types.Pointer); ok {
+		target = &ast.CallExpr{Fun: &ast.ParenExpr{X: goType}, Args: []ast.Expr{ToIdent("nil")}}
+	}
+	variable := comp.ErrorVariable(t)
+	return &ast.IfStmt{
+		Init: &ast.AssignStmt{Lhs: []ast.Expr{ToIdent(variable)}, Tok: token.DEFINE, Rhs: []ast.Expr{target}},
+		Cond: &ast.CallExpr{
+			Fun:  Selector(RequireImport("errors"), "As"),
+			Args: []ast.Expr{ToIdent("err"), &ast.UnaryExpr{Op: token.AND, X: ToIdent(variable)}}},
+		Body: handler}, nil
+}
+
+// returnNil ends a failing function without a result which doesn't return on its own
+func returnNil(body *ast.BlockStmt) {
+	if len(body.List) > 0 {
+		if _, ok := body.List[len(body.List)-1].(*ast.ReturnStmt); ok {
+			return
+		}
+	}
+	body.List = append(body.List, &ast.ReturnStmt{Results: []ast.Expr{ToIdent("nil")}})
+}
+
+// declareErrors declares the error variables of a function before its code
+func declareErrors(variables []string, body *ast.BlockStmt) {
+	declarations := []ast.Stmt{}
+	for _, variable := range variables {
+		declarations = append(declarations, declare(variable, ToIdent("error")))
+	}
+	body.List = append(declarations, body.List...)
+}
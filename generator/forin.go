@@ -23,19 +23,41 @@ func GenerateForIn(f *comp.ForIn, ctx *comp.Context) (ast.Stmt, error) {
 		if err != nil {
 			  return nil, err
 		}
-		var b []ast.Stmt = []ast.Stmt{}
-		for _, e := range f.Code.E {
-			  node, err := GenerateNode(e, ctx)
-				if err != nil {
-					  return nil, err
-				}
-				b = append(b, node)
+		body, err := GenerateCode(f.Code, ctx)
+		if err != nil {
+			  return nil, err
 		}
 		return &ast.RangeStmt{
 			  Value: value,
 				Key: key,
 				Tok: token.DEFINE,
 				X: sequence,
-				Body: &ast.BlockStmt{List: b}}, nil
+				Body: body}, nil
 		// 		// Value: labelInit: , nil
 }
+
+// GenerateForLoop generates for index := begin; index < end; index++
+func GenerateForLoop(f *comp.ForLoop, ctx *comp.Context) (ast.Stmt, error) {
+	begin, err := GenerateExpr(*f.Begin, ctx)
+	if err != nil {
+		return nil, err
+	}
+	end, err := GenerateExpr(*f.End, ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := GenerateCode(f.Code, ctx)
+	if err != nil {
+		return nil, err
+	}
+	cmp := token.LSS
+	if f.Inclusive {
+		cmp = token.LEQ
+	}
+	index := ToIdent(f.Index.Label)
+	return &ast.ForStmt{
+		Init: &ast.AssignStmt{Lhs: []ast.Expr{index}, Tok: token.DEFINE, Rhs: []ast.Expr{begin}},
+		Cond: &ast.BinaryExpr{X: index, Op: cmp, Y: end},
+		Post: &ast.IncDecStmt{X: index, Tok: token.INC},
+		Body: body}, nil
+}
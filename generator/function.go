@@ -2,7 +2,6 @@ package generator
 
 import (
 	"errors"
-	"fmt"
 	"go/ast"
 
 	comp "gitlab.com/alehander42/melt/compiler"
@@ -14,6 +13,7 @@ func GenerateFunction(f *comp.Function, ctx *comp.Context) (*ast.FuncDecl, []*as
 	if err != nil {
 		return nil, []*ast.Object{}, err
 	}
+	declareErrors(f.Errors, block)
 
 	fields := []*ast.Field{}
 	results := []*ast.Field{}
@@ -43,15 +43,26 @@ func GenerateFunction(f *comp.Function, ctx *comp.Context) (*ast.FuncDecl, []*as
 		results = append(results, &ast.Field{Type: returnType})
 	}
 
-	if m.Error == types.Correct {
-		fmt.Println("yes")
-	} else if m.Error == types.Maybe {
+	if m.Error == types.Maybe {
 		return nil, []*ast.Object{}, errors.New("? impossible")
-	} else {
+	} else if m.Error == types.Fail {
 		results = append(results, &ast.Field{Type: ToIdent("error")})
+		if len(results) == 1 {
+			returnNil(block)
+		}
+	}
+
+	var receiver *ast.FieldList
+	if f.Receiver != nil {
+		t, err := GenerateType(f.Receiver.Type, ctx)
+		if err != nil {
+			return nil, []*ast.Object{}, err
+		}
+		receiver = &ast.FieldList{List: []*ast.Field{{Names: []*ast.Ident{ToIdent(f.Receiver.ID.Label)}, Type: t}}}
 	}
 
 	f2 := &ast.FuncDecl{
+		Recv: receiver,
 		Name: ToIdent(f.Label.Label),
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
@@ -60,6 +71,10 @@ func GenerateFunction(f *comp.Function, ctx *comp.Context) (*ast.FuncDecl, []*as
 				List: results}},
 		Body: block}
 
+	// a method isn't in the scope of the file
+	if f.Receiver != nil {
+		return f2, []*ast.Object{}, nil
+	}
 	obj := &ast.Object{Kind: ast.Fun, Name: f.Label.Label, Decl: f2}
 	f2.Name.Obj = obj
 	return f2, []*ast.Object{obj}, nil
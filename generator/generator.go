@@ -19,10 +19,53 @@ func Generate(meltAst comp.Module, ctx *comp.Context) (*token.FileSet, *ast.File
 	if err != nil {
 		return nil, nil, err
 	}
+	err = GenerateInstances([]*comp.Module{&meltAst}, []*ast.File{a}, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return f, a, nil
 }
 
+// GeneratePackage returns a go file for each module of the package
+func GeneratePackage(p *comp.Package, ctx *comp.Context) (*token.FileSet, []*ast.File, error) {
+	f := token.NewFileSet()
+	files := []*ast.File{}
+	for _, m := range p.Modules {
+		a, err := GenerateModule(*m, ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %s", m.File, err)
+		}
+		files = append(files, a)
+	}
+	err := GenerateInstances(p.Modules, files, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return f, files, nil
+}
+
+// GenerateInstances declares the instances of generic sums and records,
+// an instance can use new ones so it goes on until there are none
+func GenerateInstances(modules []*comp.Module, files []*ast.File, ctx *comp.Context) error {
+	sums := make(map[string]bool)
+	records := make(map[string]int)
+	for {
+		moreSums, err := generateSumInstances(modules, files, sums, ctx)
+		if err != nil {
+			return err
+		}
+		moreRecords, err := generateRecordInstances(modules, files, records, ctx)
+		if err != nil {
+			return err
+		}
+		if !moreSums && !moreRecords {
+			return nil
+		}
+	}
+}
+
 func b() {
 	a := `
 package main
@@ -17,3 +17,32 @@ func ToType(t types.Type) ast.Expr {
 	}
 	return nil
 }
+
+func Selector(x ast.Expr, label string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{X: x, Sel: ToIdent(label)}
+}
+
+// requiredImport marks the packages used by the generated code itself
+var requiredImport = &ast.Object{Kind: ast.Pkg, Name: "required"}
+
+// RequireImport is a go package used by the generated code,
+// GenerateModule imports it if the melt code doesn't
+func RequireImport(name string) *ast.Ident {
+	return &ast.Ident{Name: name, Obj: requiredImport}
+}
+
+// RequiredImports are the packages required by the generated declarations
+func RequiredImports(decls []ast.Decl) []string {
+	found := make(map[string]bool)
+	required := []string{}
+	for _, decl := range decls {
+		ast.Inspect(decl, func(node ast.Node) bool {
+			if ident, ok := node.(*ast.Ident); ok && ident.Obj == requiredImport && !found[ident.Name] {
+				found[ident.Name] = true
+				required = append(required, ident.Name)
+			}
+			return true
+		})
+	}
+	return required
+}
@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"go/ast"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+)
+
+// GenerateIf generates an elif as an else if, an elif with failing calls
+// in its test as an else block calling them before its if
+func GenerateIf(i *comp.If, ctx *comp.Context) (ast.Stmt, error) {
+	test, err := GenerateExpr(i.Test, ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := GenerateCode(i.Code, ctx)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.IfStmt{Cond: test, Body: body}
+	if i.Otherwise == nil {
+		return stmt, nil
+	}
+
+	var elif *comp.If
+	if len(i.Otherwise.E) == 1 {
+		elif, _ = i.Otherwise.E[0].(*comp.If)
+	}
+	if elif != nil {
+		var statements []ast.Stmt
+		statements, err = lowerIn(func() ([]ast.Stmt, error) {
+			nested, err := GenerateIf(elif, ctx)
+			return []ast.Stmt{nested}, err
+		})
+		if len(statements) == 1 {
+			stmt.Else = statements[0]
+		} else {
+			stmt.Else = &ast.BlockStmt{List: statements}
+		}
+	} else {
+		stmt.Else, err = GenerateCode(i.Otherwise, ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
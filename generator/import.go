@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"strconv"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+)
+
+// GenerateImports returns the import declaration of a module
+// or nil if it doesn't import anything
+// required are go packages used by the generated code, they're added if needed
+func GenerateImports(m comp.Module, required []string, ctx *comp.Context) (*ast.GenDecl, error) {
+	specs := []ast.Spec{}
+	imported := make(map[string]bool)
+	melt := []comp.Import{}
+	if m.Imports != nil {
+		for _, i := range m.Imports.Go {
+			specs = append(specs, ImportSpec(i.Package, i.Alias))
+			imported[i.Package] = i.Alias == ""
+		}
+		melt = m.Imports.Melt
+	}
+	for _, name := range required {
+		if !imported[name] {
+			specs = append(specs, ImportSpec(name, ""))
+		}
+	}
+
+	for _, i := range melt {
+		var p *comp.Package
+		for _, imported := range ctx.Imports {
+			if imported.Path == i.Package {
+				p = imported
+			}
+		}
+		if p == nil {
+			return nil, fmt.Errorf("%s is not imported", i.Package)
+		}
+		alias := i.Alias
+		if alias == "" && path.Base(p.GoPath) != p.Name {
+			alias = p.Name
+		}
+		specs = append(specs, ImportSpec(p.GoPath, alias))
+	}
+
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	return &ast.GenDecl{Tok: token.IMPORT, Lparen: 1, Specs: specs}, nil
+}
+
+func ImportSpec(importPath string, alias string) *ast.ImportSpec {
+	spec := &ast.ImportSpec{
+		Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(importPath)}}
+	if alias != "" {
+		spec.Name = ToIdent(alias)
+	}
+	return spec
+}
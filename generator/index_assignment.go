@@ -28,5 +28,4 @@ func GenerateIndexAssignment(g *comp.IndexAssignment, ctx *comp.Context) (ast.St
       Rhs: []ast.Expr{
           v},
       Tok: token.ASSIGN}, nil
-    return &ast.ReturnStmt{Results: []ast.Expr{}}, nil
 }
@@ -2,10 +2,33 @@ package generator
 
 import (
 	"go/ast"
+	"go/token"
 
 	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
 )
 
-func GenerateInterface(i comp.Interface, ctx *comp.Interface) (*ast.GenDecl, []*ast.Object, error) {
-	return &ast.GenDecl{}, []*ast.Object{}, nil
+// GenerateInterface generates an interface with the methods in the order they're declared,
+// the generic ones are only checked by melt, their instances are the types given for them
+func GenerateInterface(i *comp.Interface, ctx *comp.Context) ([]ast.Decl, []*ast.Object, error) {
+	if t, ok := i.MeltType().(types.Interface); ok && t.IsGeneric() {
+		return []ast.Decl{}, []*ast.Object{}, nil
+	}
+
+	methods := []*ast.Field{}
+	for _, method := range i.Methods {
+		t, err := GenerateType(method.Type, ctx)
+		if err != nil {
+			return nil, []*ast.Object{}, err
+		}
+		methods = append(methods, &ast.Field{Names: []*ast.Ident{ToIdent(method.Label.Label)}, Type: t})
+	}
+
+	spec := &ast.TypeSpec{
+		Name: ToIdent(i.Label.Label),
+		Type: &ast.InterfaceType{Methods: &ast.FieldList{List: methods}}}
+	obj := &ast.Object{Kind: ast.Typ, Name: i.Label.Label, Decl: spec}
+	spec.Name.Obj = obj
+
+	return []ast.Decl{&ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{spec}}}, []*ast.Object{obj}, nil
 }
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"go/ast"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
+)
+
+// GenerateLambda generates a go func literal,
+// the body of the short form is returned
+func GenerateLambda(l *comp.Lambda, ctx *comp.Context) (ast.Expr, error) {
+	params := []*ast.Field{}
+	for _, arg := range l.Args {
+		t, err := GenerateType(arg.Type, ctx)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, &ast.Field{Names: []*ast.Ident{ToIdent(arg.ID.Label)}, Type: t})
+	}
+
+	_, empty := l.Return.(types.Empty)
+	results := []*ast.Field{}
+	if !empty {
+		t, err := GenerateType(l.Return, ctx)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &ast.Field{Type: t})
+	}
+	if l.Error == types.Fail {
+		results = append(results, &ast.Field{Type: ToIdent("error")})
+	}
+
+	var body *ast.BlockStmt
+	if l.Code != nil {
+		code, err := GenerateCode(l.Code, ctx)
+		if err != nil {
+			return nil, err
+		}
+		declareErrors(l.Errors, code)
+		if empty && l.Error == types.Fail {
+			returnNil(code)
+		}
+		body = code
+	} else {
+		var value ast.Expr
+		calls, err := lowerIn(func() ([]ast.Stmt, error) {
+			var err error
+			value, err = GenerateExpr(l.Body, ctx)
+			return nil, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		label := comp.CallLabel(l.Body)
+		if l.Error != types.Fail || label != comp.BareLabel(label) {
+			// a failing body returns its own error
+			if empty && l.Error != types.Fail {
+				body = &ast.BlockStmt{List: []ast.Stmt{&ast.ExprStmt{X: value}}}
+			} else {
+				body = &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{value}}}}
+			}
+		} else if empty {
+			body = &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: value},
+				&ast.ReturnStmt{Results: []ast.Expr{ToIdent("nil")}}}}
+		} else {
+			body = &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{value, ToIdent("nil")}}}}
+		}
+		body.List = append(calls, body.List...)
+		declareErrors(l.Errors, body)
+	}
+
+	return &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{List: params},
+			Results: &ast.FieldList{List: results}},
+		Body: body}, nil
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+)
+
+func GenerateInteger(i *comp.Integer) ast.Expr {
+	return &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(i.Value, 10)}
+}
+
+// GenerateFloat keeps a . in the literal, so go types it as a float
+func GenerateFloat(f *comp.Float) ast.Expr {
+	value := strconv.FormatFloat(f.Value, 'g', -1, 64)
+	if !strings.ContainsAny(value, ".eE") {
+		value += ".0"
+	}
+	return &ast.BasicLit{Kind: token.FLOAT, Value: value}
+}
+
+// GenerateString uses the melt literal, it's quoted like a go one
+func GenerateString(s *comp.String) ast.Expr {
+	return &ast.BasicLit{Kind: token.STRING, Value: s.Value}
+}
+
+func GenerateBool(b *comp.Bool) ast.Expr {
+	return ToIdent(strconv.FormatBool(b.Value))
+}
+
+// GenerateTemplate generates fmt.Sprintf with a %v for each slot
+func GenerateTemplate(t *comp.Template, ctx *comp.Context) (ast.Expr, error) {
+	format, args, err := templateFormat(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	literal := &ast.BasicLit{Kind: token.STRING, Value: "\"" + format + "\""}
+	return &ast.CallExpr{Fun: Selector(RequireImport("fmt"), "Sprintf"), Args: append([]ast.Expr{literal}, args...)}, nil
+}
+
+// templateFormat is the format of a template with a %v for each slot and its args
+func templateFormat(t *comp.Template, ctx *comp.Context) (string, []ast.Expr, error) {
+	texts := make([]string, len(t.Text))
+	for i, text := range t.Text {
+		texts[i] = strings.ReplaceAll(text, "%", "%%")
+	}
+	args := []ast.Expr{}
+	for _, arg := range t.Args {
+		expr, err := GenerateExpr(arg, ctx)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, expr)
+	}
+	return strings.Join(texts, "%v"), args, nil
+}
@@ -7,17 +7,17 @@ import (
 )
 
 func GenerateMake(m *comp.Make, ctx *comp.Context) (ast.Expr, error) {
-  c, err := GenerateExpr(m.Args[0], ctx)
+	t, err := GenerateType(m.Type, ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	t, err := GenerateType(m.Type, ctx)
+	args, err := GenerateArgs(m.Args, ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	return &ast.CallExpr{
-		Args: []ast.Expr{t, c},
+		Args: append([]ast.Expr{t}, args...),
 		Fun: &ast.Ident{Name: "make"}}, nil
 }
@@ -0,0 +1,288 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
+)
+
+// matchValue is the value taken apart by the arms,
+// a melt label can't have capital letters so it can't hide one
+const matchValue = "matchValue"
+
+// GenerateMatch generates a match on a sum as a type switch with a case for each
+// variant and the arms which match it in order, an arm which doesn't match
+// for sure breaks out of the switch after its code:
+//
+//	switch matchValue := shape.(type) {
+//	case Rect:
+//		if matchValue.w == 0.0 {
+//			..
+//			break
+//		}
+//		h := matchValue.h
+//		..
+//	default:
+//		..
+//	}
+//
+// Without a _ arm the default case panics, only a nil sum gets there.
+// A match on another value is a switch with only a default case
+func GenerateMatch(m *comp.MatchBlock, ctx *comp.Context) (ast.Stmt, error) {
+	value, err := GenerateExpr(m.Value, ctx)
+	if err != nil {
+		return nil, err
+	}
+	g := &matchGenerator{ctx: ctx}
+
+	sum, ok := comp.ResolveType(m.Value.MeltType(), ctx).(types.Sum)
+	if !ok {
+		body, err := g.arms(m.Arms, "")
+		if err != nil {
+			return nil, err
+		}
+		init := &ast.AssignStmt{Lhs: []ast.Expr{ToIdent(matchValue)}, Tok: token.DEFINE, Rhs: []ast.Expr{value}}
+		if !g.used {
+			init = &ast.AssignStmt{Lhs: []ast.Expr{ToIdent("_")}, Tok: token.ASSIGN, Rhs: []ast.Expr{value}}
+		}
+		return &ast.SwitchStmt{
+			Init: init,
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.CaseClause{Body: body}}}}, nil
+	}
+
+	g.sum = sum
+	clauses := []ast.Stmt{}
+	for _, variant := range sum.Variants {
+		arms := []*comp.MatchArm{}
+		specific := false
+		for _, arm := range m.Arms {
+			if arm.Pattern.Constructor == nil {
+				arms = append(arms, arm)
+			} else if arm.Pattern.Constructor.Label == variant.Label {
+				arms = append(arms, arm)
+				specific = true
+			}
+		}
+		// the other variants go to the default case
+		if !specific {
+			continue
+		}
+		body, err := g.arms(arms, variant.Label)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{ToIdent(VariantName(sum, variant.Label, g.ctx))},
+			Body: body})
+	}
+
+	others := []*comp.MatchArm{}
+	for _, arm := range m.Arms {
+		if arm.Pattern.Constructor == nil {
+			others = append(others, arm)
+		}
+	}
+	if len(others) > 0 {
+		body, err := g.arms(others, "")
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, &ast.CaseClause{Body: body})
+	} else {
+		// only a nil sum gets here, every variant is matched
+		clauses = append(clauses, &ast.CaseClause{Body: []ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  ToIdent("panic"),
+			Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("%q", "match on a nil "+sum.ToString())}}}}}})
+	}
+
+	var assign ast.Stmt = &ast.ExprStmt{X: &ast.TypeAssertExpr{X: value}}
+	if g.used {
+		assign = &ast.AssignStmt{
+			Lhs: []ast.Expr{ToIdent(matchValue)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.TypeAssertExpr{X: value}}}
+	}
+	return &ast.TypeSwitchStmt{Assign: assign, Body: &ast.BlockStmt{List: clauses}}, nil
+}
+
+type matchGenerator struct {
+	ctx *comp.Context
+	sum types.Sum
+	// used is true if an arm uses matchValue
+	used bool
+}
+
+// arms are the arms of a case in order, until one matching for sure
+// variant is the variant of the case, empty in the default case
+func (self *matchGenerator) arms(arms []*comp.MatchArm, variant string) ([]ast.Stmt, error) {
+	list := []ast.Stmt{}
+	for _, arm := range arms {
+		conditions, bindings, err := self.pattern(arm, variant)
+		if err != nil {
+			return nil, err
+		}
+		body, err := GenerateCode(arm.Code, self.ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(conditions) == 0 && arm.Guard == nil {
+			list = append(list, bindings...)
+			list = append(list, body.List...)
+			return list, nil
+		}
+
+		// the next arms run only if this one doesn't
+		if n := len(body.List); n == 0 || !terminates(body.List[n-1]) {
+			body.List = append(body.List, &ast.BranchStmt{Tok: token.BREAK})
+		}
+		inner := append(bindings, body.List...)
+		if arm.Guard != nil {
+			guard, err := GenerateExpr(arm.Guard, self.ctx)
+			if err != nil {
+				return nil, err
+			}
+			inner = append(bindings, &ast.IfStmt{Cond: guard, Body: body})
+		}
+		if len(conditions) == 0 {
+			list = append(list, &ast.BlockStmt{List: inner})
+			continue
+		}
+		condition := conditions[0]
+		for _, c := range conditions[1:] {
+			condition = &ast.BinaryExpr{X: condition, Op: token.LAND, Y: c}
+		}
+		list = append(list, &ast.IfStmt{Cond: condition, Body: &ast.BlockStmt{List: inner}})
+	}
+	return list, nil
+}
+
+// pattern returns the comparisons of the literals in the pattern of an arm
+// and the labels it binds which are used by the arm
+func (self *matchGenerator) pattern(arm *comp.MatchArm, variant string) ([]ast.Expr, []ast.Stmt, error) {
+	p := arm.Pattern
+	if p.Constructor == nil {
+		condition, binding, err := self.part(arm, p, ToIdent(matchValue), variant)
+		if err != nil {
+			return nil, nil, err
+		}
+		return condition, binding, nil
+	}
+
+	conditions := []ast.Expr{}
+	bindings := []ast.Stmt{}
+	for i, field := range p.Fields {
+		c, b, err := self.part(arm, field, Selector(ToIdent(matchValue), p.Labels[i]), "")
+		if err != nil {
+			return nil, nil, err
+		}
+		conditions = append(conditions, c...)
+		bindings = append(bindings, b...)
+	}
+	return conditions, bindings, nil
+}
+
+// part is a literal, a label or a _ matching value
+func (self *matchGenerator) part(arm *comp.MatchArm, p *comp.Pattern, value ast.Expr, variant string) ([]ast.Expr, []ast.Stmt, error) {
+	if p.Literal != nil {
+		literal, err := GenerateExpr(p.Literal, self.ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		self.used = true
+		return []ast.Expr{&ast.BinaryExpr{X: value, Op: token.EQL, Y: literal}}, nil, nil
+	} else if p.Binding == nil || !uses(arm, p.Binding.Label) {
+		return nil, nil, nil
+	}
+
+	self.used = true
+	if variant != "" && p == arm.Pattern {
+		// the variant is converted back to the sum
+		t, err := GenerateType(self.sum, self.ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		value = &ast.CallExpr{Fun: t, Args: []ast.Expr{value}}
+	}
+	return nil, []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{ToIdent(p.Binding.Label)},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{value}}}, nil
+}
+
+// uses is true if the guard or the code of an arm use label,
+// go doesn't accept labels which aren't used
+func uses(arm *comp.MatchArm, label string) bool {
+	found := false
+	check := func(node comp.Ast) {
+		if l, ok := node.(*comp.Label); ok && comp.BareLabel(l.Label) == label {
+			found = true
+		}
+	}
+	if arm.Guard != nil {
+		comp.Inspect(arm.Guard, check)
+	}
+	comp.Inspect(arm.Code, check)
+	return found
+}
+
+// terminates is true for the terminating statements of go,
+// a break after them is unreachable
+func terminates(stmt ast.Stmt) bool {
+	switch kind := stmt.(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := kind.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		f, ok := call.Fun.(*ast.Ident)
+		return ok && f.Name == "panic"
+	case *ast.BlockStmt:
+		return len(kind.List) > 0 && terminates(kind.List[len(kind.List)-1])
+	case *ast.IfStmt:
+		return kind.Else != nil && terminates(kind.Body) && terminates(kind.Else)
+	case *ast.SwitchStmt:
+		return clausesTerminate(kind.Body)
+	case *ast.TypeSwitchStmt:
+		return clausesTerminate(kind.Body)
+	}
+	return false
+}
+
+// clausesTerminate is true if a switch has a default case
+// and each case terminates without a break
+func clausesTerminate(body *ast.BlockStmt) bool {
+	hasDefault := false
+	for _, stmt := range body.List {
+		clause := stmt.(*ast.CaseClause)
+		if clause.List == nil {
+			hasDefault = true
+		}
+		if len(clause.Body) == 0 || !terminates(clause.Body[len(clause.Body)-1]) || breaks(clause.Body) {
+			return false
+		}
+	}
+	return hasDefault
+}
+
+// breaks is true if a break leaves the switch of the statements
+func breaks(list []ast.Stmt) bool {
+	found := false
+	for _, stmt := range list {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			switch kind := node.(type) {
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.FuncLit:
+				return false
+			case *ast.BranchStmt:
+				found = found || kind.Tok == token.BREAK
+			}
+			return true
+		})
+	}
+	return found
+}
@@ -10,28 +10,46 @@ func GenerateModule(m comp.Module, ctx *comp.Context) (*ast.File, error) {
 	children := []ast.Decl{}
 	objects := make(map[string]*ast.Object)
 
-	// for _, child := range m.Interfaces {
-	// 	a, err := GenerateInterface(child, ctx)
-	// 	if err != nil {
-	// 		return nil, err
-	// 	}
+	for _, child := range m.Interfaces {
+		decls, objs, err := GenerateInterface(child, ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	// 	children = append(children, a)
-	// }
+		children = append(children, decls...)
+		for _, obj := range objs {
+			objects[obj.Name] = obj
+		}
+	}
 
 	for _, child := range m.Records {
-		record, objs, err := GenerateRecord(child, ctx)
+		decls, objs, err := GenerateRecord(child, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		children = append(children, decls...)
+		for _, obj := range objs {
+			objects[obj.Name] = obj
+		}
+	}
+
+	for _, child := range m.Unions {
+		decls, objs, err := GenerateUnion(child, ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		children = append(children, record)
+		children = append(children, decls...)
 		for _, obj := range objs {
 			objects[obj.Name] = obj
 		}
 	}
 
 	for _, child := range m.Functions {
+		if child.IsGenericMethod() {
+			continue
+		}
 		function, objs, err := GenerateFunction(child, ctx)
 		if err != nil {
 			return nil, err
@@ -43,6 +61,15 @@ func GenerateModule(m comp.Module, ctx *comp.Context) (*ast.File, error) {
 		}
 	}
 
+	// the imports are generated last, they include the packages used by the generated code
+	imports, err := GenerateImports(m, RequiredImports(children), ctx)
+	if err != nil {
+		return nil, err
+	}
+	if imports != nil {
+		children = append([]ast.Decl{imports}, children...)
+	}
+
 	module := &ast.File{
 		Name:  &ast.Ident{Name: m.Package},
 		Decls: children,
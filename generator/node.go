@@ -1,19 +1,37 @@
 package generator
 
 import (
-	"fmt"
 	"go/ast"
 	// "go/token"
 
 	comp "gitlab.com/alehander42/melt/compiler"
 )
 
+// GenerateStatements generates nodes which can be more than one go statement
+func GenerateStatements(ast_ comp.Ast, ctx *comp.Context) ([]ast.Stmt, error) {
+	switch kind := ast_.(type) {
+	case *comp.Spawn:
+		return GenerateSpawn(kind, ctx)
+	case *comp.Escalate:
+		return GenerateEscalate(kind, ctx)
+	case *comp.Go:
+		if kind.Group != "" || kind.Fail {
+			return GenerateGroupGo(kind, ctx)
+		}
+	}
+	statement, err := GenerateNode(ast_, ctx)
+	if err != nil || statement == nil {
+		return nil, err
+	}
+	return []ast.Stmt{statement}, nil
+}
+
 func GenerateNode(ast_ comp.Ast, ctx *comp.Context) (ast.Stmt, error) {
-	fmt.Printf("NODE %T\n", ast_)
+	comp.Tracef(comp.TraceGenerate, "node %T", ast_)
 	switch kind := ast_.(type) {
 	default:
 		{
-			fmt.Printf("default %T\n", kind)
+			comp.Tracef(comp.TraceGenerate, "no go statement for %T", kind)
 			return nil, nil
 		}
 	case *comp.Set:
@@ -24,28 +42,84 @@ func GenerateNode(ast_ comp.Ast, ctx *comp.Context) (ast.Stmt, error) {
 		{
 			return GenerateIndexAssignment(kind, ctx)
 	  }
+	case *comp.FieldAssignment:
+		{
+			return GenerateFieldAssignment(kind, ctx)
+	  }
 	case *comp.ForIn:
 		{
-			return GenerateForIn(kind, ctx)
+			return labelLoop(kind.Exit)(GenerateForIn(kind, ctx))
 	  }
-	case *comp.Escalate:
+	case *comp.ForLoop:
+		{
+			return labelLoop(kind.Exit)(GenerateForLoop(kind, ctx))
+	  }
+	case *comp.Loop:
+		{
+			return labelLoop(kind.Exit)(GenerateLoop(kind, ctx))
+	  }
+	case *comp.Break:
+		{
+			return GenerateBreak(kind, ctx)
+	  }
+	case *comp.Send:
 		{
-			return GenerateEscalate(kind, ctx)
+			return GenerateSend(kind, ctx)
+	  }
+	case *comp.Go:
+		{
+			return GenerateGo(kind, ctx)
+	  }
+	case *comp.Select:
+		{
+			return GenerateSelect(kind, ctx)
+	  }
+	case *comp.MatchBlock:
+		{
+			return GenerateMatch(kind, ctx)
 	  }
 	case *comp.Return:
 		{
 			return GenerateReturn(kind, ctx)
 	  }
+	case *comp.ReturnError:
+		{
+			return GenerateReturnError(kind, ctx)
+	  }
+	case *comp.If:
+		{
+			return GenerateIf(kind, ctx)
+	  }
+	case *comp.CompoundAssignment:
+		{
+			return GenerateCompoundAssignment(kind, ctx)
+	  }
+	case *comp.On:
+		{
+			return GenerateOn(kind, ctx)
+	  }
+	case *comp.Call, *comp.MethodCall, *comp.Receive:
+		{
+			if call, ok := kind.(*comp.Call); ok && failing(call) {
+				return GenerateFailingStatement(kind, call.Failure, ctx)
+			} else if call, ok := kind.(*comp.MethodCall); ok && call.Failure != nil {
+				return GenerateFailingStatement(kind, call.Failure, ctx)
+			}
+			expr, err := GenerateExpr(kind, ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &ast.ExprStmt{X: expr}, nil
+		}
 	}
-	return nil, nil
 }
 
 func GenerateExpr(ast_ comp.Ast, ctx *comp.Context) (ast.Expr, error) {
-	fmt.Printf("EXPR %T\n", ast_)
+	comp.Tracef(comp.TraceGenerate, "expression %T", ast_)
 	switch kind := ast_.(type) {
 	default:
 	  {
-	    fmt.Printf("default %T\n", kind)
+	    comp.Tracef(comp.TraceGenerate, "no go expression for %T", kind)
 		  return &ast.Ident{Name: "unknown"}, nil
     }
 	case *comp.Make:
@@ -60,8 +134,75 @@ func GenerateExpr(ast_ comp.Ast, ctx *comp.Context) (ast.Expr, error) {
 		{
 			return GenerateCall(kind, ctx)
 	  }
+	case *comp.MethodCall:
+		{
+			return GenerateMethodCall(kind, ctx)
+		}
+	case *comp.BinaryOperation:
+		{
+			return GenerateBinaryOperation(kind, ctx)
+		}
+	case *comp.Cmp:
+		{
+			return GenerateCmp(kind, ctx)
+		}
+	case *comp.UnaryOperation:
+		{
+			return GenerateUnaryOperation(kind, ctx)
+		}
+	case *comp.Selector:
+		{
+			return GenerateSelector(kind, ctx)
+		}
+	case *comp.Index:
+		{
+			return GenerateIndex(kind, ctx)
+		}
+	case *comp.Cast:
+		{
+			return GenerateCast(kind, ctx)
+		}
+	case *comp.Error:
+		{
+			return ToIdent(comp.ErrorVariable(kind.MeltType())), nil
+		}
+	case *comp.Template:
+		{
+			return GenerateTemplate(kind, ctx)
+		}
+	case *comp.RecordLiteral:
+		{
+			return GenerateRecordLiteral(kind, ctx)
+		}
+	case *comp.Receive:
+		{
+			return GenerateReceive(kind, ctx)
+		}
+	case *comp.Lambda:
+		{
+			return GenerateLambda(kind, ctx)
+		}
+	case *comp.Integer:
+		{
+			return GenerateInteger(kind), nil
+		}
+	case *comp.Float:
+		{
+			return GenerateFloat(kind), nil
+		}
+	case *comp.String:
+		{
+			return GenerateString(kind), nil
+		}
+	case *comp.Bool:
+		{
+			return GenerateBool(kind), nil
+		}
+	case *comp.Nil:
+		{
+			return ToIdent("nil"), nil
+		}
 	}
-	return &ast.Ident{Name: "x"}, nil
 }
 
 // // Generate returns go ast which can be then compiled to code
@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
+)
+
+var binaryTokens = map[comp.BinaryOperator]token.Token{
+	comp.AddOp: token.ADD, comp.SubOp: token.SUB, comp.MultOp: token.MUL, comp.DivideOp: token.QUO,
+	comp.ModOp: token.REM, comp.BitAndOp: token.AND, comp.BitOrOp: token.OR, comp.XorOp: token.XOR,
+	comp.AndNotOp: token.AND_NOT, comp.ShiftLeftOp: token.SHL, comp.ShiftRightOp: token.SHR,
+	comp.AndOp: token.LAND, comp.OrOp: token.LOR}
+
+var assignTokens = map[comp.BinaryOperator]token.Token{
+	comp.AddOp: token.ADD_ASSIGN, comp.SubOp: token.SUB_ASSIGN, comp.MultOp: token.MUL_ASSIGN,
+	comp.DivideOp: token.QUO_ASSIGN, comp.ModOp: token.REM_ASSIGN, comp.BitAndOp: token.AND_ASSIGN,
+	comp.BitOrOp: token.OR_ASSIGN, comp.XorOp: token.XOR_ASSIGN, comp.AndNotOp: token.AND_NOT_ASSIGN,
+	comp.ShiftLeftOp: token.SHL_ASSIGN, comp.ShiftRightOp: token.SHR_ASSIGN}
+
+var cmpTokens = map[comp.Operator]token.Token{
+	comp.EqualOp: token.EQL, comp.NotEqualOp: token.NEQ, comp.LessOp: token.LSS,
+	comp.LessEqualOp: token.LEQ, comp.GreaterOp: token.GTR, comp.GreaterEqualOp: token.GEQ}
+
+var unaryTokens = map[comp.UnaryOperator]token.Token{
+	comp.PlusOp: token.ADD, comp.MinusOp: token.SUB, comp.NotOp: token.NOT, comp.ComplementOp: token.XOR, comp.AddressOp: token.AND}
+
+// promote converts an int operand mixed with a float, go doesn't do it itself
+func promote(expr ast.Expr, from types.Type, other types.Type, ctx *comp.Context) (ast.Expr, error) {
+	if !comp.IsInteger(from) || comp.IsInteger(other) || !comp.IsNumeric(other) {
+		return expr, nil
+	}
+	t, err := GenerateType(other, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.CallExpr{Fun: t, Args: []ast.Expr{expr}}, nil
+}
+
+func generateOperands(left comp.Ast, right comp.Ast, ctx *comp.Context) (ast.Expr, ast.Expr, error) {
+	l, err := GenerateExpr(left, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := GenerateExpr(right, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	l, err = promote(l, left.MeltType(), right.MeltType(), ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err = promote(r, right.MeltType(), left.MeltType(), ctx)
+	return l, r, err
+}
+
+// GenerateBinaryOperation doesn't add parens, the go printer adds them by precedence
+func GenerateBinaryOperation(b *comp.BinaryOperation, ctx *comp.Context) (ast.Expr, error) {
+	if b.Guarded != nil && lowered != nil {
+		return generateGuarded(b, ctx)
+	}
+	left, right, err := generateOperands(*b.Left, *b.Right, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.BinaryExpr{X: left, Op: binaryTokens[b.Op], Y: right}, nil
+}
+
+// generateGuarded lowers the failing calls in the right operand of && or ||
+// in an if, so they run only when the left operand doesn't decide:
+//
+//	var checkCond0 bool = x == 0
+//	if !checkCond0 {
+//		checkValue0, checkErr0 = check(-1)
+//		checkCond0 = checkValue0
+//	}
+func generateGuarded(b *comp.BinaryOperation, ctx *comp.Context) (ast.Expr, error) {
+	left, err := GenerateExpr(*b.Left, ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := GenerateType(b.MeltType(), ctx)
+	if err != nil {
+		return nil, err
+	}
+	cond := ToIdent(b.Guarded.Cond())
+	*lowered = append(*lowered, &ast.DeclStmt{Decl: &ast.GenDecl{
+		Tok: token.VAR,
+		Specs: []ast.Spec{&ast.ValueSpec{
+			Names:  []*ast.Ident{cond},
+			Type:   t,
+			Values: []ast.Expr{left}}}}})
+
+	calls, err := lowerIn(func() ([]ast.Stmt, error) {
+		right, err := GenerateExpr(*b.Right, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []ast.Stmt{&ast.AssignStmt{Lhs: []ast.Expr{cond}, Tok: token.ASSIGN, Rhs: []ast.Expr{right}}}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	test := ast.Expr(cond)
+	if b.Op == comp.OrOp {
+		test = &ast.UnaryExpr{Op: token.NOT, X: cond}
+	}
+	*lowered = append(*lowered, &ast.IfStmt{Cond: test, Body: &ast.BlockStmt{List: calls}})
+	return cond, nil
+}
+
+func GenerateCmp(c *comp.Cmp, ctx *comp.Context) (ast.Expr, error) {
+	left, right, err := generateOperands(c.Left, c.Right, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.BinaryExpr{X: left, Op: cmpTokens[c.Op], Y: right}, nil
+}
+
+func GenerateUnaryOperation(u *comp.UnaryOperation, ctx *comp.Context) (ast.Expr, error) {
+	expr, err := GenerateExpr(*u.Expression, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.UnaryExpr{Op: unaryTokens[u.Op], X: expr}, nil
+}
+
+func GenerateCompoundAssignment(c *comp.CompoundAssignment, ctx *comp.Context) (ast.Stmt, error) {
+	value, err := GenerateExpr(*c.Value, ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, err = promote(value, (*c.Value).MeltType(), c.Target.MeltType(), ctx)
+	if err != nil {
+		return nil, err
+	}
+	target, err := GenerateExpr(c.Target, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{target},
+		Tok: assignTokens[c.Op],
+		Rhs: []ast.Expr{value}}, nil
+}
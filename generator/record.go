@@ -1,16 +1,33 @@
 package generator
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
+	"sort"
 
 	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
 )
 
-func GenerateRecord(r *comp.Record, ctx *comp.Context) (*ast.GenDecl, []*ast.Object, error) {
+// GenerateRecord generates a record which isn't generic,
+// the instances of generic ones are generated by GenerateRecordInstances
+func GenerateRecord(r *comp.Record, ctx *comp.Context) ([]ast.Decl, []*ast.Object, error) {
+	if record, ok := r.MeltType().(types.Record); ok && record.IsGeneric() {
+		return []ast.Decl{}, []*ast.Object{}, nil
+	}
+	fields := make(map[string]types.Type)
+	for _, field := range r.Fields {
+		fields[field.Label.Label] = field.MeltType()
+	}
+	return generateStruct(r, r.Label.Label, fields, ctx)
+}
+
+// generateStruct declares a struct with the fields of r in their order
+func generateStruct(r *comp.Record, name string, fieldTypes map[string]types.Type, ctx *comp.Context) ([]ast.Decl, []*ast.Object, error) {
 	var fields []*ast.Field
 	for _, field := range r.Fields {
-		t, err := GenerateType(field.MeltType(), ctx)
+		t, err := GenerateType(fieldTypes[field.Label.Label], ctx)
 		if err != nil {
 			return nil, []*ast.Object{}, err
 		}
@@ -22,13 +39,138 @@ func GenerateRecord(r *comp.Record, ctx *comp.Context) (*ast.GenDecl, []*ast.Obj
 	}
 
 	t := &ast.TypeSpec{
-		Name: ToIdent(r.Label.Label),
+		Name: ToIdent(name),
 		Type: &ast.StructType{
 			Fields: &ast.FieldList{
 				List: fields}}}
 
-	obj := &ast.Object{Kind: ast.Typ, Name: r.Label.Label, Decl: t}
+	obj := &ast.Object{Kind: ast.Typ, Name: name, Decl: t}
 	t.Name.Obj = obj
 
-	return &ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{t}}, []*ast.Object{obj}, nil
+	return []ast.Decl{&ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{t}}}, []*ast.Object{obj}, nil
+}
+
+// generateRecordType names an instance of a generic record: Stack0 or StackOfInt
+// without safe names, it's declared after the modules
+// A record of an imported melt package is collections.Point, its instances are
+// named and declared by that package, which is generated after the importing one
+func generateRecordType(record types.Record, ctx *comp.Context) (ast.Expr, error) {
+	alias, owner := recordPackage(record, ctx)
+	name := record.Label
+	if record.IsGeneric() {
+		for _, v := range record.InstanceVars {
+			if _, ok := v.(types.GenericVar); ok || v == nil {
+				return nil, fmt.Errorf("the type of %s isn't known", record.ToString())
+			}
+		}
+		name = owner.RecordInstance(record)
+	}
+	if alias != "" {
+		return Selector(ToIdent(alias), name), nil
+	}
+	return ToIdent(name), nil
+}
+
+// recordPackage is the import alias and the context of the package defining a record,
+// the alias is empty for a record of the generated package
+func recordPackage(record types.Record, ctx *comp.Context) (string, *comp.Context) {
+	root := ctx
+	if ctx.Root != nil {
+		root = ctx.Root
+	}
+	if record.Package == root.Path {
+		return "", ctx
+	}
+	for alias, imported := range root.Imports {
+		if imported.Path == record.Package && imported.Context != nil {
+			return alias, imported.Context
+		}
+	}
+	return "", ctx
+}
+
+// generateRecordInstances declares the instances of generic records used since the last call
+// and their methods in the file of the module defining them, it returns false if there are none
+func generateRecordInstances(modules []*comp.Module, files []*ast.File, generated map[string]int, ctx *comp.Context) (bool, error) {
+	root := ctx
+	if ctx.Root != nil {
+		root = ctx.Root
+	}
+	labels := []string{}
+	for label, instances := range root.Instantiations.Records {
+		if generated[label] < len(instances) {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		// the fields can add instances while they're generated
+		for generated[label] < len(root.Instantiations.Records[label]) {
+			genericMap := root.Instantiations.Records[label][generated[label]]
+			generated[label]++
+			for i, m := range modules {
+				r, ok := definesRecord(m, label)
+				if !ok {
+					continue
+				}
+				decls, objs, err := generateInstance(r, genericMap, ctx)
+				if err != nil {
+					return false, fmt.Errorf("%s: %s", m.File, err)
+				}
+				files[i].Decls = append(files[i].Decls, decls...)
+				for _, obj := range objs {
+					files[i].Scope.Objects[obj.Name] = obj
+				}
+			}
+		}
+	}
+	return len(labels) > 0, nil
+}
+
+func generateInstance(r *comp.Record, genericMap comp.GenericMap, ctx *comp.Context) ([]ast.Decl, []*ast.Object, error) {
+	record := comp.ReplaceGenericVars(r.MeltType(), genericMap).(types.Record)
+	name := ctx.RecordInstance(record)
+	decls, objs, err := generateStruct(r, name, comp.InstanceFields(record, ctx), ctx)
+	if err != nil {
+		return nil, []*ast.Object{}, err
+	}
+	for _, method := range r.Methods {
+		expanded, err := comp.ExpandMethod(method, record)
+		if err != nil {
+			return nil, []*ast.Object{}, err
+		}
+		decl, _, err := GenerateFunction(&expanded, ctx)
+		if err != nil {
+			return nil, []*ast.Object{}, err
+		}
+		decls = append(decls, decl)
+	}
+	return decls, objs, nil
+}
+
+func definesRecord(m *comp.Module, label string) (*comp.Record, bool) {
+	for _, r := range m.Records {
+		if r.Label.Label == label {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// GenerateRecordLiteral always names the fields, the positional form gets them from the record
+func GenerateRecordLiteral(r *comp.RecordLiteral, ctx *comp.Context) (ast.Expr, error) {
+	t, err := GenerateType(r.Type, ctx)
+	if err != nil {
+		return nil, err
+	}
+	elements := []ast.Expr{}
+	for i, value := range r.Values {
+		v, err := GenerateExpr(value, ctx)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, &ast.KeyValueExpr{Key: ToIdent(r.Fields[i].Label), Value: v})
+	}
+	return &ast.CompositeLit{Type: t, Elts: elements}, nil
 }
@@ -2,15 +2,88 @@ package generator
 
 import (
 	"go/ast"
+	"go/token"
+	"strings"
 
 	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
 )
 
 func GenerateReturn(z *comp.Return, ctx *comp.Context) (ast.Stmt, error) {
-	value, err := GenerateExpr(*z.Value, ctx)
+  results := []ast.Expr{}
+  if z.Value != nil {
+	  value, err := GenerateExpr(*z.Value, ctx)
+	  if err != nil {
+		    return &ast.ReturnStmt{}, err
+	  }
+	  results = append(results, value)
+  }
+  if z.Fails {
+    results = append(results, ToIdent("nil"))
+  }
+  return &ast.ReturnStmt{Results: results}, nil
+}
+
+// GenerateReturnError returns errors.New(message) with the zero value of the result,
+// an error value as it is and !! "message" with err fmt.Errorf("message: %w", err)
+func GenerateReturnError(r *comp.ReturnError, ctx *comp.Context) (ast.Stmt, error) {
+	if r.Wrapped != nil {
+		wrapped, err := GenerateExpr(r.Wrapped, ctx)
+		if err != nil {
+			return nil, err
+		}
+		value, err := wrapError(*r.Value, wrapped, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return returnError(value, r.Return, ctx)
+	}
+
+	value, err := GenerateExpr(*r.Value, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if comp.IsString((*r.Value).MeltType()) {
+		value = &ast.CallExpr{Fun: Selector(RequireImport("errors"), "New"), Args: []ast.Expr{value}}
+	}
+	return returnError(value, r.Return, ctx)
+}
+
+// returnError returns an error with the zero value of the result,
+// a function without a result returns only the error
+func returnError(value ast.Expr, result types.Type, ctx *comp.Context) (*ast.ReturnStmt, error) {
+	results := []ast.Expr{value}
+	if _, ok := result.(types.Empty); !ok && result != nil {
+		zero, err := zeroValue(result, ctx)
+		if err != nil {
+			return nil, err
+		}
+		results = append([]ast.Expr{zero}, results...)
+	}
+	return &ast.ReturnStmt{Results: results}, nil
+}
+
+// wrapError is fmt.Errorf("message: %w", err)
+func wrapError(message comp.Ast, wrapped ast.Expr, ctx *comp.Context) (ast.Expr, error) {
+	format, args, err := messageFormat(message, ctx)
 	if err != nil {
-		  return &ast.ReturnStmt{}, err
+		return nil, err
 	}
+	literal := &ast.BasicLit{Kind: token.STRING, Value: "\"" + format + ": %w\""}
+	return &ast.CallExpr{
+		Fun:  Selector(RequireImport("fmt"), "Errorf"),
+		Args: append(append([]ast.Expr{literal}, args...), wrapped)}, nil
+}
 
-  return &ast.ReturnStmt{Results: []ast.Expr{value}}, nil
+// messageFormat is the format of a message for fmt, the text of a literal
+// or a template goes in it, it's escaped like in the melt source
+func messageFormat(message comp.Ast, ctx *comp.Context) (string, []ast.Expr, error) {
+	switch kind := message.(type) {
+	case *comp.String:
+		return strings.ReplaceAll(kind.Value[1:len(kind.Value)-1], "%", "%%"), nil, nil
+	case *comp.Template:
+		return templateFormat(kind, ctx)
+	}
+	value, err := GenerateExpr(message, ctx)
+	return "%s", []ast.Expr{value}, err
 }
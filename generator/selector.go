@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+)
+
+func GenerateSelector(s *comp.Selector, ctx *comp.Context) (ast.Expr, error) {
+	object, err := GenerateExpr(s.Object, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.SelectorExpr{X: object, Sel: ToIdent(s.Field.Label)}, nil
+}
+
+func GenerateIndex(i *comp.Index, ctx *comp.Context) (ast.Expr, error) {
+	collection, err := GenerateExpr(i.Collection, ctx)
+	if err != nil {
+		return nil, err
+	}
+	index, err := GenerateExpr(i.Index, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.IndexExpr{X: collection, Index: index}, nil
+}
+
+func GenerateFieldAssignment(f *comp.FieldAssignment, ctx *comp.Context) (ast.Stmt, error) {
+	target, err := GenerateSelector(f.Target, ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, err := GenerateExpr(f.Value, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.AssignStmt{Lhs: []ast.Expr{target}, Rhs: []ast.Expr{value}, Tok: token.ASSIGN}, nil
+}
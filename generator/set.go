@@ -12,7 +12,17 @@ func GenerateSet(set *comp.Set, ctx *comp.Context) (ast.Stmt, error) {
 	if err != nil {
 		return nil, err
 	}
-	a, err := GenerateType((*set.Value).MeltType(), ctx)
+	if !set.Define {
+		return &ast.AssignStmt{
+			Lhs: []ast.Expr{&ast.Ident{Name: set.Label.Label}},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{value}}, nil
+	}
+	t := (*set.Value).MeltType()
+	if set.Type != nil {
+		t = set.Type
+	}
+	a, err := GenerateType(t, ctx)
 	if err != nil {
 		return nil, err
 	}
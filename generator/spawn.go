@@ -0,0 +1,223 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
+)
+
+// GenerateSpawn waits for the goroutines of the block with a sync.WaitGroup.
+// The first failing one sets <label>Err and cancels the context <label>:
+//
+//	var workersErr error
+//	{
+//		workers, workersCancel := context.WithCancel(context.Background())
+//		var workersGroup sync.WaitGroup
+//		var workersOnce sync.Once
+//		workersFail := func(err error) { workersOnce.Do(..) }
+//		..
+//		workersGroup.Wait()
+//		workersCancel()
+//	}
+func GenerateSpawn(s *comp.Spawn, ctx *comp.Context) ([]ast.Stmt, error) {
+	label := s.Label.Label
+	body, err := GenerateCode(s.Code, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ToIdent(label), ToIdent(label + "Cancel")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Selector(RequireImport("context"), "WithCancel"),
+				Args: []ast.Expr{&ast.CallExpr{Fun: Selector(RequireImport("context"), "Background")}}}}},
+		// the code doesn't have to use the context
+		&ast.AssignStmt{Lhs: []ast.Expr{ToIdent("_")}, Tok: token.ASSIGN, Rhs: []ast.Expr{ToIdent(label)}},
+		declare(label+"Group", Selector(RequireImport("sync"), "WaitGroup"))}
+	if s.Fails {
+		list = append(list,
+			declare(label+"Once", Selector(RequireImport("sync"), "Once")),
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{ToIdent(label + "Fail")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.FuncLit{
+					Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{{
+						Names: []*ast.Ident{ToIdent("err")},
+						Type:  ToIdent("error")}}}},
+					Body: &ast.BlockStmt{List: []ast.Stmt{
+						&ast.ExprStmt{X: &ast.CallExpr{
+							Fun: Selector(ToIdent(label+"Once"), "Do"),
+							Args: []ast.Expr{&ast.FuncLit{
+								Type: &ast.FuncType{Params: &ast.FieldList{}},
+								Body: &ast.BlockStmt{List: []ast.Stmt{
+									&ast.AssignStmt{
+										Lhs: []ast.Expr{ToIdent(label + "Err")},
+										Tok: token.ASSIGN,
+										Rhs: []ast.Expr{ToIdent("err")}},
+									&ast.ExprStmt{X: &ast.CallExpr{Fun: ToIdent(label + "Cancel")}}}}}}}}}}}}})
+	}
+	list = append(list, body.List...)
+	list = append(list,
+		&ast.ExprStmt{X: &ast.CallExpr{Fun: Selector(ToIdent(label+"Group"), "Wait")}},
+		&ast.ExprStmt{X: &ast.CallExpr{Fun: ToIdent(label + "Cancel")}})
+
+	block := &ast.BlockStmt{List: list}
+	if !s.Fails {
+		return []ast.Stmt{block}, nil
+	}
+	return []ast.Stmt{declare(label+"Err", ToIdent("error")), block}, nil
+}
+
+// GenerateGroupGo starts a goroutine of a spawn: block,
+// it passes its error to <group>Fail
+// A failing goroutine outside of a spawn: block panics with its error
+func GenerateGroupGo(g *comp.Go, ctx *comp.Context) ([]ast.Stmt, error) {
+	group := ToIdent(g.Group + "Group")
+	body := []ast.Stmt{}
+	if g.Group != "" {
+		body = append(body, &ast.DeferStmt{Call: &ast.CallExpr{Fun: Selector(group, "Done")}})
+	}
+
+	var failing ast.Expr
+	params, values := &ast.FieldList{}, []ast.Expr{}
+	if g.Call != nil {
+		call, err := GenerateExpr(g.Call, ctx)
+		if err != nil {
+			return nil, err
+		}
+		params, values, err = startArgs(g.Call, call, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if g.Fail {
+			failing = call
+		} else {
+			body = append(body, &ast.ExprStmt{X: call})
+		}
+	} else {
+		code, err := GenerateCode(g.Code, ctx)
+		if err != nil {
+			return nil, err
+		}
+		declareErrors(g.Errors, code)
+		if g.Fail {
+			if !g.Returned {
+				code.List = append(code.List, &ast.ReturnStmt{Results: []ast.Expr{ToIdent("nil")}})
+			}
+			failing = &ast.CallExpr{Fun: &ast.FuncLit{
+				Type: &ast.FuncType{
+					Params:  &ast.FieldList{},
+					Results: &ast.FieldList{List: []*ast.Field{{Type: ToIdent("error")}}}},
+				Body: code}}
+		} else {
+			body = append(body, code.List...)
+		}
+	}
+
+	if failing != nil {
+		fail := ast.Expr(ToIdent(g.Group + "Fail"))
+		if g.Group == "" {
+			fail = ToIdent("panic")
+		}
+		results := []ast.Expr{ToIdent("err")}
+		if g.Call != nil {
+			if _, ok := g.Call.MeltType().(types.Empty); !ok {
+				results = []ast.Expr{ToIdent("_"), ToIdent("err")}
+			}
+		}
+		body = append(body, &ast.IfStmt{
+			Init: &ast.AssignStmt{Lhs: results, Tok: token.DEFINE, Rhs: []ast.Expr{failing}},
+			Cond: &ast.BinaryExpr{X: ToIdent("err"), Op: token.NEQ, Y: ToIdent("nil")},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+				Fun:  fail,
+				Args: []ast.Expr{ToIdent("err")}}}}}})
+	}
+
+	start := &ast.GoStmt{Call: &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{Params: params},
+			Body: &ast.BlockStmt{List: body}},
+		Args: values}}
+	if g.Group == "" {
+		return []ast.Stmt{start}, nil
+	}
+	return []ast.Stmt{
+		&ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Selector(group, "Add"),
+			Args: []ast.Expr{&ast.BasicLit{Kind: token.INT, Value: "1"}}}},
+		start}, nil
+}
+
+// startArgs passes the receiver and the args of a call started in a goroutine
+// as args of its function, so they're evaluated by go like in go f(x):
+//
+//	go func(goArg0 int) {
+//		if err := f(goArg0); err != nil {
+//		..
+//	}(x)
+//
+// Literals stay in the call
+func startArgs(node comp.Ast, call ast.Expr, ctx *comp.Context) (*ast.FieldList, []ast.Expr, error) {
+	params, values := &ast.FieldList{}, []ast.Expr{}
+	c, ok := call.(*ast.CallExpr)
+	if !ok {
+		return params, values, nil
+	}
+	pass := func(name string, arg comp.Ast, value ast.Expr) (ast.Expr, error) {
+		switch value.(type) {
+		case *ast.BasicLit, *ast.FuncLit:
+			return value, nil
+		}
+		if _, ok := arg.MeltType().(types.Nil); ok {
+			return value, nil
+		}
+		goType, err := GenerateType(arg.MeltType(), ctx)
+		if err != nil {
+			return nil, err
+		}
+		params.List = append(params.List, &ast.Field{Names: []*ast.Ident{ToIdent(name)}, Type: goType})
+		values = append(values, value)
+		return ToIdent(name), nil
+	}
+
+	var args []comp.Ast
+	switch kind := node.(type) {
+	case *comp.Call:
+		args = kind.Args
+	case *comp.MethodCall:
+		args = kind.Args
+		selector, ok := c.Fun.(*ast.SelectorExpr)
+		if _, isPackage := (*kind.Receiver).MeltType().(types.Package); ok && !isPackage {
+			receiver, err := pass("goReceiver", *kind.Receiver, selector.X)
+			if err != nil {
+				return nil, nil, err
+			}
+			selector.X = receiver
+		}
+	}
+	if len(args) != len(c.Args) {
+		return params, values, nil
+	}
+	for i, arg := range args {
+		value, err := pass(fmt.Sprintf("goArg%d", i), arg, c.Args[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		c.Args[i] = value
+	}
+	return params, values, nil
+}
+
+// declare is var label t
+func declare(label string, t ast.Expr) ast.Stmt {
+	return &ast.DeclStmt{Decl: &ast.GenDecl{
+		Tok: token.VAR,
+		Specs: []ast.Spec{&ast.ValueSpec{
+			Names: []*ast.Ident{ToIdent(label)},
+			Type:  t}}}}
+}
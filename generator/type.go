@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/token"
 
 	comp "gitlab.com/alehander42/melt/compiler"
 	"gitlab.com/alehander42/melt/types"
@@ -12,6 +13,12 @@ import (
 func GenerateType(t types.Type, ctx *comp.Context) (ast.Expr, error) {
 	switch other := t.(type) {
 	case types.Basic:
+		if sum, ok := comp.ResolveType(other, ctx).(types.Sum); ok {
+			return generateSumType(sum, ctx)
+		}
+		if other.Label == "float" || other.Label == "real" {
+			return &ast.Ident{Name: "float64"}, nil
+		}
 		return &ast.Ident{Name: other.Label}, nil
 
 	case types.SliceBuiltin:
@@ -22,6 +29,41 @@ func GenerateType(t types.Type, ctx *comp.Context) (ast.Expr, error) {
 
 		return &ast.ArrayType{Elt: element}, nil
 
+	case types.Array:
+		element, err := GenerateType(other.Element, ctx)
+		if err != nil {
+			return nil, err
+		}
+		length := &ast.BasicLit{Kind: token.INT, Value: fmt.Sprintf("%d", other.Length)}
+
+		return &ast.ArrayType{Len: length, Elt: element}, nil
+
+	case types.MapBuiltin:
+		key, err := GenerateType(other.Key, ctx)
+		if err != nil {
+			return nil, err
+		}
+		value, err := GenerateType(other.Value, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.MapType{Key: key, Value: value}, nil
+
+	case types.Channel:
+		element, err := GenerateType(other.Element, ctx)
+		if err != nil {
+			return nil, err
+		}
+		dir := ast.SEND | ast.RECV
+		if other.Dir == types.Send {
+			dir = ast.SEND
+		} else if other.Dir == types.Receive {
+			dir = ast.RECV
+		}
+
+		return &ast.ChanType{Dir: dir, Value: element}, nil
+
 	case types.Pointer:
 		object, err := GenerateType(other.Object, ctx)
 		if err != nil {
@@ -30,7 +72,19 @@ func GenerateType(t types.Type, ctx *comp.Context) (ast.Expr, error) {
 
 		return &ast.StarExpr{X: object}, nil
 
+	case types.Sum:
+		return generateSumType(other, ctx)
+
+	case types.Record:
+		return generateRecordType(other, ctx)
+
 	case types.Interface:
+		switch resolved := comp.ResolveType(other, ctx).(type) {
+		case types.Sum:
+			return generateSumType(resolved, ctx)
+		case types.Record:
+			return generateRecordType(resolved, ctx)
+		}
 		l := &ast.Ident{Name: other.Label}
 		return l, nil
 
@@ -50,12 +104,14 @@ func GenerateType(t types.Type, ctx *comp.Context) (ast.Expr, error) {
 			params = append(params, &ast.Field{Type: t})
 		}
 
-		t, err := GenerateType(other.Return, ctx)
-		if err != nil {
-			return nil, err
-		}
+		if _, ok := other.Return.(types.Empty); !ok {
+			t, err := GenerateType(other.Return, ctx)
+			if err != nil {
+				return nil, err
+			}
 
-		results = append(results, &ast.Field{Type: t})
+			results = append(results, &ast.Field{Type: t})
+		}
 		if other.Error == types.Fail {
 			results = append(results, &ast.Field{Type: ToIdent("error")})
 		}
@@ -63,10 +119,42 @@ func GenerateType(t types.Type, ctx *comp.Context) (ast.Expr, error) {
 		return &ast.FuncType{
 			Params:  &ast.FieldList{List: params},
 			Results: &ast.FieldList{List: results}}, nil
+	case types.Named:
+		return &ast.Ident{Name: other.Label}, nil
+	case types.Error:
+		return &ast.Ident{Name: "error"}, nil
 	case types.Empty:
 		return &ast.Ident{Name: "void"}, nil
 	default:
-		fmt.Printf("X:%s\n", t.ToString())
+		comp.Tracef(comp.TraceGenerate, "no go type for %s", t.ToString())
 		return nil, errors.New("unknown")
 	}
 }
+
+// zeroValue is the go zero value of a type: 0, "", false, Point{} or nil
+func zeroValue(t types.Type, ctx *comp.Context) (ast.Expr, error) {
+	resolved := comp.ResolveType(t, ctx)
+	if named, ok := resolved.(types.Named); ok {
+		// time.Duration(0) is 0
+		resolved = named.Underlying
+	}
+	switch {
+	case comp.IsNumeric(resolved):
+		return &ast.BasicLit{Kind: token.INT, Value: "0"}, nil
+	case comp.IsBool(resolved):
+		return ToIdent("false"), nil
+	}
+	switch other := resolved.(type) {
+	case types.Basic:
+		if other.Label == "string" {
+			return &ast.BasicLit{Kind: token.STRING, Value: "\"\""}, nil
+		}
+	case types.Record, types.Array:
+		composite, err := GenerateType(other, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.CompositeLit{Type: composite}, nil
+	}
+	return ToIdent("nil"), nil
+}
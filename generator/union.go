@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	comp "gitlab.com/alehander42/melt/compiler"
+	"gitlab.com/alehander42/melt/types"
+)
+
+// GenerateUnion generates a sum type which isn't generic,
+// the instances of generic ones are generated by GenerateSumInstances
+func GenerateUnion(u *comp.Union, ctx *comp.Context) ([]ast.Decl, []*ast.Object, error) {
+	if u.Sum().IsGeneric() {
+		return []ast.Decl{}, []*ast.Object{}, nil
+	}
+	return GenerateSum(u.Sum(), ctx)
+}
+
+// GenerateSum lowers a sum to a sealed interface with a struct for each variant:
+//
+//	type Shape interface {
+//		isShape()
+//	}
+//
+//	type Circle struct {
+//		r float64
+//	}
+//
+//	func (Circle) isShape() {}
+func GenerateSum(sum types.Sum, ctx *comp.Context) ([]ast.Decl, []*ast.Object, error) {
+	name := SumName(sum, ctx)
+	marker := "is" + name
+	spec := &ast.TypeSpec{
+		Name: ToIdent(name),
+		Type: &ast.InterfaceType{Methods: &ast.FieldList{List: []*ast.Field{{
+			Names: []*ast.Ident{ToIdent(marker)},
+			Type:  &ast.FuncType{Params: &ast.FieldList{}}}}}}}
+	obj := &ast.Object{Kind: ast.Typ, Name: name, Decl: spec}
+	spec.Name.Obj = obj
+
+	decls := []ast.Decl{&ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{spec}}}
+	objects := []*ast.Object{obj}
+	for _, variant := range sum.Variants {
+		fields := []*ast.Field{}
+		for _, field := range variant.Fields {
+			t, err := GenerateType(field.Type, ctx)
+			if err != nil {
+				return nil, []*ast.Object{}, err
+			}
+			fields = append(fields, &ast.Field{Names: []*ast.Ident{ToIdent(field.Label)}, Type: t})
+		}
+
+		variantName := VariantName(sum, variant.Label, ctx)
+		variantSpec := &ast.TypeSpec{
+			Name: ToIdent(variantName),
+			Type: &ast.StructType{Fields: &ast.FieldList{List: fields}}}
+		variantObj := &ast.Object{Kind: ast.Typ, Name: variantName, Decl: variantSpec}
+		variantSpec.Name.Obj = variantObj
+
+		decls = append(decls,
+			&ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{variantSpec}},
+			&ast.FuncDecl{
+				Recv: &ast.FieldList{List: []*ast.Field{{Type: ToIdent(variantName)}}},
+				Name: ToIdent(marker),
+				Type: &ast.FuncType{Params: &ast.FieldList{}},
+				Body: &ast.BlockStmt{}})
+		objects = append(objects, variantObj)
+	}
+	return decls, objects, nil
+}
+
+// GenerateConstructor makes a variant converted to its sum: Shape(Circle{r: 2.0})
+func GenerateConstructor(c *comp.Call, ctx *comp.Context) (ast.Expr, error) {
+	t, err := GenerateType(c.MeltType(), ctx)
+	if err != nil {
+		return nil, err
+	}
+	sum := c.MeltType().(types.Sum)
+	label := comp.BareLabel(c.Function.Label)
+	variant, _ := sum.Variant(label)
+
+	args, err := GenerateArgs(c.Args, ctx)
+	if err != nil {
+		return nil, err
+	}
+	elements := []ast.Expr{}
+	for i, arg := range args {
+		elements = append(elements, &ast.KeyValueExpr{Key: ToIdent(variant.Fields[i].Label), Value: arg})
+	}
+
+	return &ast.CallExpr{
+		Fun: t,
+		Args: []ast.Expr{&ast.CompositeLit{
+			Type: ToIdent(VariantName(sum, label, ctx)),
+			Elts: elements}}}, nil
+}
+
+// SumName is the go name of a sum: Shape, or Option0 for an instance
+// (OptionOfInt without safe names)
+func SumName(sum types.Sum, ctx *comp.Context) string {
+	if !sum.IsGeneric() {
+		return sum.Label
+	}
+	return ctx.SumInstance(sum)
+}
+
+// VariantName is the go name of a variant: Circle or Some0
+func VariantName(sum types.Sum, variant string, ctx *comp.Context) string {
+	return variant + strings.TrimPrefix(SumName(sum, ctx), sum.Label)
+}
+
+// generateSumType names a sum in a go type and remembers
+// the instances of generic sums, they're declared after the modules
+func generateSumType(sum types.Sum, ctx *comp.Context) (ast.Expr, error) {
+	for i := range sum.InstanceVars {
+		if sum.Open(i) || sum.InstanceVars[i] == nil {
+			return nil, fmt.Errorf("the type of %s isn't known", sum.ToString())
+		}
+	}
+	name := SumName(sum, ctx)
+	if sum.IsGeneric() {
+		root := ctx
+		if ctx.Root != nil {
+			root = ctx.Root
+		}
+		if _, ok := root.Instantiations.Sums[name]; !ok {
+			root.Instantiations.Sums[name] = sum
+		}
+	}
+	return ToIdent(name), nil
+}
+
+// generateSumInstances declares the instances of generic sums used since the last call
+// in the file of the module defining them, it returns false if there are none
+func generateSumInstances(modules []*comp.Module, files []*ast.File, generated map[string]bool, ctx *comp.Context) (bool, error) {
+	root := ctx
+	if ctx.Root != nil {
+		root = ctx.Root
+	}
+	names := []string{}
+	for name := range root.Instantiations.Sums {
+		if !generated[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		generated[name] = true
+		sum := root.Instantiations.Sums[name]
+		for i, m := range modules {
+			if !definesUnion(m, sum.Label) {
+				continue
+			}
+			decls, objs, err := GenerateSum(sum, ctx)
+			if err != nil {
+				return false, fmt.Errorf("%s: %s", m.File, err)
+			}
+			files[i].Decls = append(files[i].Decls, decls...)
+			for _, obj := range objs {
+				files[i].Scope.Objects[obj.Name] = obj
+			}
+		}
+	}
+	return len(names) > 0, nil
+}
+
+func definesUnion(m *comp.Module, label string) bool {
+	for _, u := range m.Unions {
+		if u.Label.Label == label {
+			return true
+		}
+	}
+	return false
+}
@@ -1,63 +1,139 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"go/format"
-	"io/ioutil"
 	"os"
 
 	"gitlab.com/alehander42/melt/compiler"
-	"gitlab.com/alehander42/melt/generator"
 )
 
-func main() {
-	// if len(os.Args) < 2 {
-	// 	problem("Please: filename")
-	// }
-	// source, err := ioutil.ReadFile(os.Args[1])
-	source, err := ioutil.ReadFile("example/map.melt")
-	if err != nil {
-		problem(fmt.Sprintf("File: %s", err))
-	}
-	filename := "example/map.melt"
+// Exit codes of the melt command
+// They are stable, so scripts can act on the kind of failure
+const (
+	ExitSuccess  = 0
+	ExitUsage    = 1
+	ExitParse    = 2
+	ExitType     = 3
+	ExitIO       = 4
+	ExitGenerate = 5
+	ExitGo       = 6
+	// ExitProgram is a program of melt run exiting with a non-zero status,
+	// its status is printed, so it doesn't pass for one of the codes above
+	ExitProgram = 7
+)
 
-	ast, err := compiler.Parse(string(source))
-	if err != nil {
-		problem(fmt.Sprintf("Parser: %s", err))
-	}
+const usage = `usage: melt <command> [arguments]
 
-	ctx := compiler.NewContext()
-	ctx.LoadBuiltinTypes()
+commands:
+  check [dir | files]             parse and type check a package
+  build [-o outdir] [dir | files] compile a package to go
+  run <dir | files> [-- args]     compile and run with the go toolchain
+  ast [-format=json] <dir | files> print the checked tree
 
-	err = ast.TypeCheck(&ctx)
-	if err != nil {
-		problem(fmt.Sprintf("%s", err))
+Without files check and build use the nearest Meltfile.yaml
+-trace=parse,typecheck,instantiate,generate traces the passes to stderr or -trace-out
+`
+
+// Format of the reported diagnostics, set with -format
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+func (f *Format) String() string {
+	return string(*f)
+}
+
+func (f *Format) Set(value string) error {
+	if Format(value) != FormatText && Format(value) != FormatJSON {
+		return fmt.Errorf("unknown format %s, expected text or json", value)
 	}
+	*f = Format(value)
+	return nil
+}
 
-	err = compiler.Instantiate(&ast, &ctx)
-	if err != nil {
-		problem(fmt.Sprintf("%s", err))
+var reportFormat = FormatText
+
+// Failure is an error tagged with the exit code of the stage which failed
+type Failure struct {
+	Code int
+	Err  error
+}
+
+func (f *Failure) Error() string {
+	return f.Err.Error()
+}
+
+func fail(code int, err error) *Failure {
+	return &Failure{Code: code, Err: err}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		problem(ExitUsage, usage)
 	}
 
-	fileSet, file, err := generator.Generate(ast, &ctx)
-	if err != nil {
-		problem(fmt.Sprintf("%s", err))
+	var err error
+	switch os.Args[1] {
+	case "check":
+		err = Check(os.Args[2:])
+	case "build":
+		err = Build(os.Args[2:])
+	case "run":
+		err = Run(os.Args[2:])
+	case "ast":
+		err = Ast(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		problem(ExitUsage, fmt.Sprintf("Unknown command %s\n\n%s", os.Args[1], usage))
 	}
 
-	e, err := os.Create(fmt.Sprintf("%s.go", filename))
 	if err != nil {
-		problem("Can't write")
+		if f, ok := err.(*Failure); ok {
+			var list compiler.DiagnosticList
+			var diagnostic *compiler.Diagnostic
+			var manifestError *compiler.ManifestError
+			if errors.As(f.Err, &list) {
+				report(f.Code, list...)
+			} else if errors.As(f.Err, &diagnostic) {
+				report(f.Code, diagnostic)
+			} else if errors.As(f.Err, &manifestError) && reportFormat == FormatJSON {
+				report(f.Code, manifestError.Diagnostic())
+			}
+			problem(f.Code, f.Error())
+		}
+		problem(ExitIO, err.Error())
 	}
-	defer e.Close()
+}
 
-	err = format.Node(e, fileSet, file)
-	// err = ioutil.WriteFile(, []byte(text), 0644)
-	if err != nil {
-		problem(fmt.Sprintf("%s", err))
+// report prints diagnostics like go tools: file:line:col: message
+// followed by the source line and a caret
+// With -format=json each diagnostic is a json object on its own line of stdout
+func report(code int, diagnostics ...*compiler.Diagnostic) {
+	warn(diagnostics...)
+	os.Exit(code)
+}
+
+// warn prints diagnostics like report, without exiting
+func warn(diagnostics ...*compiler.Diagnostic) {
+	for _, diagnostic := range diagnostics {
+		if reportFormat == FormatJSON {
+			line, err := compiler.MarshalJSON(diagnostic, "")
+			if err != nil {
+				problem(ExitIO, err.Error())
+			}
+			fmt.Println(string(line))
+		} else {
+			fmt.Fprintln(os.Stderr, diagnostic.Show())
+		}
 	}
 }
 
-func problem(message string) {
-	fmt.Printf("ERROR:\n  %s\n", message)
-	os.Exit(1)
+func problem(code int, message string) {
+	fmt.Fprintf(os.Stderr, "ERROR:\n  %s\n", message)
+	os.Exit(code)
 }
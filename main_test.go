@@ -0,0 +1,1705 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"gitlab.com/alehander42/melt/compiler"
+)
+
+// buildMelt builds melt files like melt build -o and returns the output dir
+// The files map a path to its source, a file in a subdirectory is in
+// the melt package imported with that path
+func buildMelt(t *testing.T, files map[string]string) (string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	src, out := filepath.Join(dir, "src"), filepath.Join(dir, "out")
+	args := []string{}
+	for path, source := range files {
+		target := filepath.Join(src, filepath.FromSlash(path))
+		err := os.MkdirAll(filepath.Dir(target), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(target, []byte(source), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(path, "/") {
+			args = append(args, target)
+		}
+	}
+	sort.Strings(args)
+
+	options := newOptions()
+	options.Module = "melt.run"
+	options.Out = out
+	_, err := buildPackage(args, options)
+	if err != nil {
+		return out, err
+	}
+	return out, goModule(out, options.Module)
+}
+
+// runMelt builds melt files like melt run and returns what the program printed,
+// print writes to stderr so both outputs are returned
+func runMelt(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("runs the go toolchain")
+	}
+	out, err := buildMelt(t, files)
+	if err != nil {
+		t.Fatalf("build: %s", err)
+	}
+	command := exec.Command("go", "run", ".")
+	command.Dir = out
+	// -mod=mod would add a missing go directive
+	command.Env = append(os.Environ(), "GOFLAGS=")
+	output, err := command.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run: %s\n%s", err, output)
+	}
+	return string(output)
+}
+
+// expectOutput runs a main.melt and compares what it printed
+func expectOutput(t *testing.T, source string, expected string) {
+	t.Helper()
+	output := runMelt(t, map[string]string{"main.melt": source})
+	if output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
+
+func TestGoModule(t *testing.T) {
+	dir := t.TempDir()
+	err := goModule(dir, "melt.run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(source), "\ngo 1.") {
+		t.Errorf("go.mod without a go directive:\n%s", source)
+	}
+}
+
+func TestLoopVariablesInGoroutines(t *testing.T) {
+	expectOutput(t, `package main
+
+func main:
+	results = make(~ int, 3)
+	spawn group:
+		for i in 0...3:
+			go func():
+				results <- i
+	total = 0
+	for i in 0...3:
+		total += <-results
+	print("#{total}\n")
+`, "3\n")
+}
+
+const collections = `package collections
+
+record Point:
+	X int
+	Y int
+
+record Box<T>:
+	Value T
+
+func Wrap(x int) Box<int>:
+	return Box<int>{Value: x}
+
+func Origin() Point:
+	return Point{X: 1, Y: 2}
+`
+
+func TestImportedRecords(t *testing.T) {
+	output := runMelt(t, map[string]string{
+		"collections/collections.melt": collections,
+		"main.melt": `package main
+
+import:
+	melt:
+		collections
+
+func show(p collections.Point):
+	print("#{p.X} #{p.Y}\n")
+
+func label(b collections.Box<string>) string:
+	return b.Value
+
+func main:
+	b = collections.Wrap(3)
+	show(collections.Origin())
+	print("#{b.Value}\n")
+`})
+	if output != "1 2\n3\n" {
+		t.Errorf("expected 1 2 and 3, got %q", output)
+	}
+}
+
+func TestImportedRecordIsAnotherType(t *testing.T) {
+	_, err := buildMelt(t, map[string]string{
+		"collections/collections.melt": collections,
+		"main.melt": `package main
+
+import:
+	melt:
+		collections
+
+record Point:
+	X int
+	Y int
+
+func show(p collections.Point):
+	print("#{p.X}\n")
+
+func main:
+	show(Point{X: 1, Y: 2})
+`})
+	if err == nil || !strings.Contains(err.Error(), "received Point, wanted collections.Point") {
+		t.Errorf("expected a type error for main.Point as collections.Point, got %v", err)
+	}
+}
+
+func TestGoTypes(t *testing.T) {
+	expectOutput(t, `package main
+
+import:
+	go:
+		crypto/sha256
+		encoding/hex
+		errors
+		fmt
+		io
+		time
+
+func main:
+	data = hex.DecodeString!("abcd")
+	on DecodeString:
+		print("#{$err.Error()}\n")
+	sum = sha256.Sum256(data)
+	print("#{len(sum)}\n")
+	d = time.Second
+	print("#{d.Seconds()}\n")
+	e = io.ErrUnexpectedEOF
+	print("#{e.Error()}\n")
+	wrapped = fmt.Errorf("read: %w", errors.New("closed"))
+	print("#{wrapped.Error()}\n")
+`, "32\n1\nunexpected EOF\nread: closed\n")
+}
+
+func TestRecordLiterals(t *testing.T) {
+	output := runMelt(t, map[string]string{
+		"collections/collections.melt": collections,
+		"main.melt": `package main
+
+import:
+	go:
+		sync
+	melt:
+		collections
+
+record Node<T>:
+	Value T
+	Next *Node<T>
+
+record Stack<T>:
+	Length int
+	Head *Node<T>
+	Items []T
+	Index map[string]int
+	Visit T -> bool
+
+func main:
+	s = &Stack<int>{Length: 0, Head: nil, Items: nil, Index: nil, Visit: nil}
+	lock = &sync.Mutex{}
+	lock.Lock()
+	p = collections.Point{X: 1, Y: 2}
+	b = collections.Box<int>{Value: 3}
+	lock.Unlock()
+	print("#{s.Length} #{p.Y} #{b.Value}\n")
+`})
+	if output != "0 2 3\n" {
+		t.Errorf("expected 0 2 3, got %q", output)
+	}
+}
+
+func TestNilNeedsAType(t *testing.T) {
+	_, err := buildMelt(t, map[string]string{"main.melt": `package main
+
+func main:
+	x = nil
+`})
+	if err == nil || !strings.Contains(err.Error(), "Can't infer the type of x from nil") {
+		t.Errorf("expected an error for x = nil, got %v", err)
+	}
+}
+
+func TestExamples(t *testing.T) {
+	for _, example := range []string{"mutex.melt", "stateful.melt"} {
+		source, err := os.ReadFile(example)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := buildMelt(t, map[string]string{"main.melt": string(source)})
+		if err != nil {
+			t.Errorf("%s: %s", example, err)
+			continue
+		}
+		if testing.Short() {
+			continue
+		}
+		command := exec.Command("go", "vet", ".")
+		command.Dir = out
+		command.Env = append(os.Environ(), "GOFLAGS=")
+		output, err := command.CombinedOutput()
+		if err != nil {
+			t.Errorf("%s: go vet: %s\n%s", example, err, output)
+		}
+	}
+}
+
+func TestGoStyleSyntax(t *testing.T) {
+	expectOutput(t, `package main
+
+import:
+    "strings"
+
+struct Counter:
+    hits Map[string]Int
+    done chan bool
+
+func main():
+    total : int64 = 2
+    total += 40 as int64
+    counter = &Counter{hits: make(Map[string]Int), done: make(chan bool, 1)}
+    counter.hits["a"] = 3 * 2
+    counter.done <- true
+    <-counter.done
+    print("#{total} #{counter.hits[strings.ToLower("A")]}\n")
+`, "42 6\n")
+}
+
+func TestBadConversions(t *testing.T) {
+	for _, test := range []struct{ source, message string }{
+		{`n = "a" as int`, "Can't convert string to int"},
+		{`n : int = "a"`, "n is int, can't assign string"},
+	} {
+		_, err := buildMelt(t, map[string]string{"main.melt": `package main
+
+func main:
+	` + test.source + `
+	print("#{n}")
+`})
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("expected %q, got %v", test.message, err)
+		}
+	}
+}
+
+func TestBreakAndIndex(t *testing.T) {
+	expectOutput(t, `package main
+
+func first(xs []int, limit int) int:
+	for x in xs:
+		if x > limit:
+			return x
+	return -1
+
+func report(m map[string]int):
+	if m["a"] == 0:
+		return
+	print("a #{m["a"]}\n")
+
+func main:
+	xs = make([]int, 3)
+	xs[0] = 4
+	xs[1] = 8
+	xs[2] = 15
+	counts = make(map[string]int)
+	counts["a"] = 2
+	counts["b"] = 3
+	values = make(~ int, 1)
+	found = 0
+	loop:
+		values <- xs[1]
+		select:
+			? v := <-values:
+				found = v
+				break
+	i = 0
+	loop:
+		match i:
+			? 2:
+				break
+			? _:
+				i += 1
+	report(counts)
+	report(make(map[string]int))
+	print("#{first(xs, 5)} #{found} #{i} #{counts["b"]} #{float(i) / 4.0}\n")
+`, "a 2\n8 8 2 3 0.5\n")
+}
+
+func TestBreakOutsideLoop(t *testing.T) {
+	_, err := buildMelt(t, map[string]string{"main.melt": `package main
+
+func main:
+	break
+`})
+	if err == nil || !strings.Contains(err.Error(), "break outside of a loop") {
+		t.Errorf("expected an error for break, got %v", err)
+	}
+}
+
+func TestIfElif(t *testing.T) {
+	expectOutput(t, `package main
+
+func test(x int) bool:
+	return x > 2
+
+func pick(sequence []int) int:
+	first = 0
+	if len(sequence) == 0:
+		return 0
+	elif test(sequence[0]):
+		first = sequence[0]
+	else:
+		first = 1
+	return first
+
+func main:
+	xs = make([]int, 1)
+	xs[0] = 5
+	small = make([]int, 1)
+	print("#{pick(xs)} #{pick(small)} #{pick(make([]int, 0))}\n")
+`, "5 1 0\n")
+}
+
+func TestCompoundAssignmentTargets(t *testing.T) {
+	expectOutput(t, `package main
+
+record Stack:
+	Length int
+	Items []int
+
+func main:
+	s = &Stack{Length: 0, Items: nil}
+	s.Length += 2
+	xs = make([]int, 2)
+	xs[1] += 5
+	counts = make(map[string]int)
+	counts["a"] += 3
+	total = int64(0)
+	total += 1
+	if s.Items == nil && xs != nil && nil != counts && total == 1:
+		print("#{s.Length} #{xs[1]} #{counts["a"]} #{total}\n")
+`, "2 5 3 1\n")
+}
+
+func TestCompareOnlyWithNil(t *testing.T) {
+	for _, compare := range []string{"xs == xs", "m != m", "f == f", "nil == nil"} {
+		_, err := buildMelt(t, map[string]string{"main.melt": `package main
+
+func main:
+	xs = make([]int, 1)
+	m = make(map[int]int)
+	f = (x int) -> x
+	same = ` + compare + `
+	print("#{same} #{xs} #{m} #{f(1)}\n")
+`})
+		if err == nil || !strings.Contains(err.Error(), "Can't compare") {
+			t.Errorf("expected an error for %s, got %v", compare, err)
+		}
+	}
+}
+
+func TestGoEvaluatesArgs(t *testing.T) {
+	expectOutput(t, `package main
+
+import:
+	go:
+		context
+		sync
+
+record Box:
+	Value int
+
+record Counter:
+	Total int
+	Lock *sync.Mutex
+
+func (b *Box) add!(ctx context.Context, n int, counter *Counter):
+	if n < 0:
+		!! "negative"
+	counter.Lock.Lock()
+	counter.Total += b.Value + n
+	counter.Lock.Unlock()
+
+func main:
+	counter = &Counter{Total: 0, Lock: &sync.Mutex{}}
+	b = &Box{Value: 100}
+	spawn adds:
+		n = 1
+		go b.add!(adds, n, counter)
+		n = 2
+		b = &Box{Value: 1000}
+		go b.add!(adds, n * 10, counter)
+		n = 3
+	on adds:
+		print("failed #{$err}\n")
+	print("#{counter.Total}\n")
+`, "1121\n")
+}
+
+func TestShortLambdaFailures(t *testing.T) {
+	expectOutput(t, `package main
+
+func Map?<T, U>(handler? T -> U, xs []T) []U:
+	result = make([]U, len(xs))
+	for i, item in xs:
+		result[i] = handler?(item)
+	escalate handler
+	return result
+
+func half!(n int) int:
+	if n % 2 == 1:
+		!! "odd: #{n}"
+	return n / 2
+
+func main:
+	xs = make([]int, 2)
+	xs[0] = 4
+	xs[1] = 8
+	ys = Map?(x -> half!(x) + 1, xs)
+	print("#{ys[1]}\n")
+	xs[1] = 3
+	zs = Map?(x -> half!(half!(x)) * 2, xs)
+	print("#{len(zs)}\n")
+	on Map:
+		print("failed: #{$err}\n")
+`, "5\n0\nfailed: odd: 3\n")
+}
+
+func TestGoArrowFunc(t *testing.T) {
+	expectOutput(t, `package main
+
+func main:
+	done = make(~ int)
+	go ->func():
+		done <- 1
+	go func():
+		done <- 2
+	total = <-done + <-done
+	print("#{total}\n")
+`, "3\n")
+}
+
+func TestGuardedFailingCalls(t *testing.T) {
+	expectOutput(t, `package main
+
+func check!(x int) bool:
+	print("check #{x}\n")
+	if x < 0:
+		!! "negative: #{x}"
+	return x > 3
+
+func pick(x int) int:
+	result = 0
+	if x > 0 && check!(x):
+		result = 1
+	elif check!(5):
+		result = 2
+	on check:
+		print("failed: #{$err}\n")
+	return result
+
+func main:
+	x = 0
+	y = x == 0 || check!(-1)
+	print("#{y}\n")
+	z = x != 0 || check!(-2)
+	print("#{z}\n")
+	on check:
+		print("main failed: #{$err}\n")
+	print("#{pick(7)} #{pick(0)}\n")
+`, "true\ncheck -2\nfalse\nmain failed: negative: -2\ncheck 7\ncheck 5\n1 2\n")
+}
+
+func TestFailingMatchGuard(t *testing.T) {
+	_, err := buildMelt(t, map[string]string{"main.melt": `package main
+
+func check!(x int) bool:
+	return x > 3
+
+func main:
+	match 2:
+		? n if check!(n):
+			print("big")
+		? _:
+			print("small")
+	on check:
+		print("failed")
+`})
+	if err == nil || !strings.Contains(err.Error(), "can't call the failing check") {
+		t.Errorf("expected an error for the guard, got %v", err)
+	}
+}
+
+// half fails for odd numbers, the flow tests handle its errors
+const half = `package main
+
+func half!(n int) int:
+	if n % 2 == 1:
+		!! "odd: #{n}"
+	return n / 2
+`
+
+func TestUnhandledErrors(t *testing.T) {
+	for _, test := range []struct{ name, source, message string }{
+		{"handler after return", `
+func f() int:
+	x = half!(3)
+	return x
+	on half:
+		print("failed")
+`, "The error of half isn't handled before the return"},
+		{"return in a branch", `
+func f(c bool) int:
+	x = half!(3)
+	if c:
+		return 1
+	on half:
+		print("failed")
+	return x
+`, "The error of half isn't handled before the return"},
+		{"!! before the handler", `
+func f!(c bool) int:
+	x = half!(3)
+	if c:
+		!! "stop"
+	on half:
+		print("failed")
+	return x
+`, "The error of half isn't handled before the !!"},
+		{"handled in one branch", `
+func f(c bool):
+	x = half!(3)
+	if c:
+		print("#{x}")
+		on half:
+			print("failed")
+`, "The error of half isn't handled, it needs an on half:"},
+		{"no handler in a loop", `
+func f():
+	for i in 0...3:
+		x = half!(i)
+		print("#{x}")
+`, "The error of half isn't handled"},
+		{"missing return", `
+func f(c bool) int:
+	if c:
+		return 1
+`, "f returns int, it needs a return at its end"},
+	} {
+		_, err := buildMelt(t, map[string]string{"main.melt": half + test.source + `
+func main:
+	print("main")
+`})
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("%s: expected %q, got %v", test.name, test.message, err)
+		}
+	}
+}
+
+func TestHandledPaths(t *testing.T) {
+	expectOutput(t, half+`
+func branches(n int) int:
+	x = half!(n)
+	if x > 1:
+		on half:
+			return -1
+		return x
+	else:
+		on half:
+			return -2
+		return 0
+	on half:
+		print("skipped")
+	return 1
+
+func first(n int) int:
+	loop:
+		x = half!(n)
+		on half:
+			return -1
+		if x > 0:
+			return x
+		n += 2
+
+func main:
+	print("#{branches(8)} #{branches(5)} #{first(4)} #{first(0)} #{first(3)}\n")
+`, "4 -2 2 1 -1\n")
+}
+
+func TestEscalateAfterLoop(t *testing.T) {
+	expectOutput(t, half+`
+func any!(limit int) bool:
+	for i in 0...4:
+		if half!(i * 2) > limit:
+			return true
+
+	escalate half
+	return false
+
+func main:
+	print("#{any!(1)} #{any!(4)}\n")
+	on any:
+		print("failed")
+`, "true false\n")
+}
+
+func TestReturnOfWrongValue(t *testing.T) {
+	_, err := buildMelt(t, map[string]string{"main.melt": `package main
+
+func f() int:
+	return missing
+
+func main:
+	print("#{f()}")
+`})
+	if err == nil || !strings.Contains(err.Error(), "missing is not defined") {
+		t.Fatalf("expected missing is not defined, got %v", err)
+	}
+	if strings.Contains(err.Error(), "needs a return") {
+		t.Errorf("expected no missing return error, got %v", err)
+	}
+}
+
+func TestErrorLowering(t *testing.T) {
+	out, err := buildMelt(t, map[string]string{"main.melt": half + `
+func main:
+	a = half!(4) + 1
+	b = half!(3)
+	print("#{a + b}")
+	on half:
+		print("half failed: #{$err}")
+`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := os.ReadFile(filepath.Join(out, "main.melt.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"var halfErr0 error",
+		"var halfErr1 error",
+		"halfValue0, halfErr0 = half(4)",
+		"var a int = halfValue0 + 1",
+		"halfValue1, halfErr1 = half(3)",
+		"err := halfErr0",
+		"err = halfErr1",
+	} {
+		if !strings.Contains(string(generated), line) {
+			t.Errorf("expected %q in\n%s", line, generated)
+		}
+	}
+}
+
+func TestLoopKeepsFirstError(t *testing.T) {
+	expectOutput(t, half+`
+func main:
+	total = 0
+	for i in 1..4:
+		total += half!(i)
+	on half:
+		print("failed: #{$err}\n")
+	for i in 1..3:
+		x = half!(i)
+		on half:
+			print("in the loop: #{$err}\n")
+		total += x
+	print("total #{total}\n")
+`, "failed: odd: 1\nin the loop: odd: 1\nin the loop: odd: 3\ntotal 4\n")
+}
+
+func TestCorrectHandlerInstance(t *testing.T) {
+	expectOutput(t, `package main
+
+func Map?<T, U>(handler? T -> U, xs []T) []U:
+	result = make([]U, len(xs))
+	for i, item in xs:
+		result[i] = handler?(item)
+	escalate handler
+	return result
+
+func double(n int) int:
+	return n * 2
+
+func half!(n int) int:
+	if n % 2 == 1:
+		!! "odd: #{n}"
+	return n / 2
+
+func main:
+	xs = make([]int, 2)
+	xs[0] = 4
+	xs[1] = 8
+	ys = Map?(double, xs)
+	zs = Map?(half!, xs)
+	on Map:
+		print("failed: #{$err}\n")
+	print("#{ys[1]} #{zs[1]}\n")
+`, "16 4\n")
+
+	// a handler for a call which can't fail has nothing to run
+	expectOutput(t, `package main
+
+func Map?<T, U>(handler? T -> U, xs []T) []U:
+	result = make([]U, len(xs))
+	for i, item in xs:
+		result[i] = handler?(item)
+	escalate handler
+	return result
+
+func double(n int) int:
+	return n * 2
+
+func main:
+	xs = make([]int, 1)
+	xs[0] = 3
+	ys = Map?(double, xs)
+	on Map:
+		print("failed")
+	print("#{ys[0]}\n")
+`, "6\n")
+}
+
+func TestAstDump(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.melt")
+	err := os.WriteFile(path, []byte(`package main
+
+func main:
+	ok = true
+	print("#{ok}")
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _, err := checkPackage([]string{path}, newOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dump := compiler.Dump(p.Modules[0])
+	for _, line := range []string{
+		"      E: Set Define=true : empty @4:2",
+		"        Label: Label Label=ok : bool @4:2",
+		"        Value: Bool Value=true : bool @4:7",
+	} {
+		if !strings.Contains(dump.Text(), line+"\n") {
+			t.Errorf("expected %q in\n%s", line, dump.Text())
+		}
+	}
+
+	source, err := compiler.MarshalJSON(dump, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tree struct {
+		Kind     string
+		Children []json.RawMessage
+	}
+	err = json.Unmarshal(source, &tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Kind != "Module" || len(tree.Children) != 2 {
+		t.Errorf("expected a module with imports and a function, got %s", source)
+	}
+	if !strings.Contains(string(source), `"kind":"Bool","field":"Value","attrs":{"Value":"true"},"type":"bool","range":{"start":{"line":4,"column":7}`) {
+		t.Errorf("expected the bool with its type and range in %s", source)
+	}
+
+	if text := (&compiler.Bool{Value: true}).ToString(0); text != "Bool:true" {
+		t.Errorf("expected Bool:true, got %s", text)
+	}
+}
+
+func TestRunExitCode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go toolchain")
+	}
+	path := filepath.Join(t.TempDir(), "main.melt")
+	err := os.WriteFile(path, []byte(`package main
+
+import:
+	go:
+		os
+
+func main:
+	os.Exit(2)
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Run([]string{path})
+	failure, ok := err.(*Failure)
+	if !ok || failure.Code != ExitProgram || !strings.Contains(failure.Error(), "exit status 2") {
+		t.Errorf("expected exit code %d with exit status 2, got %v", ExitProgram, err)
+	}
+}
+
+// diagnostics are the diagnostics of a failed command
+func diagnostics(err error) compiler.DiagnosticList {
+	var list compiler.DiagnosticList
+	if failure, ok := err.(*Failure); ok && errors.As(failure.Err, &list) {
+		return list
+	}
+	return nil
+}
+
+func TestErrorCap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.melt")
+	err := os.WriteFile(path, []byte(`package main
+
+func main:
+	d = four + 1
+	c = three + 1
+	b = two + 1
+	a = one + 1
+	print("#{a + b + c + d}")
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		max      int
+		messages []string
+	}{
+		{0, []string{"four is not defined", "three is not defined", "two is not defined", "one is not defined"}},
+		{2, []string{"four is not defined", "three is not defined", "too many errors"}},
+	} {
+		options := newOptions()
+		options.MaxErrors = test.max
+		_, _, err := checkPackage([]string{path}, options)
+		list := diagnostics(err)
+		if len(list) != len(test.messages) {
+			t.Errorf("max %d: expected %d errors, got %v", test.max, len(test.messages), err)
+			continue
+		}
+		for i, message := range test.messages {
+			if list[i].Message != message {
+				t.Errorf("max %d: expected %q, got %q", test.max, message, list[i].Message)
+			}
+		}
+	}
+}
+
+func TestPoisonedNames(t *testing.T) {
+	for _, test := range []struct{ name, source, message string }{
+		{"broken label", `
+func main:
+	y = missing + 1
+	z = y * 2
+	print("#{z}")
+`, "missing is not defined"},
+		{"broken import", `
+import:
+	melt:
+		util
+
+func first(p util.Pair<int>) int:
+	return 1
+
+func main:
+	p = util.Pair<int>{first: 1, second: 2}
+	b = util.Box{}
+	n = util.Twice(first(p))
+	print("#{n}")
+`, "melt package util not found"},
+	} {
+		_, err := buildMelt(t, map[string]string{"main.melt": "package main\n" + test.source})
+		list := diagnostics(err)
+		if len(list) != 1 || !strings.Contains(list[0].Message, test.message) {
+			t.Errorf("%s: expected only %q, got %v", test.name, test.message, err)
+		}
+	}
+}
+
+func TestSumInstanceNames(t *testing.T) {
+	source := `package main
+
+union Option<T>:
+	| Some(T)
+	| None
+
+func either(o Option<int>, s Option<string>) string:
+	match o:
+		? Some(n):
+			return "#{n}"
+		? None:
+			match s:
+				? Some(text):
+					return text
+				? None:
+					return "none"
+
+func main:
+	print(either(None(), Some("a")) + either(Some(2), None()) + "\n")
+`
+	expectOutput(t, source, "a2\n")
+
+	for _, test := range []struct {
+		safe  bool
+		names []string
+	}{
+		{true, []string{"type Option0 interface", "type Some0 struct", "type None1 struct", "case Some0:"}},
+		{false, []string{"type OptionOfInt interface", "type SomeOfInt struct", "type NoneOfString struct", "case SomeOfInt:"}},
+	} {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "main.melt")
+		err := os.WriteFile(path, []byte(source), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		options := newOptions()
+		options.Module = "melt.run"
+		options.Out = filepath.Join(dir, "out")
+		options.SafeName = test.safe
+		_, err = buildPackage([]string{path}, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		generated, err := os.ReadFile(filepath.Join(options.Out, "main.melt.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range test.names {
+			if !strings.Contains(string(generated), name) {
+				t.Errorf("safe names %t: expected %q in\n%s", test.safe, name, generated)
+			}
+		}
+	}
+}
+
+func TestMatchExhaustive(t *testing.T) {
+	for _, test := range []struct{ name, arms, message string }{
+		{"missing variant", `
+		? Circle(r):
+			return r
+		? Dot:
+			return 0.0
+`, "doesn't cover Rect"},
+		{"unreachable arm", `
+		? _:
+			return 0.0
+		? Dot:
+			return 1.0
+`, "The arm ? Dot can't run, every value is already matched"},
+		{"matched variant", `
+		? Rect(w, h):
+			return w * h
+		? Circle(r):
+			return r
+		? Rect(w, _):
+			return w
+		? Dot:
+			return 0.0
+`, "The arm ? Rect(w, _) can't run, Rect is already matched"},
+		{"guard doesn't cover", `
+		? Circle(r) if r > 1.0:
+			return r
+		? Rect(w, h):
+			return w * h
+		? Dot:
+			return 0.0
+`, "doesn't cover Circle"},
+	} {
+		_, err := buildMelt(t, map[string]string{"main.melt": `package main
+
+union Shape: Circle(r float) | Rect(w float, h float) | Dot
+
+func area(shape Shape) float:
+	match shape:` + test.arms + `
+func main:
+	print("#{area(Dot())}")
+`})
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("%s: expected %q, got %v", test.name, test.message, err)
+		}
+	}
+
+	_, err := buildMelt(t, map[string]string{"main.melt": `package main
+
+func sign(positive bool) int:
+	match positive:
+		? true:
+			return 1
+		? false:
+			return -1
+		? true:
+			return 2
+		? _:
+			return 0
+
+func main:
+	print("#{sign(true)}")
+`})
+	list := diagnostics(err)
+	related := map[int]string{9: "5 ? true matches it before", 11: "7 ? false matches the last values before"}
+	if len(list) != len(related) {
+		t.Fatalf("expected %d unreachable arms, got %v", len(related), err)
+	}
+	for _, diagnostic := range list {
+		if len(diagnostic.Related) != 1 || fmt.Sprintf("%d %s", diagnostic.Related[0].Line, diagnostic.Related[0].Message) != related[diagnostic.Line] {
+			t.Errorf("expected the arm at line %d related to %q, got %v", diagnostic.Line, related[diagnostic.Line], diagnostic.Related)
+		}
+	}
+
+	expectOutput(t, `package main
+
+union Shape: Circle(r float) | Rect(w float, h float) | Dot
+
+func area(shape Shape) float:
+	match shape:
+		? Circle(r):
+			return 3.0 * r * r
+		? Rect(w, h) if w == h:
+			return w * w
+		? Rect(w, h):
+			return w * h
+		? Dot:
+			return 0.0
+
+func main:
+	print("#{area(Circle(1.0))} #{area(Rect(2.0, 2.0))} #{area(Rect(2.0, 3.0))} #{area(Dot())}\n")
+`, "3 4 6 0\n")
+}
+
+func TestPackageFiles(t *testing.T) {
+	files := map[string]string{
+		"main.melt": `package main
+
+func main:
+	p = Point{x: 1, y: 2}
+	print("#{p.sum()} #{double(p.y)}\n")
+`,
+		"point.melt": `package main
+
+record Point:
+	x int
+	y int
+
+func (p *Point) sum() int:
+	return double(p.x) + p.y
+`,
+		"double.melt": `package main
+
+func double(n int) int:
+	return n * 2
+`,
+	}
+	if output := runMelt(t, files); output != "4 4\n" {
+		t.Errorf("expected output %q, got %q", "4 4\n", output)
+	}
+	out, err := buildMelt(t, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"main.melt.go", "point.melt.go", "double.melt.go"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("expected %s: %s", name, err)
+		}
+	}
+
+	files["double.melt"] = "package util\n"
+	_, err = buildMelt(t, files)
+	// double.melt is the first file
+	if err == nil || !strings.Contains(err.Error(), "main.melt: package main, expected util") {
+		t.Errorf("expected package main, expected util, got %v", err)
+	}
+}
+
+func TestMeltfileProject(t *testing.T) {
+	root := t.TempDir()
+	for path, source := range map[string]string{
+		"Meltfile.yaml": `name:      app
+source:    [src/app]
+output:    gen
+module:    example.com/app
+safe_name: false
+deps:
+  melt:
+    collections:
+      path: libs/collections
+`,
+		"src/app/main.melt": `package main
+
+import:
+	melt:
+		collections
+
+record Pair<T>:
+	first T
+	second T
+
+func main:
+	p = Pair<int>{first: 1, second: 2}
+	b = collections.Wrap(p.first + p.second)
+	print("#{b.Value}\n")
+`,
+		"libs/collections/collections.melt": collections,
+	} {
+		target := filepath.Join(root, filepath.FromSlash(path))
+		err := os.MkdirAll(filepath.Dir(target), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(target, []byte(source), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the nearest Meltfile is in a parent directory
+	err = os.Chdir(filepath.Join(root, "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	err = Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := os.ReadFile(filepath.Join(root, "gen", "src", "app", "main.melt.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{`"example.com/app/collections"`, "type PairOfInt struct"} {
+		if !strings.Contains(string(generated), line) {
+			t.Errorf("expected %q in\n%s", line, generated)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "gen", "collections", "collections.melt.go")); err != nil {
+		t.Errorf("expected the dependency in the output: %s", err)
+	}
+}
+
+func TestMeltfileErrors(t *testing.T) {
+	for _, test := range []struct {
+		source  string
+		line    int
+		message string
+	}{
+		{"name: app\nsorce: [src]\n", 2, "unknown field sorce"},
+		{"name: app\nsafe_name: [yes]\n", 2, "safe_name: expected true or false"},
+		{"name: app\ndeps:\n  melt:\n    parser: {}\n", 4, "deps: parser: expected a version or a path"},
+		{"name: app\ndeps:\n  melt:\n    parser: 2.3.0\n", 4, "dependency parser not found"},
+	} {
+		path := filepath.Join(t.TempDir(), compiler.MeltfileName)
+		meltfile, err := compiler.ParseMeltfile(path, []byte(test.source))
+		if err == nil {
+			_, err = meltfile.ResolveDeps()
+		}
+		var manifestError *compiler.ManifestError
+		if !errors.As(err, &manifestError) || manifestError.Line != test.line || !strings.Contains(manifestError.Message, test.message) {
+			t.Errorf("expected line %d: %q, got %v", test.line, test.message, err)
+		}
+	}
+}
+
+func TestDiagnosticPositions(t *testing.T) {
+	for _, test := range []struct {
+		indent string
+		column int
+		caret  string
+	}{
+		{"\t", 11, "\t\t        ^"},
+		{"    ", 17, "                ^"},
+	} {
+		path := filepath.Join(t.TempDir(), "main.melt")
+		source := strings.ReplaceAll("package main\n\nfunc main:\n\tif true:\n\t\tx = 2 + missing\n\t\tprint(\"#{x}\")\n", "\t", test.indent)
+		err := os.WriteFile(path, []byte(source), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = checkPackage([]string{path}, newOptions())
+		list := diagnostics(err)
+		if len(list) != 1 {
+			t.Errorf("indent %q: expected one error, got %v", test.indent, err)
+			continue
+		}
+		location := fmt.Sprintf("%s:5:%d: missing is not defined", path, test.column)
+		expected := strings.Join([]string{location, strings.Split(source, "\n")[4], test.caret}, "\n")
+		if list[0].Show() != expected {
+			t.Errorf("indent %q: expected\n%s\ngot\n%s", test.indent, expected, list[0].Show())
+		}
+	}
+}
+
+func TestJSONDiagnostics(t *testing.T) {
+	_, err := buildMelt(t, map[string]string{"main.melt": half + `
+func main:
+	x = half!(4)
+	on half:
+		print("failed")
+	on half:
+		print("failed again")
+	print("#{x}")
+`})
+	list := diagnostics(err)
+	if len(list) != 1 {
+		t.Fatalf("expected one error, got %v", err)
+	}
+	line, err := compiler.MarshalJSON(list[0], "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type position struct{ Line, Column int }
+	type location struct {
+		File  string
+		Range struct{ Start, End position }
+	}
+	var diagnostic struct {
+		location
+		Severity, Code, Message string
+		Related                 []struct {
+			location
+			Message string
+		}
+	}
+	err = json.Unmarshal(line, &diagnostic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diagnostic.Code != "E0403" || diagnostic.Severity != "error" || diagnostic.Message != "Already handled error of half" {
+		t.Errorf("expected E0403 for the second handler, got %s", line)
+	}
+	if filepath.Base(diagnostic.File) != "main.melt" || diagnostic.Range.Start != (position{12, 5}) || diagnostic.Range.End != (position{12, 9}) {
+		t.Errorf("expected the range of half on line 12, got %s", line)
+	}
+	if len(diagnostic.Related) != 1 || diagnostic.Related[0].Range.Start != (position{10, 2}) || diagnostic.Related[0].Message != "the error of half is handled here" {
+		t.Errorf("expected the first handler on line 10 as related, got %s", line)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	saved := *compiler.Trace
+	defer func() { *compiler.Trace = saved }()
+	dir := t.TempDir()
+	path, trace := filepath.Join(dir, "main.melt"), filepath.Join(dir, "trace")
+	err := os.WriteFile(path, []byte(`package main
+
+record Box<T>:
+	value T
+
+func main:
+	b = Box<int>{value: 2}
+	print("#{b.value}")
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// nothing is written without -trace, not even to stdout
+	stdout := os.Stdout
+	os.Stdout, err = os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var silent strings.Builder
+	compiler.Trace.Out = &silent
+	err = Build([]string{"-o", filepath.Join(dir, "silent"), path})
+	os.Stdout.Close()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatal(err)
+	}
+	printed, _ := os.ReadFile(filepath.Join(dir, "stdout"))
+	if len(printed) > 0 || silent.Len() > 0 {
+		t.Errorf("expected a silent build, got %q and the trace %q", printed, silent.String())
+	}
+
+	err = Build([]string{"-trace=instantiate", "-trace-out", trace, "-o", filepath.Join(dir, "out"), path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler.Trace.Out.(*os.File).Close()
+	source, err := os.ReadFile(trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(source)), "\n")
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "[instantiate] ") {
+			t.Errorf("expected only the instantiate channel, got %q", line)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "[instantiate] dependencies") {
+		t.Errorf("expected the dependencies in the trace, got\n%s", source)
+	}
+}
+
+const shapes = `package main
+
+interface Shape:
+	Area() float
+
+record Square:
+	side float
+
+func (s *Square) Area() float:
+	return s.side * s.side
+
+record Circle:
+	r float
+
+func (c Circle) Area() int:
+	return 3
+
+record Line:
+	length float
+
+func total(shape Shape) float:
+	return shape.Area()
+`
+
+func TestMethods(t *testing.T) {
+	expectOutput(t, shapes+`
+func (s *Square) Grow(by float):
+	s.side = s.side + by
+
+func main:
+	s = &Square{side: 2.0}
+	s.Grow(1.0)
+	print("#{total(s)}")
+`, "9")
+}
+
+func TestInterfaceSatisfaction(t *testing.T) {
+	for _, test := range []struct{ name, call, message string }{
+		{"missing method", "total(Line{length: 1.0})", "Line doesn't have a method Area of Shape"},
+		{"pointer receiver", "total(Square{side: 1.0})", "Square.Area has a pointer receiver, only *Square is Shape"},
+		{"other signature", "total(Circle{r: 1.0})", "Circle.Area is ( -> int), Shape wants ( -> float)"},
+	} {
+		_, err := buildMelt(t, map[string]string{"main.melt": shapes + `
+func main:
+	a = ` + test.call + `
+	print("#{a}")
+`})
+		list := diagnostics(err)
+		if len(list) != 1 || !strings.Contains(list[0].Message, test.message) {
+			t.Errorf("%s: expected %q, got %v", test.name, test.message, err)
+		}
+	}
+}
+
+func TestGenericRecordInstances(t *testing.T) {
+	source := `package main
+
+record Node<T>:
+	value T
+	next *Node<T>
+
+record Stack<T>:
+	length int
+	head *Node<T>
+
+func (s *Stack<T>) Push(item T):
+	s.head = &Node<T>{value: item, next: s.head}
+	s.length += 1
+
+func top(s *Stack<[]string>) int:
+	return s.length
+
+func main:
+	ints = &Stack<int>{length: 0, head: nil}
+	ints.Push(2)
+	ints.Push(3)
+	words = &Stack<[]string>{}
+	words.Push(make([]string, 1))
+	print("#{ints.head.value} #{ints.length} #{top(words)}")
+`
+	expectOutput(t, source, "3 2 1")
+
+	for _, test := range []struct {
+		safe  bool
+		names []string
+	}{
+		{true, []string{"type Stack0 struct", "type Stack1 struct", "type Node0 struct", "type Node1 struct"}},
+		{false, []string{"type StackOfInt struct", "type NodeOfInt struct", "func (s *StackOfInt) Push(item int)", "func top(s *StackOfSliceOfString) int"}},
+	} {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "main.melt")
+		err := os.WriteFile(path, []byte(source), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		options := newOptions()
+		options.Module = "melt.run"
+		options.Out = filepath.Join(dir, "out")
+		options.SafeName = test.safe
+		_, err = buildPackage([]string{path}, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		generated, err := os.ReadFile(filepath.Join(options.Out, "main.melt.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range test.names {
+			if !strings.Contains(string(generated), name) {
+				t.Errorf("safe names %t: expected %q in\n%s", test.safe, name, generated)
+			}
+		}
+	}
+}
+
+func TestEscalateZeroValues(t *testing.T) {
+	expectOutput(t, half+`
+record Point:
+	x int
+	y int
+
+func count!(n int) int:
+	x = half!(n)
+	escalate half
+	return x
+
+func name!(n int) string:
+	x = half!(n)
+	escalate half
+	return "#{x}"
+
+func point!(n int) Point:
+	x = half!(n)
+	escalate half
+	return Point{x: x, y: x}
+
+func show!(n int):
+	x = half!(n)
+	escalate half
+	print("show #{x}\n")
+
+func first!(limit int) int:
+	for i in 0...5:
+		x = half!(i + 2)
+		escalate half
+		print("step #{i} #{x}\n")
+
+	return limit
+
+func both!(n int) int:
+	a = half!(n)
+	b = count!(n + 1)
+	on count:
+		print("both: #{$err}\n")
+	escalate half
+	return a + b
+
+func main:
+	b = both!(4)
+	on both:
+		print("failed")
+	print("both #{b}\n")
+	c = count!(3)
+	on count:
+		print("count: #{$err}\n")
+	s = name!(5)
+	on name:
+		print("name: #{$err}\n")
+	p = point!(7)
+	on point:
+		print("point: #{$err}\n")
+	show!(9)
+	on show:
+		print("show: #{$err}\n")
+	f = first!(10)
+	on first:
+		print("first: #{$err}\n")
+	print("#{c} '#{s}' #{p.x} #{p.y} #{f}\n")
+`, "both: odd: 5\nboth 2\ncount: odd: 3\nname: odd: 5\npoint: odd: 7\nshow: odd: 9\nstep 0 1\nfirst: odd: 3\n0 '' 0 0 0\n")
+}
+
+func TestWrappedErrors(t *testing.T) {
+	expectOutput(t, `package main
+
+import:
+	go:
+		errors
+		io
+`+strings.TrimPrefix(half, "package main\n")+`
+record Closed:
+	name string
+
+func (c Closed) Error() string:
+	return "#{c.name} is closed"
+
+func read!(n int) int:
+	if n > 10:
+		!! io.EOF
+	if n < 0:
+		!! Closed{name: "input"}
+	x = half!(n)
+	on half:
+		!! "reading #{n}" with $err
+	return x
+
+func load!(n int) int:
+	x = read!(n)
+	escalate read with "loading"
+	return x
+
+func try(n int):
+	x = load!(n)
+	on load:
+		print("#{$err} | #{errors.Unwrap($err)} | #{errors.Is($err, io.EOF)}\n")
+	print("#{x}\n")
+
+func main:
+	try(3)
+	try(11)
+	try(-1)
+`, "loading: reading 3: odd: 3 | reading 3: odd: 3 | false\n0\n"+
+		"loading: EOF | EOF | true\n0\n"+
+		"loading: input is closed | input is closed | false\n0\n")
+}
+
+const openErrors = `package main
+
+record NotFound:
+	path string
+
+func (e NotFound) Error() string:
+	return "#{e.path} not found"
+
+record Denied:
+	path string
+
+func (e Denied) Error() string:
+	return "#{e.path} denied"
+
+func open!<NotFound | Denied>(path string) int:
+	if path == "missing":
+		!! "opening" with NotFound{path: path}
+	if path == "secret":
+		!! Denied{path: path}
+	return 6
+`
+
+func TestTypedErrors(t *testing.T) {
+	expectOutput(t, openErrors+`
+func try(path string):
+	n = open!(path)
+	on open as NotFound:
+		print("no #{$err.path}\n")
+	on open:
+		print("can't open: #{$err}\n")
+	print("#{n}\n")
+
+func main:
+	try("missing")
+	try("secret")
+	try("config")
+`, "no missing\n0\ncan't open: secret denied\n0\n6\n")
+}
+
+func TestTypedErrorChecks(t *testing.T) {
+	for _, test := range []struct{ name, source, message string }{
+		{"undeclared arm", `
+record Other:
+	path string
+
+func (e Other) Error() string:
+	return "other"
+
+func main:
+	n = open!("a")
+	on open as Other:
+		print("other")
+	on open:
+		print("failed")
+	print("#{n}")
+`, "open fails only with NotFound | Denied, not Other"},
+		{"undeclared !!", `
+func check!<NotFound>(path string):
+	!! Denied{path: path}
+
+func main:
+	check!("a")
+	on check:
+		print("failed")
+`, "check fails only with NotFound, got Denied"},
+		{"missing arm", `
+func main:
+	n = open!("a")
+	on open as NotFound:
+		print("not found")
+	print("#{n}")
+`, "Denied errors of open aren't handled"},
+		{"not an error", `
+record Plain:
+	path string
+
+func check!<Plain>(path string):
+	!! Plain{path: path}
+
+func main:
+	check!("a")
+	on check:
+		print("failed")
+`, "Plain doesn't have an Error() string method"},
+	} {
+		_, err := buildMelt(t, map[string]string{"main.melt": openErrors + test.source})
+		list := diagnostics(err)
+		if len(list) != 1 || !strings.Contains(list[0].Message, test.message) {
+			t.Errorf("%s: expected %q, got %v", test.name, test.message, err)
+		}
+	}
+}
+
+func TestNeverHandledWarning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.melt")
+	err := os.WriteFile(path, []byte(openErrors+`
+func load!<NotFound | Denied>(path string) int:
+	n = open!(path)
+	escalate open
+	return n
+
+func main:
+	n = load!("a")
+	on load as Denied:
+		print("denied")
+	on load:
+		print("failed")
+	print("#{n}")
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _, err := checkPackage([]string{path}, newOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Warnings) != 1 || p.Warnings[0].Code != compiler.CodeNeverHandled || !strings.Contains(p.Warnings[0].Message, "NotFound errors of open are escalated") {
+		t.Errorf("expected a warning about NotFound, got %v", p.Warnings)
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+import "fmt"
+
+// Array is a go array: [32]byte, like the result of sha256.Sum256
+type Array struct {
+	Element Type
+	Length  int64
+}
+
+// Methods of an array: it has none, it's iterated over like a slice
+func (a Array) Methods() []Method {
+	return []Method{}
+}
+
+func (a Array) ToString() string {
+	return fmt.Sprintf("[%d]%s", a.Length, a.Element.ToString())
+}
+
+// Accepts an array of the same length and element
+func (a Array) Accepts(t Type) bool {
+	if other, ok := t.(Array); ok {
+		return a.Length == other.Length && a.Element.Accepts(other.Element)
+	}
+	return false
+}
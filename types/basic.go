@@ -12,6 +12,11 @@ func (self Basic) Accepts(t Type) bool {
 	switch other := t.(type) {
 	case Basic:
 		return self.Label == other.Label
+	case Sum:
+		// the name of a sum type
+		return len(other.GenericVars) == 0 && self.Label == other.Label
+	case Record:
+		return len(other.GenericVars) == 0 && self.Label == other.Label
 	default:
 		return false
 	}
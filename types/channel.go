@@ -0,0 +1,42 @@
+package types
+
+import "fmt"
+
+// ChannelDir enum
+type ChannelDir int
+
+const (
+	Both    ChannelDir = 0
+	Send    ChannelDir = 1
+	Receive ChannelDir = 2
+)
+
+type Channel struct {
+	Element Type
+	Dir     ChannelDir
+}
+
+func (c Channel) ToString() string {
+	switch c.Dir {
+	case Send:
+		return fmt.Sprintf("~send<%s>", c.Element.ToString())
+	case Receive:
+		return fmt.Sprintf("~receive<%s>", c.Element.ToString())
+	default:
+		return fmt.Sprintf("~<%s>", c.Element.ToString())
+	}
+}
+
+// Accepts a channel of the same element or nil
+// A bidirectional channel can be used as a send or receive one
+func (c Channel) Accepts(t Type) bool {
+	other, ok := t.(Channel)
+	if !ok {
+		_, ok := t.(Nil)
+		return ok
+	}
+	if c.Dir != other.Dir && other.Dir != Both {
+		return false
+	}
+	return c.Element.Accepts(other.Element)
+}
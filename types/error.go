@@ -11,5 +11,20 @@ func (self Error) ToString() string {
 }
 
 func (self Error) Accepts(t Type) bool {
-	return false
+	switch t.(type) {
+	case Error, Nil:
+		return true
+	default:
+		return false
+	}
+}
+
+// Methods of an error: Error() string
+func (self Error) Methods() []Method {
+	return []Method{{Label: "Error", Function: Function{
+		Args:         []Type{},
+		Return:       Basic{Label: "string"},
+		Error:        Correct,
+		GenericVars:  []GenericVar{},
+		InstanceVars: []Type{}}}}
 }
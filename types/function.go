@@ -30,11 +30,22 @@ type Function struct {
 	GenericVars  []GenericVar
 	InstanceVars []Type
 	Error        ErrorFunction
+	// Variadic functions take the last arg many times, it's a slice
+	Variadic bool
+	// ErrorTypes are the records a failing function declares
+	// it fails with: open!<NotFound | Denied>
+	ErrorTypes []Type
+	// ErrorValue go functions return only an error, called without !
+	// they return it as a value: e = errors.New("..")
+	ErrorValue bool
 }
 
 type Method struct {
 	Label    string
 	Function Function
+	// Pointer methods have a pointer receiver, in go only
+	// a pointer to the record has them in its method set
+	Pointer bool
 }
 
 func (self Function) ToString() string {
@@ -42,8 +53,19 @@ func (self Function) ToString() string {
 	for _, arg := range self.Args {
 		args = append(args, arg.ToString())
 	}
+	if self.Variadic && len(args) > 0 {
+		args[len(args)-1] = "..." + args[len(args)-1]
+	}
 	argsString := strings.Join(args, ",")
-	return fmt.Sprintf("(%s -> %s)%s", argsString, self.Return.ToString(), Alexander(self.Error))
+	errorTypes := ""
+	if len(self.ErrorTypes) > 0 {
+		var labels []string
+		for _, t := range self.ErrorTypes {
+			labels = append(labels, t.ToString())
+		}
+		errorTypes = fmt.Sprintf("<%s>", strings.Join(labels, "|"))
+	}
+	return fmt.Sprintf("(%s -> %s)%s%s", argsString, self.Return.ToString(), Alexander(self.Error), errorTypes)
 }
 
 func (self Function) Accepts(t Type) bool {
@@ -51,7 +73,11 @@ func (self Function) Accepts(t Type) bool {
 	case Interface:
 		return other.Label == "Callable"
 	case Function:
-		if !self.Return.Accepts(other.Return) {
+		if _, ok := self.Return.(Empty); ok {
+			if _, ok := other.Return.(Empty); !ok {
+				return false
+			}
+		} else if !self.Return.Accepts(other.Return) {
 			return false
 		}
 		if self.Error == Correct && other.Error != self.Error ||
@@ -65,16 +91,28 @@ func (self Function) Accepts(t Type) bool {
 			return false
 		}
 		for i := range self.Args {
-			if self.Args[i].Accepts(other.Args[i]) {
+			if !self.Args[i].Accepts(other.Args[i]) {
 				return false
 			}
 		}
 		return true
+	case Nil:
+		return true
 	default:
 		return false
 	}
 }
 
+// Param returns the type of the i-th arg of a call
+func (self Function) Param(i int) Type {
+	if self.Variadic && i >= len(self.Args)-1 {
+		if slice, ok := self.Args[len(self.Args)-1].(SliceBuiltin); ok {
+			return slice.Element
+		}
+	}
+	return self.Args[i]
+}
+
 func (self Function) IsGeneric() bool {
 	return len(self.GenericVars) > 0
 }
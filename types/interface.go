@@ -38,8 +38,13 @@ func Accepts(d Duck, label string) (Method, bool) {
 func (i Interface) ToString() string {
 	g := ""
 	genericVars := []string{}
-	for _, v := range i.InstanceVars {
-		genericVars = append(genericVars, v.ToString())
+	for j, v := range i.InstanceVars {
+		if v == nil && j < len(i.GenericVars) {
+			// not instantiated
+			genericVars = append(genericVars, i.GenericVars[j].Label)
+		} else if v != nil {
+			genericVars = append(genericVars, v.ToString())
+		}
 	}
 	g = strings.Join(genericVars, ",")
 	if len(i.InstanceVars) > 0 {
@@ -53,28 +58,62 @@ func (i Interface) Methods() []Method {
 }
 
 func (i Interface) Accepts(t Type) bool {
-	switch other := t.(type) {
+	if len(i.methods) == 0 && len(i.GenericVars) == 0 {
+		// interface{}
+		return true
+	}
+	switch t.(type) {
+	case Nil:
+		return true
 	case Basic:
 		return len(i.methods) == 0
-	case InterfaceOrRecord:
-		for _, signature := range i.methods {
-			if !other.AcceptsFunction(signature.Function) {
-				return false
-			}
-		}
-		return true
+	case Interface, Record, Pointer:
+		_, lacks := i.Lacks(t)
+		return !lacks
 	default:
 		return false
 	}
 }
 
-func (i Interface) AcceptsFunction(f Function) bool {
+// Lacks returns the first method of the interface which t doesn't have
+// or has with another signature
+func (i Interface) Lacks(t Type) (Method, bool) {
+	methods := MethodSet(t)
 	for _, signature := range i.methods {
-		if signature.Function.Accepts(f) {
-			return true
+		method, ok := find(methods, signature.Label)
+		if !ok || !signature.Function.Accepts(method.Function) {
+			return signature, true
 		}
 	}
-	return false
+	return Method{}, false
+}
+
+// MethodSet returns the methods of t as go sees them:
+// a record doesn't have the methods with a pointer receiver
+func MethodSet(t Type) []Method {
+	switch other := t.(type) {
+	case Record:
+		methods := []Method{}
+		for _, method := range other.Methods() {
+			if !method.Pointer {
+				methods = append(methods, method)
+			}
+		}
+		return methods
+	case Duck:
+		return other.Methods()
+	default:
+		return []Method{}
+	}
+}
+
+func find(methods []Method, label string) (Method, bool) {
+	for _, method := range methods {
+		if method.Label == label {
+			return method, true
+		}
+	}
+	return Method{}, false
 }
 
 func (i Interface) IsGeneric() bool {
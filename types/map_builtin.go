@@ -65,6 +65,7 @@ func (m MapBuiltin) Accepts(t Type) bool {
 	if a, ok := t.(MapBuiltin); ok {
 		return m.Key.Accepts(a.Key) && m.Value.Accepts(a.Value)
 	} else {
-		return false
+		_, ok := t.(Nil)
+		return ok
 	}
 }
@@ -0,0 +1,26 @@
+package types
+
+// Named is a go type defined on a type which isn't a struct or an interface,
+// like time.Duration: it keeps its go name and its methods
+type Named struct {
+	Label      string
+	Underlying Type
+	methods    []Method
+}
+
+func NewNamed(label string, underlying Type, methods []Method) Named {
+	return Named{Label: label, Underlying: underlying, methods: methods}
+}
+
+func (n Named) Methods() []Method {
+	return n.methods
+}
+
+func (n Named) ToString() string {
+	return n.Label
+}
+
+func (n Named) Accepts(t Type) bool {
+	other, ok := t.(Named)
+	return ok && n.Label == other.Label
+}
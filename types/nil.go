@@ -1,7 +1,5 @@
 package types
 
-import "fmt"
-
 type Nil struct {
 }
 
@@ -10,7 +8,6 @@ func (self Nil) ToString() string {
 }
 
 func (self Nil) Accepts(t Type) bool {
-	a, ok := t.(Nil)
-	fmt.Printf("%s", a)
+	_, ok := t.(Nil)
 	return ok
 }
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Package type
+// The exported members of an imported package, used as pkg.Member
+type Package struct {
+	Label   string
+	Path    string
+	Members map[string]Type
+}
+
+func NewPackage(label string, path string) Package {
+	return Package{Label: label, Path: path, Members: make(map[string]Type)}
+}
+
+func (p Package) ToString() string {
+	return fmt.Sprintf("package %s", p.Label)
+}
+
+func (p Package) Accepts(t Type) bool {
+	return false
+}
+
+// Methods returns the exported functions, so pkg.F(..) is checked
+// like a method call
+func (p Package) Methods() []Method {
+	labels := []string{}
+	for label := range p.Members {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	methods := []Method{}
+	for _, label := range labels {
+		if f, ok := p.Members[label].(Function); ok {
+			methods = append(methods, Method{Label: label, Function: f})
+		}
+	}
+	return methods
+}
@@ -15,7 +15,18 @@ func (self Pointer) Accepts(t Type) bool {
 	switch other := t.(type) {
 	case Pointer:
 		return self.Object.Accepts(other.Object)
+	case Nil:
+		return true
 	default:
 		return false
 	}
 }
+
+// Methods of a pointer are the methods of its object,
+// go takes the object for them
+func (self Pointer) Methods() []Method {
+	if duck, ok := self.Object.(Duck); ok {
+		return duck.Methods()
+	}
+	return []Method{}
+}
@@ -6,11 +6,20 @@ import (
 )
 
 type Record struct {
-	Label        string
-	methods      []Method
-	Fields       map[string]Type
+	Label   string
+	methods []Method
+	Fields  map[string]Type
+	// Order are the labels of the fields in the order they're declared
+	Order        []string
 	GenericVars  []GenericVar
 	InstanceVars []Type
+	// Package is the import path of the melt package defining the record,
+	// empty for the main package and for go records
+	Package string
+}
+
+func NewRecord(label string, fields map[string]Type, methods []Method, vars []GenericVar) Record {
+	return Record{Label: label, Fields: fields, methods: methods, GenericVars: vars, InstanceVars: make([]Type, len(vars))}
 }
 
 func (r Record) Methods() []Method {
@@ -18,24 +27,46 @@ func (r Record) Methods() []Method {
 }
 
 func (r *Record) ReplaceMethods(methods []Method) {
-	copy(r.methods, methods)
+	r.methods = append([]Method{}, methods...)
 }
 
+// ToString is the name of the record: Point, Stack<int> or collections.Point
+// for a record of an imported package
 func (r Record) ToString() string {
-	var elements []string
-	for field, kind := range r.Fields {
-		elements = append(elements, field+"."+kind.ToString())
+	label := r.Label
+	if r.Package != "" {
+		label = fmt.Sprintf("%s.%s", r.Package[strings.LastIndex(r.Package, "/")+1:], r.Label)
+	}
+	if len(r.GenericVars) == 0 {
+		return label
 	}
-	fields := strings.Join(elements, ",")
-	return fmt.Sprintf("#%s{%s}", r.Label, fields)
+	vars := []string{}
+	for i, v := range r.GenericVars {
+		if i < len(r.InstanceVars) && r.InstanceVars[i] != nil {
+			vars = append(vars, r.InstanceVars[i].ToString())
+		} else {
+			vars = append(vars, v.Label)
+		}
+	}
+	return fmt.Sprintf("%s<%s>", label, strings.Join(vars, ","))
 }
 
+// Accepts another value of the record, a name of a type
+// is accepted only for a record which isn't generic
 func (r Record) Accepts(t Type) bool {
 	switch other := t.(type) {
 	case Basic:
-		return false
+		return len(r.GenericVars) == 0 && r.Label == other.Label
 	case Record:
-		return r.Label == other.Label
+		if r.Label != other.Label || r.Package != other.Package || len(r.InstanceVars) != len(other.InstanceVars) {
+			return false
+		}
+		for i, v := range r.InstanceVars {
+			if v != nil && other.InstanceVars[i] != nil && !v.Accepts(other.InstanceVars[i]) {
+				return false
+			}
+		}
+		return true
 	default:
 		return false
 	}
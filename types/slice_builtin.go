@@ -47,6 +47,7 @@ func (s SliceBuiltin) Accepts(t Type) bool {
 	if a, ok := t.(SliceBuiltin); ok {
 		return s.Element.Accepts(a.Element)
 	} else {
-		return false
+		_, ok := t.(Nil)
+		return ok
 	}
 }
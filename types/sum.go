@@ -0,0 +1,96 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sum type: union Shape: Circle(r float) | Rect(w float, h float)
+// A value of it is one of its variants
+type Sum struct {
+	Label        string
+	Variants     []Variant
+	GenericVars  []GenericVar
+	InstanceVars []Type
+}
+
+// Variant of a sum type, its fields are in the order of the constructor args
+type Variant struct {
+	Label  string
+	Fields []VariantField
+}
+
+type VariantField struct {
+	Label string
+	Type  Type
+}
+
+func (s Sum) ToString() string {
+	if len(s.GenericVars) == 0 {
+		return s.Label
+	}
+	vars := []string{}
+	for i, v := range s.GenericVars {
+		if i < len(s.InstanceVars) && s.Open(i) {
+			vars = append(vars, "?")
+		} else if i < len(s.InstanceVars) && s.InstanceVars[i] != nil {
+			vars = append(vars, s.InstanceVars[i].ToString())
+		} else {
+			vars = append(vars, v.Label)
+		}
+	}
+	return fmt.Sprintf("%s<%s>", s.Label, strings.Join(vars, ","))
+}
+
+// Accepts another value of the sum, a name of a type
+// is accepted only for a sum which isn't generic
+func (s Sum) Accepts(t Type) bool {
+	switch other := t.(type) {
+	case Basic:
+		return len(s.GenericVars) == 0 && s.Label == other.Label
+	case Sum:
+		if s.Label != other.Label || len(s.InstanceVars) != len(other.InstanceVars) {
+			return false
+		}
+		for i, v := range s.InstanceVars {
+			if v == nil || other.InstanceVars[i] == nil || s.Open(i) || other.Open(i) {
+				continue
+			}
+			if !v.Accepts(other.InstanceVars[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// Open is true if the i-th generic var isn't known yet:
+// None() of an Option<T> doesn't say what T is
+func (s Sum) Open(i int) bool {
+	_, ok := s.InstanceVars[i].(GenericVar)
+	return ok
+}
+
+// Variant returns the variant with this label
+func (s Sum) Variant(label string) (Variant, bool) {
+	for _, v := range s.Variants {
+		if v.Label == label {
+			return v, true
+		}
+	}
+	return Variant{}, false
+}
+
+func (s Sum) IsGeneric() bool {
+	return len(s.GenericVars) > 0
+}
+
+func (s Sum) Vars() []GenericVar {
+	return s.GenericVars
+}
+
+func (s Sum) IVars() []Type {
+	return s.InstanceVars
+}